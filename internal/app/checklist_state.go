@@ -0,0 +1,155 @@
+package app
+
+import (
+	"kahn/internal/domain"
+	"kahn/internal/ui/colors"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ChecklistState manages the pane listing one task's checklist. While an item is
+// added or edited, the pane's text input has focus.
+type ChecklistState struct {
+	showing   bool
+	task      domain.Task
+	items     []domain.ChecklistItem
+	cursor    int
+	editing   bool
+	editingID string // item being edited; empty while adding a new one
+	input     textinput.Model
+	err       string
+}
+
+// NewChecklistState creates a ChecklistState with the pane hidden
+func NewChecklistState() *ChecklistState {
+	return &ChecklistState{}
+}
+
+// Show opens the pane on the task and its items with the cursor on the first one
+func (cs *ChecklistState) Show(task domain.Task, items []domain.ChecklistItem) {
+	cs.Hide()
+	cs.showing = true
+	cs.task = task
+	cs.items = items
+}
+
+// Hide closes the pane and drops the captured task
+func (cs *ChecklistState) Hide() {
+	*cs = ChecklistState{}
+}
+
+// IsShowing returns whether the pane is open
+func (cs *ChecklistState) IsShowing() bool {
+	return cs.showing
+}
+
+// GetTask returns the task the pane was opened on
+func (cs *ChecklistState) GetTask() domain.Task {
+	return cs.task
+}
+
+// SetTask replaces the captured task, e.g. after its checklist changed
+func (cs *ChecklistState) SetTask(task domain.Task) {
+	cs.task = task
+}
+
+// GetItems returns the checklist shown in the pane
+func (cs *ChecklistState) GetItems() []domain.ChecklistItem {
+	return cs.items
+}
+
+// SetItems replaces the shown checklist, keeping the cursor on an item
+func (cs *ChecklistState) SetItems(items []domain.ChecklistItem) {
+	cs.items = items
+	cs.cursor = max(min(cs.cursor, len(items)-1), 0)
+}
+
+// GetCursor returns the index of the highlighted item
+func (cs *ChecklistState) GetCursor() int {
+	return cs.cursor
+}
+
+// SetCursor moves the highlight to the item at index, within bounds
+func (cs *ChecklistState) SetCursor(index int) {
+	cs.cursor = max(min(index, len(cs.items)-1), 0)
+}
+
+// MoveCursor moves the highlight by delta items
+func (cs *ChecklistState) MoveCursor(delta int) {
+	cs.SetCursor(cs.cursor + delta)
+}
+
+// SelectedItem returns the highlighted item, or nil for an empty checklist
+func (cs *ChecklistState) SelectedItem() *domain.ChecklistItem {
+	if cs.cursor < 0 || cs.cursor >= len(cs.items) {
+		return nil
+	}
+	return &cs.items[cs.cursor]
+}
+
+// StartAdding focuses an empty input for a new item
+func (cs *ChecklistState) StartAdding() {
+	cs.startEditing("", "")
+}
+
+// StartEditing focuses the input on the text of an existing item
+func (cs *ChecklistState) StartEditing(item domain.ChecklistItem) {
+	cs.startEditing(item.ID, item.Text)
+}
+
+func (cs *ChecklistState) startEditing(itemID, text string) {
+	input := textinput.New()
+	input.Placeholder = "Checklist item"
+	input.PlaceholderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0))
+	input.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Text))
+	input.CharLimit = domain.MaxChecklistItemLength
+	input.Width = 50
+	input.SetValue(text)
+	input.Focus()
+
+	cs.editing = true
+	cs.editingID = itemID
+	cs.input = input
+	cs.err = ""
+}
+
+// StopEditing drops the input without saving it
+func (cs *ChecklistState) StopEditing() {
+	cs.editing = false
+	cs.editingID = ""
+	cs.input = textinput.Model{}
+}
+
+// IsEditing returns whether an item is being added or edited
+func (cs *ChecklistState) IsEditing() bool {
+	return cs.editing
+}
+
+// GetEditingID returns the ID of the item being edited, or "" while adding
+func (cs *ChecklistState) GetEditingID() string {
+	return cs.editingID
+}
+
+// Input returns the text input used while adding or editing
+func (cs *ChecklistState) Input() *textinput.Model {
+	return &cs.input
+}
+
+// InputView renders the text input, or "" when nothing is being edited
+func (cs *ChecklistState) InputView() string {
+	if !cs.editing {
+		return ""
+	}
+	return cs.input.View()
+}
+
+// SetError shows a message under the checklist until the next change
+func (cs *ChecklistState) SetError(message string) {
+	cs.err = message
+}
+
+// GetError returns the message shown under the checklist
+func (cs *ChecklistState) GetError() string {
+	return cs.err
+}
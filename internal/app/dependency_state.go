@@ -0,0 +1,46 @@
+package app
+
+import "kahn/internal/domain"
+
+// DependencyState manages the overlay showing the blocker tree of one task.
+// The project's tasks are captured when the overlay opens so the tree reflects
+// the stored blockers rather than the board's cached copies.
+type DependencyState struct {
+	showing bool
+	rootID  int
+	tasks   []domain.Task
+}
+
+// NewDependencyState creates a DependencyState with the overlay hidden
+func NewDependencyState() *DependencyState {
+	return &DependencyState{}
+}
+
+// Show opens the overlay for the task numbered rootID among tasks
+func (ds *DependencyState) Show(rootID int, tasks []domain.Task) {
+	ds.showing = true
+	ds.rootID = rootID
+	ds.tasks = tasks
+}
+
+// Hide closes the overlay and drops the captured tasks
+func (ds *DependencyState) Hide() {
+	ds.showing = false
+	ds.rootID = 0
+	ds.tasks = nil
+}
+
+// IsShowing returns whether the overlay is open
+func (ds *DependencyState) IsShowing() bool {
+	return ds.showing
+}
+
+// GetRootID returns the number of the task the overlay was opened on
+func (ds *DependencyState) GetRootID() int {
+	return ds.rootID
+}
+
+// GetTasks returns the tasks captured when the overlay opened
+func (ds *DependencyState) GetTasks() []domain.Task {
+	return ds.tasks
+}
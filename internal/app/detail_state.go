@@ -0,0 +1,245 @@
+package app
+
+import (
+	"kahn/internal/domain"
+	"kahn/internal/ui/colors"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DetailState manages the pane showing one task with its comments, or with its
+// history in their place. While a comment is written, the pane's text area has
+// focus. The tasks it waits on and those waiting on it are listed as links that
+// open in the same pane, remembering the tasks opened before.
+type DetailState struct {
+	showing       bool
+	task          domain.Task
+	blockers      []domain.Task
+	dependents    []domain.Task
+	linkCursor    int      // index into blockers then dependents, or -1 for none
+	previous      []string // IDs of the tasks shown before the current one, oldest first
+	commentOffset int      // index of the first comment shown
+	history       []domain.Event
+	showHistory   bool
+	historyOffset int // index of the first event shown
+	commenting    bool
+	input         textarea.Model
+	err           string
+}
+
+// NewDetailState creates a DetailState with the pane hidden
+func NewDetailState() *DetailState {
+	return &DetailState{}
+}
+
+// Show opens the pane on the task, scrolled to its first comment
+func (ds *DetailState) Show(task domain.Task) {
+	ds.Hide()
+	ds.showing = true
+	ds.task = task
+	ds.linkCursor = -1
+}
+
+// Open shows another task in the pane, remembering the current one for Back
+func (ds *DetailState) Open(task domain.Task) {
+	previous := append(ds.previous, ds.task.ID)
+	ds.Show(task)
+	ds.previous = previous
+}
+
+// Back forgets the most recently remembered task and returns its ID, or ""
+// when the pane was opened on the current task
+func (ds *DetailState) Back() string {
+	if len(ds.previous) == 0 {
+		return ""
+	}
+	id := ds.previous[len(ds.previous)-1]
+	ds.previous = ds.previous[:len(ds.previous)-1]
+	return id
+}
+
+// CanGoBack returns whether a task was shown before the current one
+func (ds *DetailState) CanGoBack() bool {
+	return len(ds.previous) > 0
+}
+
+// SetLinks lists the tasks the shown task waits on and the tasks waiting on it
+func (ds *DetailState) SetLinks(blockers, dependents []domain.Task) {
+	ds.blockers = blockers
+	ds.dependents = dependents
+	ds.linkCursor = -1
+}
+
+// GetBlockers returns the tasks the shown task waits on
+func (ds *DetailState) GetBlockers() []domain.Task {
+	return ds.blockers
+}
+
+// GetDependents returns the tasks waiting on the shown task
+func (ds *DetailState) GetDependents() []domain.Task {
+	return ds.dependents
+}
+
+// GetLinkCursor returns the index of the highlighted link among the blockers
+// and then the dependents, or -1 when none is highlighted
+func (ds *DetailState) GetLinkCursor() int {
+	return ds.linkCursor
+}
+
+// MoveLinkCursor highlights the next link, or the previous one for a negative
+// delta, wrapping around
+func (ds *DetailState) MoveLinkCursor(delta int) {
+	count := len(ds.blockers) + len(ds.dependents)
+	if count == 0 {
+		return
+	}
+	if ds.linkCursor < 0 && delta < 0 {
+		ds.linkCursor = 0
+	}
+	ds.linkCursor = ((ds.linkCursor+delta)%count + count) % count
+}
+
+// SelectedLink returns the highlighted linked task, or nil
+func (ds *DetailState) SelectedLink() *domain.Task {
+	switch {
+	case ds.linkCursor < 0:
+		return nil
+	case ds.linkCursor < len(ds.blockers):
+		return &ds.blockers[ds.linkCursor]
+	case ds.linkCursor < len(ds.blockers)+len(ds.dependents):
+		return &ds.dependents[ds.linkCursor-len(ds.blockers)]
+	}
+	return nil
+}
+
+// Hide closes the pane and drops the captured task
+func (ds *DetailState) Hide() {
+	*ds = DetailState{}
+}
+
+// IsShowing returns whether the pane is open
+func (ds *DetailState) IsShowing() bool {
+	return ds.showing
+}
+
+// GetTask returns the task the pane was opened on
+func (ds *DetailState) GetTask() domain.Task {
+	return ds.task
+}
+
+// SetTask replaces the captured task, e.g. after a comment was added
+func (ds *DetailState) SetTask(task domain.Task) {
+	ds.task = task
+	ds.ScrollComments(0)
+}
+
+// GetCommentOffset returns the index of the first comment shown
+func (ds *DetailState) GetCommentOffset() int {
+	return ds.commentOffset
+}
+
+// ScrollComments moves the first comment shown by delta, within bounds
+func (ds *DetailState) ScrollComments(delta int) {
+	ds.commentOffset = max(min(ds.commentOffset+delta, len(ds.task.Comments)-1), 0)
+}
+
+// ScrollToLastComment shows the newest comment at the top of the list
+func (ds *DetailState) ScrollToLastComment() {
+	ds.ScrollComments(len(ds.task.Comments))
+}
+
+// ShowHistory lists the task's events, oldest first, in place of its comments
+func (ds *DetailState) ShowHistory(events []domain.Event) {
+	ds.history = events
+	ds.showHistory = true
+	ds.historyOffset = 0
+}
+
+// HideHistory shows the comments again
+func (ds *DetailState) HideHistory() {
+	ds.history = nil
+	ds.showHistory = false
+	ds.historyOffset = 0
+}
+
+// IsShowingHistory returns whether the history is shown in place of the comments
+func (ds *DetailState) IsShowingHistory() bool {
+	return ds.showHistory
+}
+
+// GetHistory returns the events shown by ShowHistory
+func (ds *DetailState) GetHistory() []domain.Event {
+	return ds.history
+}
+
+// GetOffset returns the index of the first comment or event shown, whichever
+// list is showing
+func (ds *DetailState) GetOffset() int {
+	if ds.showHistory {
+		return ds.historyOffset
+	}
+	return ds.commentOffset
+}
+
+// Scroll moves the list that is showing by delta, within bounds
+func (ds *DetailState) Scroll(delta int) {
+	if ds.showHistory {
+		ds.historyOffset = max(min(ds.historyOffset+delta, len(ds.history)-1), 0)
+		return
+	}
+	ds.ScrollComments(delta)
+}
+
+// StartCommenting focuses an empty text area for a new comment
+func (ds *DetailState) StartCommenting() {
+	input := textarea.New()
+	input.Placeholder = "Comment"
+	input.CharLimit = domain.MaxCommentLength
+	input.SetWidth(60)
+	input.SetHeight(4)
+	input.ShowLineNumbers = false
+	input.FocusedStyle.Placeholder = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0))
+	input.FocusedStyle.Text = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Text))
+	input.FocusedStyle.CursorLine = lipgloss.NewStyle()
+	input.Focus()
+
+	ds.HideHistory()
+	ds.commenting = true
+	ds.input = input
+	ds.err = ""
+}
+
+// StopCommenting drops the text area without saving it
+func (ds *DetailState) StopCommenting() {
+	ds.commenting = false
+	ds.input = textarea.Model{}
+}
+
+// IsCommenting returns whether a comment is being written
+func (ds *DetailState) IsCommenting() bool {
+	return ds.commenting
+}
+
+// Input returns the text area used while writing a comment
+func (ds *DetailState) Input() *textarea.Model {
+	return &ds.input
+}
+
+// InputView renders the text area, or "" when no comment is being written
+func (ds *DetailState) InputView() string {
+	if !ds.commenting {
+		return ""
+	}
+	return ds.input.View()
+}
+
+// SetError shows a message under the comments until the next change
+func (ds *DetailState) SetError(message string) {
+	ds.err = message
+}
+
+// GetError returns the message shown under the comments
+func (ds *DetailState) GetError() string {
+	return ds.err
+}
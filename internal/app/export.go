@@ -0,0 +1,84 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"kahn/internal/domain"
+	"kahn/internal/formats"
+)
+
+// exportBoardMarkdown writes the active project as Markdown into a new file of
+// exportDir and reports the outcome in the footer notice
+func (km *KahnModel) exportBoardMarkdown() {
+	activeProj := km.GetActiveProject()
+	if activeProj == nil {
+		return
+	}
+
+	path, err := writeBoardMarkdownFile(km.exportDir, markdownFileName(activeProj.Name), *activeProj)
+	if err != nil {
+		km.notice = "Export failed: " + err.Error()
+		return
+	}
+
+	if absPath, err := filepath.Abs(path); err == nil {
+		path = absPath
+	}
+	km.notice = fmt.Sprintf("Exported %s to %s", activeProj.Name, path)
+}
+
+// writeBoardMarkdownFile writes the project into a new file of dir named
+// fileName, or with a numeric suffix such as "website-board-2.md" when that
+// name is taken, so earlier exports are never overwritten. Returns the path.
+func writeBoardMarkdownFile(dir, fileName string, project domain.Project) (string, error) {
+	base := strings.TrimSuffix(fileName, ".md")
+	for n := 1; n <= maxExportSuffix; n++ {
+		path := filepath.Join(dir, fileName)
+		if n > 1 {
+			path = filepath.Join(dir, fmt.Sprintf("%s-%d.md", base, n))
+		}
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if err := formats.WriteBoardMarkdown(file, project); err != nil {
+			file.Close()
+			return "", err
+		}
+		return path, file.Close()
+	}
+	return "", fmt.Errorf("%s and %d numbered copies already exist in %s", fileName, maxExportSuffix-1, dir)
+}
+
+// maxExportSuffix bounds the numbered copies tried before an export gives up
+const maxExportSuffix = 100
+
+// markdownFileName turns a project name into a file name such as "my-project-board.md"
+func markdownFileName(projectName string) string {
+	var slug strings.Builder
+	lastDash := true
+	for _, r := range strings.ToLower(projectName) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			slug.WriteRune(r)
+			lastDash = false
+		} else if !lastDash {
+			slug.WriteRune('-')
+			lastDash = true
+		}
+	}
+
+	name := strings.TrimSuffix(slug.String(), "-")
+	if name == "" {
+		name = "project"
+	}
+	return name + "-board.md"
+}
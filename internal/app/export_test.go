@@ -0,0 +1,82 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleNormalMode_XKey_ExportsMarkdown(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	km.exportDir = t.TempDir()
+	createTestTask(t, km, "Write docs", "")
+
+	simulateKeyPress(km, "x")
+
+	path := filepath.Join(km.exportDir, "default-project-board.md")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err, "Export should write the board file")
+	assert.Contains(t, string(content), "# Default Project")
+	assert.Contains(t, string(content), "Write docs")
+	assert.Contains(t, km.notice, "Exported Default Project to")
+	assert.Contains(t, km.View(), "Exported Default Project to", "Notice replaces the footer")
+
+	simulateKeyPress(km, "j")
+	assert.Empty(t, km.notice, "Notice clears on the next key press")
+}
+
+func TestHandleNormalMode_XKey_ReportsFailure(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	km.exportDir = filepath.Join(t.TempDir(), "missing")
+
+	simulateKeyPress(km, "x")
+
+	assert.Contains(t, km.notice, "Export failed")
+}
+
+func TestMarkdownFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"Website", "website-board.md"},
+		{"My  Project!", "my-project-board.md"},
+		{"../etc", "etc-board.md"},
+		{"???", "project-board.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, markdownFileName(tt.name))
+		})
+	}
+}
+
+func TestHandleNormalMode_XKey_KeepsEarlierExports(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	km.SetExportDir(t.TempDir())
+	existing := filepath.Join(km.exportDir, "default-project-board.md")
+	require.NoError(t, os.WriteFile(existing, []byte("my notes"), 0o644))
+
+	simulateKeyPress(km, "x")
+	simulateKeyPress(km, "x")
+
+	content, err := os.ReadFile(existing)
+	require.NoError(t, err)
+	assert.Equal(t, "my notes", string(content), "An existing file is never overwritten")
+	for _, name := range []string{"default-project-board-2.md", "default-project-board-3.md"} {
+		content, err := os.ReadFile(filepath.Join(km.exportDir, name))
+		require.NoError(t, err, name)
+		assert.Contains(t, string(content), "# Default Project")
+	}
+	assert.Contains(t, km.notice, "default-project-board-3.md")
+}
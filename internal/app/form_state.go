@@ -1,6 +1,8 @@
 package app
 
 import (
+	"time"
+
 	"kahn/internal/domain"
 	"kahn/internal/ui/input"
 )
@@ -29,8 +31,8 @@ func (fs *FormState) ShowTaskForm(availableTasks []domain.Task) {
 	fs.ClearError()
 }
 
-func (fs *FormState) ShowTaskEditForm(taskID string, name, description string, priority domain.Priority, taskType domain.TaskType, blockedByIntID *int, availableTasks []domain.Task) {
-	fs.taskComponents.SetupForTaskEdit(taskID, name, description, priority, taskType, blockedByIntID)
+func (fs *FormState) ShowTaskEditForm(taskID string, name, description string, priority domain.Priority, taskType domain.TaskType, blockedBy []int, availableTasks []domain.Task) {
+	fs.taskComponents.SetupForTaskEdit(taskID, name, description, priority, taskType, blockedBy)
 	fs.taskComponents.SetAvailableTasks(availableTasks)
 	fs.activeFormType = input.TaskEditForm
 	fs.showForm = true
@@ -86,16 +88,36 @@ func (fs *FormState) ValidateForSubmit() (bool, string, string) {
 	return comps.ValidateForSubmit()
 }
 
-func (fs *FormState) GetFormData() (string, string, domain.TaskType, domain.Priority, *int) {
+func (fs *FormState) GetFormData() (string, string, domain.TaskType, domain.Priority, []int) {
 	comps := fs.GetActiveInputComponents()
 	name := comps.NameInput.Value()
 	desc := comps.DescInput.Value()
 	taskType := comps.TypeValue
 	priority := comps.PriorityValue
-	blockedBy := comps.BlockedByValue
+	blockedBy := comps.BlockedByValues
 	return name, desc, taskType, priority, blockedBy
 }
 
+// SetLabelNames fills the labels field of the task form
+func (fs *FormState) SetLabelNames(names []string) {
+	fs.taskComponents.SetLabelNames(names)
+}
+
+// GetLabelNames returns the label names entered in the task form
+func (fs *FormState) GetLabelNames() []string {
+	return fs.taskComponents.GetLabelNames()
+}
+
+// SetDueDate fills the due date field of the task form
+func (fs *FormState) SetDueDate(date *time.Time) {
+	fs.taskComponents.SetDueDate(date)
+}
+
+// GetDueDate returns the due date entered in the task form
+func (fs *FormState) GetDueDate() (*time.Time, error) {
+	return fs.taskComponents.GetDueDate()
+}
+
 func (fs *FormState) GetTaskID() string {
 	if fs.activeFormType == input.TaskEditForm {
 		return fs.taskComponents.GetTaskID()
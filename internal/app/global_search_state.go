@@ -0,0 +1,90 @@
+package app
+
+import "kahn/internal/domain"
+
+// GlobalSearchState manages the pane that searches the names, descriptions and
+// comments of every project's tasks as the query is typed
+type GlobalSearchState struct {
+	showing bool
+	query   string
+	results []domain.SearchResult
+	cursor  int
+	err     string
+}
+
+// NewGlobalSearchState creates a GlobalSearchState with the pane hidden
+func NewGlobalSearchState() *GlobalSearchState {
+	return &GlobalSearchState{}
+}
+
+// Show opens the pane with an empty query
+func (gs *GlobalSearchState) Show() {
+	*gs = GlobalSearchState{showing: true}
+}
+
+// Hide closes the pane and drops the query and results
+func (gs *GlobalSearchState) Hide() {
+	*gs = GlobalSearchState{}
+}
+
+// IsShowing returns whether the pane is open
+func (gs *GlobalSearchState) IsShowing() bool {
+	return gs.showing
+}
+
+// GetQuery returns the typed query
+func (gs *GlobalSearchState) GetQuery() string {
+	return gs.query
+}
+
+// AppendChar adds a typed character to the query
+func (gs *GlobalSearchState) AppendChar(char string) {
+	gs.query += char
+}
+
+// Backspace removes the last character of the query
+func (gs *GlobalSearchState) Backspace() {
+	if runes := []rune(gs.query); len(runes) > 0 {
+		gs.query = string(runes[:len(runes)-1])
+	}
+}
+
+// SetResults replaces the listed results with the cursor on the best match
+func (gs *GlobalSearchState) SetResults(results []domain.SearchResult) {
+	gs.results = results
+	gs.cursor = 0
+	gs.err = ""
+}
+
+// GetResults returns the listed results, best match first
+func (gs *GlobalSearchState) GetResults() []domain.SearchResult {
+	return gs.results
+}
+
+// GetCursor returns the index of the highlighted result
+func (gs *GlobalSearchState) GetCursor() int {
+	return gs.cursor
+}
+
+// MoveCursor moves the highlight by delta results, within bounds
+func (gs *GlobalSearchState) MoveCursor(delta int) {
+	gs.cursor = max(min(gs.cursor+delta, len(gs.results)-1), 0)
+}
+
+// SelectedResult returns the highlighted result, or nil when there are none
+func (gs *GlobalSearchState) SelectedResult() *domain.SearchResult {
+	if gs.cursor < len(gs.results) {
+		return &gs.results[gs.cursor]
+	}
+	return nil
+}
+
+// SetError shows a message below the results, or clears it when empty
+func (gs *GlobalSearchState) SetError(message string) {
+	gs.err = message
+}
+
+// GetError returns the message shown below the results
+func (gs *GlobalSearchState) GetError() string {
+	return gs.err
+}
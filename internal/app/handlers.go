@@ -10,10 +10,28 @@ import (
 func (km *KahnModel) handleFormInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	comps := km.uiStateManager.FormState().GetActiveInputComponents()
 
+	// The description preview is read-only: ctrl+p closes it, and only the keys
+	// that leave the field or the form still work
+	if comps.IsPreviewingDesc() {
+		switch msg.String() {
+		case "ctrl+p":
+			comps.ToggleDescPreview()
+			return km, nil
+		case "esc", "tab", "ctrl+enter":
+		default:
+			return km, nil
+		}
+	}
+
 	switch msg.String() {
 	case "esc":
 		km.uiStateManager.HideAllStates()
 		return km, nil
+	case "ctrl+p":
+		if comps.FocusedField == 1 {
+			comps.ToggleDescPreview()
+			return km, nil
+		}
 	case "tab":
 		return km.handleTabKey(), nil
 	case "ctrl+enter":
@@ -70,17 +88,34 @@ func (km *KahnModel) handleFormInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 		// Let textinput/textarea handle for other fields
+	case " ":
+		// Space toggles the highlighted task when the blocked by field is focused
+		if comps.IsTaskForm() && comps.FocusedField == 4 {
+			km.ClearFormError()
+			comps.ToggleBlockedBy()
+			return km, nil
+		}
+		km.ClearFormError()
 	default:
 		// Clear any previous errors when user types
 		km.ClearFormError()
 	}
 
 	// Update the focused input field
-	if comps.FocusedField == 0 {
+	switch comps.FocusedField {
+	case 0:
 		updatedName, cmd := comps.NameInput.Update(msg)
 		comps.NameInput = updatedName
 		return km, cmd
-	} else {
+	case 5:
+		updatedLabels, cmd := comps.LabelsInput.Update(msg)
+		comps.LabelsInput = updatedLabels
+		return km, cmd
+	case 6:
+		updatedDue, cmd := comps.DueInput.Update(msg)
+		comps.DueInput = updatedDue
+		return km, cmd
+	default:
 		updatedDesc, cmd := comps.DescInput.Update(msg)
 		comps.DescInput = updatedDesc
 		return km, cmd
@@ -110,9 +145,15 @@ func (km *KahnModel) handleTabKey() tea.Model {
 	case 3: // Type -> BlockedBy (only for task forms)
 		comps.FocusBlockedBy()
 		comps.BlurType()
-	case 4: // BlockedBy -> Name (only for task forms, cycle back)
-		comps.FocusName()
+	case 4: // BlockedBy -> Labels (only for task forms)
+		comps.FocusLabels()
 		comps.BlurBlockedBy()
+	case 5: // Labels -> Due date (only for task forms)
+		comps.FocusDue()
+		comps.BlurLabels()
+	case 6: // Due date -> Name (only for task forms, cycle back)
+		comps.FocusName()
+		comps.BlurDue()
 	default:
 		// Fallback to name focus
 		comps.FocusName()
@@ -156,11 +197,8 @@ func (km *KahnModel) handleProjectSwitch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				nextIndex := (i + 1) % len(projects)
 				km.projectManager.SwitchToProject(projects[nextIndex].ID)
 
-				// Clear search when switching projects
-				if km.searchState.IsActive() {
-					km.searchState.Clear()
-					km.RefreshTasksWithSearch()
-				}
+				// Clear search, and a view of the previous project, when switching projects
+				km.resetBoardFilters()
 
 				return km, nil
 			}
@@ -173,11 +211,8 @@ func (km *KahnModel) handleProjectSwitch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				prevIndex := (i - 1 + len(projects)) % len(projects)
 				km.projectManager.SwitchToProject(projects[prevIndex].ID)
 
-				// Clear search when switching projects
-				if km.searchState.IsActive() {
-					km.searchState.Clear()
-					km.RefreshTasksWithSearch()
-				}
+				// Clear search, and a view of the previous project, when switching projects
+				km.resetBoardFilters()
 
 				return km, nil
 			}
@@ -185,11 +220,8 @@ func (km *KahnModel) handleProjectSwitch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "enter":
 		navState.HideProjectSwitch()
 
-		// Clear search when switching projects
-		if km.searchState.IsActive() {
-			km.searchState.Clear()
-			km.RefreshTasksWithSearch()
-		}
+		// Clear search, and a view of the previous project, when switching projects
+		km.resetBoardFilters()
 
 		return km, nil
 	default:
@@ -200,18 +232,262 @@ func (km *KahnModel) handleProjectSwitch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				km.projectManager.SwitchToProject(projects[index].ID)
 				navState.HideProjectSwitch()
 
-				// Clear search when switching projects
-				if km.searchState.IsActive() {
-					km.searchState.Clear()
-					km.RefreshTasksWithSearch()
-				}
+				// Clear search, and a view of the previous project, when switching projects
+				km.resetBoardFilters()
+			}
+		}
+		return km, nil
+	}
+	return km, nil
+}
+
+// handleDependencyView closes the dependency overlay; it has no other keys
+func (km *KahnModel) handleDependencyView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "g", "q", "enter":
+		km.uiStateManager.DependencyState().Hide()
+	}
+	return km, nil
+}
+
+// handleChecklistView ticks off, adds, edits, deletes, reorders and promotes the
+// items of the checklist pane. While an item is being typed, keys go to the input.
+func (km *KahnModel) handleChecklistView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	listState := km.uiStateManager.ChecklistState()
+
+	if listState.IsEditing() {
+		switch msg.String() {
+		case "esc":
+			listState.StopEditing()
+			listState.SetError("")
+			return km, nil
+		case "enter":
+			text := listState.Input().Value()
+			var err error
+			if itemID := listState.GetEditingID(); itemID != "" {
+				err = km.RenameChecklistItem(itemID, text)
+			} else {
+				err = km.AddChecklistItem(text)
+			}
+			if err != nil {
+				listState.SetError(err.Error())
+				return km, nil
+			}
+			listState.StopEditing()
+			listState.SetError("")
+			return km, nil
+		}
+		var cmd tea.Cmd
+		*listState.Input(), cmd = listState.Input().Update(msg)
+		return km, cmd
+	}
+
+	var err error
+	switch msg.String() {
+	case "esc", "q", "c":
+		listState.Hide()
+		return km, nil
+	case "j", "down":
+		listState.MoveCursor(1)
+	case "k", "up":
+		listState.MoveCursor(-1)
+	case " ", "x", "enter":
+		err = km.ToggleChecklistItem()
+	case "a", "n":
+		listState.StartAdding()
+		return km, nil
+	case "e":
+		if item := listState.SelectedItem(); item != nil {
+			listState.StartEditing(*item)
+		}
+		return km, nil
+	case "d":
+		err = km.DeleteChecklistItem()
+	case "J", "shift+down":
+		err = km.MoveChecklistItem(1)
+	case "K", "shift+up":
+		err = km.MoveChecklistItem(-1)
+	case "p":
+		err = km.PromoteChecklistItem()
+	default:
+		return km, nil
+	}
+
+	if err != nil {
+		listState.SetError(err.Error())
+	} else {
+		listState.SetError("")
+	}
+	return km, nil
+}
+
+// handleDetailView scrolls the comments of the detail pane, writes new ones and
+// follows the links to blockers and dependents. While a comment is written, keys
+// go to the text area and enter starts a new line.
+func (km *KahnModel) handleDetailView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	detailState := km.uiStateManager.DetailState()
+
+	if detailState.IsCommenting() {
+		switch msg.String() {
+		case "esc":
+			detailState.StopCommenting()
+			detailState.SetError("")
+			return km, nil
+		case "ctrl+s", "ctrl+enter":
+			if err := km.AddComment(detailState.Input().Value()); err != nil {
+				detailState.SetError(err.Error())
+				return km, nil
+			}
+			detailState.StopCommenting()
+			detailState.SetError("")
+			return km, nil
+		}
+		var cmd tea.Cmd
+		*detailState.Input(), cmd = detailState.Input().Update(msg)
+		return km, cmd
+	}
+
+	switch msg.String() {
+	case "esc", "q", "v":
+		km.CloseTaskDetail()
+	case "tab":
+		detailState.MoveLinkCursor(1)
+	case "shift+tab":
+		detailState.MoveLinkCursor(-1)
+	case "enter":
+		km.OpenTaskLink()
+	case "b":
+		km.OpenTaskBlocker()
+	case "backspace":
+		km.BackInTaskDetail()
+	case "j", "down":
+		detailState.Scroll(1)
+	case "k", "up":
+		detailState.Scroll(-1)
+	case "h":
+		km.ToggleTaskHistory()
+	case "a", "c":
+		detailState.StartCommenting()
+	}
+	return km, nil
+}
+
+// handleTrashView switches between the Archive and Trash tabs and restores or,
+// after a y/n confirmation, purges the highlighted entry
+func (km *KahnModel) handleTrashView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	trashState := km.uiStateManager.TrashState()
+
+	if trashState.IsConfirming() {
+		trashState.StopConfirm()
+		if key := msg.String(); key == "y" || key == "Y" {
+			km.setTrashError(km.PurgeTrashEntry())
+		}
+		return km, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q", "t":
+		trashState.Hide()
+	case "tab", "shift+tab":
+		trashState.SwitchTab()
+	case "j", "down":
+		trashState.MoveCursor(1)
+	case "k", "up":
+		trashState.MoveCursor(-1)
+	case "r", "enter":
+		km.setTrashError(km.RestoreTrashEntry())
+	case "D":
+		if trashState.IsShowingTrash() && (trashState.SelectedTask() != nil || trashState.SelectedProject() != nil) {
+			trashState.StartConfirm()
+		}
+	}
+	return km, nil
+}
+
+// handleGlobalSearch searches every project as the query is typed and jumps to
+// the highlighted result on enter
+func (km *KahnModel) handleGlobalSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	globalState := km.uiStateManager.GlobalSearchState()
+
+	switch msg.String() {
+	case "esc":
+		globalState.Hide()
+	case "enter":
+		km.OpenGlobalSearchResult()
+	case "down", "ctrl+n", "tab":
+		globalState.MoveCursor(1)
+	case "up", "ctrl+p", "shift+tab":
+		globalState.MoveCursor(-1)
+	case "backspace":
+		globalState.Backspace()
+		km.RefreshGlobalSearch()
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			globalState.AppendChar(string(msg.Runes))
+			km.RefreshGlobalSearch()
+		}
+	}
+	return km, nil
+}
+
+// handleSavedViews applies, clears and deletes the views listed in the picker,
+// or types the name of the view being saved
+func (km *KahnModel) handleSavedViews(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	viewsState := km.uiStateManager.SavedViewsState()
+
+	if viewsState.IsNaming() {
+		switch msg.String() {
+		case "esc":
+			viewsState.Hide()
+		case "enter":
+			if err := km.SaveView(); err != nil {
+				viewsState.SetError(err.Error())
+			}
+		case "tab":
+			viewsState.ToggleGlobal()
+		case "backspace":
+			viewsState.Backspace()
+		default:
+			if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+				viewsState.AppendChar(string(msg.Runes))
 			}
 		}
 		return km, nil
 	}
+
+	switch key := msg.String(); key {
+	case "esc", "q", "s":
+		viewsState.Hide()
+	case "j", "down":
+		viewsState.MoveCursor(1)
+	case "k", "up":
+		viewsState.MoveCursor(-1)
+	case "enter":
+		km.ApplySavedView(viewsState.SelectedView())
+	case "0":
+		km.ApplySavedView(nil)
+	case "D":
+		if err := km.DeleteSavedView(); err != nil {
+			viewsState.SetError(err.Error())
+		}
+	default:
+		if len(key) == 1 && key[0] >= '1' && key[0] <= '9' {
+			if index := int(key[0] - '1'); index < len(viewsState.GetViews()) {
+				km.ApplySavedView(&viewsState.GetViews()[index])
+			}
+		}
+	}
 	return km, nil
 }
 
+func (km *KahnModel) setTrashError(err error) {
+	if err != nil {
+		km.uiStateManager.TrashState().SetError(err.Error())
+	} else {
+		km.uiStateManager.TrashState().SetError("")
+	}
+}
+
 func (km *KahnModel) handleTaskDeleteConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	confirmState := km.uiStateManager.ConfirmationState()
 
@@ -245,6 +521,15 @@ func (km *KahnModel) handleSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Enter doesn't do anything special (already filtering in real-time)
 		return km, nil
 
+	case "ctrl+s":
+		km.StartSavingView()
+		return km, nil
+
+	case "ctrl+f":
+		km.searchState.ToggleFuzzy()
+		km.RefreshTasksWithSearch()
+		return km, nil
+
 	default:
 		// Append typed character to search query
 		// Filter printable characters only
@@ -292,6 +577,15 @@ func (km *KahnModel) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "p":
 		km.uiStateManager.ShowProjectSwitcher()
 		return km, nil
+	case "x":
+		km.exportBoardMarkdown()
+		return km, nil
+	case "u":
+		km.Undo()
+		return km, nil
+	case "ctrl+r":
+		km.Redo()
+		return km, nil
 	case "e":
 		if selectedItem := km.navState.GetActiveList().SelectedItem(); selectedItem != nil {
 			if taskWrapper, ok := selectedItem.(styles.TaskWithTitle); ok {
@@ -299,6 +593,27 @@ func (km *KahnModel) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 		return km, nil
+	case "g":
+		if selectedItem := km.navState.GetActiveList().SelectedItem(); selectedItem != nil {
+			if taskWrapper, ok := selectedItem.(styles.TaskWithTitle); ok {
+				km.ShowDependencies(taskWrapper.Task)
+			}
+		}
+		return km, nil
+	case "c":
+		if selectedItem := km.navState.GetActiveList().SelectedItem(); selectedItem != nil {
+			if taskWrapper, ok := selectedItem.(styles.TaskWithTitle); ok {
+				km.ShowChecklist(taskWrapper.Task)
+			}
+		}
+		return km, nil
+	case "v", "enter":
+		if selectedItem := km.navState.GetActiveList().SelectedItem(); selectedItem != nil {
+			if taskWrapper, ok := selectedItem.(styles.TaskWithTitle); ok {
+				km.ShowTaskDetail(taskWrapper.Task)
+			}
+		}
+		return km, nil
 	case "d":
 		if selectedItem := km.navState.GetActiveList().SelectedItem(); selectedItem != nil {
 			if taskWrapper, ok := selectedItem.(styles.TaskWithTitle); ok {
@@ -306,6 +621,27 @@ func (km *KahnModel) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 		return km, nil
+	case "a":
+		if selectedItem := km.navState.GetActiveList().SelectedItem(); selectedItem != nil {
+			if taskWrapper, ok := selectedItem.(styles.TaskWithTitle); ok {
+				if err := km.ArchiveTask(taskWrapper.ID); err != nil {
+					km.notice = err.Error()
+				}
+			}
+		}
+		return km, nil
+	case "t":
+		km.ShowTrash()
+		return km, nil
+	case "f":
+		km.ShowGlobalSearch()
+		return km, nil
+	case "s":
+		km.ShowSavedViews()
+		return km, nil
+	case "w":
+		km.ShowMyWork()
+		return km, nil
 	case " ":
 		if selectedItem := km.navState.GetActiveList().SelectedItem(); selectedItem != nil {
 			if taskWrapper, ok := selectedItem.(styles.TaskWithTitle); ok {
@@ -324,6 +660,46 @@ func (km *KahnModel) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return km, nil
 }
 
+// handleMyWork handles keys on the My Work board, where moves and edits apply
+// to the highlighted task in its own project
+func (km *KahnModel) handleMyWork(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "down", "j", "k":
+		return km, km.myWork.UpdateActiveList(msg)
+	case "l", "right":
+		km.myWork.NextList()
+	case "h", "left":
+		km.myWork.PrevList()
+	case "w", "esc":
+		km.HideMyWork()
+	case "q":
+		return km, tea.Quit
+	case "u":
+		km.Undo()
+	case "ctrl+r":
+		km.Redo()
+	case "o":
+		km.OpenMyWorkTask()
+	case "e":
+		if task := km.myWork.SelectedTask(); task != nil {
+			km.ShowTaskEditForm(task.ID, task.Name, task.Desc, task.Priority, task.Type, task.BlockedBy)
+		}
+	case "v", "enter":
+		if task := km.myWork.SelectedTask(); task != nil {
+			km.ShowTaskDetail(*task)
+		}
+	case " ":
+		if task := km.myWork.SelectedTask(); task != nil {
+			km.MoveTaskToNextStatus(task.ID)
+		}
+	case "backspace":
+		if task := km.myWork.SelectedTask(); task != nil {
+			km.MoveTaskToPreviousStatus(task.ID)
+		}
+	}
+	return km, nil
+}
+
 func (km *KahnModel) handleResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	km.width = msg.Width
 	km.height = msg.Height
@@ -336,12 +712,15 @@ func (km *KahnModel) handleResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	activeProj := km.GetActiveProject()
 	projectFooterHeight := 0
 	if activeProj != nil {
-		projectFooter := km.board.GetRenderer().RenderProjectFooter(activeProj, availableWidth-3, km.version)
+		projectFooter := km.board.GetRenderer().RenderProjectFooter(activeProj, km.activeViewName(), availableWidth-3, km.version)
 		projectFooterHeight = lipgloss.Height(projectFooter)
 	}
 
 	// Set list heights accounting for both frame and project footer
 	listHeight := availableHeight - projectFooterHeight
 	km.navState.UpdateListSizes(availableWidth, listHeight)
+
+	myWorkFooter := km.board.GetRenderer().RenderMyWorkFooter(availableWidth-3, km.version)
+	km.myWork.UpdateListSizes(availableWidth, availableHeight-lipgloss.Height(myWorkFooter))
 	return km, nil
 }
@@ -4,10 +4,12 @@ import (
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"kahn/internal/domain"
+	"kahn/internal/ui/styles"
 )
 
 // handleFormInput Tests
@@ -207,6 +209,37 @@ func TestHandleFormInput_CharacterInput_UpdatesField(t *testing.T) {
 	assertNoFormError(t, km)
 }
 
+func TestHandleFormInput_DescriptionPreview(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	km.uiStateManager.ShowTaskForm([]domain.Task{})
+	comps := km.uiStateManager.FormState().GetActiveInputComponents()
+
+	// ctrl+p only previews from the description field
+	simulateKeyType(km, tea.KeyCtrlP)
+	assert.False(t, comps.IsPreviewingDesc())
+
+	simulateKeyType(km, tea.KeyTab)
+	simulateKeyPress(km, "- step **one**")
+	simulateKeyType(km, tea.KeyCtrlP)
+	require.True(t, comps.IsPreviewingDesc())
+	assert.Contains(t, km.View(), "• step one")
+
+	// The preview is read-only
+	simulateKeyPress(km, "x")
+	assert.Equal(t, "- step **one**", comps.DescInput.Value())
+
+	simulateKeyType(km, tea.KeyCtrlP)
+	assert.False(t, comps.IsPreviewingDesc())
+
+	// Leaving the field closes the preview
+	simulateKeyType(km, tea.KeyCtrlP)
+	simulateKeyType(km, tea.KeyTab)
+	assert.False(t, comps.IsPreviewingDesc())
+	assert.Equal(t, 2, comps.FocusedField)
+}
+
 // handleSearchInput Tests
 
 func TestHandleSearchInput_EscKey_ClearsSearch(t *testing.T) {
@@ -289,6 +322,77 @@ func TestHandleSearchInput_CharacterInput(t *testing.T) {
 	assert.Equal(t, "AP", km.searchState.GetQuery())
 }
 
+func TestHandleSearchInput_QueryLanguage(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	createTestTaskWithPriority(t, km, "API Task", "Description", domain.High)
+	createTestTask(t, km, "Database Task", "Description")
+
+	simulateKeyPress(km, "/")
+	for _, r := range "priority:high" {
+		simulateKeyPress(km, string(r))
+	}
+	assert.Empty(t, km.searchState.GetError())
+	assert.Equal(t, 1, km.searchState.GetMatchCount())
+	assert.Len(t, km.navState.Tasks[domain.NotStarted].Items(), 1)
+
+	// A query that does not parse shows why and keeps the last filter
+	for _, r := range " (" {
+		simulateKeyPress(km, string(r))
+	}
+	assert.Equal(t, "expected a search term", km.searchState.GetError())
+	assert.Len(t, km.navState.Tasks[domain.NotStarted].Items(), 1)
+	assert.Contains(t, km.View(), "(expected a search term)")
+
+	for _, r := range "api | database)" {
+		simulateKeyPress(km, string(r))
+	}
+	assert.Empty(t, km.searchState.GetError())
+	assert.Len(t, km.navState.Tasks[domain.NotStarted].Items(), 1)
+
+	simulateKeyType(km, tea.KeyEsc)
+	assert.Empty(t, km.searchState.GetError())
+	assert.Len(t, km.navState.Tasks[domain.NotStarted].Items(), 2)
+}
+
+func TestHandleSearchInput_FuzzyMode(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	createTestTask(t, km, "Update docs", "Description")
+	createTestTask(t, km, "Log slow queries in", "Description")
+	createTestTask(t, km, "Login page", "Description")
+
+	simulateKeyPress(km, "/")
+	for _, r := range "lgn" {
+		simulateKeyPress(km, string(r))
+	}
+	assert.Equal(t, 0, km.searchState.GetMatchCount(), "Substrings match by default")
+
+	simulateKeyType(km, tea.KeyCtrlF)
+	require.True(t, km.searchState.IsFuzzy())
+	assert.Equal(t, 2, km.searchState.GetMatchCount())
+	items := km.navState.Tasks[domain.NotStarted].Items()
+	require.Len(t, items, 2)
+	assert.Equal(t, "Login page", items[0].(styles.TaskWithTitle).Name, "Tasks are ranked by score")
+	assert.Equal(t, "Log slow queries in", items[1].(styles.TaskWithTitle).Name)
+	assert.Contains(t, km.View(), "Fuzzy search:")
+
+	// Fuzzy queries are not in the query language and cannot be saved as views
+	simulateKeyType(km, tea.KeyCtrlS)
+	assertViewState(t, km, BoardView)
+	assertSearchActive(t, km, true)
+
+	// The mode stays on for the next search
+	simulateKeyType(km, tea.KeyEsc)
+	assert.Len(t, km.navState.Tasks[domain.NotStarted].Items(), 3)
+	simulateKeyPress(km, "/")
+	assert.True(t, km.searchState.IsFuzzy())
+	simulateKeyType(km, tea.KeyCtrlF)
+	assert.False(t, km.searchState.IsFuzzy())
+}
+
 func TestHandleSearchInput_FiltersTasksRealtime(t *testing.T) {
 	km, cleanup := setupTestApp(t)
 	defer cleanup()
@@ -707,3 +811,828 @@ func TestHandleResize_UpdatesDimensions(t *testing.T) {
 	assert.Equal(t, 120, km.width)
 	assert.Equal(t, 40, km.height)
 }
+
+func TestHandleFormInput_Labels(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	km.uiStateManager.ShowTaskForm([]domain.Task{})
+	comps := km.uiStateManager.FormState().GetActiveInputComponents()
+	comps.NameInput.SetValue("Labelled Task")
+
+	// Tab through description, priority, type and blocked by to reach labels
+	for i := 0; i < 5; i++ {
+		simulateKeyType(km, tea.KeyTab)
+	}
+	assert.Equal(t, 5, comps.FocusedField)
+
+	simulateKeyPress(km, "backend, tech-debt")
+	simulateKeyType(km, tea.KeyEnter)
+	assertViewState(t, km, BoardView)
+
+	activeProj := km.projectManager.GetActiveProject()
+	require.Len(t, activeProj.Tasks, 1)
+	assert.Equal(t, []string{"backend", "tech-debt"}, activeProj.Tasks[0].LabelNames())
+
+	// Editing starts from the current labels and replaces them
+	task := activeProj.Tasks[0]
+	km.ShowTaskEditForm(task.ID, task.Name, task.Desc, task.Priority, task.Type, task.BlockedBy)
+	assert.Equal(t, "backend, tech-debt", comps.LabelsInput.Value())
+
+	comps.SetLabelNames([]string{"frontend"})
+	require.NoError(t, km.SubmitCurrentForm())
+
+	stored, err := km.taskService.GetTask(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend"}, stored.LabelNames())
+	assert.Equal(t, []string{"frontend"}, km.projectManager.GetActiveProject().Tasks[0].LabelNames())
+}
+
+func TestHandleFormInput_InvalidLabel(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	km.uiStateManager.ShowTaskForm([]domain.Task{})
+	comps := km.uiStateManager.FormState().GetActiveInputComponents()
+	comps.NameInput.SetValue("Task")
+	comps.SetLabelNames([]string{"tech/debt"})
+
+	simulateKeyType(km, tea.KeyEnter)
+
+	assertViewState(t, km, FormView)
+	assertFormError(t, km, "Invalid label")
+}
+
+func TestHandleFormInput_DueDate(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	km.uiStateManager.ShowTaskForm([]domain.Task{})
+	comps := km.uiStateManager.FormState().GetActiveInputComponents()
+	comps.NameInput.SetValue("Dated Task")
+
+	// Tab past labels to reach the due date
+	for i := 0; i < 6; i++ {
+		simulateKeyType(km, tea.KeyTab)
+	}
+	assert.Equal(t, 6, comps.FocusedField)
+
+	simulateKeyPress(km, "2030-01-15")
+	simulateKeyType(km, tea.KeyEnter)
+	assertViewState(t, km, BoardView)
+
+	activeProj := km.projectManager.GetActiveProject()
+	require.Len(t, activeProj.Tasks, 1)
+	assert.Equal(t, "2030-01-15", domain.FormatDate(activeProj.Tasks[0].DueDate))
+
+	// Editing starts from the current due date; clearing the field removes it
+	task := activeProj.Tasks[0]
+	km.ShowTaskEditForm(task.ID, task.Name, task.Desc, task.Priority, task.Type, task.BlockedBy)
+	assert.Equal(t, "2030-01-15", comps.DueInput.Value())
+
+	comps.SetDueDate(nil)
+	require.NoError(t, km.SubmitCurrentForm())
+
+	stored, err := km.taskService.GetTask(task.ID)
+	require.NoError(t, err)
+	assert.Nil(t, stored.DueDate)
+	assert.Nil(t, km.projectManager.GetActiveProject().Tasks[0].DueDate)
+}
+
+func TestHandleFormInput_InvalidDueDate(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	km.uiStateManager.ShowTaskForm([]domain.Task{})
+	comps := km.uiStateManager.FormState().GetActiveInputComponents()
+	comps.NameInput.SetValue("Task")
+	comps.DueInput.SetValue("next someday")
+
+	simulateKeyType(km, tea.KeyEnter)
+
+	assertViewState(t, km, FormView)
+	assertFormError(t, km, "Invalid due date")
+}
+
+func TestHandleFormInput_MultipleBlockers(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	activeProj := km.projectManager.GetActiveProject()
+	var blockers []domain.Task
+	for _, name := range []string{"Design", "Build"} {
+		task, err := km.taskService.CreateTask(name, "", activeProj.ID, domain.RegularTask, domain.Medium, nil)
+		require.NoError(t, err)
+		activeProj.Tasks = append(activeProj.Tasks, *task)
+		blockers = append(blockers, *task)
+	}
+
+	km.ShowTaskForm()
+	comps := km.uiStateManager.FormState().GetActiveInputComponents()
+	comps.NameInput.SetValue("Ship")
+
+	for i := 0; i < 4; i++ {
+		simulateKeyType(km, tea.KeyTab)
+	}
+	assert.Equal(t, 4, comps.FocusedField)
+
+	// Space toggles the highlighted task; arrows move the highlight
+	simulateKeyPress(km, " ")
+	simulateKeyType(km, tea.KeyDown)
+	simulateKeyPress(km, " ")
+	assert.Equal(t, []int{blockers[0].IntID, blockers[1].IntID}, comps.BlockedByValues)
+	simulateKeyPress(km, " ")
+	simulateKeyPress(km, " ")
+
+	simulateKeyType(km, tea.KeyEnter)
+	assertViewState(t, km, BoardView)
+
+	activeProj = km.projectManager.GetActiveProject()
+	require.Len(t, activeProj.Tasks, 3)
+	var ship domain.Task
+	for _, task := range activeProj.Tasks {
+		if task.Name == "Ship" {
+			ship = task
+		}
+	}
+	assert.Equal(t, []int{blockers[0].IntID, blockers[1].IntID}, ship.BlockedBy)
+
+	// Making a blocker wait on its own dependent is rejected in the form
+	design := blockers[0]
+	km.ShowTaskEditForm(design.ID, design.Name, design.Desc, design.Priority, design.Type, design.BlockedBy)
+	comps.BlockedByValues = []int{ship.IntID}
+	assert.Error(t, km.SubmitCurrentForm())
+	assertFormError(t, km, "dependency cycle")
+
+	stored, err := km.taskService.GetTask(design.ID)
+	require.NoError(t, err)
+	assert.Empty(t, stored.BlockedBy)
+}
+
+func TestHandleDependencyView(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	activeProj := km.projectManager.GetActiveProject()
+	design, err := km.taskService.CreateTask("Design", "", activeProj.ID, domain.RegularTask, domain.Medium, nil)
+	require.NoError(t, err)
+	build, err := km.taskService.CreateTask("Build", "", activeProj.ID, domain.RegularTask, domain.Medium, []int{design.IntID})
+	require.NoError(t, err)
+
+	km.ShowDependencies(*build)
+	assertViewState(t, km, DependencyView)
+	assert.Equal(t, build.IntID, km.uiStateManager.DependencyState().GetRootID())
+	assert.Contains(t, km.View(), "#1 Design")
+
+	simulateKeyType(km, tea.KeyEsc)
+	assertViewState(t, km, BoardView)
+	assert.Empty(t, km.uiStateManager.DependencyState().GetTasks())
+}
+
+func TestHandleChecklistView(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	require.NoError(t, km.CreateTask("Story", ""))
+	activeProj := km.projectManager.GetActiveProject()
+	story := activeProj.Tasks[0]
+
+	simulateKeyPress(km, "c")
+	assertViewState(t, km, ChecklistView)
+	listState := km.uiStateManager.ChecklistState()
+	assert.Equal(t, story.ID, listState.GetTask().ID)
+
+	// Items are typed into the pane's input and saved with enter
+	for _, text := range []string{"Write tests", "Update docs"} {
+		simulateKeyPress(km, "a")
+		require.True(t, listState.IsEditing())
+		simulateKeyPress(km, text)
+		simulateKeyType(km, tea.KeyEnter)
+		require.False(t, listState.IsEditing(), listState.GetError())
+	}
+	require.Len(t, listState.GetItems(), 2)
+	assert.Equal(t, 1, listState.GetCursor(), "The cursor follows the new item")
+
+	// Ticking an item off updates the progress on the board
+	simulateKeyPress(km, " ")
+	assert.True(t, listState.GetItems()[1].Done)
+	assert.Equal(t, "1/2", activeProj.Tasks[0].ChecklistProgress())
+	assert.Contains(t, km.View(), "(1/2)")
+
+	// Moving keeps the item under the cursor
+	simulateKeyPress(km, "K")
+	assert.Equal(t, "Update docs", listState.GetItems()[0].Text)
+	assert.Equal(t, 0, listState.GetCursor())
+
+	// Done items cannot be promoted
+	simulateKeyPress(km, "p")
+	assert.NotEmpty(t, listState.GetError())
+
+	// Promoting makes a task the story waits on
+	simulateKeyPress(km, "j")
+	simulateKeyPress(km, "p")
+	assert.Empty(t, listState.GetError())
+	require.Len(t, listState.GetItems(), 1)
+	require.Len(t, activeProj.Tasks, 2)
+
+	var promoted, parent domain.Task
+	for _, task := range activeProj.Tasks {
+		if task.Name == "Write tests" {
+			promoted = task
+		} else {
+			parent = task
+		}
+	}
+	assert.Equal(t, []int{promoted.IntID}, parent.BlockedBy)
+	assert.Equal(t, "1/1", parent.ChecklistProgress())
+
+	simulateKeyType(km, tea.KeyEsc)
+	assertViewState(t, km, BoardView)
+}
+
+func TestHandleDetailView(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+	km.SetAuthor("alice")
+
+	require.NoError(t, km.CreateTask("Flaky test", "Fails on CI"))
+	activeProj := km.projectManager.GetActiveProject()
+
+	simulateKeyPress(km, "v")
+	assertViewState(t, km, DetailView)
+	detailState := km.uiStateManager.DetailState()
+	assert.Contains(t, km.View(), "Fails on CI")
+	assert.Contains(t, km.View(), "No comments yet")
+
+	// Comments are typed into the text area, where enter starts a new line
+	simulateKeyPress(km, "a")
+	require.True(t, detailState.IsCommenting())
+	simulateKeyPress(km, "Raised the")
+	simulateKeyType(km, tea.KeyEnter)
+	simulateKeyPress(km, "timeout")
+	simulateKeyType(km, tea.KeyCtrlS)
+	require.False(t, detailState.IsCommenting(), detailState.GetError())
+
+	comments := detailState.GetTask().Comments
+	require.Len(t, comments, 1)
+	assert.Equal(t, "alice", comments[0].Author)
+	assert.Equal(t, "Raised the\ntimeout", comments[0].Body)
+	assert.Len(t, activeProj.Tasks[0].Comments, 1, "The board copy carries the comment")
+	assert.Contains(t, km.View(), "Comments (1)")
+
+	// Empty comments are rejected and the text area stays open
+	simulateKeyPress(km, "a")
+	simulateKeyType(km, tea.KeyCtrlS)
+	assert.True(t, detailState.IsCommenting())
+	assert.NotEmpty(t, detailState.GetError())
+	simulateKeyType(km, tea.KeyEsc)
+	assert.False(t, detailState.IsCommenting())
+
+	// The history replaces the comments until h is pressed again
+	simulateKeyPress(km, "h")
+	require.True(t, detailState.IsShowingHistory())
+	history := detailState.GetHistory()
+	require.Len(t, history, 1)
+	assert.Equal(t, domain.EventCreated, history[0].Kind)
+	assert.Equal(t, "alice", history[0].Actor)
+	assert.Contains(t, km.View(), "History (1)")
+	simulateKeyPress(km, "h")
+	assert.False(t, detailState.IsShowingHistory())
+
+	simulateKeyType(km, tea.KeyEsc)
+	assertViewState(t, km, BoardView)
+
+	// Search matches comment text
+	km.searchState.Activate()
+	km.searchState.SetQuery("timeout")
+	km.RefreshTasksWithSearch()
+	assert.Equal(t, 1, km.searchState.GetMatchCount())
+}
+
+func TestHandleDetailView_Links(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	activeProj := km.projectManager.GetActiveProject()
+	design, err := km.taskService.CreateTask("Design", "", activeProj.ID, domain.RegularTask, domain.Medium, nil)
+	require.NoError(t, err)
+	build, err := km.taskService.CreateTask("Build", "", activeProj.ID, domain.RegularTask, domain.Medium, []int{design.IntID})
+	require.NoError(t, err)
+	release, err := km.taskService.CreateTask("Release", "", activeProj.ID, domain.RegularTask, domain.Medium, []int{build.IntID})
+	require.NoError(t, err)
+	km.RefreshTasksWithSearch()
+
+	km.ShowTaskDetail(*build)
+	assertViewState(t, km, DetailView)
+	detailState := km.uiStateManager.DetailState()
+	require.Len(t, detailState.GetBlockers(), 1)
+	require.Len(t, detailState.GetDependents(), 1)
+	view := km.View()
+	assert.Contains(t, view, "Blocked by")
+	assert.Contains(t, view, "#1 Design")
+	assert.Contains(t, view, "#3 Release")
+	assert.Contains(t, view, "Created ")
+
+	// Without a highlighted link enter opens nothing
+	simulateKeyType(km, tea.KeyEnter)
+	assert.Equal(t, build.ID, detailState.GetTask().ID)
+
+	// b jumps to the blocker and backspace comes back
+	simulateKeyPress(km, "b")
+	assert.Equal(t, design.ID, detailState.GetTask().ID)
+	assert.Empty(t, detailState.GetBlockers())
+	assert.Contains(t, km.View(), "#2 Build")
+	simulateKeyPress(km, "b")
+	assert.NotEmpty(t, detailState.GetError(), "Design waits on nothing")
+	simulateKeyType(km, tea.KeyBackspace)
+	assert.Equal(t, build.ID, detailState.GetTask().ID)
+	assert.False(t, detailState.CanGoBack())
+
+	// tab walks the blockers, then the dependents
+	simulateKeyType(km, tea.KeyTab)
+	simulateKeyType(km, tea.KeyTab)
+	simulateKeyType(km, tea.KeyEnter)
+	assert.Equal(t, release.ID, detailState.GetTask().ID)
+
+	// Closing leaves the last shown task selected on the board
+	simulateKeyType(km, tea.KeyEsc)
+	assertViewState(t, km, BoardView)
+	selected, ok := km.navState.GetActiveList().SelectedItem().(styles.TaskWithTitle)
+	require.True(t, ok)
+	assert.Equal(t, release.ID, selected.ID)
+
+	// enter on the board opens the detail of the selected task
+	simulateKeyType(km, tea.KeyEnter)
+	assertViewState(t, km, DetailView)
+	assert.Equal(t, release.ID, detailState.GetTask().ID)
+}
+
+func TestHandleNormalMode_UndoRedoMoves(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	simulateKeyPress(km, "u")
+	assert.Equal(t, "Nothing to undo", km.notice)
+
+	activeProj := km.projectManager.GetActiveProject()
+	design, err := km.taskService.CreateTask("Design", "", activeProj.ID, domain.RegularTask, domain.Medium, nil)
+	require.NoError(t, err)
+	build, err := km.taskService.CreateTask("Build", "", activeProj.ID, domain.RegularTask, domain.Medium, []int{design.IntID})
+	require.NoError(t, err)
+	km.RefreshTasksWithSearch()
+
+	require.NoError(t, km.MoveTaskToNextStatus(design.ID))
+	require.NoError(t, km.MoveTaskToNextStatus(design.ID))
+	assertTaskCount(t, km, domain.Done, 1)
+	released, _ := km.taskService.GetTask(build.ID)
+	require.Empty(t, released.BlockedBy, "Finishing the blocker releases its dependent")
+
+	simulateKeyPress(km, "u")
+	assert.Equal(t, "Undid move to Done on #1 Design", km.notice)
+	assertTaskCount(t, km, domain.InProgress, 1)
+	blocked, _ := km.taskService.GetTask(build.ID)
+	assert.Equal(t, []int{design.IntID}, blocked.BlockedBy, "Undoing the move makes the dependent wait again")
+
+	simulateKeyPress(km, "u")
+	assertTaskCount(t, km, domain.NotStarted, 2)
+
+	simulateKeyType(km, tea.KeyCtrlR)
+	assert.Equal(t, "Redid move to In Progress on #1 Design", km.notice)
+	assertTaskCount(t, km, domain.InProgress, 1)
+
+	// A new change discards what is left to redo
+	require.NoError(t, km.MoveTaskToPreviousStatus(design.ID))
+	simulateKeyType(km, tea.KeyCtrlR)
+	assert.Equal(t, "Nothing to redo", km.notice)
+
+	// A step that can no longer be applied is reported and dropped
+	require.NoError(t, km.taskService.DeleteTask(design.ID))
+	simulateKeyPress(km, "u")
+	assert.Contains(t, km.notice, "cannot undo move to Not Started on #1 Design")
+	simulateKeyPress(km, "u")
+	assert.Contains(t, km.notice, "cannot undo move to In Progress", "The next step is the one below it")
+
+	history, err := km.eventLog.List(domain.EventFilter{TaskIntID: design.IntID})
+	require.NoError(t, err)
+	var undos []string
+	for _, event := range history {
+		if event.Kind == domain.EventUndone || event.Kind == domain.EventRedone {
+			undos = append(undos, event.Describe())
+		}
+	}
+	assert.Equal(t, []string{"undid move to Done", "undid move to In Progress", "redid move to In Progress"}, undos)
+}
+
+func TestHandleNormalMode_UndoChecksWIPLimit(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	activeProj := km.projectManager.GetActiveProject()
+	_, err := km.projectService.SetWIPLimit(activeProj.ID, domain.InProgress, 1)
+	require.NoError(t, err)
+	design := createTestTask(t, km, "Design", "")
+	build := createTestTask(t, km, "Build", "")
+
+	require.NoError(t, km.MoveTaskToNextStatus(design))
+	require.NoError(t, km.MoveTaskToPreviousStatus(design))
+	_, err = km.taskService.UpdateTaskStatus(build, domain.InProgress)
+	require.NoError(t, err)
+
+	// Undoing the move back would put two tasks in In Progress
+	simulateKeyPress(km, "u")
+	assert.Contains(t, km.notice, "cannot undo move to Not Started on #1 Design")
+	assert.Contains(t, km.notice, "WIP limit")
+	unmoved, _ := km.taskService.GetTask(design)
+	assert.Equal(t, domain.NotStarted, unmoved.Status, "Nothing is written")
+
+	// The step stays on the stack and applies once the column has room
+	_, err = km.taskService.UpdateTaskStatus(build, domain.NotStarted)
+	require.NoError(t, err)
+	simulateKeyPress(km, "u")
+	assert.Equal(t, "Undid move to Not Started on #1 Design", km.notice)
+	assertTaskCount(t, km, domain.InProgress, 1)
+
+	// Under WIPWarn the step is made and the column reported
+	km.SetWIPEnforcement(domain.WIPWarn)
+	require.NoError(t, km.MoveTaskToPreviousStatus(design))
+	_, err = km.taskService.UpdateTaskStatus(build, domain.InProgress)
+	require.NoError(t, err)
+	simulateKeyPress(km, "u")
+	assert.Equal(t, "Undid move to Not Started on #1 Design · WIP limit: 'In Progress' is over its WIP limit (2/1)", km.notice)
+	assertTaskCount(t, km, domain.InProgress, 2)
+}
+
+func TestHandleNormalMode_UndoTaskCreateAndDelete(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+	km.SetAuthor("alice")
+
+	require.NoError(t, km.CreateTask("Design", ""))
+	activeProj := km.projectManager.GetActiveProject()
+	design := activeProj.Tasks[0]
+	build, err := km.taskService.CreateTask("Build", "", activeProj.ID, domain.RegularTask, domain.Medium, []int{design.IntID})
+	require.NoError(t, err)
+	_, err = km.taskService.AddComment(design.ID, "alice", "Use the old schema")
+	require.NoError(t, err)
+	_, err = km.taskService.AddChecklistItem(design.ID, "Draw tables")
+	require.NoError(t, err)
+
+	km.uiStateManager.ShowTaskDeleteConfirm(design.ID)
+	simulateKeyPress(km, "y")
+	assert.Len(t, km.projectManager.GetActiveProject().Tasks, 1)
+
+	simulateKeyPress(km, "u")
+	assert.Equal(t, "Undid delete on #1 Design", km.notice)
+	restored, err := km.taskService.GetTask(design.ID)
+	require.NoError(t, err)
+	require.NotNil(t, restored)
+	assert.Equal(t, design.IntID, restored.IntID)
+	assert.Len(t, restored.Comments, 1)
+	checklist, _ := km.taskService.GetChecklist(design.ID)
+	assert.Len(t, checklist, 1)
+	dependent, _ := km.taskService.GetTask(build.ID)
+	assert.Equal(t, []int{design.IntID}, dependent.BlockedBy)
+
+	// Undoing the creation deletes the task; redoing it brings back the comment
+	// added after it was created
+	simulateKeyPress(km, "u")
+	assert.Equal(t, "Undid create on #1 Design", km.notice)
+	gone, _ := km.taskService.GetTask(design.ID)
+	assert.Nil(t, gone)
+	simulateKeyType(km, tea.KeyCtrlR)
+	recreated, _ := km.taskService.GetTask(design.ID)
+	require.NotNil(t, recreated)
+	assert.Len(t, recreated.Comments, 1)
+	dependent, _ = km.taskService.GetTask(build.ID)
+	assert.Equal(t, []int{design.IntID}, dependent.BlockedBy)
+}
+
+func TestHandleNormalMode_UndoEdit(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	taskID := createTestTask(t, km, "Design", "")
+	km.ShowTaskEditForm(taskID, "Design", "", domain.Medium, domain.RegularTask, nil)
+	km.uiStateManager.FormState().GetActiveInputComponents().NameInput.SetValue("Design schema")
+	simulateKeyType(km, tea.KeyEnter)
+	assertViewState(t, km, BoardView)
+	edited, _ := km.taskService.GetTask(taskID)
+	require.Equal(t, "Design schema", edited.Name)
+
+	simulateKeyPress(km, "u")
+	assert.Equal(t, "Undid edit on #1 Design schema", km.notice)
+	reverted, _ := km.taskService.GetTask(taskID)
+	assert.Equal(t, "Design", reverted.Name)
+	assert.Equal(t, "Design", km.projectManager.GetActiveProject().Tasks[0].Name)
+}
+
+func TestHandleProjectSwitch_UndoDelete(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	projectID := km.projectManager.GetActiveProjectID()
+	design := createTestTask(t, km, "Design", "")
+	createTestTask(t, km, "Build", "")
+	_, err := km.labelService.SetTaskLabels(design, []string{"backend"})
+	require.NoError(t, err)
+	require.NoError(t, km.projectManager.CreateProject("Other", ""))
+	km.projectManager.SwitchToProject(projectID)
+
+	km.uiStateManager.ShowProjectSwitcher()
+	km.uiStateManager.ShowProjectDeleteConfirm(projectID)
+	simulateKeyPress(km, "y")
+	require.Equal(t, 1, km.projectManager.GetProjectCount())
+	tasks, err := km.taskService.GetTasksByProject(projectID)
+	require.NoError(t, err)
+	require.Empty(t, tasks, "The project's tasks go with it")
+
+	simulateKeyPress(km, "u")
+	assert.Equal(t, "Undid delete (2 tasks) on project Default Project", km.notice)
+	assert.Equal(t, 2, km.projectManager.GetProjectCount())
+	assertActiveProject(t, km, projectID)
+	assertTaskCount(t, km, domain.NotStarted, 2)
+	labelled, _ := km.taskService.GetTask(design)
+	assert.Equal(t, []string{"backend"}, labelled.LabelNames())
+
+	simulateKeyType(km, tea.KeyCtrlR)
+	assert.Equal(t, 1, km.projectManager.GetProjectCount())
+	assert.NotEqual(t, projectID, km.projectManager.GetActiveProjectID())
+}
+
+func TestHandleTrashView(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	design := createTestTask(t, km, "Design", "")
+	build := createTestTask(t, km, "Build", "")
+	require.NoError(t, km.MoveTaskToNextStatus(design))
+	require.NoError(t, km.MoveTaskToNextStatus(design))
+
+	// Only finished tasks can be archived
+	simulateKeyPress(km, "a")
+	assert.Contains(t, km.notice, "can be archived")
+	assertTaskCount(t, km, domain.NotStarted, 1)
+
+	simulateKeyPress(km, "l")
+	simulateKeyPress(km, "l")
+	simulateKeyPress(km, "a")
+	assert.Equal(t, "Archived #1 Design", km.notice)
+	assertTaskCount(t, km, domain.Done, 0)
+
+	km.uiStateManager.ShowTaskDeleteConfirm(build)
+	simulateKeyPress(km, "y")
+	assertTaskCount(t, km, domain.NotStarted, 0)
+
+	simulateKeyPress(km, "t")
+	assertViewState(t, km, TrashView)
+	trashState := km.uiStateManager.TrashState()
+	assert.Contains(t, km.View(), "Archive (1)")
+	assert.Contains(t, km.View(), "Trash (1)")
+
+	simulateKeyPress(km, "r")
+	assert.Equal(t, "Unarchived #1 Design", km.notice)
+	assert.Empty(t, trashState.GetArchived())
+	assertTaskCount(t, km, domain.Done, 1)
+
+	simulateKeyType(km, tea.KeyTab)
+	require.True(t, trashState.IsShowingTrash())
+	simulateKeyPress(km, "D")
+	require.True(t, trashState.IsConfirming())
+	simulateKeyPress(km, "n")
+	assert.Len(t, trashState.GetTrashedTasks(), 1, "Purging waits for confirmation")
+
+	simulateKeyPress(km, "r")
+	assert.Equal(t, "Restored #2 Build", km.notice)
+	assertTaskCount(t, km, domain.NotStarted, 1)
+
+	km.uiStateManager.ShowTaskDeleteConfirm(build)
+	simulateKeyPress(km, "y")
+	simulateKeyPress(km, "t")
+	simulateKeyType(km, tea.KeyTab)
+	simulateKeyPress(km, "D")
+	simulateKeyPress(km, "y")
+	assert.Equal(t, "Deleted #2 Build permanently", km.notice)
+	assert.Empty(t, trashState.GetTrashedTasks())
+	gone, err := km.taskService.GetTrashedTasks("")
+	require.NoError(t, err)
+	assert.Empty(t, gone)
+
+	simulateKeyType(km, tea.KeyEsc)
+	assertViewState(t, km, BoardView)
+}
+
+func TestHandleTrashView_RestoreProject(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	projectID := km.projectManager.GetActiveProjectID()
+	createTestTask(t, km, "Design", "")
+	require.NoError(t, km.projectManager.CreateProject("Other", ""))
+	require.NoError(t, km.DeleteProject(projectID))
+	require.Equal(t, 1, km.projectManager.GetProjectCount())
+
+	simulateKeyPress(km, "t")
+	simulateKeyType(km, tea.KeyTab)
+	assert.Contains(t, km.View(), "project Default Project")
+	simulateKeyPress(km, "r")
+	assert.Equal(t, "Restored project Default Project", km.notice)
+	assert.Equal(t, 2, km.projectManager.GetProjectCount())
+	assertActiveProject(t, km, projectID)
+	assertTaskCount(t, km, domain.NotStarted, 1)
+}
+
+func TestHandleGlobalSearch(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	activeProj := km.projectManager.GetActiveProject()
+	_, err := km.taskService.CreateTask("Fix login", "", activeProj.ID, domain.RegularTask, domain.Medium, nil)
+	require.NoError(t, err)
+	otherID := createTestProject(t, km, "Mobile", "")
+	session, err := km.taskService.CreateTask("Refresh sessions", "Users land on the login page", otherID, domain.RegularTask, domain.Low, nil)
+	require.NoError(t, err)
+	_, err = km.taskService.UpdateTaskStatus(session.ID, domain.InProgress)
+	require.NoError(t, err)
+
+	simulateKeyPress(km, "f")
+	assertViewState(t, km, GlobalSearchView)
+	for _, char := range "login pa" {
+		simulateKeyPress(km, string(char))
+	}
+	globalState := km.uiStateManager.GlobalSearchState()
+	assert.Equal(t, "login pa", globalState.GetQuery())
+	results := globalState.GetResults()
+	require.Len(t, results, 1, "Every word has to match")
+	assert.Equal(t, session.ID, results[0].Task.ID)
+	view := km.View()
+	assert.Contains(t, view, "Refresh sessions")
+	assert.Contains(t, view, "Mobile")
+
+	simulateKeyType(km, tea.KeyBackspace)
+	simulateKeyType(km, tea.KeyBackspace)
+	simulateKeyType(km, tea.KeyBackspace)
+	require.Len(t, globalState.GetResults(), 2, "Both projects match")
+	assert.Equal(t, "Fix login", globalState.GetResults()[0].Task.Name, "Name matches rank first")
+
+	// enter switches to the result's project and selects the task
+	simulateKeyType(km, tea.KeyDown)
+	simulateKeyType(km, tea.KeyEnter)
+	assertViewState(t, km, BoardView)
+	assertActiveProject(t, km, otherID)
+	assert.Equal(t, domain.InProgress, km.navState.GetActiveListIndex())
+	selected, ok := km.navState.GetActiveList().SelectedItem().(styles.TaskWithTitle)
+	require.True(t, ok)
+	assert.Equal(t, session.ID, selected.ID)
+
+	// esc closes without moving
+	simulateKeyPress(km, "f")
+	simulateKeyType(km, tea.KeyEsc)
+	assertViewState(t, km, BoardView)
+	assertActiveProject(t, km, otherID)
+}
+
+func TestHandleSavedViews(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	activeProj := km.projectManager.GetActiveProject()
+	_, err := km.taskService.CreateTask("Login bug", "", activeProj.ID, domain.RegularTask, domain.High, nil)
+	require.NoError(t, err)
+	_, err = km.taskService.CreateTask("Dark mode", "", activeProj.ID, domain.RegularTask, domain.Low, nil)
+	require.NoError(t, err)
+	km.RefreshTasksWithSearch()
+
+	// ctrl+s in search mode names the query and applies it as a view
+	simulateKeyPress(km, "/")
+	for _, char := range "priority:high" {
+		simulateKeyPress(km, string(char))
+	}
+	simulateKeyType(km, tea.KeyCtrlS)
+	assertViewState(t, km, SavedViewsView)
+	simulateKeyType(km, tea.KeyEnter)
+	assert.NotEmpty(t, km.uiStateManager.SavedViewsState().GetError(), "A view needs a name")
+	for _, char := range "Urgent" {
+		simulateKeyPress(km, string(char))
+	}
+	simulateKeyType(km, tea.KeyEnter)
+	assertViewState(t, km, BoardView)
+	assertSearchActive(t, km, false)
+	assert.Len(t, km.GetTaskItems(domain.NotStarted), 1)
+	assert.Contains(t, km.View(), "View: Urgent")
+
+	views, err := km.viewService.GetViews(activeProj.ID)
+	require.NoError(t, err)
+	require.Len(t, views, 1)
+	assert.Equal(t, activeProj.ID, views[0].ProjectID, "Views are saved for the project unless toggled global")
+
+	// A search narrows the view further
+	simulateKeyPress(km, "/")
+	for _, char := range "dark" {
+		simulateKeyPress(km, string(char))
+	}
+	assert.Len(t, km.GetTaskItems(domain.NotStarted), 0)
+	simulateKeyType(km, tea.KeyEsc)
+	assert.Len(t, km.GetTaskItems(domain.NotStarted), 1)
+
+	// 0 in the picker shows all tasks again, and 1 applies the first view
+	simulateKeyPress(km, "s")
+	assertViewState(t, km, SavedViewsView)
+	simulateKeyPress(km, "0")
+	assertViewState(t, km, BoardView)
+	assert.Len(t, km.GetTaskItems(domain.NotStarted), 2)
+	assert.NotContains(t, km.View(), "View:")
+	simulateKeyPress(km, "s")
+	simulateKeyPress(km, "1")
+	assert.Len(t, km.GetTaskItems(domain.NotStarted), 1)
+
+	// Switching projects drops the view of the previous one
+	require.NoError(t, km.CreateProject("Mobile", ""))
+	assert.NotEqual(t, activeProj.ID, km.GetActiveProjectID())
+	assert.Nil(t, km.uiStateManager.SavedViewsState().GetActive())
+	assert.NotContains(t, km.View(), "View:")
+
+	// D deletes the highlighted view
+	simulateKeyPress(km, "p")
+	simulateKeyPress(km, "k")
+	simulateKeyType(km, tea.KeyEnter)
+	assertActiveProject(t, km, activeProj.ID)
+	simulateKeyPress(km, "s")
+	simulateKeyType(km, tea.KeyDown)
+	simulateKeyPress(km, "D")
+	assert.Empty(t, km.uiStateManager.SavedViewsState().GetViews())
+	views, err = km.viewService.GetViews(activeProj.ID)
+	require.NoError(t, err)
+	assert.Empty(t, views)
+}
+
+func TestHandleMyWork(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	website := km.projectManager.GetActiveProject()
+	login, err := km.taskService.CreateTask("Fix login", "", website.ID, domain.RegularTask, domain.High, nil)
+	require.NoError(t, err)
+	_, err = km.taskService.CreateTask("Tweak footer", "", website.ID, domain.RegularTask, domain.Low, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, km.CreateProject("API", ""))
+	apiID := km.GetActiveProjectID()
+	limits, err := km.taskService.CreateTask("Rate limits", "", apiID, domain.RegularTask, domain.Low, nil)
+	require.NoError(t, err)
+	moveTaskToStatus(t, km, limits.ID, domain.InProgress)
+
+	names := func(column int) []string {
+		var names []string
+		for _, item := range km.myWork.GetTaskItems(domain.Status(column)) {
+			names = append(names, item.(styles.TaskWithTitle).Name)
+		}
+		return names
+	}
+
+	// w gathers the high-priority tasks waiting to start and those in progress
+	simulateKeyPress(km, "w")
+	require.True(t, km.myWork.IsActive())
+	assert.Equal(t, []string{"Fix login"}, names(domain.MyWorkUpNext))
+	assert.Equal(t, []string{"Rate limits"}, names(domain.MyWorkInProgress))
+	view := ansi.Strip(km.View())
+	assert.Regexp(t, website.Name+" .*Fix login", view, "Cards start with their project's name")
+	assert.Regexp(t, "API .*Rate limits", view)
+
+	// Moves apply to the task's own project, not the active one
+	simulateKeyPress(km, " ")
+	moved, err := km.taskService.GetTask(login.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.InProgress, moved.Status)
+	assert.Empty(t, names(domain.MyWorkUpNext))
+	assert.ElementsMatch(t, []string{"Fix login", "Rate limits"}, names(domain.MyWorkInProgress))
+	assert.Equal(t, apiID, km.GetActiveProjectID())
+
+	simulateKeyPress(km, "u")
+	assert.Equal(t, []string{"Fix login"}, names(domain.MyWorkUpNext))
+	assert.Equal(t, apiID, km.GetActiveProjectID(), "Undo keeps the board behind My Work")
+
+	// Edits are saved to the task and return to My Work
+	simulateKeyPress(km, "e")
+	assertViewState(t, km, FormView)
+	comps := km.uiStateManager.FormState().GetActiveInputComponents()
+	comps.NameInput.SetValue("Fix login redirect")
+	simulateKeyType(km, tea.KeyEnter)
+	assertViewState(t, km, BoardView)
+	edited, err := km.taskService.GetTask(login.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Fix login redirect", edited.Name)
+	assert.Equal(t, []string{"Fix login redirect"}, names(domain.MyWorkUpNext))
+	assert.True(t, km.myWork.IsActive())
+
+	// o opens the task on its project's board
+	simulateKeyPress(km, "o")
+	assert.False(t, km.myWork.IsActive())
+	assert.Equal(t, website.ID, km.GetActiveProjectID())
+	selected, ok := km.getSelectedTask()
+	require.True(t, ok)
+	assert.Equal(t, login.ID, selected.ID)
+
+	simulateKeyPress(km, "w")
+	simulateKeyType(km, tea.KeyEsc)
+	assert.False(t, km.myWork.IsActive())
+}
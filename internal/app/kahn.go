@@ -1,8 +1,12 @@
 package app
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"kahn/internal/config"
 	"kahn/internal/database"
 	"kahn/internal/domain"
 	repo "kahn/internal/repository"
@@ -21,15 +25,23 @@ type KahnModel struct {
 	database        *database.Database
 	taskService     *services.TaskService
 	projectService  *services.ProjectService
+	labelService    *services.LabelService
+	viewService     *services.SavedViewService
+	eventLog        *services.EventLog
+	undoStack       *UndoStack
 	board           *components.Board
 	projectSwitcher *components.ProjectSwitcher
 	version         string
+	exportDir       string // directory the Markdown export key writes into
+	author          string // signs the comments written in the detail pane
+	notice          string // one-line message shown in place of the footer until the next key press
 
 	// State managers
 	uiStateManager *UIStateManager
 	projectManager *ProjectManager
 	navState       *NavigationState
 	searchState    *SearchState
+	myWork         *MyWorkState
 }
 
 func (km KahnModel) Init() tea.Cmd {
@@ -71,16 +83,88 @@ func (km *KahnModel) renderProjectDeleteConfirm() string {
 	return km.board.GetRenderer().RenderTaskDeleteConfirmWithError(nil, confirmState.GetProjectError(), km.width, km.height)
 }
 
+// renderDependencies renders the blocker tree of the task the overlay was opened on
+func (km *KahnModel) renderDependencies() string {
+	activeProj := km.GetActiveProject()
+	if activeProj == nil {
+		return ""
+	}
+	depState := km.uiStateManager.DependencyState()
+	return km.board.GetRenderer().RenderDependencyTree(depState.GetRootID(), depState.GetTasks(), activeProj.Workflow, km.width, km.height)
+}
+
+// renderChecklist renders the checklist pane of the task it was opened on
+func (km *KahnModel) renderChecklist() string {
+	listState := km.uiStateManager.ChecklistState()
+	return km.board.GetRenderer().RenderChecklist(listState.GetTask(), listState.GetItems(), listState.GetCursor(),
+		listState.InputView(), listState.GetError(), km.width, km.height)
+}
+
+// renderTaskDetail renders the detail pane of the task it was opened on
+func (km *KahnModel) renderTaskDetail() string {
+	detailState := km.uiStateManager.DetailState()
+	// Tasks opened from the My Work board may belong to another project
+	var workflow domain.Workflow
+	if project := km.projectManager.GetProject(detailState.GetTask().ProjectID); project != nil {
+		workflow = project.Workflow
+	} else if activeProj := km.GetActiveProject(); activeProj != nil {
+		workflow = activeProj.Workflow
+	}
+	return km.board.GetRenderer().RenderTaskDetail(detailState.GetTask(), workflow, detailState.GetBlockers(),
+		detailState.GetDependents(), detailState.GetLinkCursor(), detailState.CanGoBack(), detailState.GetHistory(),
+		detailState.IsShowingHistory(), detailState.GetOffset(), detailState.InputView(), detailState.GetError(),
+		km.width, km.height)
+}
+
+// renderTrash renders the pane listing archived and trashed entries
+func (km *KahnModel) renderTrash() string {
+	trashState := km.uiStateManager.TrashState()
+	var workflow domain.Workflow
+	if activeProj := km.GetActiveProject(); activeProj != nil {
+		workflow = activeProj.Workflow
+	}
+	return km.board.GetRenderer().RenderTrash(trashState.IsShowingTrash(), trashState.GetArchived(), trashState.GetTrashedTasks(),
+		trashState.GetTrashedProjects(), workflow, trashState.GetCursor(), trashState.IsConfirming(), trashState.GetError(),
+		km.width, km.height)
+}
+
+// renderGlobalSearch renders the pane searching every project
+func (km *KahnModel) renderGlobalSearch() string {
+	globalState := km.uiStateManager.GlobalSearchState()
+	return km.board.GetRenderer().RenderGlobalSearch(globalState.GetQuery(), globalState.GetResults(),
+		globalState.GetCursor(), globalState.GetError(), km.width, km.height)
+}
+
+// renderSavedViews renders the picker of saved views, or the prompt naming one
+func (km *KahnModel) renderSavedViews() string {
+	viewsState := km.uiStateManager.SavedViewsState()
+	activeID := ""
+	if active := viewsState.GetActive(); active != nil {
+		activeID = active.ID
+	}
+	return km.board.GetRenderer().RenderSavedViews(viewsState.GetViews(), activeID, viewsState.GetCursor(),
+		viewsState.IsNaming(), viewsState.GetName(), viewsState.GetQuery(), viewsState.IsGlobal(),
+		viewsState.GetError(), km.width, km.height)
+}
+
 // renderNoProjects renders the no projects state
 func (km *KahnModel) renderNoProjects() string {
 	return km.board.GetRenderer().RenderNoProjectsBoard(km.width, km.height)
 }
 
+// renderMyWork renders the My Work board in place of the project board
+func (km *KahnModel) renderMyWork() string {
+	return km.board.GetRenderer().RenderMyWork(km.myWork.Tasks, km.myWork.GetActiveListIndex(), km.width, km.version, km.notice)
+}
+
 // renderBoard renders the main board view with task lists
 func (km *KahnModel) renderBoard() string {
 	if !km.projectManager.HasProjects() {
 		return km.renderNoProjects()
 	}
+	if km.myWork.IsActive() {
+		return km.renderMyWork()
+	}
 
 	activeProj := km.projectManager.GetActiveProject()
 	if activeProj == nil {
@@ -95,9 +179,13 @@ func (km *KahnModel) renderBoard() string {
 		navState.GetActiveListIndex(),
 		km.width,
 		km.version,
+		km.activeViewName(),
 		km.searchState.IsActive(),
 		km.searchState.GetQuery(),
+		km.searchState.IsFuzzy(),
 		km.searchState.GetMatchCount(),
+		km.searchState.GetError(),
+		km.notice,
 	)
 }
 
@@ -114,6 +202,18 @@ func (km KahnModel) View() string {
 		return km.renderProjectDeleteConfirm()
 	case NoProjectsView:
 		return km.renderNoProjects()
+	case DependencyView:
+		return km.renderDependencies()
+	case ChecklistView:
+		return km.renderChecklist()
+	case DetailView:
+		return km.renderTaskDetail()
+	case TrashView:
+		return km.renderTrash()
+	case GlobalSearchView:
+		return km.renderGlobalSearch()
+	case SavedViewsView:
+		return km.renderSavedViews()
 	default: // BoardView
 		return km.renderBoard()
 	}
@@ -123,30 +223,97 @@ func (km *KahnModel) GetActiveProject() *domain.Project {
 	return km.projectManager.GetActiveProject()
 }
 
-// RefreshTasksWithSearch updates task lists applying the current search filter if active,
-// or shows all tasks if search is inactive. Updates the match count when search is active.
+// RefreshTasksWithSearch updates task lists applying the saved view and the current
+// search filter if active, ranking tasks by score in fuzzy mode, or shows all tasks
+// if neither is. Updates the match count when search is active, and the My Work
+// board when it is open.
 func (km *KahnModel) RefreshTasksWithSearch() {
+	if km.myWork.IsActive() {
+		km.RefreshMyWork()
+	}
+
 	activeProj := km.GetActiveProject()
 	if activeProj == nil {
 		return
 	}
 
-	if km.searchState.IsActive() {
+	viewFilter, viewActive := km.activeViewFilter(activeProj)
+	if km.searchState.IsActive() && km.searchState.IsFuzzy() {
+		km.searchState.SetFilter(domain.TaskQuery{})
+		km.navState.UpdateTaskListsWithFuzzy(activeProj, km.taskService, viewFilter, km.searchState.GetQuery())
+		km.searchState.UpdateMatchCount(len(domain.FuzzyFilter(viewFilter.Filter(activeProj.Tasks), km.searchState.GetQuery())))
+	} else if km.searchState.IsActive() {
+		filter, err := domain.ParseTaskQuery(km.searchState.GetQuery(), activeProj.Workflow, time.Now())
+		var validationErr *domain.ValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			km.searchState.SetError(validationErr.Message)
+		case err != nil:
+			km.searchState.SetError(err.Error())
+		default:
+			km.searchState.SetFilter(filter)
+		}
+
+		combined := viewFilter.And(km.searchState.GetFilter())
 		km.navState.UpdateTaskListsWithSearch(
 			activeProj,
 			km.taskService,
-			km.searchState.GetQuery(),
+			combined,
 		)
 
 		// Update match count
-		allTasks := activeProj.Tasks
-		matchCount := domain.CountSearchMatches(allTasks, km.searchState.GetQuery())
-		km.searchState.UpdateMatchCount(matchCount)
+		km.searchState.UpdateMatchCount(combined.Count(activeProj.Tasks))
+	} else if viewActive {
+		km.navState.UpdateTaskListsWithSearch(activeProj, km.taskService, viewFilter)
 	} else {
 		km.navState.UpdateTaskLists(activeProj, km.taskService)
 	}
 }
 
+// activeViewFilter parses the query of the applied saved view with the project's
+// workflow. A view saved for another project, or whose query no longer parses,
+// such as after a workflow change, is dropped.
+func (km *KahnModel) activeViewFilter(project *domain.Project) (domain.TaskQuery, bool) {
+	viewsState := km.uiStateManager.SavedViewsState()
+	view := viewsState.GetActive()
+	if view == nil {
+		return domain.TaskQuery{}, false
+	}
+	if !view.IsGlobal() && view.ProjectID != project.ID {
+		viewsState.SetActive(nil)
+		return domain.TaskQuery{}, false
+	}
+
+	filter, err := domain.ParseTaskQuery(view.Query, project.Workflow, time.Now())
+	if err != nil {
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			err = errors.New(validationErr.Message)
+		}
+		km.notice = fmt.Sprintf("View %s no longer applies: %v", view.Name, err)
+		viewsState.SetActive(nil)
+		return domain.TaskQuery{}, false
+	}
+	return filter, true
+}
+
+// activeViewName returns the name of the saved view applied to the active
+// project's board, or "" when there is none
+func (km *KahnModel) activeViewName() string {
+	view := km.uiStateManager.SavedViewsState().GetActive()
+	if view == nil || (!view.IsGlobal() && view.ProjectID != km.GetActiveProjectID()) {
+		return ""
+	}
+	return view.Name
+}
+
+// resetBoardFilters clears the search after the active project changes and
+// refreshes the board, dropping a view saved for another project
+func (km *KahnModel) resetBoardFilters() {
+	km.searchState.Clear()
+	km.RefreshTasksWithSearch()
+}
+
 func (km *KahnModel) GetActiveProjectID() string {
 	return km.projectManager.GetActiveProjectID()
 }
@@ -165,6 +332,7 @@ func (km *KahnModel) CreateTaskWithPriority(name, description string, priority d
 	if err != nil {
 		return err
 	}
+	km.recordUndo(domain.NewTaskCreatedUndo(domain.TaskSnapshot{Task: *newTask}))
 
 	activeProj.AddTask(*newTask)
 
@@ -201,10 +369,19 @@ func (km *KahnModel) UpdateTask(id, name, description string, priority domain.Pr
 	return nil
 }
 
+// DeleteTask moves the task to the trash
 func (km *KahnModel) DeleteTask(id string) error {
+	task, err := km.taskService.GetTask(id)
+	if err != nil {
+		return err
+	}
+
 	if err := km.taskService.DeleteTask(id); err != nil {
 		return err
 	}
+	if task != nil {
+		km.recordUndo(domain.NewTaskDeletedUndo(*task))
+	}
 
 	activeProj := km.GetActiveProject()
 	if activeProj != nil {
@@ -219,73 +396,188 @@ func (km *KahnModel) DeleteTask(id string) error {
 }
 
 func (km *KahnModel) MoveTaskToNextStatus(id string) error {
-	activeProj := km.GetActiveProject()
-	if activeProj == nil {
+	return km.moveTask(id, km.taskService.MoveTaskToNextStatus)
+}
+
+func (km *KahnModel) MoveTaskToPreviousStatus(id string) error {
+	return km.moveTask(id, km.taskService.MoveTaskToPreviousStatus)
+}
+
+// moveTask moves the task to another column of its project's board, which is
+// the active project unless the task is moved from the My Work board
+func (km *KahnModel) moveTask(id string, move func(id string) (*domain.Task, error)) error {
+	project := km.projectOfTask(id)
+	if project == nil {
 		return nil
 	}
 
 	// Find current status before movement for dirty flags
 	var oldStatus domain.Status
-	var movingToComplete bool
-	for _, t := range activeProj.Tasks {
+	for _, t := range project.Tasks {
 		if t.ID == id {
 			oldStatus = t.Status
-			movingToComplete = (t.Status == domain.InProgress)
 			break
 		}
 	}
 
-	task, err := km.taskService.MoveTaskToNextStatus(id)
-	if err != nil {
+	before, snapErr := km.undoStack.SnapshotTask(id)
+	var dependents []domain.BlockerLink
+	if snapErr == nil {
+		dependents = km.undoStack.Dependents(before.Task)
+	}
+
+	task, err := move(id)
+	km.showWIPLimitNotice(err)
+	if task == nil {
 		return err
 	}
+	if snapErr == nil {
+		km.recordMove(before, dependents, project.Workflow, task.Status)
+	}
 
-	activeProj.UpdateTaskStatus(id, task.Status)
-	km.navState.MarkListDirty(oldStatus)
-	km.navState.MarkListDirty(task.Status)
+	project.UpdateTaskStatus(id, task.Status)
+	if project == km.GetActiveProject() {
+		km.navState.MarkListDirty(oldStatus)
+		km.navState.MarkListDirty(task.Status)
 
-	// Refresh all columns to update visual indicators for unblocked tasks
-	if movingToComplete {
-		km.navState.MarkAllListsDirty()
+		// Refresh all columns to update visual indicators for unblocked tasks
+		if project.Workflow.IsDone(task.Status) {
+			km.navState.MarkAllListsDirty()
+		}
 	}
 
 	km.RefreshTasksWithSearch()
-	return nil
+	return err
 }
 
-func (km *KahnModel) MoveTaskToPreviousStatus(id string) error {
+// projectOfTask returns the loaded project holding the task, with its tasks read
+// again unless it is the active project. A task that cannot be read falls back
+// to the active project.
+func (km *KahnModel) projectOfTask(id string) *domain.Project {
 	activeProj := km.GetActiveProject()
-	if activeProj == nil {
-		return nil
+	if activeProj != nil {
+		for _, task := range activeProj.Tasks {
+			if task.ID == id {
+				return activeProj
+			}
+		}
 	}
 
-	// Find current status before movement for dirty flags
-	var oldStatus domain.Status
-	var movingToComplete bool
-	for _, t := range activeProj.Tasks {
-		if t.ID == id {
-			oldStatus = t.Status
-			movingToComplete = (t.Status == domain.NotStarted)
-			break
+	task, err := km.taskService.GetTask(id)
+	if err != nil || task == nil {
+		return activeProj
+	}
+	if task.ProjectID == km.GetActiveProjectID() {
+		return activeProj
+	}
+	// The project may have been created since the board last loaded, such as
+	// from the command line
+	project := km.projectManager.GetProject(task.ProjectID)
+	if project == nil {
+		project, err = km.projectService.GetProjectWithTasks(task.ProjectID)
+		if err != nil {
+			return nil
 		}
+		return project
+	}
+	if tasks, err := km.taskService.GetTasksByProject(project.ID); err == nil {
+		project.Tasks = tasks
 	}
+	return project
+}
 
-	task, err := km.taskService.MoveTaskToPreviousStatus(id)
+// recordMove records a move of the task into status. Reaching the done column
+// also released the dependents, so undoing it makes them wait again.
+func (km *KahnModel) recordMove(before domain.TaskSnapshot, dependents []domain.BlockerLink, workflow domain.Workflow, status domain.Status) {
+	if !workflow.IsDone(status) || workflow.IsDone(before.Task.Status) {
+		dependents = nil
+	}
+	km.recordTaskChange("move to "+workflow.Name(status), before, dependents)
+}
+
+// recordTaskChange records the change of a task from before to what is stored
+// now, unless nothing an undo would restore changed
+func (km *KahnModel) recordTaskChange(description string, before domain.TaskSnapshot, unlinked []domain.BlockerLink) {
+	after, err := km.undoStack.SnapshotTask(before.Task.ID)
 	if err != nil {
-		return err
+		km.notice = fmt.Sprintf("Could not record undo: %v", err)
+		return
+	}
+	if domain.SameTaskFields(before.Task, after.Task) && len(unlinked) == 0 {
+		return
 	}
+	km.recordUndo(domain.NewTaskChangedUndo(description, before.Task, after.Task, unlinked))
+}
+
+// recordUndo pushes a change made on the board onto the undo stack
+func (km *KahnModel) recordUndo(op domain.UndoOperation) {
+	if err := km.undoStack.Record(op); err != nil {
+		km.notice = fmt.Sprintf("Could not record undo: %v", err)
+	}
+}
 
-	activeProj.UpdateTaskStatus(id, task.Status)
-	km.navState.MarkListDirty(oldStatus)
-	km.navState.MarkListDirty(task.Status)
+// Undo reverts the most recent change made on the board and reports it in the footer
+func (km *KahnModel) Undo() {
+	km.applyUndo(km.undoStack.Undo, "Nothing to undo", "Undid")
+}
 
-	// Refresh all columns to update visual indicators for unblocked tasks
-	if movingToComplete {
-		km.navState.MarkAllListsDirty()
+// Redo makes again the most recently undone change and reports it in the footer
+func (km *KahnModel) Redo() {
+	km.applyUndo(km.undoStack.Redo, "Nothing to redo", "Redid")
+}
+
+func (km *KahnModel) applyUndo(step func() (*domain.UndoOperation, error), nothing, done string) {
+	op, err := step()
+	if op == nil && err == nil {
+		km.notice = nothing
+		return
+	}
+	if op != nil {
+		// Undo writes straight to the database, so everything shown is reloaded.
+		// The board behind My Work stays on its project.
+		preferredID := op.ProjectID
+		if km.myWork.IsActive() {
+			preferredID = km.GetActiveProjectID()
+		}
+		km.projectManager.Reload(preferredID)
+		km.RefreshTasksWithSearch()
+	}
+	var wipErr *domain.WIPLimitError
+	if errors.As(err, &wipErr) && !wipErr.Enforced {
+		km.notice = done + " " + op.Summary() + " · WIP limit: " + wipErr.Error()
+		return
+	}
+	if err != nil {
+		km.notice = err.Error()
+		return
+	}
+	km.notice = done + " " + op.Summary()
+}
+
+// showWIPLimitNotice reports a rejected or over-limit move in the footer
+func (km *KahnModel) showWIPLimitNotice(err error) {
+	var wipErr *domain.WIPLimitError
+	if errors.As(err, &wipErr) {
+		km.notice = "WIP limit: " + wipErr.Error()
 	}
+}
 
-	km.RefreshTasksWithSearch()
-	return nil
+// SetWIPEnforcement chooses whether moves over a column's WIP limit are rejected or
+// allowed with a warning
+func (km *KahnModel) SetWIPEnforcement(enforcement domain.WIPEnforcement) {
+	km.taskService.SetWIPEnforcement(enforcement)
+}
+
+// SetAuthor sets the name that signs comments written in the detail pane and
+// the changes recorded in the event log
+func (km *KahnModel) SetAuthor(author string) {
+	km.author = author
+	km.eventLog.SetActor(author)
+}
+
+// SetExportDir sets the directory the Markdown export key writes into
+func (km *KahnModel) SetExportDir(dir string) {
+	km.exportDir = dir
 }
 
 // GetSelectedTask returns the currently selected task for internal use
@@ -303,12 +595,25 @@ func (km *KahnModel) getSelectedTask() (*styles.TaskWithTitle, bool) {
 	return &taskWrapper, true
 }
 
+// CreateProject creates a project and switches to it
 func (km *KahnModel) CreateProject(name, description string) error {
-	return km.projectManager.CreateProject(name, description)
+	if err := km.projectManager.CreateProject(name, description); err != nil {
+		return err
+	}
+	km.resetBoardFilters()
+	return nil
 }
 
+// DeleteProject moves the project to the trash with its tasks
 func (km *KahnModel) DeleteProject(id string) error {
-	return km.projectManager.DeleteProject(id)
+	project, projErr := km.projectService.GetProjectWithTasks(id)
+	if err := km.projectManager.DeleteProject(id); err != nil {
+		return err
+	}
+	if projErr == nil {
+		km.recordUndo(domain.NewProjectDeletedUndo(*project, len(project.Tasks)))
+	}
+	return nil
 }
 
 func (km *KahnModel) SwitchToProject(id string) error {
@@ -346,11 +651,22 @@ func (km *KahnModel) SubmitCurrentForm() error {
 	}
 
 	formState.ClearError()
-	name, desc, taskType, priority, blockedByIntID := formState.GetFormData()
+	name, desc, taskType, priority, blockedBy := formState.GetFormData()
+	labelNames := formState.GetLabelNames()
+	dueDate, _ := formState.GetDueDate() // already checked by ValidateForSubmit
 
 	switch formState.GetActiveFormType() {
 	case input.TaskCreateForm:
-		newTask, err := km.taskService.CreateTask(name, desc, km.GetActiveProjectID(), taskType, priority, blockedByIntID)
+		newTask, err := km.taskService.CreateTask(name, desc, km.GetActiveProjectID(), taskType, priority, blockedBy)
+		if err == nil {
+			km.recordUndo(domain.NewTaskCreatedUndo(domain.TaskSnapshot{Task: *newTask}))
+		}
+		if err == nil && len(labelNames) > 0 {
+			newTask, err = km.labelService.SetTaskLabels(newTask.ID, labelNames)
+		}
+		if err == nil && dueDate != nil {
+			newTask, err = km.taskService.SetTaskDates(newTask.ID, nil, dueDate)
+		}
 		if err == nil {
 			activeProj := km.GetActiveProject()
 			if activeProj != nil {
@@ -361,14 +677,29 @@ func (km *KahnModel) SubmitCurrentForm() error {
 		return err
 	case input.TaskEditForm:
 		taskID := formState.GetTaskID()
+		// The edit is one undo step, covering whatever part of it was saved
+		if before, err := km.undoStack.SnapshotTask(taskID); err == nil {
+			defer km.recordTaskChange("edit", before, nil)
+		}
 		// Update basic task fields
 		err := km.UpdateTask(taskID, name, desc, priority, taskType)
 		if err != nil {
 			return err
 		}
-		// Update BlockedBy field separately
-		_, err = km.taskService.SetTaskBlockedBy(taskID, blockedByIntID)
+		// Update blockers separately; this is where dependency cycles are caught
+		blocked, err := km.taskService.SetTaskBlockers(taskID, blockedBy)
+		if err != nil {
+			formState.SetError(err.Error(), "blocked_by")
+			return err
+		}
+		labelled, err := km.labelService.SetTaskLabels(taskID, labelNames)
+		if err != nil {
+			return err
+		}
+		// The form only edits the due date; the start date is kept as is
+		dated, err := km.taskService.SetTaskDates(taskID, labelled.StartDate, dueDate)
 		if err != nil {
+			formState.SetError(err.Error(), "due_date")
 			return err
 		}
 		// Update the task in the active project and refresh display
@@ -378,7 +709,9 @@ func (km *KahnModel) SubmitCurrentForm() error {
 			for i, t := range activeProj.Tasks {
 				if t.ID == taskID {
 					taskStatus = t.Status // Save status for dirty flag
-					activeProj.Tasks[i].BlockedBy = blockedByIntID
+					activeProj.Tasks[i].BlockedBy = blocked.BlockedBy
+					activeProj.Tasks[i].Labels = labelled.Labels
+					activeProj.Tasks[i].DueDate = dated.DueDate
 					break
 				}
 			}
@@ -388,7 +721,7 @@ func (km *KahnModel) SubmitCurrentForm() error {
 		}
 		return nil
 	case input.ProjectCreateForm:
-		return km.projectManager.CreateProject(name, desc)
+		return km.CreateProject(name, desc)
 	}
 	return nil
 }
@@ -399,14 +732,594 @@ func (km *KahnModel) CancelCurrentForm() {
 
 func (km *KahnModel) ShowTaskForm() {
 	// Get available tasks for BlockedBy field
-	availableTasks := km.getAvailableBlockerTasks("")
+	availableTasks := km.getAvailableBlockerTasks(km.GetActiveProject(), "")
 	km.uiStateManager.ShowTaskForm(availableTasks)
 }
 
-func (km *KahnModel) ShowTaskEditForm(taskID string, name, description string, priority domain.Priority, taskType domain.TaskType, blockedByIntID *int) {
-	// Get available tasks for BlockedBy field (exclude current task)
-	availableTasks := km.getAvailableBlockerTasks(taskID)
-	km.uiStateManager.ShowTaskEditForm(taskID, name, description, priority, taskType, blockedByIntID, availableTasks)
+func (km *KahnModel) ShowTaskEditForm(taskID string, name, description string, priority domain.Priority, taskType domain.TaskType, blockedBy []int) {
+	// Get available tasks for BlockedBy field (exclude current task), from the
+	// task's own project when it is edited from the My Work board
+	project := km.projectOfTask(taskID)
+	availableTasks := km.getAvailableBlockerTasks(project, taskID)
+	km.uiStateManager.ShowTaskEditForm(taskID, name, description, priority, taskType, blockedBy, availableTasks)
+
+	if project != nil {
+		for _, task := range project.Tasks {
+			if task.ID == taskID {
+				km.uiStateManager.FormState().SetLabelNames(task.LabelNames())
+				km.uiStateManager.FormState().SetDueDate(task.DueDate)
+				break
+			}
+		}
+	}
+}
+
+// ShowDependencies opens the blocker tree of the task, read fresh from the
+// database so blockers cleared by finished tasks are not shown
+func (km *KahnModel) ShowDependencies(task domain.Task) {
+	tasks, err := km.taskService.GetTasksByProject(task.ProjectID)
+	if err != nil {
+		km.notice = fmt.Sprintf("Could not load dependencies: %v", err)
+		return
+	}
+	km.uiStateManager.ShowDependencies(task.IntID, tasks)
+}
+
+// ShowChecklist opens the checklist pane of the task
+func (km *KahnModel) ShowChecklist(task domain.Task) {
+	items, err := km.taskService.GetChecklist(task.ID)
+	if err != nil {
+		km.notice = fmt.Sprintf("Could not load checklist: %v", err)
+		return
+	}
+	km.uiStateManager.ShowChecklist(task, items)
+}
+
+// AddChecklistItem appends an item to the checklist shown in the pane
+func (km *KahnModel) AddChecklistItem(text string) error {
+	listState := km.uiStateManager.ChecklistState()
+	if _, err := km.taskService.AddChecklistItem(listState.GetTask().ID, text); err != nil {
+		return err
+	}
+	if err := km.reloadChecklist(); err != nil {
+		return err
+	}
+	listState.SetCursor(len(listState.GetItems()) - 1)
+	return nil
+}
+
+// RenameChecklistItem replaces the text of an item in the pane
+func (km *KahnModel) RenameChecklistItem(itemID, text string) error {
+	if _, err := km.taskService.RenameChecklistItem(itemID, text); err != nil {
+		return err
+	}
+	return km.reloadChecklist()
+}
+
+// ToggleChecklistItem ticks the highlighted item off, or unticks it
+func (km *KahnModel) ToggleChecklistItem() error {
+	item := km.uiStateManager.ChecklistState().SelectedItem()
+	if item == nil {
+		return nil
+	}
+	if _, err := km.taskService.SetChecklistItemDone(item.ID, !item.Done); err != nil {
+		return err
+	}
+	return km.reloadChecklist()
+}
+
+// DeleteChecklistItem removes the highlighted item
+func (km *KahnModel) DeleteChecklistItem() error {
+	item := km.uiStateManager.ChecklistState().SelectedItem()
+	if item == nil {
+		return nil
+	}
+	if err := km.taskService.DeleteChecklistItem(item.ID); err != nil {
+		return err
+	}
+	return km.reloadChecklist()
+}
+
+// MoveChecklistItem moves the highlighted item delta places, keeping it highlighted
+func (km *KahnModel) MoveChecklistItem(delta int) error {
+	listState := km.uiStateManager.ChecklistState()
+	item := listState.SelectedItem()
+	position := listState.GetCursor() + 1 + delta
+	if item == nil || position < 1 || position > len(listState.GetItems()) {
+		return nil
+	}
+	items, err := km.taskService.MoveChecklistItem(item.ID, position)
+	if err != nil {
+		return err
+	}
+	listState.SetItems(items)
+	listState.SetCursor(position - 1)
+	return nil
+}
+
+// PromoteChecklistItem turns the highlighted item into a task the pane's task
+// waits on, and adds it to the board
+func (km *KahnModel) PromoteChecklistItem() error {
+	item := km.uiStateManager.ChecklistState().SelectedItem()
+	if item == nil {
+		return nil
+	}
+	task, err := km.taskService.PromoteChecklistItem(item.ID)
+	if err != nil {
+		return err
+	}
+
+	if activeProj := km.GetActiveProject(); activeProj != nil && activeProj.ID == task.ProjectID {
+		activeProj.AddTask(*task)
+		km.navState.MarkListDirty(task.Status)
+	}
+	return km.reloadChecklist()
+}
+
+// reloadChecklist reads the pane's checklist and task again and copies the new
+// progress and blockers onto the board
+func (km *KahnModel) reloadChecklist() error {
+	listState := km.uiStateManager.ChecklistState()
+	taskID := listState.GetTask().ID
+
+	items, err := km.taskService.GetChecklist(taskID)
+	if err != nil {
+		return err
+	}
+	task, err := km.taskService.GetTask(taskID)
+	if err != nil {
+		return err
+	}
+	listState.SetTask(*task)
+	listState.SetItems(items)
+
+	if activeProj := km.GetActiveProject(); activeProj != nil {
+		for i, t := range activeProj.Tasks {
+			if t.ID == taskID {
+				activeProj.Tasks[i].ChecklistTotal = task.ChecklistTotal
+				activeProj.Tasks[i].ChecklistDone = task.ChecklistDone
+				activeProj.Tasks[i].BlockedBy = task.BlockedBy
+				activeProj.Tasks[i].UpdatedAt = task.UpdatedAt
+				km.navState.MarkListDirty(t.Status)
+				break
+			}
+		}
+		km.RefreshTasksWithSearch()
+	}
+	return nil
+}
+
+// ShowTaskDetail opens the detail pane of the task, read fresh from the database
+// so its comments are current
+func (km *KahnModel) ShowTaskDetail(task domain.Task) {
+	loaded, err := km.taskService.GetTask(task.ID)
+	if err != nil {
+		km.notice = fmt.Sprintf("Could not load task: %v", err)
+		return
+	}
+	if loaded == nil {
+		km.notice = "Task not found"
+		return
+	}
+	km.uiStateManager.ShowTaskDetail(*loaded)
+	km.loadTaskLinks()
+}
+
+// OpenTaskLink shows the task highlighted among the blockers and dependents in
+// the detail pane
+func (km *KahnModel) OpenTaskLink() {
+	detailState := km.uiStateManager.DetailState()
+	if link := detailState.SelectedLink(); link != nil {
+		km.openInDetail(link.ID, detailState.Open)
+	}
+}
+
+// OpenTaskBlocker shows the first task the task in the detail pane waits on
+func (km *KahnModel) OpenTaskBlocker() {
+	detailState := km.uiStateManager.DetailState()
+	blockers := detailState.GetBlockers()
+	if len(blockers) == 0 {
+		detailState.SetError("This task does not wait on any task")
+		return
+	}
+	km.openInDetail(blockers[0].ID, detailState.Open)
+}
+
+// BackInTaskDetail shows again the task the detail pane showed before the
+// current one was opened from it
+func (km *KahnModel) BackInTaskDetail() {
+	detailState := km.uiStateManager.DetailState()
+	if id := detailState.Back(); id != "" {
+		previous := detailState.previous
+		km.openInDetail(id, func(task domain.Task) {
+			detailState.Show(task)
+			detailState.previous = previous
+		})
+	}
+}
+
+func (km *KahnModel) openInDetail(id string, show func(domain.Task)) {
+	detailState := km.uiStateManager.DetailState()
+	task, err := km.taskService.GetTask(id)
+	if err != nil || task == nil {
+		detailState.SetError("Could not load the task; it may have been deleted")
+		return
+	}
+	show(*task)
+	km.loadTaskLinks()
+}
+
+// loadTaskLinks lists in the detail pane the tasks the shown task waits on and
+// those waiting on it
+func (km *KahnModel) loadTaskLinks() {
+	detailState := km.uiStateManager.DetailState()
+	task := detailState.GetTask()
+	tasks, err := km.taskService.GetTasksByProject(task.ProjectID)
+	if err != nil {
+		detailState.SetError(fmt.Sprintf("Could not load dependencies: %v", err))
+		return
+	}
+
+	byIntID := make(map[int]domain.Task, len(tasks))
+	var dependents []domain.Task
+	for _, t := range tasks {
+		byIntID[t.IntID] = t
+		if t.IsBlockedBy(task.IntID) {
+			dependents = append(dependents, t)
+		}
+	}
+	var blockers []domain.Task
+	for _, intID := range task.BlockedBy {
+		if blocker, ok := byIntID[intID]; ok {
+			blockers = append(blockers, blocker)
+		}
+	}
+	detailState.SetLinks(blockers, dependents)
+}
+
+// CloseTaskDetail closes the detail pane with the task it showed last selected
+// on the board, so a jump to a blocker carries over
+func (km *KahnModel) CloseTaskDetail() {
+	detailState := km.uiStateManager.DetailState()
+	task := detailState.GetTask()
+	detailState.Hide()
+	if activeProj := km.GetActiveProject(); activeProj != nil && task.ProjectID == activeProj.ID {
+		km.navState.SelectTask(task)
+	}
+}
+
+// ToggleTaskHistory switches the detail pane between the task's comments and
+// its history
+func (km *KahnModel) ToggleTaskHistory() {
+	detailState := km.uiStateManager.DetailState()
+	if detailState.IsShowingHistory() {
+		detailState.HideHistory()
+		return
+	}
+
+	events, err := km.eventLog.List(domain.EventFilter{TaskIntID: detailState.GetTask().IntID})
+	if err != nil {
+		detailState.SetError(fmt.Sprintf("Could not load history: %v", err))
+		return
+	}
+	detailState.SetError("")
+	detailState.ShowHistory(events)
+}
+
+// AddComment appends a comment to the task shown in the detail pane and scrolls
+// to it. The board copy of the task takes the new comments so search finds them.
+func (km *KahnModel) AddComment(body string) error {
+	detailState := km.uiStateManager.DetailState()
+	taskID := detailState.GetTask().ID
+	if _, err := km.taskService.AddComment(taskID, km.author, body); err != nil {
+		return err
+	}
+
+	task, err := km.taskService.GetTask(taskID)
+	if err != nil {
+		return err
+	}
+	detailState.SetTask(*task)
+	detailState.ScrollToLastComment()
+
+	if activeProj := km.GetActiveProject(); activeProj != nil {
+		for i, t := range activeProj.Tasks {
+			if t.ID == taskID {
+				activeProj.Tasks[i].Comments = task.Comments
+				activeProj.Tasks[i].UpdatedAt = task.UpdatedAt
+				km.navState.MarkListDirty(t.Status)
+				break
+			}
+		}
+		km.RefreshTasksWithSearch()
+	}
+	return nil
+}
+
+// ArchiveTask hides a finished task from the board
+func (km *KahnModel) ArchiveTask(id string) error {
+	task, err := km.taskService.ArchiveTask(id)
+	if err != nil {
+		return err
+	}
+	km.recordUndo(domain.NewTaskArchivedUndo(*task))
+	km.notice = fmt.Sprintf("Archived #%d %s", task.IntID, task.Name)
+
+	if activeProj := km.GetActiveProject(); activeProj != nil {
+		activeProj.RemoveTask(id)
+		km.navState.MarkListDirty(task.Status)
+		km.RefreshTasksWithSearch()
+	}
+	return nil
+}
+
+// AutoArchive archives the tasks left in a done column for more than days days
+// and reports how many in the footer
+func (km *KahnModel) AutoArchive(days int) {
+	archived, err := km.taskService.AutoArchive(days)
+	if err != nil {
+		km.notice = fmt.Sprintf("Could not archive old tasks: %v", err)
+	}
+	if archived == 0 {
+		return
+	}
+	if err == nil {
+		km.notice = fmt.Sprintf("Archived %d tasks done more than %d days ago", archived, days)
+	}
+	km.projectManager.Reload("")
+	km.RefreshTasksWithSearch()
+}
+
+// ShowTrash opens the pane listing the active project's archived and trashed
+// tasks, and the trashed projects
+func (km *KahnModel) ShowTrash() {
+	archived, tasks, projects, err := km.loadTrash()
+	if err != nil {
+		km.notice = fmt.Sprintf("Could not load trash: %v", err)
+		return
+	}
+	km.uiStateManager.ShowTrash(archived, tasks, projects)
+}
+
+// RestoreTrashEntry puts the task highlighted in the pane back on the board, or
+// takes the highlighted project out of the trash and switches to it
+func (km *KahnModel) RestoreTrashEntry() error {
+	trashState := km.uiStateManager.TrashState()
+	activeID := km.GetActiveProjectID()
+
+	switch project, task := trashState.SelectedProject(), trashState.SelectedTask(); {
+	case project != nil:
+		if _, err := km.projectService.RestoreProject(project.ID); err != nil {
+			return err
+		}
+		activeID = project.ID
+		km.notice = "Restored project " + project.Name
+	case task != nil && trashState.IsShowingTrash():
+		restored, err := km.taskService.RestoreTask(task.ID)
+		if restored == nil {
+			return err
+		}
+		km.notice = fmt.Sprintf("Restored #%d %s", task.IntID, task.Name)
+		var wipErr *domain.WIPLimitError
+		if errors.As(err, &wipErr) {
+			km.notice += " · WIP limit: " + wipErr.Error()
+		}
+	case task != nil:
+		if _, err := km.taskService.UnarchiveTask(task.ID); err != nil {
+			return err
+		}
+		km.notice = fmt.Sprintf("Unarchived #%d %s", task.IntID, task.Name)
+	default:
+		return nil
+	}
+
+	km.projectManager.Reload(activeID)
+	km.RefreshTasksWithSearch()
+	return km.reloadTrash()
+}
+
+// PurgeTrashEntry deletes the task or project highlighted on the Trash tab for good
+func (km *KahnModel) PurgeTrashEntry() error {
+	trashState := km.uiStateManager.TrashState()
+	if !trashState.IsShowingTrash() {
+		return nil
+	}
+
+	switch project, task := trashState.SelectedProject(), trashState.SelectedTask(); {
+	case project != nil:
+		if err := km.projectService.PurgeProject(project.ID); err != nil {
+			return err
+		}
+		km.notice = "Deleted project " + project.Name + " permanently"
+	case task != nil:
+		if err := km.taskService.PurgeTask(task.ID); err != nil {
+			return err
+		}
+		km.notice = fmt.Sprintf("Deleted #%d %s permanently", task.IntID, task.Name)
+	default:
+		return nil
+	}
+	return km.reloadTrash()
+}
+
+// reloadTrash reads the pane's contents again after one of them changed
+func (km *KahnModel) reloadTrash() error {
+	archived, tasks, projects, err := km.loadTrash()
+	if err != nil {
+		return err
+	}
+	km.uiStateManager.TrashState().SetContents(archived, tasks, projects)
+	return nil
+}
+
+func (km *KahnModel) loadTrash() ([]domain.Task, []domain.Task, []domain.Project, error) {
+	projectID := km.GetActiveProjectID()
+	var archived []domain.Task
+	if projectID != "" {
+		var err error
+		if archived, err = km.taskService.GetArchivedTasks(projectID); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	tasks, err := km.taskService.GetTrashedTasks(projectID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	projects, err := km.projectService.GetTrashedProjects()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return archived, tasks, projects, nil
+}
+
+// ShowGlobalSearch opens the pane searching the tasks of every project
+func (km *KahnModel) ShowGlobalSearch() {
+	km.uiStateManager.ShowGlobalSearch()
+}
+
+// RefreshGlobalSearch runs the typed query again, listing the best matches first
+func (km *KahnModel) RefreshGlobalSearch() {
+	globalState := km.uiStateManager.GlobalSearchState()
+	results, err := km.taskService.SearchTasks(globalState.GetQuery())
+	if err != nil {
+		globalState.SetError(err.Error())
+		return
+	}
+	globalState.SetResults(results)
+}
+
+// OpenGlobalSearchResult closes the pane and selects the highlighted result on
+// the board, switching to its project first
+func (km *KahnModel) OpenGlobalSearchResult() {
+	globalState := km.uiStateManager.GlobalSearchState()
+	result := globalState.SelectedResult()
+	if result == nil {
+		return
+	}
+	globalState.Hide()
+	km.openOnBoard(result.Task)
+}
+
+// openOnBoard selects the task on the board, switching to its project first and
+// dropping the applied saved view so the task is shown
+func (km *KahnModel) openOnBoard(task domain.Task) {
+	km.uiStateManager.SavedViewsState().SetActive(nil)
+
+	// The project may have been created since the board last loaded, such as
+	// from the command line
+	if err := km.projectManager.Reload(task.ProjectID); err != nil {
+		km.notice = err.Error()
+		return
+	}
+	km.navState.SelectTask(task)
+}
+
+// ShowMyWork replaces the project board with the My Work board gathering the
+// high-priority tasks waiting to start and the tasks in progress of every project
+func (km *KahnModel) ShowMyWork() {
+	km.myWork.Show()
+	km.RefreshMyWork()
+}
+
+// HideMyWork goes back from the My Work board to the active project's board,
+// refreshed since tasks of it may have been changed
+func (km *KahnModel) HideMyWork() {
+	km.myWork.Hide()
+	km.RefreshTasksWithSearch()
+}
+
+// RefreshMyWork reloads the cards of the My Work board from every project
+func (km *KahnModel) RefreshMyWork() {
+	columns, err := km.projectService.GetMyWork()
+	if err != nil {
+		km.notice = fmt.Sprintf("Could not load My Work: %v", err)
+		return
+	}
+	km.myWork.SetTasks(columns)
+}
+
+// OpenMyWorkTask closes the My Work board and selects the highlighted task on
+// its project's board
+func (km *KahnModel) OpenMyWorkTask() {
+	task := km.myWork.SelectedTask()
+	if task == nil {
+		return
+	}
+	km.myWork.Hide()
+	km.openOnBoard(*task)
+}
+
+// ShowSavedViews opens the picker of the active project's saved views and the
+// global ones
+func (km *KahnModel) ShowSavedViews() {
+	views, err := km.viewService.GetViews(km.GetActiveProjectID())
+	km.uiStateManager.ShowSavedViews(views)
+	if err != nil {
+		km.uiStateManager.SavedViewsState().SetError(err.Error())
+	}
+}
+
+// StartSavingView opens the prompt naming a view of the search query
+func (km *KahnModel) StartSavingView() {
+	// A fuzzy query is not in the query language a view is saved in
+	if km.searchState.IsFuzzy() || strings.TrimSpace(km.searchState.GetQuery()) == "" {
+		return
+	}
+	km.uiStateManager.ShowSaveView(km.searchState.GetQuery())
+}
+
+// SaveView saves the query named in the prompt as a view of the active project,
+// or of every project, and applies it in place of the search
+func (km *KahnModel) SaveView() error {
+	viewsState := km.uiStateManager.SavedViewsState()
+	projectID := km.GetActiveProjectID()
+	if viewsState.IsGlobal() {
+		projectID = ""
+	}
+	view, err := km.viewService.SaveView(projectID, viewsState.GetName(), viewsState.GetQuery())
+	if err != nil {
+		return err
+	}
+
+	viewsState.Hide()
+	km.searchState.Clear()
+	km.ApplySavedView(view)
+	return nil
+}
+
+// ApplySavedView filters the board with the view, or shows all tasks when nil
+func (km *KahnModel) ApplySavedView(view *domain.SavedView) {
+	viewsState := km.uiStateManager.SavedViewsState()
+	viewsState.Hide()
+	if view != nil {
+		applied := *view
+		view = &applied
+	}
+	viewsState.SetActive(view)
+	km.RefreshTasksWithSearch()
+}
+
+// DeleteSavedView deletes the highlighted view, and stops applying it
+func (km *KahnModel) DeleteSavedView() error {
+	viewsState := km.uiStateManager.SavedViewsState()
+	view := viewsState.SelectedView()
+	if view == nil {
+		return nil
+	}
+	if err := km.viewService.DeleteView(view.ID); err != nil {
+		return err
+	}
+	if active := viewsState.GetActive(); active != nil && active.ID == view.ID {
+		viewsState.SetActive(nil)
+		km.RefreshTasksWithSearch()
+	}
+
+	views, err := km.viewService.GetViews(km.GetActiveProjectID())
+	if err != nil {
+		return err
+	}
+	viewsState.SetViews(views)
+	return nil
 }
 
 func (km *KahnModel) ShowProjectForm() {
@@ -462,33 +1375,27 @@ func (km *KahnModel) findTaskForDeletion() *domain.Task {
 	return nil
 }
 
-// getTaskListsForBoard builds the task lists array needed for board rendering
-func (km *KahnModel) getTaskListsForBoard() [3]list.Model {
-	navState := km.navState
-	return [3]list.Model{
-		navState.Tasks[domain.NotStarted],
-		navState.Tasks[domain.InProgress],
-		navState.Tasks[domain.Done],
-	}
+// getTaskListsForBoard returns the task lists needed for board rendering, one per workflow column
+func (km *KahnModel) getTaskListsForBoard() []list.Model {
+	return km.navState.Tasks
 }
 
-// getAvailableBlockerTasks returns tasks that can block another task
-// Filters to only NotStarted and InProgress tasks
+// getAvailableBlockerTasks returns the project's tasks that can block another task
+// Filters out tasks in the workflow's done column
 // If excludeTaskID is provided, that task is excluded from the list
-func (km *KahnModel) getAvailableBlockerTasks(excludeTaskID string) []domain.Task {
-	activeProj := km.GetActiveProject()
-	if activeProj == nil {
+func (km *KahnModel) getAvailableBlockerTasks(project *domain.Project, excludeTaskID string) []domain.Task {
+	if project == nil {
 		return []domain.Task{}
 	}
 
 	var availableTasks []domain.Task
-	for _, task := range activeProj.Tasks {
+	for _, task := range project.Tasks {
 		// Skip the task being edited
 		if task.ID == excludeTaskID {
 			continue
 		}
-		// Only include NotStarted and InProgress tasks
-		if task.Status == domain.NotStarted || task.Status == domain.InProgress {
+		// Only include tasks that are not done yet
+		if !project.Workflow.IsDone(task.Status) {
 			availableTasks = append(availableTasks, task)
 		}
 	}
@@ -503,17 +1410,11 @@ func (km *KahnModel) executeTaskDeletion() tea.Model {
 		return km
 	}
 
-	if err := km.taskService.DeleteTask(taskToDelete); err != nil {
+	if err := km.DeleteTask(taskToDelete); err != nil {
 		confirmState.SetTaskError("Failed to delete task: " + err.Error())
 		return km
 	}
 
-	activeProj := km.GetActiveProject()
-	if activeProj != nil {
-		activeProj.RemoveTask(taskToDelete)
-		km.RefreshTasksWithSearch()
-	}
-
 	confirmState.ClearTaskDelete()
 	return km
 }
@@ -527,9 +1428,11 @@ func (km *KahnModel) executeProjectDeletion() tea.Model {
 	}
 
 	// The project manager handles the deletion logic
-	err := km.projectManager.DeleteProject(projectToDelete)
+	err := km.DeleteProject(projectToDelete)
 	if err != nil {
 		confirmState.SetProjectError("Failed to delete project: " + err.Error())
+	} else {
+		km.resetBoardFilters()
 	}
 
 	confirmState.ClearProjectDelete()
@@ -539,6 +1442,13 @@ func (km *KahnModel) executeProjectDeletion() tea.Model {
 func (km *KahnModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		km.notice = ""
+
+		// The prompt naming a view opens from search mode, which resumes if it is
+		// cancelled
+		if km.uiStateManager.SavedViewsState().IsShowing() {
+			return km.handleSavedViews(msg)
+		}
 		// Check if in search mode first
 		if km.searchState.IsActive() {
 			return km.handleSearchInput(msg)
@@ -552,6 +1462,24 @@ func (km *KahnModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if km.uiStateManager.ConfirmationState().IsShowingTaskDeleteConfirm() {
 			return km.handleTaskDeleteConfirm(msg)
 		}
+		if km.uiStateManager.DependencyState().IsShowing() {
+			return km.handleDependencyView(msg)
+		}
+		if km.uiStateManager.ChecklistState().IsShowing() {
+			return km.handleChecklistView(msg)
+		}
+		if km.uiStateManager.DetailState().IsShowing() {
+			return km.handleDetailView(msg)
+		}
+		if km.uiStateManager.TrashState().IsShowing() {
+			return km.handleTrashView(msg)
+		}
+		if km.uiStateManager.GlobalSearchState().IsShowing() {
+			return km.handleGlobalSearch(msg)
+		}
+		if km.myWork.IsActive() {
+			return km.handleMyWork(msg)
+		}
 		return km.handleNormalMode(msg)
 	case tea.WindowSizeMsg:
 		return km.handleResize(msg)
@@ -592,18 +1520,8 @@ func (km *KahnModel) IsShowingProjectSwitch() bool {
 }
 
 func NewKahnModel(database *database.Database, version string) *KahnModel {
-	// Create delegates for different list states
-	activeDelegate := styles.NewActiveListDelegate()
-	inactiveDelegate := styles.NewInactiveListDelegate()
-
-	// Create lists with appropriate delegates - first list is active by default
-	activeList := list.New([]list.Item{}, activeDelegate, 100, 0)
-	activeList.SetShowHelp(false)
-
-	inactiveList := list.New([]list.Item{}, inactiveDelegate, 100, 0)
-	inactiveList.SetShowHelp(false)
-
-	taskLists := []list.Model{activeList, inactiveList, inactiveList}
+	// Start with the default columns; loading the active project applies its workflow
+	taskLists := newTaskLists(domain.DefaultWorkflow())
 
 	taskComps := input.NewInputComponents()
 	taskInputComponents := &taskComps
@@ -613,50 +1531,58 @@ func NewKahnModel(database *database.Database, version string) *KahnModel {
 	// Create repositories
 	taskRepo := repo.NewSQLiteTaskRepository(database.GetDB())
 	projectRepo := repo.NewSQLiteProjectRepository(database.GetDB())
+	labelRepo := repo.NewSQLiteLabelRepository(database.GetDB())
+	viewRepo := repo.NewSQLiteSavedViewRepository(database.GetDB())
 
 	// Create services
 	taskService := services.NewTaskService(taskRepo, projectRepo)
 	projectService := services.NewProjectService(projectRepo, taskRepo)
+	labelService := services.NewLabelService(labelRepo, projectRepo, taskRepo)
+	viewService := services.NewSavedViewService(viewRepo, projectRepo)
+	eventLog := services.NewEventLog(repo.NewSQLiteEventRepository(database.GetDB()), config.DefaultAuthor)
+	taskService.SetEventLog(eventLog)
+	projectService.SetEventLog(eventLog)
+	labelService.SetEventLog(eventLog)
+	undoStack := NewUndoStack(repo.NewSQLiteUndoRepository(database.GetDB()), taskService, eventLog)
 
 	// Create state management components
 	formState := NewFormState(taskInputComponents, projectInputComponents)
 	confirmState := NewConfirmationState()
 	navState := NewNavigationState(taskLists)
+	depState := NewDependencyState()
+	listState := NewChecklistState()
+	detailState := NewDetailState()
+	trashState := NewTrashState()
+	globalState := NewGlobalSearchState()
+	viewsState := NewSavedViewsState()
 	searchState := NewSearchState()
 
 	// Create managers
 	projectManager := NewProjectManager(projectService, taskService, navState)
-	uiStateManager := NewUIStateManager(formState, confirmState, navState)
+	uiStateManager := NewUIStateManager(formState, confirmState, navState, depState, listState, detailState, trashState, globalState, viewsState)
 
 	// Initialize projects through project manager
 	projectManager.InitializeProjects()
 
-	// Apply list titles and styles
-	taskLists[domain.NotStarted].Title = domain.NotStarted.ToString()
-	taskLists[domain.InProgress].Title = domain.InProgress.ToString()
-	taskLists[domain.Done].Title = domain.Done.ToString()
-
-	// Update selection states after initialization
-	// NotStarted is the active list by default, others are inactive
-	taskLists[domain.NotStarted].SetItems(styles.UpdateTaskSelection(taskLists[domain.NotStarted].Items(), taskLists[domain.NotStarted].Index(), true))
-	taskLists[domain.InProgress].SetItems(styles.UpdateTaskSelection(taskLists[domain.InProgress].Items(), taskLists[domain.InProgress].Index(), false))
-	taskLists[domain.Done].SetItems(styles.UpdateTaskSelection(taskLists[domain.Done].Items(), taskLists[domain.Done].Index(), false))
-
-	// Apply title styles to all lists based on active list index
-	styles.ApplyFocusedTitleStyles(taskLists, domain.NotStarted) // Default to NotStarted as initial active list
-
 	return &KahnModel{
 		width:           80,
 		height:          24,
 		database:        database,
 		taskService:     taskService,
 		projectService:  projectService,
+		labelService:    labelService,
+		viewService:     viewService,
+		eventLog:        eventLog,
+		undoStack:       undoStack,
 		board:           components.NewBoard(),
 		projectSwitcher: components.NewProjectSwitcher(),
 		version:         version,
+		exportDir:       ".",
+		author:          config.DefaultAuthor,
 		uiStateManager:  uiStateManager,
 		projectManager:  projectManager,
 		navState:        navState,
 		searchState:     searchState,
+		myWork:          NewMyWorkState(),
 	}
 }
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"kahn/internal/domain"
+	"kahn/internal/ui/styles"
+)
+
+// MyWorkState manages the My Work board, which takes the place of the project
+// board with the columns gathered from every project. It navigates its own
+// lists so the project board keeps its cursors while My Work is open.
+type MyWorkState struct {
+	*NavigationState
+	active bool
+}
+
+// NewMyWorkState creates a closed MyWorkState with one empty list per My Work column
+func NewMyWorkState() *MyWorkState {
+	taskLists := make([]list.Model, len(domain.MyWorkColumnNames))
+	for i, name := range domain.MyWorkColumnNames {
+		delegate := styles.NewInactiveListDelegate()
+		if i == 0 {
+			delegate = styles.NewActiveListDelegate()
+		}
+		taskLists[i] = list.New([]list.Item{}, delegate, 100, 0)
+		taskLists[i].SetShowHelp(false)
+		taskLists[i].Title = name
+	}
+	styles.ApplyFocusedTitleStyles(taskLists, domain.Status(domain.MyWorkUpNext))
+	return &MyWorkState{NavigationState: NewNavigationState(taskLists)}
+}
+
+// Show opens the My Work board
+func (ms *MyWorkState) Show() {
+	ms.active = true
+}
+
+// Hide closes the My Work board, back to the project board
+func (ms *MyWorkState) Hide() {
+	ms.active = false
+}
+
+// IsActive returns whether the My Work board is shown
+func (ms *MyWorkState) IsActive() bool {
+	return ms.active
+}
+
+// SetTasks fills the columns with the cards gathered from every project, each
+// after its project's name. Cards in a custom workflow's middle columns also
+// name their column. Preserves cursor positions.
+func (ms *MyWorkState) SetTasks(columns [][]domain.MyWorkTask) {
+	selections := ms.listSelections()
+	ms.titles = make([]styles.ColumnTitle, len(ms.Tasks))
+	for i, name := range domain.MyWorkColumnNames {
+		column := domain.Status(i)
+		items := make([]list.Item, len(columns[i]))
+		for j, card := range columns[i] {
+			label := card.Project.Name
+			if status := card.Project.Workflow.Name(card.Task.Status); i == domain.MyWorkInProgress && status != name {
+				label += " · " + status
+			}
+			items[j] = styles.NewTaskWithTitle(card.Task).WithProject(label, card.Project.Color)
+		}
+		ms.Tasks[column].SetItems(styles.UpdateTaskSelection(items, selections[column], ms.activeListIndex == column))
+		ms.titles[i] = styles.ColumnTitle{Name: fmt.Sprintf("%s (%d)", name, len(items))}
+	}
+	styles.ApplyFocusedTitleStyles(ms.Tasks, ms.activeListIndex, ms.titles...)
+}
+
+// SelectedTask returns the card under the cursor in the focused column, or nil
+// when the column is empty
+func (ms *MyWorkState) SelectedTask() *domain.Task {
+	if item, ok := ms.GetActiveList().SelectedItem().(styles.TaskWithTitle); ok {
+		return &item.Task
+	}
+	return nil
+}
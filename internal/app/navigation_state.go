@@ -11,7 +11,12 @@ import (
 type NavigationState struct {
 	showProjectSwitch bool
 	activeListIndex   domain.Status
-	Tasks             []list.Model
+	Tasks             []list.Model // one list per column of the active project's workflow
+	titles            []styles.ColumnTitle
+
+	// Last size passed to UpdateListSizes, applied to columns added by a workflow change
+	listWidth  int
+	listHeight int
 
 	dirtyFlags map[domain.Status]bool
 }
@@ -23,6 +28,44 @@ func NewNavigationState(tasks []list.Model) *NavigationState {
 	}
 }
 
+// newTaskLists creates one empty, titled list per workflow column with the first
+// column active
+func newTaskLists(workflow domain.Workflow) []list.Model {
+	taskLists := make([]list.Model, workflow.Len())
+	for i, status := range workflow.Statuses() {
+		delegate := styles.NewInactiveListDelegate()
+		if i == 0 {
+			delegate = styles.NewActiveListDelegate()
+		}
+		taskLists[i] = list.New([]list.Item{}, delegate, 100, 0)
+		taskLists[i].SetShowHelp(false)
+		taskLists[i].Title = workflow.Name(status)
+	}
+	styles.ApplyFocusedTitleStyles(taskLists, domain.NotStarted)
+	return taskLists
+}
+
+// syncColumns makes the lists match the project's workflow. Titles are refreshed
+// in place; a different column count rebuilds the lists and keeps the focus on
+// the nearest column.
+func (ns *NavigationState) syncColumns(workflow domain.Workflow) {
+	if len(ns.Tasks) == workflow.Len() {
+		for _, status := range workflow.Statuses() {
+			ns.Tasks[status].Title = workflow.Name(status)
+		}
+		return
+	}
+
+	ns.Tasks = newTaskLists(workflow)
+	ns.titles = nil
+	ns.activeListIndex = min(ns.activeListIndex, domain.Status(workflow.Len()-1))
+	if ns.listWidth > 0 {
+		ns.UpdateListSizes(ns.listWidth, ns.listHeight)
+	}
+	ns.switchToList(ns.activeListIndex)
+	ns.dirtyFlags = nil
+}
+
 func (ns *NavigationState) ShowProjectSwitch() {
 	ns.showProjectSwitch = true
 }
@@ -65,88 +108,85 @@ func (ns *NavigationState) switchToList(newIndex domain.Status) {
 	ns.Tasks[ns.activeListIndex].SetItems(styles.UpdateTaskSelection(newItems, ns.Tasks[ns.activeListIndex].Index(), true))
 
 	// Update title styles to reflect new focus
-	styles.ApplyFocusedTitleStyles(ns.Tasks[:], ns.activeListIndex)
+	styles.ApplyFocusedTitleStyles(ns.Tasks, ns.activeListIndex, ns.titles...)
 }
 
-func (ns *NavigationState) NextList() {
-	var nextIndex domain.Status
-	if ns.activeListIndex == domain.Done {
-		nextIndex = domain.NotStarted
-	} else {
-		nextIndex = ns.activeListIndex + 1
+// updateTitles refreshes the column titles with the WIP counters of the project.
+// Counts include tasks hidden by a search, since the limit applies to the whole column.
+func (ns *NavigationState) updateTitles(project *domain.Project) {
+	ns.titles = make([]styles.ColumnTitle, len(ns.Tasks))
+	for i := range ns.titles {
+		status := domain.Status(i)
+		ns.titles[i] = styles.ColumnTitle{
+			Name:  project.Workflow.Name(status),
+			Count: len(project.GetTasksByStatus(status)),
+			Limit: project.Workflow.WIPLimit(status),
+		}
 	}
-	ns.switchToList(nextIndex)
+	styles.ApplyFocusedTitleStyles(ns.Tasks, ns.activeListIndex, ns.titles...)
+}
+
+func (ns *NavigationState) NextList() {
+	ns.switchToList(domain.Status((int(ns.activeListIndex) + 1) % len(ns.Tasks)))
 }
 
 func (ns *NavigationState) PrevList() {
-	var prevIndex domain.Status
-	if ns.activeListIndex == domain.NotStarted {
-		prevIndex = domain.Done
-	} else {
-		prevIndex = ns.activeListIndex - 1
-	}
-	ns.switchToList(prevIndex)
+	ns.switchToList(domain.Status((int(ns.activeListIndex) - 1 + len(ns.Tasks)) % len(ns.Tasks)))
 }
 
 func (ns *NavigationState) UpdateTaskLists(project *domain.Project, taskService *services.TaskService) {
-	if project == nil {
-		return
-	}
-
-	// Save current selection states before refreshing
-	notStartedIndex := ns.Tasks[domain.NotStarted].Index()
-	inProgressIndex := ns.Tasks[domain.InProgress].Index()
-	doneIndex := ns.Tasks[domain.Done].Index()
-
-	allTasks, err := taskService.GetTasksByProject(project.ID)
-	if err != nil {
-		// Handle error - set empty lists
-		ns.Tasks[domain.NotStarted].SetItems([]list.Item{})
-		ns.Tasks[domain.InProgress].SetItems([]list.Item{})
-		ns.Tasks[domain.Done].SetItems([]list.Item{})
-		return
-	}
-
-	// Update project tasks with all tasks from database
-	project.Tasks = allTasks
-
-	// Convert tasks to list items and update each status list
-	// This preserves the existing filtering and UI behavior while using single DB query
-	ns.Tasks[domain.NotStarted].SetItems(convertTasksToListItems(project.GetTasksByStatus(domain.NotStarted)))
-	ns.Tasks[domain.InProgress].SetItems(convertTasksToListItems(project.GetTasksByStatus(domain.InProgress)))
-	ns.Tasks[domain.Done].SetItems(convertTasksToListItems(project.GetTasksByStatus(domain.Done)))
-
-	// Update selection states after refresh
-	ns.Tasks[domain.NotStarted].SetItems(styles.UpdateTaskSelection(ns.Tasks[domain.NotStarted].Items(), notStartedIndex, ns.activeListIndex == domain.NotStarted))
-	ns.Tasks[domain.InProgress].SetItems(styles.UpdateTaskSelection(ns.Tasks[domain.InProgress].Items(), inProgressIndex, ns.activeListIndex == domain.InProgress))
-	ns.Tasks[domain.Done].SetItems(styles.UpdateTaskSelection(ns.Tasks[domain.Done].Items(), doneIndex, ns.activeListIndex == domain.Done))
-
-	ns.clearAllDirtyFlags()
+	ns.UpdateTaskListsWithSearch(project, taskService, domain.TaskQuery{})
 }
 
-// UpdateTaskListsWithSearch updates task lists with search filter applied
 // UpdateTaskListsWithSearch refreshes all task lists from the database and applies
-// the search filter to each status column. Preserves cursor positions across refresh.
+// the search filter to each workflow column; the zero TaskQuery shows every task.
+// Preserves cursor positions across refresh.
 func (ns *NavigationState) UpdateTaskListsWithSearch(
 	project *domain.Project,
 	taskService *services.TaskService,
-	searchQuery string,
+	filter domain.TaskQuery,
+) {
+	ns.updateTaskLists(project, taskService, func(tasks []domain.Task, isDone bool) []list.Item {
+		return convertTasksToListItems(filter.Filter(tasks), isDone)
+	})
+}
+
+// UpdateTaskListsWithFuzzy refreshes all task lists from the database, keeping in
+// each column the tasks that match filter and whose name fuzzily matches pattern,
+// best match first with the matched characters highlighted
+func (ns *NavigationState) UpdateTaskListsWithFuzzy(
+	project *domain.Project,
+	taskService *services.TaskService,
+	filter domain.TaskQuery,
+	pattern string,
+) {
+	ns.updateTaskLists(project, taskService, func(tasks []domain.Task, isDone bool) []list.Item {
+		return convertFuzzyMatchesToListItems(domain.FuzzyFilter(filter.Filter(tasks), pattern), isDone)
+	})
+}
+
+// updateTaskLists reloads the project's tasks and fills each workflow column with
+// the items built from its tasks. Preserves cursor positions across refresh.
+func (ns *NavigationState) updateTaskLists(
+	project *domain.Project,
+	taskService *services.TaskService,
+	items func(tasks []domain.Task, isDone bool) []list.Item,
 ) {
 	if project == nil {
 		return
 	}
 
+	ns.syncColumns(project.Workflow)
+
 	// Save current selection states before refreshing
-	notStartedIndex := ns.Tasks[domain.NotStarted].Index()
-	inProgressIndex := ns.Tasks[domain.InProgress].Index()
-	doneIndex := ns.Tasks[domain.Done].Index()
+	selections := ns.listSelections()
 
 	allTasks, err := taskService.GetTasksByProject(project.ID)
 	if err != nil {
 		// Handle error - set empty lists
-		ns.Tasks[domain.NotStarted].SetItems([]list.Item{})
-		ns.Tasks[domain.InProgress].SetItems([]list.Item{})
-		ns.Tasks[domain.Done].SetItems([]list.Item{})
+		for i := range ns.Tasks {
+			ns.Tasks[i].SetItems([]list.Item{})
+		}
 		return
 	}
 
@@ -154,32 +194,48 @@ func (ns *NavigationState) UpdateTaskListsWithSearch(
 	project.Tasks = allTasks
 
 	// Get tasks by status and apply search filter
-	for _, status := range []domain.Status{domain.NotStarted, domain.InProgress, domain.Done} {
-		statusTasks := project.GetTasksByStatus(status)
-		filteredTasks := domain.SearchTasks(statusTasks, searchQuery)
-		ns.Tasks[status].SetItems(convertTasksToListItems(filteredTasks))
+	for _, status := range project.Workflow.Statuses() {
+		ns.Tasks[status].SetItems(items(project.GetTasksByStatus(status), project.Workflow.IsDone(status)))
+
+		// Update selection state after refresh
+		ns.Tasks[status].SetItems(styles.UpdateTaskSelection(
+			ns.Tasks[status].Items(),
+			selections[status],
+			ns.activeListIndex == status,
+		))
 	}
 
-	// Update selection states after refresh
-	ns.Tasks[domain.NotStarted].SetItems(styles.UpdateTaskSelection(
-		ns.Tasks[domain.NotStarted].Items(),
-		notStartedIndex,
-		ns.activeListIndex == domain.NotStarted,
-	))
-	ns.Tasks[domain.InProgress].SetItems(styles.UpdateTaskSelection(
-		ns.Tasks[domain.InProgress].Items(),
-		inProgressIndex,
-		ns.activeListIndex == domain.InProgress,
-	))
-	ns.Tasks[domain.Done].SetItems(styles.UpdateTaskSelection(
-		ns.Tasks[domain.Done].Items(),
-		doneIndex,
-		ns.activeListIndex == domain.Done,
-	))
-
+	ns.updateTitles(project)
 	ns.clearAllDirtyFlags()
 }
 
+// listSelections returns the cursor position of every list keyed by status
+func (ns *NavigationState) listSelections() map[domain.Status]int {
+	selections := make(map[domain.Status]int, len(ns.Tasks))
+	for i := range ns.Tasks {
+		selections[domain.Status(i)] = ns.Tasks[i].Index()
+	}
+	return selections
+}
+
+// SelectTask focuses the column of the task and moves its cursor onto it. Nothing
+// moves when the task is not listed, for instance when a search hides it.
+func (ns *NavigationState) SelectTask(task domain.Task) {
+	if int(task.Status) >= len(ns.Tasks) {
+		return
+	}
+	for i, item := range ns.Tasks[task.Status].Items() {
+		if listed, ok := item.(styles.TaskWithTitle); ok && listed.ID == task.ID {
+			if task.Status != ns.activeListIndex {
+				ns.switchToList(task.Status)
+			}
+			ns.Tasks[task.Status].Select(i)
+			ns.Tasks[task.Status].SetItems(styles.UpdateTaskSelection(ns.Tasks[task.Status].Items(), i, true))
+			return
+		}
+	}
+}
+
 func (ns *NavigationState) GetActiveList() *list.Model {
 	return &ns.Tasks[ns.activeListIndex]
 }
@@ -195,9 +251,9 @@ func (ns *NavigationState) MarkAllListsDirty() {
 	if ns.dirtyFlags == nil {
 		ns.dirtyFlags = make(map[domain.Status]bool)
 	}
-	ns.dirtyFlags[domain.NotStarted] = true
-	ns.dirtyFlags[domain.InProgress] = true
-	ns.dirtyFlags[domain.Done] = true
+	for i := range ns.Tasks {
+		ns.dirtyFlags[domain.Status(i)] = true
+	}
 }
 
 func (ns *NavigationState) clearAllDirtyFlags() {
@@ -218,8 +274,8 @@ func (ns *NavigationState) UpdateDirtyLists(project *domain.Project, taskService
 		return
 	}
 
-	// If no dirty flags, treat as all dirty (fallback behavior)
-	if ns.dirtyFlags == nil || len(ns.dirtyFlags) == 0 {
+	// If no dirty flags or the workflow changed, treat as all dirty (fallback behavior)
+	if ns.dirtyFlags == nil || len(ns.dirtyFlags) == 0 || len(ns.Tasks) != project.Workflow.Len() {
 		ns.UpdateTaskLists(project, taskService)
 		return
 	}
@@ -235,16 +291,12 @@ func (ns *NavigationState) UpdateDirtyLists(project *domain.Project, taskService
 	project.Tasks = allTasks
 
 	// Save selection states for lists that will be updated
-	selections := map[domain.Status]int{
-		domain.NotStarted: ns.Tasks[domain.NotStarted].Index(),
-		domain.InProgress: ns.Tasks[domain.InProgress].Index(),
-		domain.Done:       ns.Tasks[domain.Done].Index(),
-	}
+	selections := ns.listSelections()
 
 	// Update only dirty lists
 	for status, isDirty := range ns.dirtyFlags {
 		if isDirty {
-			ns.Tasks[status].SetItems(convertTasksToListItems(project.GetTasksByStatus(status)))
+			ns.Tasks[status].SetItems(convertTasksToListItems(project.GetTasksByStatus(status), project.Workflow.IsDone(status)))
 			// Update selection state for the updated list
 			ns.Tasks[status].SetItems(styles.UpdateTaskSelection(
 				ns.Tasks[status].Items(),
@@ -254,16 +306,19 @@ func (ns *NavigationState) UpdateDirtyLists(project *domain.Project, taskService
 		}
 	}
 
+	ns.updateTitles(project)
+
 	// Clear dirty flags after processing
 	ns.clearAllDirtyFlags()
 }
 
 func (ns *NavigationState) UpdateListSizes(width, height int) {
-	columnWidth := max(20, (width-3)/3)
+	ns.listWidth, ns.listHeight = width, height
+	columnWidth := max(20, (width-3)/len(ns.Tasks))
 
-	ns.Tasks[domain.NotStarted].SetSize(columnWidth, height)
-	ns.Tasks[domain.InProgress].SetSize(columnWidth, height)
-	ns.Tasks[domain.Done].SetSize(columnWidth, height)
+	for i := range ns.Tasks {
+		ns.Tasks[i].SetSize(columnWidth, height)
+	}
 }
 
 func (ns *NavigationState) UpdateActiveList(msg tea.Msg) tea.Cmd {
@@ -64,8 +64,13 @@ func (pm *ProjectManager) InitializeProjects() error {
 
 // GetActiveProject returns the currently active project
 func (pm *ProjectManager) GetActiveProject() *domain.Project {
+	return pm.GetProject(pm.activeProjectID)
+}
+
+// GetProject returns the loaded project with the ID, or nil
+func (pm *ProjectManager) GetProject(id string) *domain.Project {
 	for i, proj := range pm.projects {
-		if proj.ID == pm.activeProjectID {
+		if proj.ID == id {
 			return &pm.projects[i]
 		}
 	}
@@ -132,6 +137,49 @@ func (pm *ProjectManager) DeleteProject(id string) error {
 	return nil
 }
 
+// Reload loads the projects and their tasks again after they were changed
+// directly in the database, keeping preferredID or else the current project active
+func (pm *ProjectManager) Reload(preferredID string) error {
+	projects, err := pm.projectService.GetAllProjects()
+	if err != nil {
+		return err
+	}
+	for i := range projects {
+		tasks, err := pm.taskService.GetTasksByProject(projects[i].ID)
+		if err != nil {
+			projects[i].Tasks = []domain.Task{}
+		} else {
+			projects[i].Tasks = tasks
+		}
+	}
+	pm.projects = projects
+
+	activeID := ""
+	for _, id := range []string{preferredID, pm.activeProjectID} {
+		if pm.hasProject(id) {
+			activeID = id
+			break
+		}
+	}
+	if activeID == "" && len(projects) > 0 {
+		activeID = projects[0].ID
+	}
+	pm.activeProjectID = activeID
+
+	pm.navState.MarkAllListsDirty()
+	pm.navState.UpdateTaskListsConditional(pm.GetActiveProject(), pm.taskService)
+	return nil
+}
+
+func (pm *ProjectManager) hasProject(id string) bool {
+	for _, proj := range pm.projects {
+		if proj.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
 // HasProjects returns true if there are any projects
 func (pm *ProjectManager) HasProjects() bool {
 	return len(pm.projects) > 0
@@ -0,0 +1,149 @@
+package app
+
+import "kahn/internal/domain"
+
+// SavedViewsState manages the picker listing the active project's saved views
+// and the global ones below an "All tasks" row, and the prompt naming the
+// search query being saved as a view. The view applied to the board stays
+// active after the picker closes.
+type SavedViewsState struct {
+	showing bool
+	views   []domain.SavedView
+	cursor  int // 0 is the "All tasks" row, and i+1 the view at index i
+	err     string
+
+	naming bool
+	name   string
+	query  string
+	global bool
+
+	active *domain.SavedView
+}
+
+// NewSavedViewsState creates a SavedViewsState with the picker hidden and no
+// view applied
+func NewSavedViewsState() *SavedViewsState {
+	return &SavedViewsState{}
+}
+
+// Show opens the picker with the cursor on the applied view, or on "All tasks"
+func (vs *SavedViewsState) Show(views []domain.SavedView) {
+	vs.reset()
+	vs.showing = true
+	vs.SetViews(views)
+	if vs.active != nil {
+		for i, view := range views {
+			if view.ID == vs.active.ID {
+				vs.cursor = i + 1
+			}
+		}
+	}
+}
+
+// ShowNaming opens the prompt naming a new view of query, scoped to the project
+func (vs *SavedViewsState) ShowNaming(query string) {
+	vs.reset()
+	vs.showing = true
+	vs.naming = true
+	vs.query = query
+}
+
+// Hide closes the picker or prompt, keeping the applied view
+func (vs *SavedViewsState) Hide() {
+	vs.reset()
+}
+
+func (vs *SavedViewsState) reset() {
+	*vs = SavedViewsState{active: vs.active}
+}
+
+// IsShowing returns whether the picker or prompt is open
+func (vs *SavedViewsState) IsShowing() bool {
+	return vs.showing
+}
+
+// IsNaming returns whether the prompt naming a new view is open
+func (vs *SavedViewsState) IsNaming() bool {
+	return vs.naming
+}
+
+// SetViews replaces the listed views, keeping the cursor on a row
+func (vs *SavedViewsState) SetViews(views []domain.SavedView) {
+	vs.views = views
+	vs.MoveCursor(0)
+}
+
+// GetViews returns the listed views, the project's first
+func (vs *SavedViewsState) GetViews() []domain.SavedView {
+	return vs.views
+}
+
+// GetCursor returns the highlighted row, where 0 is "All tasks"
+func (vs *SavedViewsState) GetCursor() int {
+	return vs.cursor
+}
+
+// MoveCursor moves the highlight by delta rows, within bounds
+func (vs *SavedViewsState) MoveCursor(delta int) {
+	vs.cursor = max(min(vs.cursor+delta, len(vs.views)), 0)
+}
+
+// SelectedView returns the highlighted view, or nil on the "All tasks" row
+func (vs *SavedViewsState) SelectedView() *domain.SavedView {
+	if vs.cursor > 0 && vs.cursor <= len(vs.views) {
+		return &vs.views[vs.cursor-1]
+	}
+	return nil
+}
+
+// GetName returns the typed name of the view being saved
+func (vs *SavedViewsState) GetName() string {
+	return vs.name
+}
+
+// AppendChar adds a typed character to the name
+func (vs *SavedViewsState) AppendChar(char string) {
+	vs.name += char
+}
+
+// Backspace removes the last character of the name
+func (vs *SavedViewsState) Backspace() {
+	if runes := []rune(vs.name); len(runes) > 0 {
+		vs.name = string(runes[:len(runes)-1])
+	}
+}
+
+// GetQuery returns the search query being saved
+func (vs *SavedViewsState) GetQuery() string {
+	return vs.query
+}
+
+// IsGlobal returns whether the view being saved applies to every project
+func (vs *SavedViewsState) IsGlobal() bool {
+	return vs.global
+}
+
+// ToggleGlobal switches the view being saved between the project and every project
+func (vs *SavedViewsState) ToggleGlobal() {
+	vs.global = !vs.global
+}
+
+// SetError shows a message below the views or the name, or clears it when empty
+func (vs *SavedViewsState) SetError(message string) {
+	vs.err = message
+}
+
+// GetError returns the message shown below the views or the name
+func (vs *SavedViewsState) GetError() string {
+	return vs.err
+}
+
+// SetActive applies view to the board, or no view when nil
+func (vs *SavedViewsState) SetActive(view *domain.SavedView) {
+	vs.active = view
+}
+
+// GetActive returns the view applied to the board, or nil
+func (vs *SavedViewsState) GetActive() *domain.SavedView {
+	return vs.active
+}
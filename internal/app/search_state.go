@@ -1,10 +1,19 @@
 package app
 
+import "kahn/internal/domain"
+
 // SearchState manages the state of the search/filter feature including
-// the active status, current query string, and match count.
+// the active status, current query string, and match count. While the query
+// does not parse, the board keeps the filter of the last query that did and
+// the search bar shows the parse error. In fuzzy mode the query is not parsed:
+// it is matched fuzzily against task names instead, which stays on for later
+// searches until toggled off.
 type SearchState struct {
 	active     bool
+	fuzzy      bool
 	query      string
+	filter     domain.TaskQuery
+	err        string
 	matchCount int
 }
 
@@ -27,6 +36,16 @@ func (ss *SearchState) GetQuery() string {
 	return ss.query
 }
 
+// IsFuzzy returns whether the query is matched fuzzily against task names
+func (ss *SearchState) IsFuzzy() bool {
+	return ss.fuzzy
+}
+
+// ToggleFuzzy switches between fuzzy matching and the query language
+func (ss *SearchState) ToggleFuzzy() {
+	ss.fuzzy = !ss.fuzzy
+}
+
 // GetMatchCount returns the number of tasks matching the current query
 func (ss *SearchState) GetMatchCount() int {
 	return ss.matchCount
@@ -36,6 +55,8 @@ func (ss *SearchState) GetMatchCount() int {
 func (ss *SearchState) Activate() {
 	ss.active = true
 	ss.query = ""
+	ss.filter = domain.TaskQuery{}
+	ss.err = ""
 	ss.matchCount = 0
 }
 
@@ -44,6 +65,27 @@ func (ss *SearchState) SetQuery(query string) {
 	ss.query = query
 }
 
+// SetFilter sets the parsed query the board is filtered with and clears the parse error
+func (ss *SearchState) SetFilter(filter domain.TaskQuery) {
+	ss.filter = filter
+	ss.err = ""
+}
+
+// GetFilter returns the parsed query the board is filtered with
+func (ss *SearchState) GetFilter() domain.TaskQuery {
+	return ss.filter
+}
+
+// SetError records why the current query does not parse
+func (ss *SearchState) SetError(message string) {
+	ss.err = message
+}
+
+// GetError returns why the current query does not parse, or "" when it does
+func (ss *SearchState) GetError() string {
+	return ss.err
+}
+
 // UpdateMatchCount updates the count of tasks matching the current query
 func (ss *SearchState) UpdateMatchCount(count int) {
 	ss.matchCount = count
@@ -53,6 +95,8 @@ func (ss *SearchState) UpdateMatchCount(count int) {
 func (ss *SearchState) Clear() {
 	ss.active = false
 	ss.query = ""
+	ss.filter = domain.TaskQuery{}
+	ss.err = ""
 	ss.matchCount = 0
 }
 
@@ -3,10 +3,12 @@ package app
 import (
 	"testing"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"kahn/internal/domain"
+	"kahn/internal/ui/colors"
 )
 
 // UIStateManager Tests
@@ -573,7 +575,7 @@ func TestNavigationState_UpdateTaskListsWithSearch(t *testing.T) {
 	activeProj := km.projectManager.GetActiveProject()
 
 	// Apply search filter for "API"
-	km.navState.UpdateTaskListsWithSearch(activeProj, km.taskService, "API")
+	km.navState.UpdateTaskListsWithSearch(activeProj, km.taskService, mustParseQuery(t, "API"))
 
 	// Verify only API tasks are shown
 	notStartedItems := km.navState.GetTaskItems(domain.NotStarted)
@@ -591,7 +593,7 @@ func TestNavigationState_UpdateTaskListsWithSearch_EmptyQuery(t *testing.T) {
 	activeProj := km.projectManager.GetActiveProject()
 
 	// Apply empty search (should show all)
-	km.navState.UpdateTaskListsWithSearch(activeProj, km.taskService, "")
+	km.navState.UpdateTaskListsWithSearch(activeProj, km.taskService, domain.TaskQuery{})
 
 	// Verify all tasks shown
 	notStartedItems := km.navState.GetTaskItems(domain.NotStarted)
@@ -839,3 +841,151 @@ func TestFormState_HideForm_ClearsError(t *testing.T) {
 	errorMsg, _ := formState.GetError()
 	assert.Empty(t, errorMsg)
 }
+
+func TestNavigationState_CustomWorkflowColumns(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	activeProj := km.GetActiveProject()
+	require.NotNil(t, activeProj)
+	workflow, err := domain.NewWorkflow([]string{"Backlog", "Ready", "In Progress", "In Review", "Done"}, "")
+	require.NoError(t, err)
+	_, err = km.projectService.SetWorkflow(activeProj.ID, workflow)
+	require.NoError(t, err)
+	activeProj.Workflow = workflow
+
+	require.NoError(t, km.CreateTask("Task", ""))
+	km.navState.UpdateTaskLists(activeProj, km.taskService)
+
+	require.Len(t, km.navState.Tasks, 5)
+	assert.Equal(t, "In Review", km.navState.Tasks[3].Title)
+
+	km.navState.SetActiveListIndex(domain.Status(4))
+	km.navState.NextList()
+	assert.Equal(t, domain.NotStarted, km.navState.GetActiveListIndex(), "Last column wraps to the first")
+	km.navState.PrevList()
+	assert.Equal(t, domain.Status(4), km.navState.GetActiveListIndex())
+
+	task := activeProj.Tasks[0]
+	for i := 0; i < 4; i++ {
+		require.NoError(t, km.MoveTaskToNextStatus(task.ID))
+	}
+	assert.Len(t, km.navState.GetTaskItems(domain.Status(4)), 1, "Four moves reach the Done column")
+
+	view := km.View()
+	assert.Contains(t, view, "In Review")
+	assert.Contains(t, view, "Ready")
+}
+
+func TestKahnModel_WIPLimits(t *testing.T) {
+	km, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	activeProj := km.GetActiveProject()
+	require.NotNil(t, activeProj)
+	updated, err := km.projectService.SetWIPLimit(activeProj.ID, domain.InProgress, 1)
+	require.NoError(t, err)
+	activeProj.Workflow = updated.Workflow
+
+	require.NoError(t, km.CreateTask("First", ""))
+	require.NoError(t, km.CreateTask("Second", ""))
+	first, second := activeProj.Tasks[0], activeProj.Tasks[1]
+
+	require.NoError(t, km.MoveTaskToNextStatus(first.ID))
+	assert.Equal(t, "In Progress 1/1", km.navState.Tasks[domain.InProgress].Title)
+
+	err = km.MoveTaskToNextStatus(second.ID)
+	var wipErr *domain.WIPLimitError
+	require.ErrorAs(t, err, &wipErr)
+	assert.True(t, wipErr.Enforced)
+	assert.Contains(t, km.notice, "WIP limit")
+	assert.Len(t, km.navState.GetTaskItems(domain.InProgress), 1, "Rejected move leaves the column unchanged")
+
+	km.SetWIPEnforcement(domain.WIPWarn)
+	err = km.MoveTaskToNextStatus(second.ID)
+	require.ErrorAs(t, err, &wipErr)
+	assert.False(t, wipErr.Enforced)
+	assert.Len(t, km.navState.GetTaskItems(domain.InProgress), 2, "Warned move is saved")
+	assert.Equal(t, "In Progress 2/1", km.navState.Tasks[domain.InProgress].Title)
+	assert.Equal(t, lipgloss.Color(colors.Red), km.navState.Tasks[domain.InProgress].Styles.Title.GetForeground(), "Columns over their limit get a red title")
+}
+
+func TestTrashState_Selection(t *testing.T) {
+	ts := NewTrashState()
+	archived := []domain.Task{{ID: "a1"}}
+	trashed := []domain.Task{{ID: "t1"}, {ID: "t2"}}
+	projects := []domain.Project{{ID: "p1"}}
+
+	ts.Show(archived, trashed, projects)
+	require.True(t, ts.IsShowing())
+	assert.False(t, ts.IsShowingTrash(), "The pane opens on the Archive tab")
+	assert.Equal(t, "a1", ts.SelectedTask().ID)
+	assert.Nil(t, ts.SelectedProject())
+	ts.MoveCursor(1)
+	assert.Equal(t, 0, ts.GetCursor(), "The cursor stays on the last entry")
+
+	ts.SwitchTab()
+	assert.Equal(t, "p1", ts.SelectedProject().ID, "Trashed projects come first")
+	assert.Nil(t, ts.SelectedTask())
+	ts.MoveCursor(2)
+	assert.Equal(t, "t2", ts.SelectedTask().ID)
+
+	ts.SetContents(archived, trashed[:1], projects)
+	assert.Equal(t, 1, ts.GetCursor(), "Removing the last entry moves the cursor up")
+	assert.Equal(t, "t1", ts.SelectedTask().ID)
+
+	ts.Hide()
+	assert.False(t, ts.IsShowing())
+	assert.Empty(t, ts.GetTrashedTasks())
+}
+
+func TestGlobalSearchState_Query(t *testing.T) {
+	gs := NewGlobalSearchState()
+	gs.Show()
+	require.True(t, gs.IsShowing())
+	assert.Nil(t, gs.SelectedResult(), "Nothing is selected before a search")
+
+	gs.AppendChar("lo")
+	gs.AppendChar("é")
+	gs.Backspace()
+	assert.Equal(t, "lo", gs.GetQuery(), "Backspace removes a whole character")
+
+	gs.SetError("failed")
+	gs.SetResults([]domain.SearchResult{{Task: domain.Task{ID: "t1"}}, {Task: domain.Task{ID: "t2"}}})
+	assert.Empty(t, gs.GetError(), "New results clear the error")
+	gs.MoveCursor(5)
+	assert.Equal(t, "t2", gs.SelectedResult().Task.ID, "The cursor stays on the last result")
+	gs.SetResults(gs.GetResults()[:1])
+	assert.Equal(t, 0, gs.GetCursor(), "New results put the cursor on the best match")
+
+	gs.Hide()
+	assert.False(t, gs.IsShowing())
+	assert.Empty(t, gs.GetQuery())
+}
+
+func TestSavedViewsState_KeepsActiveView(t *testing.T) {
+	vs := NewSavedViewsState()
+	views := []domain.SavedView{{ID: "v1", Name: "Bugs"}, {ID: "v2", Name: "Mine"}}
+	vs.Show(views)
+	assert.Nil(t, vs.SelectedView(), "The cursor starts on All tasks")
+	vs.MoveCursor(5)
+	assert.Equal(t, "v2", vs.SelectedView().ID, "The cursor stays on the last view")
+
+	vs.SetActive(&views[1])
+	vs.Hide()
+	assert.False(t, vs.IsShowing())
+	require.NotNil(t, vs.GetActive(), "Closing the picker keeps the view applied")
+
+	vs.Show(views)
+	assert.Equal(t, 2, vs.GetCursor(), "The picker opens on the applied view")
+
+	vs.ShowNaming("type:bug")
+	assert.True(t, vs.IsNaming())
+	vs.AppendChar("Bugé")
+	vs.Backspace()
+	vs.ToggleGlobal()
+	assert.Equal(t, "Bug", vs.GetName())
+	assert.Equal(t, "type:bug", vs.GetQuery())
+	assert.True(t, vs.IsGlobal())
+	assert.Equal(t, "v2", vs.GetActive().ID)
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/require"
@@ -196,6 +197,15 @@ func assertSearchQuery(t *testing.T, km *KahnModel, expected string) {
 	require.Equal(t, expected, actual, "Expected search query '%s', got '%s'", expected, actual)
 }
 
+// mustParseQuery parses a search query for the default workflow
+func mustParseQuery(t *testing.T, query string) domain.TaskQuery {
+	t.Helper()
+
+	parsed, err := domain.ParseTaskQuery(query, domain.DefaultWorkflow(), time.Now())
+	require.NoError(t, err)
+	return parsed
+}
+
 // assertProjectCount is a helper to assert the number of projects
 func assertProjectCount(t *testing.T, km *KahnModel, expectedCount int) {
 	t.Helper()
@@ -0,0 +1,145 @@
+package app
+
+import "kahn/internal/domain"
+
+// TrashState manages the pane listing the active project's archived tasks on
+// one tab and, on the other, what is in the trash: trashed projects first, then
+// the project's trashed tasks. Purging waits for a y/n confirmation.
+type TrashState struct {
+	showing    bool
+	trashTab   bool
+	archived   []domain.Task
+	tasks      []domain.Task
+	projects   []domain.Project
+	cursor     int
+	confirming bool
+	err        string
+}
+
+// NewTrashState creates a TrashState with the pane hidden
+func NewTrashState() *TrashState {
+	return &TrashState{}
+}
+
+// Show opens the pane on the Archive tab
+func (ts *TrashState) Show(archived, tasks []domain.Task, projects []domain.Project) {
+	*ts = TrashState{showing: true}
+	ts.SetContents(archived, tasks, projects)
+}
+
+// SetContents replaces the listed tasks and projects, keeping the tab and the
+// cursor on an entry
+func (ts *TrashState) SetContents(archived, tasks []domain.Task, projects []domain.Project) {
+	ts.archived = archived
+	ts.tasks = tasks
+	ts.projects = projects
+	ts.SetCursor(ts.cursor)
+}
+
+// Hide closes the pane and drops the listed tasks and projects
+func (ts *TrashState) Hide() {
+	*ts = TrashState{}
+}
+
+// IsShowing returns whether the pane is open
+func (ts *TrashState) IsShowing() bool {
+	return ts.showing
+}
+
+// IsShowingTrash returns whether the Trash tab is shown rather than the Archive tab
+func (ts *TrashState) IsShowingTrash() bool {
+	return ts.trashTab
+}
+
+// SwitchTab shows the other tab with the cursor on its first entry
+func (ts *TrashState) SwitchTab() {
+	ts.trashTab = !ts.trashTab
+	ts.cursor = 0
+	ts.confirming = false
+	ts.err = ""
+}
+
+// GetArchived returns the archived tasks listed on the Archive tab
+func (ts *TrashState) GetArchived() []domain.Task {
+	return ts.archived
+}
+
+// GetTrashedTasks returns the trashed tasks listed on the Trash tab
+func (ts *TrashState) GetTrashedTasks() []domain.Task {
+	return ts.tasks
+}
+
+// GetTrashedProjects returns the trashed projects listed on the Trash tab
+func (ts *TrashState) GetTrashedProjects() []domain.Project {
+	return ts.projects
+}
+
+// entryCount returns how many entries the shown tab lists
+func (ts *TrashState) entryCount() int {
+	if ts.trashTab {
+		return len(ts.projects) + len(ts.tasks)
+	}
+	return len(ts.archived)
+}
+
+// GetCursor returns the index of the highlighted entry
+func (ts *TrashState) GetCursor() int {
+	return ts.cursor
+}
+
+// SetCursor moves the highlight to the entry at index, within bounds
+func (ts *TrashState) SetCursor(index int) {
+	ts.cursor = max(min(index, ts.entryCount()-1), 0)
+}
+
+// MoveCursor moves the highlight by delta entries
+func (ts *TrashState) MoveCursor(delta int) {
+	ts.SetCursor(ts.cursor + delta)
+}
+
+// SelectedTask returns the highlighted task, or nil when a project or nothing is highlighted
+func (ts *TrashState) SelectedTask() *domain.Task {
+	if !ts.trashTab {
+		if ts.cursor < len(ts.archived) {
+			return &ts.archived[ts.cursor]
+		}
+		return nil
+	}
+	if index := ts.cursor - len(ts.projects); index >= 0 && index < len(ts.tasks) {
+		return &ts.tasks[index]
+	}
+	return nil
+}
+
+// SelectedProject returns the highlighted trashed project, or nil
+func (ts *TrashState) SelectedProject() *domain.Project {
+	if ts.trashTab && ts.cursor < len(ts.projects) {
+		return &ts.projects[ts.cursor]
+	}
+	return nil
+}
+
+// StartConfirm asks for confirmation before purging the highlighted entry
+func (ts *TrashState) StartConfirm() {
+	ts.confirming = true
+}
+
+// StopConfirm drops the pending confirmation
+func (ts *TrashState) StopConfirm() {
+	ts.confirming = false
+}
+
+// IsConfirming returns whether a purge waits for confirmation
+func (ts *TrashState) IsConfirming() bool {
+	return ts.confirming
+}
+
+// SetError shows a message below the list, or clears it when empty
+func (ts *TrashState) SetError(message string) {
+	ts.err = message
+}
+
+// GetError returns the message shown below the list
+func (ts *TrashState) GetError() string {
+	return ts.err
+}
@@ -14,6 +14,12 @@ const (
 	TaskDeleteConfirmView
 	ProjectDeleteConfirmView
 	NoProjectsView
+	DependencyView
+	ChecklistView
+	DetailView
+	TrashView
+	GlobalSearchView
+	SavedViewsView
 )
 
 // UIStateManager coordinates all UI states and provides a single source of truth
@@ -21,14 +27,26 @@ type UIStateManager struct {
 	formState    *FormState
 	confirmState *ConfirmationState
 	navState     *NavigationState
+	depState     *DependencyState
+	listState    *ChecklistState
+	detailState  *DetailState
+	trashState   *TrashState
+	globalState  *GlobalSearchState
+	viewsState   *SavedViewsState
 }
 
 // NewUIStateManager creates a new UI state manager
-func NewUIStateManager(formState *FormState, confirmState *ConfirmationState, navState *NavigationState) *UIStateManager {
+func NewUIStateManager(formState *FormState, confirmState *ConfirmationState, navState *NavigationState, depState *DependencyState, listState *ChecklistState, detailState *DetailState, trashState *TrashState, globalState *GlobalSearchState, viewsState *SavedViewsState) *UIStateManager {
 	return &UIStateManager{
 		formState:    formState,
 		confirmState: confirmState,
 		navState:     navState,
+		depState:     depState,
+		listState:    listState,
+		detailState:  detailState,
+		trashState:   trashState,
+		globalState:  globalState,
+		viewsState:   viewsState,
 	}
 }
 
@@ -46,6 +64,24 @@ func (usm *UIStateManager) GetCurrentViewState() ViewState {
 	if usm.confirmState.IsShowingProjectDeleteConfirm() {
 		return ProjectDeleteConfirmView
 	}
+	if usm.depState.IsShowing() {
+		return DependencyView
+	}
+	if usm.listState.IsShowing() {
+		return ChecklistView
+	}
+	if usm.detailState.IsShowing() {
+		return DetailView
+	}
+	if usm.trashState.IsShowing() {
+		return TrashView
+	}
+	if usm.globalState.IsShowing() {
+		return GlobalSearchView
+	}
+	if usm.viewsState.IsShowing() {
+		return SavedViewsView
+	}
 	return BoardView
 }
 
@@ -54,7 +90,13 @@ func (usm *UIStateManager) IsShowingAnyForm() bool {
 	return usm.formState.IsShowingForm() ||
 		usm.navState.IsShowingProjectSwitch() ||
 		usm.confirmState.IsShowingTaskDeleteConfirm() ||
-		usm.confirmState.IsShowingProjectDeleteConfirm()
+		usm.confirmState.IsShowingProjectDeleteConfirm() ||
+		usm.depState.IsShowing() ||
+		usm.listState.IsShowing() ||
+		usm.detailState.IsShowing() ||
+		usm.trashState.IsShowing() ||
+		usm.globalState.IsShowing() ||
+		usm.viewsState.IsShowing()
 }
 
 // HideAllStates hides all forms and confirmations
@@ -62,6 +104,12 @@ func (usm *UIStateManager) HideAllStates() {
 	usm.formState.HideForm()
 	usm.navState.HideProjectSwitch()
 	usm.confirmState.HideAllConfirmations()
+	usm.depState.Hide()
+	usm.listState.Hide()
+	usm.detailState.Hide()
+	usm.trashState.Hide()
+	usm.globalState.Hide()
+	usm.viewsState.Hide()
 }
 
 // ShowTaskForm shows the task creation form
@@ -71,9 +119,9 @@ func (usm *UIStateManager) ShowTaskForm(availableTasks []domain.Task) {
 }
 
 // ShowTaskEditForm shows the task editing form
-func (usm *UIStateManager) ShowTaskEditForm(taskID string, name, description string, priority domain.Priority, taskType domain.TaskType, blockedByIntID *int, availableTasks []domain.Task) {
+func (usm *UIStateManager) ShowTaskEditForm(taskID string, name, description string, priority domain.Priority, taskType domain.TaskType, blockedBy []int, availableTasks []domain.Task) {
 	usm.HideAllStates()
-	usm.formState.ShowTaskEditForm(taskID, name, description, priority, taskType, blockedByIntID, availableTasks)
+	usm.formState.ShowTaskEditForm(taskID, name, description, priority, taskType, blockedBy, availableTasks)
 }
 
 // ShowProjectForm shows the project creation form
@@ -100,6 +148,48 @@ func (usm *UIStateManager) ShowProjectDeleteConfirm(projectID string) {
 	usm.confirmState.ShowProjectDeleteConfirm(projectID)
 }
 
+// ShowDependencies shows the blocker tree of the task numbered rootID
+func (usm *UIStateManager) ShowDependencies(rootID int, tasks []domain.Task) {
+	usm.HideAllStates()
+	usm.depState.Show(rootID, tasks)
+}
+
+// ShowChecklist shows the checklist pane of the task
+func (usm *UIStateManager) ShowChecklist(task domain.Task, items []domain.ChecklistItem) {
+	usm.HideAllStates()
+	usm.listState.Show(task, items)
+}
+
+// ShowTaskDetail shows the detail pane of the task
+func (usm *UIStateManager) ShowTaskDetail(task domain.Task) {
+	usm.HideAllStates()
+	usm.detailState.Show(task)
+}
+
+// ShowTrash shows the archived tasks, and the trashed tasks and projects
+func (usm *UIStateManager) ShowTrash(archived, tasks []domain.Task, projects []domain.Project) {
+	usm.HideAllStates()
+	usm.trashState.Show(archived, tasks, projects)
+}
+
+// ShowGlobalSearch shows the search across every project with an empty query
+func (usm *UIStateManager) ShowGlobalSearch() {
+	usm.HideAllStates()
+	usm.globalState.Show()
+}
+
+// ShowSavedViews shows the picker listing the saved views
+func (usm *UIStateManager) ShowSavedViews(views []domain.SavedView) {
+	usm.HideAllStates()
+	usm.viewsState.Show(views)
+}
+
+// ShowSaveView shows the prompt naming a new view of the search query
+func (usm *UIStateManager) ShowSaveView(query string) {
+	usm.HideAllStates()
+	usm.viewsState.ShowNaming(query)
+}
+
 // Getter methods for accessing specific state managers
 func (usm *UIStateManager) FormState() *FormState {
 	return usm.formState
@@ -112,3 +202,27 @@ func (usm *UIStateManager) ConfirmationState() *ConfirmationState {
 func (usm *UIStateManager) NavigationState() *NavigationState {
 	return usm.navState
 }
+
+func (usm *UIStateManager) DependencyState() *DependencyState {
+	return usm.depState
+}
+
+func (usm *UIStateManager) ChecklistState() *ChecklistState {
+	return usm.listState
+}
+
+func (usm *UIStateManager) DetailState() *DetailState {
+	return usm.detailState
+}
+
+func (usm *UIStateManager) TrashState() *TrashState {
+	return usm.trashState
+}
+
+func (usm *UIStateManager) GlobalSearchState() *GlobalSearchState {
+	return usm.globalState
+}
+
+func (usm *UIStateManager) SavedViewsState() *SavedViewsState {
+	return usm.viewsState
+}
@@ -0,0 +1,167 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"kahn/internal/domain"
+	"kahn/internal/services"
+)
+
+// UndoStack records the changes made on the board so they can be undone with u
+// and redone with ctrl+r. Each operation is persisted with the actions that
+// revert and repeat it, so they can be undone even after a restart.
+type UndoStack struct {
+	repo        domain.UndoRepository
+	taskService *services.TaskService
+	eventLog    *services.EventLog
+}
+
+// NewUndoStack creates an undo stack reading snapshots through the services
+func NewUndoStack(repo domain.UndoRepository, taskService *services.TaskService, eventLog *services.EventLog) *UndoStack {
+	return &UndoStack{
+		repo:        repo,
+		taskService: taskService,
+		eventLog:    eventLog,
+	}
+}
+
+// SnapshotTask reads everything stored about the task, enough to restore it
+func (s *UndoStack) SnapshotTask(id string) (domain.TaskSnapshot, error) {
+	task, err := s.taskService.GetTask(id)
+	if err != nil {
+		return domain.TaskSnapshot{}, err
+	}
+	if task == nil {
+		return domain.TaskSnapshot{}, domain.NewValidationError("id", "task not found")
+	}
+	checklist, err := s.taskService.GetChecklist(id)
+	if err != nil {
+		return domain.TaskSnapshot{}, err
+	}
+	return domain.TaskSnapshot{Task: *task, Checklist: checklist}, nil
+}
+
+// Dependents returns a link for every stored task waiting on task
+func (s *UndoStack) Dependents(task domain.Task) []domain.BlockerLink {
+	tasks, err := s.taskService.GetTasksByProject(task.ProjectID)
+	if err != nil {
+		return nil
+	}
+	return domain.DependentLinks(tasks, task.IntID)
+}
+
+// Record pushes a change that was just made, discarding anything undone before it
+func (s *UndoStack) Record(op domain.UndoOperation) error {
+	if err := s.repo.Push(&op); err != nil {
+		return domain.NewRepositoryError("record", "undo operation", op.Description, err)
+	}
+	return nil
+}
+
+// Undo reverts the most recent change still done. It returns nil when there is
+// nothing to undo.
+func (s *UndoStack) Undo() (*domain.UndoOperation, error) {
+	op, err := s.repo.LastDone()
+	if err != nil {
+		return nil, domain.NewRepositoryError("get", "undo operation", "", err)
+	}
+	if op == nil {
+		return nil, nil
+	}
+	return op, s.apply(op, true)
+}
+
+// Redo makes again the most recently undone change. It returns nil when there
+// is nothing to redo.
+func (s *UndoStack) Redo() (*domain.UndoOperation, error) {
+	op, err := s.repo.FirstUndone()
+	if err != nil {
+		return nil, domain.NewRepositoryError("get", "redo operation", "", err)
+	}
+	if op == nil {
+		return nil, nil
+	}
+	return op, s.apply(op, false)
+}
+
+// apply runs one side of op. A task deleted for good, as undoing its creation
+// does, is snapshotted again first, so comments, checklist items or dependents
+// added since the operation was recorded come back with it.
+//
+// An operation that can no longer be applied, e.g. because the task it updates
+// was deleted from the command line or the step would close a dependency cycle,
+// is dropped so it does not block the rest of the stack. One refused by an
+// enforced WIP limit is kept, since it applies once the column has room. A
+// column left over its limit under WIPWarn is returned as a warning.
+func (s *UndoStack) apply(op *domain.UndoOperation, undo bool) error {
+	actions, inverse := op.Redo, &op.Undo
+	if undo {
+		actions, inverse = op.Undo, &op.Redo
+	}
+	for _, action := range actions {
+		if action.Kind != domain.UndoDeleteTask {
+			continue
+		}
+		if snapshot, err := s.SnapshotTask(action.ID); err == nil {
+			*inverse = s.refreshTaskSnapshot(*inverse, snapshot)
+		}
+	}
+
+	warning, err := s.taskService.CheckUndo(actions)
+	var wipErr *domain.WIPLimitError
+	if errors.As(err, &wipErr) {
+		return fmt.Errorf("cannot %s %s: %w", undoVerb(undo), op.Summary(), err)
+	}
+	if err == nil {
+		err = s.repo.Apply(op, undo)
+	}
+	if err != nil {
+		_ = s.repo.Drop(op.ID)
+		return fmt.Errorf("cannot %s %s: %w", undoVerb(undo), op.Summary(), err)
+	}
+	s.eventLog.RecordUndo(*op, undo)
+	if warning != nil {
+		return warning
+	}
+	return nil
+}
+
+// refreshTaskSnapshot replaces the snapshot restored for the task in actions,
+// and the links making its dependents wait on it again
+func (s *UndoStack) refreshTaskSnapshot(actions []domain.UndoAction, snapshot domain.TaskSnapshot) []domain.UndoAction {
+	dependents := s.Dependents(snapshot.Task)
+	var refreshed []domain.UndoAction
+	for _, action := range actions {
+		switch {
+		case action.Kind == domain.UndoRestoreTask && action.Task != nil && action.Task.Task.ID == snapshot.Task.ID:
+			action.Task = &snapshot
+			refreshed = append(refreshed, action)
+			if len(dependents) > 0 {
+				refreshed = append(refreshed, domain.UndoAction{Kind: domain.UndoLinkBlockers, Links: dependents})
+			}
+		case action.Kind == domain.UndoLinkBlockers && linksTo(action.Links, snapshot.Task.IntID):
+			// Replaced by the dependents found above
+		default:
+			refreshed = append(refreshed, action)
+		}
+	}
+	return refreshed
+}
+
+// linksTo reports whether every link makes a task wait on the task numbered intID
+func linksTo(links []domain.BlockerLink, intID int) bool {
+	for _, link := range links {
+		if link.BlockerIntID != intID {
+			return false
+		}
+	}
+	return len(links) > 0
+}
+
+func undoVerb(undo bool) string {
+	if undo {
+		return "undo"
+	}
+	return "redo"
+}
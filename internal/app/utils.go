@@ -6,11 +6,23 @@ import (
 	"kahn/internal/ui/styles"
 )
 
-func convertTasksToListItems(tasks []domain.Task) []list.Item {
+// convertTasksToListItems wraps the tasks of one column; isDone marks the
+// workflow's done column, whose tasks are never shown as overdue
+func convertTasksToListItems(tasks []domain.Task, isDone bool) []list.Item {
 	items := make([]list.Item, len(tasks))
 	for i, task := range tasks {
 
-		items[i] = styles.NewTaskWithTitle(task)
+		items[i] = styles.NewTaskWithTitle(task).WithDone(isDone)
+	}
+	return items
+}
+
+// convertFuzzyMatchesToListItems converts fuzzy matches to list items in their
+// order, highlighting the matched characters of each name
+func convertFuzzyMatchesToListItems(matches []domain.FuzzyMatch, isDone bool) []list.Item {
+	items := make([]list.Item, len(matches))
+	for i, match := range matches {
+		items[i] = styles.NewTaskWithTitle(match.Task).WithDone(isDone).WithMatches(match.MatchedIndexes)
 	}
 	return items
 }
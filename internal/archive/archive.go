@@ -0,0 +1,597 @@
+package archive
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+
+	"kahn/internal/database"
+	"kahn/internal/domain"
+	"kahn/internal/formats"
+	repo "kahn/internal/repository"
+)
+
+// Mode controls how Import treats projects and tasks whose IDs already exist
+type Mode int
+
+const (
+	// ModeStrict refuses to import when any project or task ID already exists
+	ModeStrict Mode = iota
+	// ModeMerge skips projects and tasks whose IDs already exist
+	ModeMerge
+	// ModeReplace deletes every existing project and task before importing
+	ModeReplace
+)
+
+// Result summarises what Import changed
+type Result struct {
+	ProjectsImported int
+	ProjectsSkipped  int
+	TasksImported    int
+	TasksSkipped     int
+	BlockersLinked   int
+	BlockersDropped  int // a blocker referenced a task missing from the archive
+	LabelsImported   int
+	ViewsImported    int
+	ViewsSkipped     int // a view of the same name already existed in its scope
+	ChecklistItems   int
+	Comments         int
+}
+
+// Export snapshots every project, label, task, checklist item, comment and saved
+// view in db, leaving out what is in the trash. Tasks are ordered by int_id so
+// that re-importing assigns new numbers in the same relative order.
+func Export(db *database.Database) (*formats.Archive, error) {
+	migrations, err := db.AppliedMigrations()
+	if err != nil {
+		return nil, domain.NewRepositoryError("export", "migrations", "", err)
+	}
+
+	projectRepo := repo.NewSQLiteProjectRepository(db.GetDB())
+	taskRepo := repo.NewSQLiteTaskRepository(db.GetDB())
+	labelRepo := repo.NewSQLiteLabelRepository(db.GetDB())
+
+	projects, err := projectRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(projects, func(i, j int) bool {
+		return projects[i].CreatedAt.Before(projects[j].CreatedAt)
+	})
+
+	projectRecords := make([]formats.ProjectRecord, 0, len(projects))
+	var labelRecords []formats.LabelRecord
+	var taskRecords []formats.TaskRecord
+	var checklistRecords []formats.ChecklistItemRecord
+	for _, project := range projects {
+		labels, err := labelRepo.GetByProjectID(project.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, label := range labels {
+			labelRecords = append(labelRecords, formats.NewLabelRecord(label))
+		}
+
+		tasks, err := taskRepo.GetByProjectID(project.ID)
+		if err != nil {
+			return nil, err
+		}
+		archived, err := taskRepo.GetArchived(project.ID)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, archived...)
+		projectRecords = append(projectRecords, formats.NewProjectRecord(project, len(tasks)))
+		for _, task := range tasks {
+			taskRecords = append(taskRecords, formats.NewTaskRecord(task, project.Workflow))
+
+			items, err := taskRepo.GetChecklist(task.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range items {
+				checklistRecords = append(checklistRecords, formats.NewChecklistItemRecord(item))
+			}
+		}
+	}
+	sort.Slice(taskRecords, func(i, j int) bool {
+		return taskRecords[i].IntID < taskRecords[j].IntID
+	})
+
+	views, err := repo.NewSQLiteSavedViewRepository(db.GetDB()).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	viewRecords := make([]formats.SavedViewRecord, 0, len(views))
+	for _, view := range views {
+		viewRecords = append(viewRecords, formats.NewSavedViewRecord(view))
+	}
+
+	return formats.NewArchive(migrations, projectRecords, labelRecords, taskRecords, checklistRecords, viewRecords), nil
+}
+
+// Import restores archive into db inside a single transaction. Tasks receive new
+// int_id values and dependency links are rewritten to match; nothing is changed
+// if any step fails.
+func Import(db *database.Database, archive *formats.Archive, mode Mode) (*Result, error) {
+	if err := validateArchive(archive); err != nil {
+		return nil, err
+	}
+
+	tx, err := db.BeginTransaction()
+	if err != nil {
+		return nil, domain.NewRepositoryError("begin", "import", "", err)
+	}
+	defer tx.Rollback()
+
+	result := &Result{}
+	if mode == ModeReplace {
+		if _, err := tx.Exec("DELETE FROM task_comments"); err != nil {
+			return nil, domain.NewRepositoryError("delete", "task comments", "", err)
+		}
+		if _, err := tx.Exec("DELETE FROM checklist_items"); err != nil {
+			return nil, domain.NewRepositoryError("delete", "checklist items", "", err)
+		}
+		if _, err := tx.Exec("DELETE FROM task_dependencies"); err != nil {
+			return nil, domain.NewRepositoryError("delete", "task dependencies", "", err)
+		}
+		if _, err := tx.Exec("DELETE FROM task_labels"); err != nil {
+			return nil, domain.NewRepositoryError("delete", "task labels", "", err)
+		}
+		if _, err := tx.Exec("DELETE FROM saved_views"); err != nil {
+			return nil, domain.NewRepositoryError("delete", "saved views", "", err)
+		}
+		if _, err := tx.Exec("DELETE FROM labels"); err != nil {
+			return nil, domain.NewRepositoryError("delete", "labels", "", err)
+		}
+		if _, err := tx.Exec("DELETE FROM tasks"); err != nil {
+			return nil, domain.NewRepositoryError("delete", "tasks", "", err)
+		}
+		if _, err := tx.Exec("DELETE FROM workflow_statuses"); err != nil {
+			return nil, domain.NewRepositoryError("delete", "workflows", "", err)
+		}
+		if _, err := tx.Exec("DELETE FROM projects"); err != nil {
+			return nil, domain.NewRepositoryError("delete", "projects", "", err)
+		}
+		// The board's undo stack describes the data being replaced
+		if _, err := tx.Exec("DELETE FROM undo_operations"); err != nil {
+			return nil, domain.NewRepositoryError("delete", "undo operations", "", err)
+		}
+	}
+
+	for _, record := range archive.Projects {
+		exists, err := rowExists(tx, "SELECT COUNT(*) FROM projects WHERE id = ?", record.ID)
+		if err != nil {
+			return nil, domain.NewRepositoryError("get", "project", record.ID, err)
+		}
+		if exists {
+			if mode != ModeMerge {
+				return nil, domain.NewValidationError("id", fmt.Sprintf("project %q already exists; use merge or replace mode", record.ID))
+			}
+			result.ProjectsSkipped++
+			continue
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO projects (id, name, description, color, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, record.ID, record.Name, record.Description, record.Color, record.CreatedAt, record.UpdatedAt)
+		if err != nil {
+			return nil, domain.NewRepositoryError("create", "project", record.ID, err)
+		}
+
+		// Archives written before workflows existed get the default columns
+		workflow := record.Workflow
+		if len(workflow) == 0 {
+			workflow = domain.DefaultWorkflow()
+		}
+		for position, status := range workflow {
+			_, err = tx.Exec(`
+				INSERT INTO workflow_statuses (project_id, position, name, is_done, wip_limit)
+				VALUES (?, ?, ?, ?, ?)
+			`, record.ID, position, status.Name, status.IsDone, status.WIPLimit)
+			if err != nil {
+				return nil, domain.NewRepositoryError("create", "workflow", record.ID, err)
+			}
+		}
+		result.ProjectsImported++
+	}
+
+	// Labels merge by name: a project that already has a label keeps its color
+	for _, record := range archive.Labels {
+		created, err := ensureLabel(tx, record.ProjectID, record.Name, record.Color, record.ID)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			result.LabelsImported++
+		}
+	}
+
+	// Views merge by name within their project, or among the global views
+	for _, record := range archive.Views {
+		created, err := ensureView(tx, record)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			result.ViewsImported++
+		} else {
+			result.ViewsSkipped++
+		}
+	}
+
+	// Archive int_id -> int_id in this database, including tasks skipped by a merge
+	// so that imported tasks can still point at them
+	intIDs := make(map[int]int, len(archive.Tasks))
+	var imported []formats.TaskRecord
+	workflows := make(map[string]domain.Workflow)
+
+	for _, record := range archive.Tasks {
+		var existingIntID int
+		err := tx.QueryRow("SELECT int_id FROM tasks WHERE id = ?", record.ID).Scan(&existingIntID)
+		switch {
+		case err == nil:
+			if mode != ModeMerge {
+				return nil, domain.NewValidationError("id", fmt.Sprintf("task %q already exists; use merge or replace mode", record.ID))
+			}
+			intIDs[record.IntID] = existingIntID
+			result.TasksSkipped++
+			continue
+		case err != sql.ErrNoRows:
+			return nil, domain.NewRepositoryError("get", "task", record.ID, err)
+		}
+
+		var trashed bool
+		err = tx.QueryRow("SELECT deleted_at IS NOT NULL FROM projects WHERE id = ?", record.ProjectID).Scan(&trashed)
+		switch {
+		case err == sql.ErrNoRows:
+			return nil, domain.NewValidationError("project_id", fmt.Sprintf("task %q belongs to unknown project %q", record.ID, record.ProjectID))
+		case err != nil:
+			return nil, domain.NewRepositoryError("get", "project", record.ProjectID, err)
+		case trashed:
+			// A merge leaves a project in the trash as it is, tasks included
+			result.TasksSkipped++
+			continue
+		}
+
+		// A merge keeps the workflow of a project already in the database, so the
+		// task is checked against the columns it will land in
+		workflow, ok := workflows[record.ProjectID]
+		if !ok {
+			if workflow, err = storedWorkflow(tx, record.ProjectID); err != nil {
+				return nil, err
+			}
+			workflows[record.ProjectID] = workflow
+		}
+		if !workflow.Contains(domain.Status(record.Status)) {
+			return nil, domain.NewValidationError("status", fmt.Sprintf("task %q has status %d outside its project's workflow", record.ID, record.Status))
+		}
+
+		task := record.Task()
+		res, err := tx.Exec(`
+			INSERT INTO tasks (id, project_id, name, desc, status, type, priority, blocked_by, created_at, updated_at, start_date, due_date, archived_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, NULL, ?, ?, ?, ?, ?)
+		`, record.ID, record.ProjectID, record.Name, record.Description, record.Status, record.Type,
+			record.Priority, record.CreatedAt, record.UpdatedAt, repo.DateValue(task.StartDate), repo.DateValue(task.DueDate), record.ArchivedAt)
+		if err != nil {
+			return nil, domain.NewRepositoryError("create", "task", record.ID, err)
+		}
+		newIntID, err := res.LastInsertId()
+		if err != nil {
+			return nil, domain.NewRepositoryError("read int_id", "task", record.ID, err)
+		}
+
+		intIDs[record.IntID] = int(newIntID)
+		imported = append(imported, record)
+		result.TasksImported++
+
+		for _, name := range record.Labels {
+			labelID, err := labelIDByName(tx, record.ProjectID, name)
+			if err != nil {
+				return nil, err
+			}
+			// Archives edited by hand may name labels they do not list
+			if labelID == "" {
+				if _, err := ensureLabel(tx, record.ProjectID, name, "", ""); err != nil {
+					return nil, err
+				}
+				result.LabelsImported++
+				if labelID, err = labelIDByName(tx, record.ProjectID, name); err != nil {
+					return nil, err
+				}
+			}
+			if _, err := tx.Exec("INSERT OR IGNORE INTO task_labels (task_id, label_id) VALUES (?, ?)", record.ID, labelID); err != nil {
+				return nil, domain.NewRepositoryError("create", "task label", record.ID, err)
+			}
+		}
+
+		for _, comment := range record.Comments {
+			_, err := tx.Exec(`
+				INSERT INTO task_comments (id, task_id, author, body, created_at)
+				VALUES (?, ?, ?, ?, ?)
+			`, comment.ID, record.ID, comment.Author, comment.Body, comment.CreatedAt)
+			if err != nil {
+				return nil, domain.NewRepositoryError("create", "comment", comment.ID, err)
+			}
+			result.Comments++
+		}
+	}
+
+	for _, record := range imported {
+		for _, blockerIntID := range record.BlockerIDs() {
+			blocker, ok := intIDs[blockerIntID]
+			if !ok {
+				result.BlockersDropped++
+				continue
+			}
+			if _, err := tx.Exec("INSERT OR IGNORE INTO task_dependencies (task_id, blocker_id) VALUES (?, ?)", intIDs[record.IntID], blocker); err != nil {
+				return nil, domain.NewRepositoryError("create", "task dependency", record.ID, err)
+			}
+			result.BlockersLinked++
+		}
+	}
+
+	// Checklists come with their task; a task skipped by a merge keeps its own.
+	// Positions are renumbered so each checklist runs from 1 without gaps.
+	importedTasks := make(map[string]bool, len(imported))
+	for _, record := range imported {
+		importedTasks[record.ID] = true
+	}
+	checklist := slices.Clone(archive.Checklist)
+	sort.SliceStable(checklist, func(i, j int) bool {
+		if checklist[i].TaskID != checklist[j].TaskID {
+			return checklist[i].TaskID < checklist[j].TaskID
+		}
+		return checklist[i].Position < checklist[j].Position
+	})
+	positions := make(map[string]int)
+	for _, record := range checklist {
+		if !importedTasks[record.TaskID] {
+			continue
+		}
+		positions[record.TaskID]++
+		_, err := tx.Exec(`
+			INSERT INTO checklist_items (id, task_id, text, done, position, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, record.ID, record.TaskID, record.Text, record.Done, positions[record.TaskID], record.CreatedAt, record.UpdatedAt)
+		if err != nil {
+			return nil, domain.NewRepositoryError("create", "checklist item", record.ID, err)
+		}
+		result.ChecklistItems++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, domain.NewRepositoryError("commit", "import", "", err)
+	}
+	return result, nil
+}
+
+// validateArchive checks the whole document before the transaction starts so that
+// malformed archives are reported without touching the database
+func validateArchive(archive *formats.Archive) error {
+	known := database.MigrationNames()
+	for _, name := range archive.Migrations {
+		if !slices.Contains(known, name) {
+			return domain.NewValidationError("migrations", fmt.Sprintf("archive was written by a newer version of kahn (unknown migration %q)", name))
+		}
+	}
+
+	workflows := make(map[string]domain.Workflow, len(archive.Projects))
+	for _, record := range archive.Projects {
+		if _, ok := workflows[record.ID]; ok {
+			return domain.NewValidationError("id", fmt.Sprintf("duplicate project %q in archive", record.ID))
+		}
+		workflows[record.ID] = record.Workflow
+
+		project := record.Project()
+		if err := project.Validate(); err != nil {
+			return fmt.Errorf("project %q: %w", record.ID, err)
+		}
+	}
+
+	for _, record := range archive.Labels {
+		if _, ok := workflows[record.ProjectID]; !ok {
+			return domain.NewValidationError("project_id", fmt.Sprintf("label %q belongs to a project missing from the archive", record.Name))
+		}
+		label := record.Label()
+		if err := label.Validate(); err != nil {
+			return fmt.Errorf("label %q: %w", record.Name, err)
+		}
+	}
+
+	for _, record := range archive.Views {
+		if _, ok := workflows[record.ProjectID]; record.ProjectID != "" && !ok {
+			return domain.NewValidationError("project_id", fmt.Sprintf("view %q belongs to a project missing from the archive", record.Name))
+		}
+		view := record.View()
+		if err := view.Validate(); err != nil {
+			return fmt.Errorf("view %q: %w", record.Name, err)
+		}
+	}
+
+	taskIDs := make(map[string]bool, len(archive.Tasks))
+	intIDs := make(map[int]bool, len(archive.Tasks))
+	commentIDs := make(map[string]bool)
+	for _, record := range archive.Tasks {
+		if taskIDs[record.ID] || intIDs[record.IntID] {
+			return domain.NewValidationError("id", fmt.Sprintf("duplicate task %q (#%d) in archive", record.ID, record.IntID))
+		}
+		taskIDs[record.ID] = true
+		intIDs[record.IntID] = true
+
+		if _, _, err := record.Dates(); err != nil {
+			return fmt.Errorf("task %q: %w", record.ID, err)
+		}
+		task := record.Task()
+		if err := task.Validate(); err != nil {
+			return fmt.Errorf("task %q: %w", record.ID, err)
+		}
+		for _, name := range record.Labels {
+			if err := domain.ValidateLabelName(name); err != nil {
+				return fmt.Errorf("task %q: %w", record.ID, err)
+			}
+		}
+		for _, commentRecord := range record.Comments {
+			if commentIDs[commentRecord.ID] {
+				return domain.NewValidationError("id", fmt.Sprintf("duplicate comment %q in archive", commentRecord.ID))
+			}
+			commentIDs[commentRecord.ID] = true
+
+			comment := commentRecord.Comment(record.ID)
+			if err := comment.Validate(); err != nil {
+				return fmt.Errorf("task %q comment %q: %w", record.ID, commentRecord.ID, err)
+			}
+		}
+		// Only workflows carried by the archive can be checked before the import starts
+		if workflow, ok := workflows[record.ProjectID]; ok && !workflow.Contains(task.Status) {
+			return domain.NewValidationError("status", fmt.Sprintf("task %q has status %d outside its project's workflow", record.ID, record.Status))
+		}
+	}
+
+	itemIDs := make(map[string]bool, len(archive.Checklist))
+	for _, record := range archive.Checklist {
+		if itemIDs[record.ID] {
+			return domain.NewValidationError("id", fmt.Sprintf("duplicate checklist item %q in archive", record.ID))
+		}
+		itemIDs[record.ID] = true
+
+		if !taskIDs[record.TaskID] {
+			return domain.NewValidationError("task_id", fmt.Sprintf("checklist item %q belongs to a task missing from the archive", record.ID))
+		}
+		item := record.Item()
+		if err := item.Validate(); err != nil {
+			return fmt.Errorf("checklist item %q: %w", record.ID, err)
+		}
+	}
+
+	graph := make(domain.DependencyGraph, len(archive.Tasks))
+	for _, record := range archive.Tasks {
+		graph[record.IntID] = record.BlockerIDs()
+	}
+	for _, record := range archive.Tasks {
+		if cycle := graph.FindCycle(record.IntID, graph[record.IntID]); cycle != nil {
+			return fmt.Errorf("task %q: %w", record.ID, domain.NewDependencyCycleError(cycle))
+		}
+	}
+	return nil
+}
+
+// ensureLabel creates the named label unless the project already has it. An
+// empty color picks one from the palette; an empty or taken ID gets a new one.
+func ensureLabel(tx *sql.Tx, projectID, name, color, id string) (bool, error) {
+	existing, err := labelIDByName(tx, projectID, name)
+	if err != nil || existing != "" {
+		return false, err
+	}
+
+	var count int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM labels WHERE project_id = ?", projectID).Scan(&count); err != nil {
+		return false, domain.NewRepositoryError("get", "labels", projectID, err)
+	}
+	if color == "" {
+		color = domain.LabelColors[count%len(domain.LabelColors)]
+	}
+	label := domain.NewLabel(projectID, name, color)
+	if id != "" {
+		taken, err := rowExists(tx, "SELECT COUNT(*) FROM labels WHERE id = ?", id)
+		if err != nil {
+			return false, domain.NewRepositoryError("get", "label", id, err)
+		}
+		if !taken {
+			label.ID = id
+		}
+	}
+	_, err = tx.Exec(`
+		INSERT INTO labels (id, project_id, name, color, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, label.ID, label.ProjectID, label.Name, label.Color, label.CreatedAt)
+	if err != nil {
+		return false, domain.NewRepositoryError("create", "label", label.ID, err)
+	}
+	return true, nil
+}
+
+// ensureView creates the view unless its scope already has one of that name,
+// ignoring case. An empty or taken ID gets a new one.
+func ensureView(tx *sql.Tx, record formats.SavedViewRecord) (bool, error) {
+	exists, err := rowExists(tx, `
+		SELECT COUNT(*) FROM saved_views
+		WHERE COALESCE(project_id, '') = ? AND name = ? COLLATE NOCASE
+	`, record.ProjectID, record.Name)
+	if err != nil {
+		return false, domain.NewRepositoryError("get", "saved view", record.Name, err)
+	}
+	if exists {
+		return false, nil
+	}
+
+	view := record.View()
+	if view.ID == "" {
+		view.ID = domain.NewSavedView(view.ProjectID, view.Name, view.Query).ID
+	} else {
+		taken, err := rowExists(tx, "SELECT COUNT(*) FROM saved_views WHERE id = ?", view.ID)
+		if err != nil {
+			return false, domain.NewRepositoryError("get", "saved view", view.ID, err)
+		}
+		if taken {
+			view.ID = domain.NewSavedView(view.ProjectID, view.Name, view.Query).ID
+		}
+	}
+	var projectID interface{}
+	if view.ProjectID != "" {
+		projectID = view.ProjectID
+	}
+	_, err = tx.Exec(`
+		INSERT INTO saved_views (id, project_id, name, query, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, view.ID, projectID, view.Name, view.Query, view.CreatedAt)
+	if err != nil {
+		return false, domain.NewRepositoryError("create", "saved view", view.ID, err)
+	}
+	return true, nil
+}
+
+func labelIDByName(tx *sql.Tx, projectID, name string) (string, error) {
+	var id string
+	err := tx.QueryRow("SELECT id FROM labels WHERE project_id = ? AND name = ?", projectID, name).Scan(&id)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", domain.NewRepositoryError("get", "label", name, err)
+	}
+	return id, nil
+}
+
+// storedWorkflow reads the columns of the project from the database, as they
+// stand inside tx
+func storedWorkflow(tx *sql.Tx, projectID string) (domain.Workflow, error) {
+	rows, err := tx.Query("SELECT name, is_done, wip_limit FROM workflow_statuses WHERE project_id = ? ORDER BY position", projectID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("get", "workflow", projectID, err)
+	}
+	defer rows.Close()
+
+	var workflow domain.Workflow
+	for rows.Next() {
+		var status domain.WorkflowStatus
+		if err := rows.Scan(&status.Name, &status.IsDone, &status.WIPLimit); err != nil {
+			return nil, domain.NewRepositoryError("scan", "workflow", projectID, err)
+		}
+		workflow = append(workflow, status)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.NewRepositoryError("get", "workflow", projectID, err)
+	}
+	return workflow, nil
+}
+
+func rowExists(tx *sql.Tx, query string, args ...any) (bool, error) {
+	var count int
+	if err := tx.QueryRow(query, args...).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,483 @@
+package archive
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+	"time"
+
+	"kahn/internal/config"
+	"kahn/internal/database"
+	"kahn/internal/domain"
+	"kahn/internal/formats"
+	repo "kahn/internal/repository"
+	"kahn/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testStore struct {
+	db       *database.Database
+	tasks    *services.TaskService
+	projects *services.ProjectService
+	labels   *services.LabelService
+	views    *services.SavedViewService
+}
+
+func setupTestStore(t *testing.T) *testStore {
+	t.Helper()
+
+	cfg := &config.Config{}
+	cfg.Database.Path = ":memory:"
+	cfg.Database.BusyTimeout = 5000
+	cfg.Database.JournalMode = "WAL"
+	cfg.Database.CacheSize = 10000
+	cfg.Database.ForeignKeys = true
+
+	db, err := database.NewDatabase(cfg)
+	require.NoError(t, err, "Failed to create test database")
+	t.Cleanup(func() { db.Close() })
+
+	taskRepo := repo.NewSQLiteTaskRepository(db.GetDB())
+	projectRepo := repo.NewSQLiteProjectRepository(db.GetDB())
+	return &testStore{
+		db:       db,
+		tasks:    services.NewTaskService(taskRepo, projectRepo),
+		projects: services.NewProjectService(projectRepo, taskRepo),
+		labels:   services.NewLabelService(repo.NewSQLiteLabelRepository(db.GetDB()), projectRepo, taskRepo),
+		views:    services.NewSavedViewService(repo.NewSQLiteSavedViewRepository(db.GetDB()), projectRepo),
+	}
+}
+
+// seedBoard creates a project with a blocker chain and returns the project
+func seedBoard(t *testing.T, store *testStore) *domain.Project {
+	t.Helper()
+
+	project, err := store.projects.CreateProject("Alpha", "First project")
+	require.NoError(t, err)
+
+	blocker, err := store.tasks.CreateTask("Blocker", "", project.ID, domain.Bug, domain.High, nil)
+	require.NoError(t, err)
+	_, err = store.tasks.CreateTask("Blocked", "waits", project.ID, domain.Feature, domain.Medium, []int{blocker.IntID})
+	require.NoError(t, err)
+	return project
+}
+
+func countRows(t *testing.T, store *testStore, table string) int {
+	t.Helper()
+
+	var count int
+	require.NoError(t, store.db.GetDB().QueryRow("SELECT COUNT(*) FROM "+table).Scan(&count))
+	return count
+}
+
+func TestExport(t *testing.T) {
+	store := setupTestStore(t)
+	seedBoard(t, store)
+
+	archive, err := Export(store.db)
+	require.NoError(t, err)
+
+	assert.Equal(t, formats.ArchiveFormat, archive.Format)
+	assert.Equal(t, database.MigrationNames(), archive.Migrations)
+	require.Len(t, archive.Projects, 1)
+	assert.Equal(t, 2, archive.Projects[0].TaskCount)
+	require.Len(t, archive.Tasks, 2)
+	assert.Equal(t, "Blocker", archive.Tasks[0].Name, "Tasks are ordered by int_id")
+	require.NotNil(t, archive.Tasks[1].BlockedBy)
+	assert.Equal(t, archive.Tasks[0].IntID, *archive.Tasks[1].BlockedBy)
+	assert.Equal(t, []int{archive.Tasks[0].IntID}, archive.Tasks[1].Blockers)
+}
+
+func TestImport_RemapsBlockers(t *testing.T) {
+	source := setupTestStore(t)
+	seedBoard(t, source)
+
+	var buf bytes.Buffer
+	exported, err := Export(source.db)
+	require.NoError(t, err)
+	require.NoError(t, formats.WriteArchive(&buf, exported))
+	archive, err := formats.ReadArchive(&buf)
+	require.NoError(t, err)
+
+	// Occupy the low task numbers so imported tasks are renumbered
+	target := setupTestStore(t)
+	other, err := target.projects.CreateProject("Other", "")
+	require.NoError(t, err)
+	for _, name := range []string{"One", "Two", "Three"} {
+		_, err := target.tasks.CreateTask(name, "", other.ID, domain.RegularTask, domain.Low, nil)
+		require.NoError(t, err)
+	}
+
+	result, err := Import(target.db, archive, ModeStrict)
+	require.NoError(t, err)
+	assert.Equal(t, &Result{ProjectsImported: 1, TasksImported: 2, BlockersLinked: 1}, result)
+
+	blocker, err := target.tasks.GetTask(archive.Tasks[0].ID)
+	require.NoError(t, err)
+	blocked, err := target.tasks.GetTask(archive.Tasks[1].ID)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, archive.Tasks[0].IntID, blocker.IntID, "Imported tasks receive new numbers")
+	assert.Equal(t, []int{blocker.IntID}, blocked.BlockedBy, "blockers follow the renumbered blocker")
+	assert.Equal(t, archive.Tasks[1].CreatedAt.Unix(), blocked.CreatedAt.Unix(), "Timestamps are preserved")
+}
+
+func TestImport_Modes(t *testing.T) {
+	source := setupTestStore(t)
+	seedBoard(t, source)
+	archive, err := Export(source.db)
+	require.NoError(t, err)
+
+	t.Run("strict mode rejects existing IDs without changing anything", func(t *testing.T) {
+		target := setupTestStore(t)
+		_, err := Import(target.db, archive, ModeStrict)
+		require.NoError(t, err)
+
+		_, err = Import(target.db, archive, ModeStrict)
+		var validationErr *domain.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, 2, countRows(t, target, "tasks"), "Failed import must roll back")
+	})
+
+	t.Run("merge mode skips existing IDs", func(t *testing.T) {
+		target := setupTestStore(t)
+		_, err := Import(target.db, archive, ModeStrict)
+		require.NoError(t, err)
+
+		result, err := Import(target.db, archive, ModeMerge)
+		require.NoError(t, err)
+		assert.Equal(t, &Result{ProjectsSkipped: 1, TasksSkipped: 2}, result)
+		assert.Equal(t, 2, countRows(t, target, "tasks"))
+	})
+
+	t.Run("merge mode checks new tasks against the stored workflow", func(t *testing.T) {
+		target := setupTestStore(t)
+		_, err := Import(target.db, archive, ModeStrict)
+		require.NoError(t, err)
+
+		merged := *archive
+		merged.Projects = slices.Clone(archive.Projects)
+		merged.Projects[0].Workflow, err = domain.NewWorkflow([]string{"One", "Two", "Three", "Four", "Five"}, "")
+		require.NoError(t, err)
+		late := archive.Tasks[0]
+		late.ID, late.IntID, late.Status = "task_late", 99, 4
+		merged.Tasks = append(slices.Clone(archive.Tasks), late)
+
+		_, err = Import(target.db, &merged, ModeMerge)
+		var validationErr *domain.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, 2, countRows(t, target, "tasks"), "Failed import must roll back")
+	})
+
+	t.Run("merge mode skips tasks of a project in the trash", func(t *testing.T) {
+		target := setupTestStore(t)
+		_, err := Import(target.db, archive, ModeStrict)
+		require.NoError(t, err)
+		require.NoError(t, target.projects.DeleteProject(archive.Projects[0].ID))
+
+		merged := *archive
+		extra := archive.Tasks[0]
+		extra.ID, extra.IntID = "task_extra", 99
+		merged.Tasks = append(slices.Clone(archive.Tasks), extra)
+
+		result, err := Import(target.db, &merged, ModeMerge)
+		require.NoError(t, err)
+		assert.Equal(t, &Result{ProjectsSkipped: 1, TasksSkipped: 3}, result)
+		assert.Equal(t, 2, countRows(t, target, "tasks"))
+	})
+
+	t.Run("replace mode discards existing data", func(t *testing.T) {
+		target := setupTestStore(t)
+		_, err := target.projects.CreateProject("Doomed", "")
+		require.NoError(t, err)
+
+		result, err := Import(target.db, archive, ModeReplace)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.ProjectsImported)
+		assert.Equal(t, 1, countRows(t, target, "projects"))
+		assert.Equal(t, 2, countRows(t, target, "tasks"))
+	})
+}
+
+func TestImport_DanglingBlockerIsDropped(t *testing.T) {
+	source := setupTestStore(t)
+	seedBoard(t, source)
+	archive, err := Export(source.db)
+	require.NoError(t, err)
+	archive.Tasks = archive.Tasks[1:]
+
+	target := setupTestStore(t)
+	result, err := Import(target.db, archive, ModeStrict)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.BlockersDropped)
+
+	task, err := target.tasks.GetTask(archive.Tasks[0].ID)
+	require.NoError(t, err)
+	assert.Empty(t, task.BlockedBy)
+}
+
+func TestImport_RejectsInvalidArchives(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(a *formats.Archive)
+	}{
+		{"unknown migration", func(a *formats.Archive) { a.Migrations = append(a.Migrations, "999_from_the_future") }},
+		{"invalid task", func(a *formats.Archive) { a.Tasks[0].Name = "" }},
+		{"duplicate task", func(a *formats.Archive) { a.Tasks = append(a.Tasks, a.Tasks[0]) }},
+		{"unknown project", func(a *formats.Archive) { a.Tasks[0].ProjectID = "proj_missing" }},
+		{"invalid label", func(a *formats.Archive) { a.Tasks[0].Labels = []string{"two words"} }},
+		{"invalid due date", func(a *formats.Archive) { due := "01/11/2026"; a.Tasks[0].DueDate = &due }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := setupTestStore(t)
+			seedBoard(t, source)
+			archive, err := Export(source.db)
+			require.NoError(t, err)
+			tt.mutate(archive)
+
+			target := setupTestStore(t)
+			_, err = Import(target.db, archive, ModeStrict)
+			var validationErr *domain.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, 0, countRows(t, target, "projects"), "Nothing is written for an invalid archive")
+		})
+	}
+}
+
+func TestImport_PreservesWorkflow(t *testing.T) {
+	source := setupTestStore(t)
+	project := seedBoard(t, source)
+	workflow, err := domain.NewWorkflow([]string{"Backlog", "Doing", "Review", "Shipped"}, "")
+	require.NoError(t, err)
+	_, err = source.projects.SetWorkflow(project.ID, workflow)
+	require.NoError(t, err)
+
+	archive, err := Export(source.db)
+	require.NoError(t, err)
+
+	target := setupTestStore(t)
+	_, err = Import(target.db, archive, ModeStrict)
+	require.NoError(t, err)
+
+	imported, err := target.projects.GetProject(project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, workflow, imported.Workflow)
+}
+
+func TestImport_Labels(t *testing.T) {
+	source := setupTestStore(t)
+	project := seedBoard(t, source)
+	_, err := source.labels.CreateLabel(project.ID, "backend", "#f38ba8")
+	require.NoError(t, err)
+	tasks, err := source.tasks.GetTasksByProject(project.ID)
+	require.NoError(t, err)
+	_, err = source.labels.SetTaskLabels(tasks[0].ID, []string{"backend", "urgent"})
+	require.NoError(t, err)
+
+	archive, err := Export(source.db)
+	require.NoError(t, err)
+	require.Len(t, archive.Labels, 2)
+
+	// A label named only by a task is created with a palette color
+	archive.Tasks[1].Labels = []string{"frontend"}
+
+	target := setupTestStore(t)
+	result, err := Import(target.db, archive, ModeStrict)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.LabelsImported)
+
+	labels, err := target.labels.GetLabels(project.ID)
+	require.NoError(t, err)
+	require.Len(t, labels, 3)
+	assert.Equal(t, "backend", labels[0].Name)
+	assert.Equal(t, "#f38ba8", labels[0].Color, "Colors survive the round trip")
+
+	for _, record := range archive.Tasks {
+		task, err := target.tasks.GetTask(record.ID)
+		require.NoError(t, err)
+		assert.Equal(t, record.Labels, task.LabelNames())
+	}
+
+	// Merging again does not duplicate labels
+	result, err = Import(target.db, archive, ModeMerge)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.LabelsImported)
+	assert.Equal(t, 3, countRows(t, target, "labels"))
+}
+
+func TestImport_Views(t *testing.T) {
+	source := setupTestStore(t)
+	project := seedBoard(t, source)
+	_, err := source.views.SaveView(project.ID, "Bugs", "type:bug")
+	require.NoError(t, err)
+	_, err = source.views.SaveView("", "Blocked", "blocked:yes")
+	require.NoError(t, err)
+
+	archive, err := Export(source.db)
+	require.NoError(t, err)
+	require.Len(t, archive.Views, 2)
+
+	target := setupTestStore(t)
+	_, err = target.views.SaveView("", "blocked", "blocked:yes priority:high")
+	require.NoError(t, err)
+	result, err := Import(target.db, archive, ModeStrict)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.ViewsImported)
+	assert.Equal(t, 1, result.ViewsSkipped, "A global view of the same name is kept")
+
+	views, err := target.views.GetViews(project.ID)
+	require.NoError(t, err)
+	require.Len(t, views, 2)
+	assert.Equal(t, "Bugs", views[0].Name)
+	assert.Equal(t, project.ID, views[0].ProjectID)
+	assert.Equal(t, "blocked:yes priority:high", views[1].Query)
+
+	archive.Views = append(archive.Views, formats.SavedViewRecord{ProjectID: "missing", Name: "Lost", Query: "type:bug"})
+	_, err = Import(target.db, archive, ModeMerge)
+	assert.ErrorContains(t, err, "view \"Lost\" belongs to a project missing from the archive")
+}
+
+func TestImport_Dates(t *testing.T) {
+	source := setupTestStore(t)
+	project := seedBoard(t, source)
+	tasks, err := source.tasks.GetTasksByProject(project.ID)
+	require.NoError(t, err)
+	start := time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local)
+	due := time.Date(2026, 11, 20, 0, 0, 0, 0, time.Local)
+	_, err = source.tasks.SetTaskDates(tasks[0].ID, &start, &due)
+	require.NoError(t, err)
+
+	archive, err := Export(source.db)
+	require.NoError(t, err)
+
+	target := setupTestStore(t)
+	_, err = Import(target.db, archive, ModeStrict)
+	require.NoError(t, err)
+
+	for _, record := range archive.Tasks {
+		task, err := target.tasks.GetTask(record.ID)
+		require.NoError(t, err)
+		if record.ID == tasks[0].ID {
+			assert.Equal(t, "2026-11-01", domain.FormatDate(task.StartDate))
+			assert.Equal(t, "2026-11-20", domain.FormatDate(task.DueDate))
+		} else {
+			assert.Nil(t, task.DueDate, "Tasks without dates stay undated")
+		}
+	}
+}
+
+func TestImport_Checklist(t *testing.T) {
+	source := setupTestStore(t)
+	project := seedBoard(t, source)
+	tasks, err := source.tasks.GetTasksByProject(project.ID)
+	require.NoError(t, err)
+	for _, text := range []string{"Reproduce", "Fix", "Add regression test"} {
+		_, err := source.tasks.AddChecklistItem(tasks[0].ID, text)
+		require.NoError(t, err)
+	}
+	items, err := source.tasks.GetChecklist(tasks[0].ID)
+	require.NoError(t, err)
+	_, err = source.tasks.SetChecklistItemDone(items[0].ID, true)
+	require.NoError(t, err)
+
+	archive, err := Export(source.db)
+	require.NoError(t, err)
+	require.Len(t, archive.Checklist, 3)
+
+	target := setupTestStore(t)
+	result, err := Import(target.db, archive, ModeStrict)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.ChecklistItems)
+
+	imported, err := target.tasks.GetChecklist(tasks[0].ID)
+	require.NoError(t, err)
+	require.Len(t, imported, 3)
+	assert.Equal(t, "Reproduce", imported[0].Text)
+	assert.True(t, imported[0].Done)
+	assert.Equal(t, 3, imported[2].Position)
+
+	// Merging again keeps the existing checklists as they are
+	result, err = Import(target.db, archive, ModeMerge)
+	require.NoError(t, err)
+	assert.Zero(t, result.ChecklistItems)
+	assert.Equal(t, 3, countRows(t, target, "checklist_items"))
+
+	// Items must belong to a task in the archive
+	archive.Checklist[0].TaskID = "task_missing"
+	_, err = Import(setupTestStore(t).db, archive, ModeStrict)
+	assert.Error(t, err)
+}
+
+func TestImport_Comments(t *testing.T) {
+	source := setupTestStore(t)
+	project := seedBoard(t, source)
+	tasks, err := source.tasks.GetTasksByProject(project.ID)
+	require.NoError(t, err)
+	for _, body := range []string{"Seen on staging", "Root cause is the retry loop\nsee the logs"} {
+		_, err := source.tasks.AddComment(tasks[0].ID, "alice", body)
+		require.NoError(t, err)
+	}
+
+	archive, err := Export(source.db)
+	require.NoError(t, err)
+
+	target := setupTestStore(t)
+	result, err := Import(target.db, archive, ModeStrict)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Comments)
+
+	imported, err := target.tasks.GetComments(tasks[0].ID)
+	require.NoError(t, err)
+	require.Len(t, imported, 2)
+	assert.Equal(t, "alice", imported[0].Author)
+	assert.Equal(t, "Root cause is the retry loop\nsee the logs", imported[1].Body)
+
+	// Merging again keeps the existing comments as they are
+	result, err = Import(target.db, archive, ModeMerge)
+	require.NoError(t, err)
+	assert.Zero(t, result.Comments)
+	assert.Equal(t, 2, countRows(t, target, "task_comments"))
+
+	// Comments are validated before anything is written
+	for i := range archive.Tasks {
+		if len(archive.Tasks[i].Comments) > 0 {
+			archive.Tasks[i].Comments[0].Body = " "
+		}
+	}
+	_, err = Import(setupTestStore(t).db, archive, ModeStrict)
+	assert.Error(t, err)
+}
+
+func TestExport_ArchivedAndTrashedTasks(t *testing.T) {
+	source := setupTestStore(t)
+	project := seedBoard(t, source)
+	done, err := source.tasks.CreateTask("Shipped", "", project.ID, domain.RegularTask, domain.Low, nil)
+	require.NoError(t, err)
+	_, err = source.tasks.UpdateTaskStatus(done.ID, domain.Done)
+	require.NoError(t, err)
+	_, err = source.tasks.ArchiveTask(done.ID)
+	require.NoError(t, err)
+	trashed, err := source.tasks.CreateTask("Dropped", "", project.ID, domain.RegularTask, domain.Low, nil)
+	require.NoError(t, err)
+	require.NoError(t, source.tasks.DeleteTask(trashed.ID))
+
+	archive, err := Export(source.db)
+	require.NoError(t, err)
+	require.Len(t, archive.Tasks, 3, "Archived tasks are exported and trashed ones are not")
+	assert.NotNil(t, archive.Tasks[2].ArchivedAt)
+
+	target := setupTestStore(t)
+	_, err = Import(target.db, archive, ModeStrict)
+	require.NoError(t, err)
+	onBoard, err := target.tasks.GetTasksByProject(project.ID)
+	require.NoError(t, err)
+	assert.Len(t, onBoard, 2)
+	archived, err := target.tasks.GetArchivedTasks(project.ID)
+	require.NoError(t, err)
+	require.Len(t, archived, 1)
+	assert.Equal(t, "Shipped", archived[0].Name)
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"kahn/internal/archive"
+	"kahn/internal/formats"
+
+	"github.com/spf13/pflag"
+)
+
+func archiveCommands() []*command {
+	return []*command{
+		{
+			name:    "export",
+			args:    "[file]",
+			summary: "Write every project and task to a JSON archive",
+			run:     runExport,
+		},
+		{
+			name:    "import",
+			args:    "<file>",
+			summary: "Restore projects and tasks from a JSON archive",
+			flags: func(fs *pflag.FlagSet) {
+				fs.Bool("merge", false, "Skip projects and tasks whose IDs already exist")
+				fs.Bool("replace", false, "Delete all existing projects and tasks before importing")
+			},
+			run: runImport,
+		},
+	}
+}
+
+func runExport(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 0, 1)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := archive.Export(env.Database)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 || args[0] == "-" {
+		return formats.WriteArchive(env.Out, snapshot)
+	}
+
+	file, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+	if err := formats.WriteArchive(file, snapshot); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Exported %d project(s) and %d task(s) to %s\n", len(snapshot.Projects), len(snapshot.Tasks), args[0])
+	return nil
+}
+
+func runImport(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	merge, _ := fs.GetBool("merge")
+	replace, _ := fs.GetBool("replace")
+	mode := archive.ModeStrict
+	switch {
+	case merge && replace:
+		return newUsageError("--merge and --replace cannot be combined")
+	case merge:
+		mode = archive.ModeMerge
+	case replace:
+		mode = archive.ModeReplace
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	snapshot, err := formats.ReadArchive(file)
+	if err != nil {
+		return err
+	}
+
+	result, err := archive.Import(env.Database, snapshot, mode)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Imported %d project(s) and %d task(s)\n", result.ProjectsImported, result.TasksImported)
+	if result.ProjectsSkipped > 0 || result.TasksSkipped > 0 {
+		fmt.Fprintf(env.Out, "Skipped %d existing project(s) and %d existing task(s)\n", result.ProjectsSkipped, result.TasksSkipped)
+	}
+	if result.BlockersDropped > 0 {
+		fmt.Fprintf(env.Out, "Dropped %d blocker link(s) to tasks missing from the archive\n", result.BlockersDropped)
+	}
+	return nil
+}
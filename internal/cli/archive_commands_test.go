@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImport(t *testing.T) {
+	source := setupTestEnv(t)
+	mustRunCLI(t, source, "project", "add", "Alpha")
+	mustRunCLI(t, source, "task", "add", "Blocker")
+	mustRunCLI(t, source, "task", "add", "Blocked", "--blocked-by", "1")
+
+	path := filepath.Join(t.TempDir(), "board.json")
+	out := mustRunCLI(t, source, "export", path)
+	assert.Contains(t, out, "Exported 1 project(s) and 2 task(s)")
+
+	target := setupTestEnv(t)
+	out = mustRunCLI(t, target, "import", path)
+	assert.Contains(t, out, "Imported 1 project(s) and 2 task(s)")
+
+	blocked, err := target.TaskService.GetTaskByIntID(2)
+	require.NoError(t, err)
+	assert.Equal(t, "Blocked", blocked.Name)
+	assert.Equal(t, []int{1}, blocked.BlockedBy)
+
+	t.Run("re-importing without a mode is a validation error", func(t *testing.T) {
+		code, _, stderr := runCLI(t, target, "import", path)
+		assert.Equal(t, ExitValidation, code)
+		assert.Contains(t, stderr, "already exists")
+	})
+
+	t.Run("merge skips existing records", func(t *testing.T) {
+		out := mustRunCLI(t, target, "import", path, "--merge")
+		assert.Contains(t, out, "Skipped 1 existing project(s) and 2 existing task(s)")
+	})
+
+	t.Run("merge and replace are mutually exclusive", func(t *testing.T) {
+		code, _, _ := runCLI(t, target, "import", path, "--merge", "--replace")
+		assert.Equal(t, ExitUsage, code)
+	})
+}
+
+func TestExport_Stdout(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+
+	out := mustRunCLI(t, env, "export")
+	assert.Contains(t, out, `"format": "kahn-archive"`)
+}
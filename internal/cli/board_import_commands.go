@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"kahn/internal/domain"
+	"kahn/internal/formats"
+
+	"github.com/spf13/pflag"
+)
+
+func boardImportCommands() []*command {
+	return []*command{
+		{
+			name:    "trello import",
+			args:    "<file>",
+			summary: "Create a project from a Trello board JSON export",
+			flags:   addBoardImportFlags,
+			run: func(env *Env, fs *pflag.FlagSet) error {
+				return runBoardImport(env, fs, formats.ReadTrelloBoard)
+			},
+		},
+		{
+			name:    "github import",
+			args:    "<file>",
+			summary: "Create a project from a GitHub issue list or Projects item list JSON export",
+			flags:   addBoardImportFlags,
+			run: func(env *Env, fs *pflag.FlagSet) error {
+				return runBoardImport(env, fs, formats.ReadGitHubBoard)
+			},
+		},
+	}
+}
+
+func addBoardImportFlags(fs *pflag.FlagSet) {
+	fs.String("name", "", "Project name (defaults to the board name, or the file name for GitHub)")
+	fs.StringArray("map", nil, `Map a column onto a status, e.g. --map "QA=inprogress" (repeatable)`)
+	fs.Bool("dry-run", false, "Report how the board would be mapped without creating anything")
+}
+
+func runBoardImport(env *Env, fs *pflag.FlagSet, read func(io.Reader) (formats.ExternalBoard, error)) error {
+	args, err := requireArgs(fs, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	entries, _ := fs.GetStringArray("map")
+	mapping, err := formats.ParseColumnMapping(entries)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	board, err := read(file)
+	if err != nil {
+		return err
+	}
+
+	if name, _ := fs.GetString("name"); name != "" {
+		board.Name = name
+	} else if board.Name == "" {
+		board.Name = strings.TrimSuffix(filepath.Base(args[0]), filepath.Ext(args[0]))
+	}
+
+	plan := formats.PlanBoardImport(board, mapping)
+	if err := checkProjectNameFree(env, plan.Project.Name); err != nil {
+		return err
+	}
+
+	for _, warning := range plan.Warnings {
+		fmt.Fprintf(env.Out, "warning: %s\n", warning)
+	}
+
+	if dryRun, _ := fs.GetBool("dry-run"); dryRun {
+		fmt.Fprintf(env.Out, "Dry run: would create project %s with %d task(s) from %s\n", plan.Project.Name, len(plan.Tasks), board.Source)
+		return nil
+	}
+
+	project, err := env.ProjectService.CreateProject(plan.Project.Name, plan.Project.Description)
+	if err != nil {
+		return err
+	}
+
+	if err := createBoardTasks(env, project.ID, plan.Tasks); err != nil {
+		// Remove the partly imported project so the import can simply be run again
+		if discardErr := discardProject(env, project.ID); discardErr != nil {
+			return fmt.Errorf("%w; the partly imported project %s could not be removed: %v", err, project.Name, discardErr)
+		}
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Created project %s with %d task(s) from %s\n", project.Name, len(plan.Tasks), board.Source)
+	return nil
+}
+
+// createBoardTasks creates the planned tasks in the project and moves each one
+// to its column
+func createBoardTasks(env *Env, projectID string, tasks []domain.Task) error {
+	for _, task := range tasks {
+		created, err := env.TaskService.CreateTask(task.Name, task.Desc, projectID, task.Type, task.Priority, nil)
+		if err != nil {
+			return err
+		}
+		if task.Status == domain.NotStarted {
+			continue
+		}
+		if _, err := env.TaskService.UpdateTaskStatus(created.ID, task.Status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// discardProject deletes the project for good with its tasks
+func discardProject(env *Env, projectID string) error {
+	if err := env.ProjectService.DeleteProject(projectID); err != nil {
+		return err
+	}
+	return env.ProjectService.PurgeProject(projectID)
+}
+
+// checkProjectNameFree keeps imports from creating a second project with the same
+// name, which would make --project lookups by name ambiguous
+func checkProjectNameFree(env *Env, name string) error {
+	projects, err := env.ProjectService.GetAllProjects()
+	if err != nil {
+		return err
+	}
+	for _, project := range projects {
+		if strings.EqualFold(project.Name, name) {
+			return domain.NewValidationError("name", fmt.Sprintf("project %q already exists; choose another name with --name", name))
+		}
+	}
+	return nil
+}
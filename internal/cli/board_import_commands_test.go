@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"testing"
+
+	"kahn/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrelloImport(t *testing.T) {
+	env := setupTestEnv(t)
+	path := writeTestFile(t, "board.json", `{
+  "name": "Launch",
+  "lists": [{"id": "l1", "name": "To Do", "pos": 1}, {"id": "l2", "name": "QA", "pos": 2}],
+  "cards": [
+    {"idShort": 1, "name": "Fix crash", "idList": "l1", "labels": [{"name": "bug"}, {"name": "p1"}]},
+    {"idShort": 2, "name": "Smoke test", "idList": "l2"}
+  ]
+}`)
+
+	out := mustRunCLI(t, env, "trello", "import", path, "--dry-run")
+	assert.Contains(t, out, `warning: column "QA" is not mapped`)
+	assert.Contains(t, out, `warning: label "p1"`)
+	assert.Contains(t, out, "Dry run: would create project Launch with 2 task(s) from Trello")
+
+	out = mustRunCLI(t, env, "trello", "import", path, "--map", "QA=in progress")
+	assert.NotContains(t, out, `column "QA"`)
+	assert.Contains(t, out, "Created project Launch with 2 task(s) from Trello")
+
+	project := mustResolveProject(t, env)
+	assert.Equal(t, "Launch", project.Name)
+
+	crash, err := env.TaskService.GetTaskByIntID(1)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Bug, crash.Type)
+	smoke, err := env.TaskService.GetTaskByIntID(2)
+	require.NoError(t, err)
+	assert.Equal(t, domain.InProgress, smoke.Status)
+
+	code, _, stderr := runCLI(t, env, "trello", "import", path)
+	assert.Equal(t, ExitValidation, code)
+	assert.Contains(t, stderr, `project "Launch" already exists`)
+}
+
+func TestTrelloImport_FailureRemovesProject(t *testing.T) {
+	env := setupTestEnv(t)
+	_, err := env.Database.GetDB().Exec(`
+		CREATE TRIGGER refuse_boom BEFORE INSERT ON tasks WHEN NEW.name = 'Boom'
+		BEGIN SELECT RAISE(ABORT, 'refused'); END
+	`)
+	require.NoError(t, err)
+	path := writeTestFile(t, "board.json", `{
+  "name": "Launch",
+  "lists": [{"id": "l1", "name": "To Do", "pos": 1}],
+  "cards": [
+    {"idShort": 1, "name": "Fine", "idList": "l1"},
+    {"idShort": 2, "name": "Boom", "idList": "l1"}
+  ]
+}`)
+
+	code, _, _ := runCLI(t, env, "trello", "import", path)
+	assert.Equal(t, ExitRepository, code)
+
+	projects, err := env.ProjectService.GetAllProjects()
+	require.NoError(t, err)
+	assert.Empty(t, projects, "A failed import leaves no project behind")
+	trashed, err := env.ProjectService.GetTrashedProjects()
+	require.NoError(t, err)
+	assert.Empty(t, trashed)
+}
+
+func TestGitHubImport(t *testing.T) {
+	env := setupTestEnv(t)
+	path := writeTestFile(t, "issues.json", `[
+  {"number": 1, "title": "Crash", "state": "OPEN", "labels": [{"name": "bug"}]},
+  {"number": 2, "title": "Dark mode", "state": "CLOSED", "labels": [{"name": "enhancement"}]}
+]`)
+
+	out := mustRunCLI(t, env, "github", "import", path)
+	assert.Contains(t, out, "Created project issues with 2 task(s) from GitHub")
+
+	mustRunCLI(t, env, "github", "import", path, "--name", "Backend")
+	backend, err := resolveProject(env, "Backend")
+	require.NoError(t, err)
+
+	tasks, err := env.TaskService.GetTasksByProject(backend.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	for _, task := range tasks {
+		if task.Name == "Dark mode" {
+			assert.Equal(t, domain.Done, task.Status)
+			assert.Equal(t, domain.Feature, task.Type)
+		}
+	}
+
+	code, _, _ := runCLI(t, env, "github", "import", path, "--map", "QA")
+	assert.Equal(t, ExitValidation, code)
+}
@@ -0,0 +1,289 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"kahn/internal/domain"
+	"kahn/internal/formats"
+
+	"github.com/spf13/pflag"
+)
+
+// Checklist items are referred to by their 1-based position in the checklist, as
+// printed by "task checklist"
+func checklistCommands() []*command {
+	return []*command{
+		{
+			name:    "task checklist",
+			args:    "<task>",
+			summary: "List the checklist of a task",
+			flags:   addOutputFlag,
+			run:     runChecklistList,
+		},
+		{
+			name:    "task checklist add",
+			args:    "<task> <text>...",
+			summary: "Add an item to the end of a task's checklist",
+			run:     runChecklistAdd,
+		},
+		{
+			name:    "task checklist done",
+			args:    "<task> <item>...",
+			summary: "Tick checklist items off",
+			run:     runChecklistDone,
+		},
+		{
+			name:    "task checklist undone",
+			args:    "<task> <item>...",
+			summary: "Untick checklist items",
+			run:     runChecklistUndone,
+		},
+		{
+			name:    "task checklist edit",
+			args:    "<task> <item> <text>...",
+			summary: "Change the text of a checklist item",
+			run:     runChecklistEdit,
+		},
+		{
+			name:    "task checklist move",
+			args:    "<task> <item> <position>",
+			summary: "Move a checklist item to another position",
+			run:     runChecklistMove,
+		},
+		{
+			name:    "task checklist rm",
+			args:    "<task> <item>",
+			summary: "Delete a checklist item",
+			run:     runChecklistRemove,
+		},
+		{
+			name:    "task checklist promote",
+			args:    "<task> <item>",
+			summary: "Turn a checklist item into a task the parent task waits on",
+			run:     runChecklistPromote,
+		},
+	}
+}
+
+func runChecklistList(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 1, 1)
+	if err != nil {
+		return err
+	}
+	format, err := outputFormat(fs)
+	if err != nil {
+		return err
+	}
+
+	task, items, err := resolveChecklist(env, args[0])
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case outputJSON:
+		return writeJSON(env.Out, formats.NewChecklistDocument(*task, items))
+	case outputNDJSON:
+		return writeNDJSON(env.Out, formats.NewChecklistDocument(*task, items).Items)
+	}
+
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		rows[i] = []string{strconv.Itoa(item.Position), checkbox(item.Done), item.Text}
+	}
+	return writeRows(env.Out, format, []string{"#", "DONE", "TEXT"}, rows)
+}
+
+func runChecklistAdd(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 2, -1)
+	if err != nil {
+		return err
+	}
+
+	task, err := resolveTask(env, args[0])
+	if err != nil {
+		return err
+	}
+
+	item, err := env.TaskService.AddChecklistItem(task.ID, strings.Join(args[1:], " "))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Added item %d to task #%d: %s\n", item.Position, task.IntID, item.Text)
+	return nil
+}
+
+func runChecklistDone(env *Env, fs *pflag.FlagSet) error {
+	return setChecklistDone(env, fs, true)
+}
+
+func runChecklistUndone(env *Env, fs *pflag.FlagSet) error {
+	return setChecklistDone(env, fs, false)
+}
+
+// setChecklistDone ticks or unticks the items named by every argument after the task
+func setChecklistDone(env *Env, fs *pflag.FlagSet, done bool) error {
+	args, err := requireArgs(fs, 2, -1)
+	if err != nil {
+		return err
+	}
+
+	task, items, err := resolveChecklist(env, args[0])
+	if err != nil {
+		return err
+	}
+
+	var picked []domain.ChecklistItem
+	for _, ref := range args[1:] {
+		item, err := checklistItemAt(items, ref)
+		if err != nil {
+			return err
+		}
+		picked = append(picked, *item)
+	}
+	for _, item := range picked {
+		if _, err := env.TaskService.SetChecklistItemDone(item.ID, done); err != nil {
+			return err
+		}
+	}
+
+	updated, err := env.TaskService.GetTask(task.ID)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(env.Out, "Task #%d checklist: %s done\n", updated.IntID, updated.ChecklistProgress())
+	return nil
+}
+
+func runChecklistEdit(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 3, -1)
+	if err != nil {
+		return err
+	}
+
+	task, items, err := resolveChecklist(env, args[0])
+	if err != nil {
+		return err
+	}
+	item, err := checklistItemAt(items, args[1])
+	if err != nil {
+		return err
+	}
+
+	renamed, err := env.TaskService.RenameChecklistItem(item.ID, strings.Join(args[2:], " "))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Updated item %d of task #%d: %s\n", renamed.Position, task.IntID, renamed.Text)
+	return nil
+}
+
+func runChecklistMove(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 3, 3)
+	if err != nil {
+		return err
+	}
+
+	task, items, err := resolveChecklist(env, args[0])
+	if err != nil {
+		return err
+	}
+	item, err := checklistItemAt(items, args[1])
+	if err != nil {
+		return err
+	}
+	position, err := strconv.Atoi(args[2])
+	if err != nil {
+		return domain.NewValidationError("position", fmt.Sprintf("invalid position %q", args[2]))
+	}
+
+	if _, err := env.TaskService.MoveChecklistItem(item.ID, position); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Moved item %q of task #%d to position %d\n", item.Text, task.IntID, position)
+	return nil
+}
+
+func runChecklistRemove(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 2, 2)
+	if err != nil {
+		return err
+	}
+
+	task, items, err := resolveChecklist(env, args[0])
+	if err != nil {
+		return err
+	}
+	item, err := checklistItemAt(items, args[1])
+	if err != nil {
+		return err
+	}
+
+	if err := env.TaskService.DeleteChecklistItem(item.ID); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Deleted item %q from task #%d\n", item.Text, task.IntID)
+	return nil
+}
+
+func runChecklistPromote(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 2, 2)
+	if err != nil {
+		return err
+	}
+
+	task, items, err := resolveChecklist(env, args[0])
+	if err != nil {
+		return err
+	}
+	item, err := checklistItemAt(items, args[1])
+	if err != nil {
+		return err
+	}
+
+	promoted, err := env.TaskService.PromoteChecklistItem(item.ID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Created task #%d %s; task #%d now waits on it\n", promoted.IntID, promoted.Name, task.IntID)
+	return nil
+}
+
+// resolveChecklist looks up the task and loads its checklist in order
+func resolveChecklist(env *Env, ref string) (*domain.Task, []domain.ChecklistItem, error) {
+	task, err := resolveTask(env, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, err := env.TaskService.GetChecklist(task.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return task, items, nil
+}
+
+// checklistItemAt picks the item at the 1-based position ref
+func checklistItemAt(items []domain.ChecklistItem, ref string) (*domain.ChecklistItem, error) {
+	position, err := strconv.Atoi(ref)
+	if err != nil || position < 1 || position > len(items) {
+		if len(items) == 0 {
+			return nil, domain.NewValidationError("item", "the task has no checklist items")
+		}
+		return nil, domain.NewValidationError("item", fmt.Sprintf("invalid checklist item %q; use a number from 1 to %d", ref, len(items)))
+	}
+	return &items[position-1], nil
+}
+
+func checkbox(done bool) string {
+	if done {
+		return "[x]"
+	}
+	return "[ ]"
+}
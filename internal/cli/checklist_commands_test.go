@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"kahn/internal/domain"
+	"kahn/internal/formats"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskChecklist(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Story", "--priority", "high")
+
+	out := mustRunCLI(t, env, "task", "checklist", "add", "1", "Write", "migration")
+	assert.Contains(t, out, "Added item 1 to task #1: Write migration")
+	mustRunCLI(t, env, "task", "checklist", "add", "1", "Add endpoint")
+	mustRunCLI(t, env, "task", "checklist", "add", "#1", "Update docs")
+
+	out = mustRunCLI(t, env, "task", "checklist", "done", "1", "1", "3")
+	assert.Contains(t, out, "Task #1 checklist: 2/3 done")
+	mustRunCLI(t, env, "task", "checklist", "undone", "1", "3")
+
+	out = mustRunCLI(t, env, "task", "show", "1")
+	assert.Contains(t, out, "1/3 done")
+
+	mustRunCLI(t, env, "task", "checklist", "edit", "1", "2", "Add", "API", "endpoint")
+	mustRunCLI(t, env, "task", "checklist", "move", "1", "3", "1")
+
+	out = mustRunCLI(t, env, "task", "checklist", "1", "-o", "plain")
+	assert.Equal(t, "1\t[ ]\tUpdate docs\n2\t[x]\tWrite migration\n3\t[ ]\tAdd API endpoint\n", out)
+
+	code, _, stderr := runCLI(t, env, "task", "checklist", "done", "1", "4")
+	assert.Equal(t, ExitValidation, code)
+	assert.Contains(t, stderr, "use a number from 1 to 3")
+
+	code, _, _ = runCLI(t, env, "task", "checklist", "add", "1")
+	assert.Equal(t, ExitUsage, code, "The item text is required")
+
+	// Promoting makes the story wait on a new task
+	out = mustRunCLI(t, env, "task", "checklist", "promote", "1", "3")
+	assert.Contains(t, out, "Created task #2 Add API endpoint; task #1 now waits on it")
+	promoted, err := env.TaskService.GetTaskByIntID(2)
+	require.NoError(t, err)
+	assert.Equal(t, domain.High, promoted.Priority)
+	story, err := env.TaskService.GetTaskByIntID(1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, story.BlockedBy)
+
+	mustRunCLI(t, env, "task", "checklist", "rm", "1", "1")
+
+	var document formats.ChecklistDocument
+	require.NoError(t, json.Unmarshal([]byte(mustRunCLI(t, env, "task", "checklist", "1", "-o", "json")), &document))
+	require.Len(t, document.Items, 1)
+	assert.Equal(t, "Write migration", document.Items[0].Text)
+	assert.True(t, document.Items[0].Done)
+	assert.Equal(t, 1, document.Items[0].Position)
+
+	out = mustRunCLI(t, env, "task", "show", "1", "-o", "json")
+	assert.True(t, strings.Contains(out, `"checklist_done": 1`) && strings.Contains(out, `"checklist_total": 1`), out)
+}
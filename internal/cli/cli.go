@@ -0,0 +1,291 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"kahn/internal/config"
+	"kahn/internal/database"
+	"kahn/internal/domain"
+	repo "kahn/internal/repository"
+	"kahn/internal/services"
+
+	"github.com/spf13/pflag"
+)
+
+// Exit codes returned by Run so scripts can distinguish failure kinds
+const (
+	ExitOK         = 0
+	ExitFailure    = 1
+	ExitUsage      = 2
+	ExitValidation = 3
+	ExitRepository = 4
+)
+
+// Env carries the services and output stream a command runs against
+type Env struct {
+	Database        *database.Database
+	TaskService     *services.TaskService
+	ProjectService  *services.ProjectService
+	LabelService    *services.LabelService
+	ViewService     *services.SavedViewService
+	EventLog        *services.EventLog
+	Author          string // signs comments and events; user.name from the config or $USER
+	AutoArchiveDays int    // board.auto_archive_days from the config, for task archive --auto
+	Out             io.Writer
+}
+
+// NewEnv wires repositories and services for db the same way the TUI does
+func NewEnv(db *database.Database, out io.Writer) *Env {
+	taskRepo := repo.NewSQLiteTaskRepository(db.GetDB())
+	projectRepo := repo.NewSQLiteProjectRepository(db.GetDB())
+	labelRepo := repo.NewSQLiteLabelRepository(db.GetDB())
+	eventLog := services.NewEventLog(repo.NewSQLiteEventRepository(db.GetDB()), config.DefaultAuthor)
+
+	taskService := services.NewTaskService(taskRepo, projectRepo)
+	taskService.SetEventLog(eventLog)
+	projectService := services.NewProjectService(projectRepo, taskRepo)
+	projectService.SetEventLog(eventLog)
+	labelService := services.NewLabelService(labelRepo, projectRepo, taskRepo)
+	labelService.SetEventLog(eventLog)
+
+	return &Env{
+		Database:       db,
+		TaskService:    taskService,
+		ProjectService: projectService,
+		LabelService:   labelService,
+		ViewService:    services.NewSavedViewService(repo.NewSQLiteSavedViewRepository(db.GetDB()), projectRepo),
+		EventLog:       eventLog,
+		Author:         config.DefaultAuthor,
+		Out:            out,
+	}
+}
+
+type command struct {
+	name    string // space separated path, e.g. "task add"
+	args    string // positional argument synopsis shown in usage
+	summary string
+	flags   func(fs *pflag.FlagSet)
+	run     func(env *Env, fs *pflag.FlagSet) error
+}
+
+func (c *command) synopsis() string {
+	return strings.TrimSpace("kahn " + c.name + " " + c.args)
+}
+
+func allCommands() []*command {
+	var commands []*command
+	commands = append(commands, taskCommands()...)
+	commands = append(commands, checklistCommands()...)
+	commands = append(commands, commentCommands()...)
+	commands = append(commands, projectCommands()...)
+	commands = append(commands, trashCommands()...)
+	commands = append(commands, labelCommands()...)
+	commands = append(commands, viewCommands()...)
+	commands = append(commands, logCommands()...)
+	commands = append(commands, archiveCommands()...)
+	commands = append(commands, csvCommands()...)
+	commands = append(commands, markdownCommands()...)
+	commands = append(commands, graphCommands()...)
+	commands = append(commands, todoTxtCommands()...)
+	commands = append(commands, boardImportCommands()...)
+	return commands
+}
+
+// envOpener builds the Env for a parsed command and returns a cleanup function
+type envOpener func(fs *pflag.FlagSet, out io.Writer) (*Env, func(), error)
+
+// IsCommand reports whether args name a CLI subcommand rather than TUI flags
+func IsCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	if args[0] == "help" {
+		return true
+	}
+	for _, cmd := range allCommands() {
+		if strings.Fields(cmd.name)[0] == args[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// Run executes the subcommand named by args and returns the process exit code
+func Run(args []string, stdout, stderr io.Writer) int {
+	return run(args, stdout, stderr, openDatabaseEnv)
+}
+
+func run(args []string, stdout, stderr io.Writer, open envOpener) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "--help" || args[0] == "-h" {
+		printUsage(stdout)
+		return ExitOK
+	}
+
+	cmd, rest := findCommand(args)
+	if cmd == nil {
+		fmt.Fprintf(stderr, "kahn: unknown command %q\n\n", strings.Join(args, " "))
+		printUsage(stderr)
+		return ExitUsage
+	}
+
+	fs := newFlagSet(cmd, stderr)
+	if err := fs.Parse(rest); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return ExitOK
+		}
+		fmt.Fprintf(stderr, "kahn %s: %v\n", cmd.name, err)
+		fs.Usage()
+		return ExitUsage
+	}
+
+	env, cleanup, err := open(fs, stdout)
+	if err != nil {
+		fmt.Fprintf(stderr, "kahn: %v\n", err)
+		return ExitFailure
+	}
+	defer cleanup()
+
+	if err := cmd.run(env, fs); err != nil {
+		fmt.Fprintf(stderr, "kahn %s: %v\n", cmd.name, err)
+		var usageErr *usageError
+		if errors.As(err, &usageErr) {
+			fmt.Fprintf(stderr, "usage: %s\n", cmd.synopsis())
+		}
+		return exitCode(err)
+	}
+
+	return ExitOK
+}
+
+func openDatabaseEnv(fs *pflag.FlagSet, out io.Writer) (*Env, func(), error) {
+	cfg, err := config.LoadConfigFromFlags(fs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	wipEnforcement, err := domain.ParseWIPEnforcement(cfg.Board.WIPEnforcement)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	db, err := database.NewDatabase(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	env := NewEnv(db, out)
+	env.TaskService.SetWIPEnforcement(wipEnforcement)
+	env.Author = cfg.User.Name
+	env.EventLog.SetActor(cfg.User.Name)
+	env.AutoArchiveDays = cfg.Board.AutoArchiveDays
+	return env, func() { db.Close() }, nil
+}
+
+// findCommand matches the longest command name prefix of args
+func findCommand(args []string) (*command, []string) {
+	var best *command
+	bestLen := 0
+	for _, cmd := range allCommands() {
+		parts := strings.Fields(cmd.name)
+		if len(parts) > len(args) || len(parts) <= bestLen {
+			continue
+		}
+		if strings.Join(args[:len(parts)], " ") == cmd.name {
+			best = cmd
+			bestLen = len(parts)
+		}
+	}
+	if best == nil {
+		return nil, nil
+	}
+	return best, args[bestLen:]
+}
+
+func newFlagSet(cmd *command, output io.Writer) *pflag.FlagSet {
+	fs := pflag.NewFlagSet("kahn "+cmd.name, pflag.ContinueOnError)
+	fs.SetOutput(output)
+	config.RegisterFlags(fs)
+	if cmd.flags != nil {
+		cmd.flags(fs)
+	}
+	fs.Usage = func() {
+		fmt.Fprintf(output, "usage: %s\n\n%s\n\nFlags:\n%s", cmd.synopsis(), cmd.summary, fs.FlagUsages())
+	}
+	return fs
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: kahn [--config FILE] [--db-path FILE]   start the interactive board")
+	fmt.Fprintln(w, "       kahn <command> [arguments] [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	commands := allCommands()
+	// Align the summaries after the longest command name
+	width := 0
+	for _, cmd := range commands {
+		width = max(width, len(cmd.name))
+	}
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-*s  %s\n", width, cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'kahn <command> --help' for the flags of a command.")
+}
+
+type usageError struct {
+	message string
+}
+
+func (e *usageError) Error() string {
+	return e.message
+}
+
+func newUsageError(format string, args ...any) error {
+	return &usageError{message: fmt.Sprintf(format, args...)}
+}
+
+// exitCode maps domain error types onto distinct process exit codes
+func exitCode(err error) int {
+	var usageErr *usageError
+	var validationErr *domain.ValidationError
+	var repositoryErr *domain.RepositoryError
+	var wipErr *domain.WIPLimitError
+
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &usageErr):
+		return ExitUsage
+	case errors.As(err, &validationErr), errors.As(err, &wipErr):
+		return ExitValidation
+	case errors.As(err, &repositoryErr):
+		return ExitRepository
+	default:
+		return ExitFailure
+	}
+}
+
+// withoutWIPWarning drops a WIP limit error that is only a warning, since the move
+// it reports was saved
+func withoutWIPWarning(err error) error {
+	var wipErr *domain.WIPLimitError
+	if errors.As(err, &wipErr) && !wipErr.Enforced {
+		return nil
+	}
+	return err
+}
+
+// requireArgs checks the number of positional arguments left after flag parsing
+func requireArgs(fs *pflag.FlagSet, minArgs, maxArgs int) ([]string, error) {
+	args := fs.Args()
+	if len(args) < minArgs {
+		return nil, newUsageError("expected at least %d argument(s), got %d", minArgs, len(args))
+	}
+	if maxArgs >= 0 && len(args) > maxArgs {
+		return nil, newUsageError("expected at most %d argument(s), got %d", maxArgs, len(args))
+	}
+	return args, nil
+}
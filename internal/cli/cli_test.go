@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"kahn/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected bool
+	}{
+		{name: "no arguments starts the TUI", args: []string{}, expected: false},
+		{name: "TUI flags start the TUI", args: []string{"--db-path", "/tmp/kahn.db"}, expected: false},
+		{name: "task group", args: []string{"task", "list"}, expected: true},
+		{name: "project group", args: []string{"project", "add", "x"}, expected: true},
+		{name: "help", args: []string{"help"}, expected: true},
+		{name: "unknown word", args: []string{"frobnicate"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsCommand(tt.args))
+		})
+	}
+}
+
+func TestFindCommand(t *testing.T) {
+	cmd, rest := findCommand([]string{"task", "add", "Write tests", "--priority", "high"})
+	if assert.NotNil(t, cmd) {
+		assert.Equal(t, "task add", cmd.name)
+	}
+	assert.Equal(t, []string{"Write tests", "--priority", "high"}, rest)
+
+	cmd, _ = findCommand([]string{"task"})
+	assert.Nil(t, cmd, "A group name alone is not a command")
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "nil", err: nil, expected: ExitOK},
+		{name: "usage", err: newUsageError("bad"), expected: ExitUsage},
+		{name: "validation", err: domain.NewValidationError("name", "bad"), expected: ExitValidation},
+		{name: "repository", err: domain.NewRepositoryError("get", "task", "1", errors.New("boom")), expected: ExitRepository},
+		{name: "other", err: errors.New("boom"), expected: ExitFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, exitCode(tt.err))
+		})
+	}
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	env := setupTestEnv(t)
+
+	code, _, stderr := runCLI(t, env, "task", "frobnicate")
+	assert.Equal(t, ExitUsage, code)
+	assert.Contains(t, stderr, "unknown command")
+}
+
+func TestRun_UnknownFlag(t *testing.T) {
+	env := setupTestEnv(t)
+
+	code, _, stderr := runCLI(t, env, "task", "list", "--bogus")
+	assert.Equal(t, ExitUsage, code)
+	assert.Contains(t, stderr, "unknown flag")
+}
+
+func TestPrintUsage_AlignsSummaries(t *testing.T) {
+	var out strings.Builder
+	printUsage(&out)
+
+	// Every summary starts in the same column, however long the command name
+	column := -1
+	for _, cmd := range allCommands() {
+		for _, line := range strings.Split(out.String(), "\n") {
+			if strings.HasPrefix(line, "  "+cmd.name+" ") && strings.HasSuffix(line, cmd.summary) {
+				index := strings.Index(line, cmd.summary)
+				if column == -1 {
+					column = index
+				}
+				assert.Equal(t, column, index, "summary of %s", cmd.name)
+			}
+		}
+	}
+	assert.Greater(t, column, 0)
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"kahn/internal/domain"
+	"kahn/internal/formats"
+
+	"github.com/spf13/pflag"
+)
+
+// Comments are signed with env.Author and can only be added, never edited
+func commentCommands() []*command {
+	return []*command{
+		{
+			name:    "task comment",
+			args:    "<task> <text>...",
+			summary: "Add a comment to a task",
+			run:     runCommentAdd,
+		},
+		{
+			name:    "task comments",
+			args:    "<task>",
+			summary: "List the comments on a task, oldest first",
+			flags:   addOutputFlag,
+			run:     runCommentList,
+		},
+	}
+}
+
+func runCommentAdd(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 2, -1)
+	if err != nil {
+		return err
+	}
+
+	task, err := resolveTask(env, args[0])
+	if err != nil {
+		return err
+	}
+
+	comment, err := env.TaskService.AddComment(task.ID, env.Author, strings.Join(args[1:], " "))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Commented on task #%d as %s\n", task.IntID, comment.Author)
+	return nil
+}
+
+func runCommentList(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 1, 1)
+	if err != nil {
+		return err
+	}
+	format, err := outputFormat(fs)
+	if err != nil {
+		return err
+	}
+
+	task, err := resolveTask(env, args[0])
+	if err != nil {
+		return err
+	}
+	comments, err := env.TaskService.GetComments(task.ID)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case outputJSON:
+		return writeJSON(env.Out, formats.NewCommentListDocument(*task, comments))
+	case outputNDJSON:
+		return writeNDJSON(env.Out, formats.NewCommentListDocument(*task, comments).Comments)
+	case outputPlain:
+		rows := make([][]string, len(comments))
+		for i, comment := range comments {
+			rows[i] = []string{comment.Timestamp(), comment.Author, strings.Join(strings.Fields(comment.Body), " ")}
+		}
+		return writeRows(env.Out, format, nil, rows)
+	}
+
+	if len(comments) == 0 {
+		fmt.Fprintf(env.Out, "Task #%d has no comments\n", task.IntID)
+		return nil
+	}
+	writeComments(env.Out, comments)
+	return nil
+}
+
+// writeComments prints each comment as a heading line with its body indented
+// below, keeping the line breaks of the body
+func writeComments(w io.Writer, comments []domain.Comment) {
+	for i, comment := range comments {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s · %s\n", comment.Author, comment.Timestamp())
+		for _, line := range strings.Split(comment.Body, "\n") {
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+	}
+}
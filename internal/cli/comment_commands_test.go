@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"kahn/internal/formats"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskComments(t *testing.T) {
+	env := setupTestEnv(t)
+	env.Author = "alice"
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Flaky test")
+
+	out := mustRunCLI(t, env, "task", "comment", "1", "Reproduced", "on", "CI")
+	assert.Contains(t, out, "Commented on task #1 as alice")
+	mustRunCLI(t, env, "task", "comment", "#1", "Timeout raised\nwatching it")
+
+	out = mustRunCLI(t, env, "task", "comments", "1")
+	assert.Contains(t, out, "alice · ")
+	assert.Contains(t, out, "    Reproduced on CI\n")
+	assert.Contains(t, out, "    Timeout raised\n    watching it\n")
+
+	out = mustRunCLI(t, env, "task", "show", "1")
+	assert.Contains(t, out, "Comments (2):")
+	assert.Contains(t, out, "    Reproduced on CI")
+
+	var document formats.CommentListDocument
+	require.NoError(t, json.Unmarshal([]byte(mustRunCLI(t, env, "task", "comments", "1", "-o", "json")), &document))
+	assert.Equal(t, 1, document.TaskID)
+	require.Len(t, document.Comments, 2)
+	assert.Equal(t, "alice", document.Comments[0].Author)
+	assert.Equal(t, "Timeout raised\nwatching it", document.Comments[1].Body)
+
+	// Comments travel with the task in its JSON record
+	var record formats.TaskRecord
+	require.NoError(t, json.Unmarshal([]byte(mustRunCLI(t, env, "task", "show", "1", "-o", "json")), &record))
+	assert.Len(t, record.Comments, 2)
+
+	code, _, _ := runCLI(t, env, "task", "comment", "1")
+	assert.Equal(t, ExitUsage, code, "The comment text is required")
+	code, _, _ = runCLI(t, env, "task", "comment", "1", "   ")
+	assert.Equal(t, ExitValidation, code)
+
+	mustRunCLI(t, env, "task", "add", "Quiet")
+	assert.Contains(t, mustRunCLI(t, env, "task", "comments", "2"), "Task #2 has no comments")
+}
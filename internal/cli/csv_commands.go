@@ -0,0 +1,301 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"kahn/internal/domain"
+	"kahn/internal/formats"
+
+	"github.com/spf13/pflag"
+)
+
+func csvCommands() []*command {
+	return []*command{
+		{
+			name:    "csv export",
+			args:    "[file]",
+			summary: "Write a project's tasks as CSV (stdout by default)",
+			flags: func(fs *pflag.FlagSet) {
+				fs.StringP("project", "p", "", "Project ID or name (optional when only one project exists)")
+			},
+			run: runCSVExport,
+		},
+		{
+			name:    "csv import",
+			args:    "<file>",
+			summary: "Create tasks in a project from a CSV file",
+			flags: func(fs *pflag.FlagSet) {
+				fs.StringP("project", "p", "", "Project ID or name (optional when only one project exists)")
+				fs.Bool("dry-run", false, "Validate every row and report problems without creating tasks")
+			},
+			run: runCSVImport,
+		},
+	}
+}
+
+func runCSVExport(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 0, 1)
+	if err != nil {
+		return err
+	}
+
+	projectRef, _ := fs.GetString("project")
+	project, err := resolveProject(env, projectRef)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := tasksInBoardOrder(env, project, project.Workflow.Statuses())
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 || args[0] == "-" {
+		return formats.WriteTaskCSV(env.Out, tasks, project.Workflow)
+	}
+
+	file, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+	if err := formats.WriteTaskCSV(file, tasks, project.Workflow); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Exported %d task(s) from %s to %s\n", len(tasks), project.Name, args[0])
+	return nil
+}
+
+// csvImportRow is a CSV row that passed validation and is ready to be created
+type csvImportRow struct {
+	line          int
+	ref           string // value of the id column, used by other rows' blocked_by
+	task          *domain.Task
+	localBlockers []string // blocked_by entries pointing at other rows in the same file
+}
+
+func runCSVImport(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	projectRef, _ := fs.GetString("project")
+	project, err := resolveProject(env, projectRef)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	rows, err := formats.ReadTaskCSV(file)
+	if err != nil {
+		return err
+	}
+
+	planned, rowErrors := planCSVImport(env, project, rows)
+	wipErrors, wipWarnings, err := csvImportWIP(env, project.ID, planned)
+	if err != nil {
+		return err
+	}
+	rowErrors = append(rowErrors, wipErrors...)
+	for _, rowErr := range rowErrors {
+		fmt.Fprintln(env.Out, rowErr)
+	}
+	for _, warning := range wipWarnings {
+		fmt.Fprintf(env.Out, "Warning: %s\n", warning)
+	}
+	if len(rowErrors) > 0 {
+		return domain.NewValidationError("csv", fmt.Sprintf("%d of %d row(s) failed validation; nothing was imported", len(rowErrors), len(rows)))
+	}
+
+	if dryRun, _ := fs.GetBool("dry-run"); dryRun {
+		fmt.Fprintf(env.Out, "Dry run: %d row(s) are valid and would be imported into %s\n", len(planned), project.Name)
+		return nil
+	}
+
+	// Tasks are created first, then linked, then moved, so that moving a blocker
+	// to the done column clears its dependents exactly as it does on the board
+	created := make(map[string]int, len(planned))
+	for _, row := range planned {
+		task, err := env.TaskService.CreateTask(row.task.Name, row.task.Desc, project.ID, row.task.Type, row.task.Priority, row.task.BlockedBy)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", row.line, err)
+		}
+		row.task.ID = task.ID
+		if row.ref != "" {
+			created[row.ref] = task.IntID
+		}
+	}
+
+	for _, row := range planned {
+		if len(row.localBlockers) == 0 {
+			continue
+		}
+		blockers := make([]int, len(row.localBlockers))
+		for i, ref := range row.localBlockers {
+			blockers[i] = created[ref]
+		}
+		if _, err := env.TaskService.AddTaskBlockers(row.task.ID, blockers...); err != nil {
+			return fmt.Errorf("line %d: %w", row.line, err)
+		}
+	}
+
+	for _, row := range planned {
+		if row.task.Status == domain.NotStarted {
+			continue
+		}
+		if _, err := env.TaskService.UpdateTaskStatus(row.task.ID, row.task.Status); withoutWIPWarning(err) != nil {
+			return fmt.Errorf("line %d: %w", row.line, err)
+		}
+	}
+
+	fmt.Fprintf(env.Out, "Imported %d task(s) into %s\n", len(planned), project.Name)
+	return nil
+}
+
+// planCSVImport validates every row without writing anything and returns the
+// rows ready to create along with one error message per invalid row
+func planCSVImport(env *Env, project *domain.Project, rows []formats.TaskCSVRow) ([]csvImportRow, []string) {
+	refs := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		if ref := strings.TrimPrefix(row.Get("id"), "#"); ref != "" {
+			refs[ref] = true
+		}
+	}
+
+	var planned []csvImportRow
+	var rowErrors []string
+	seen := make(map[string]bool, len(rows))
+
+	for _, row := range rows {
+		item, err := planCSVRow(env, project, row, refs)
+		if err == nil && item.ref != "" && seen[item.ref] {
+			err = domain.NewValidationError("id", fmt.Sprintf("duplicate id %q", item.ref))
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, fmt.Sprintf("line %d: %v", row.Line, err))
+			continue
+		}
+		if item.ref != "" {
+			seen[item.ref] = true
+		}
+		planned = append(planned, item)
+	}
+	return planned, append(rowErrors, csvDependencyCycles(planned)...)
+}
+
+// csvImportWIP follows the planned rows into their columns, in the order the
+// import creates and moves them, and reports each row that takes a column over
+// its WIP limit. Enforced limits are errors, so the import stops before it
+// writes anything rather than halfway through.
+func csvImportWIP(env *Env, projectID string, planned []csvImportRow) (wipErrors, warnings []string, err error) {
+	plan, err := env.TaskService.PlanWIP(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for range planned {
+		plan.Create()
+	}
+	for _, row := range planned {
+		wipErr := plan.Move(domain.NotStarted, row.task.Status)
+		switch {
+		case wipErr == nil:
+		case wipErr.Enforced:
+			wipErrors = append(wipErrors, fmt.Sprintf("line %d: %v", row.line, wipErr))
+		default:
+			warnings = append(warnings, fmt.Sprintf("line %d: %v", row.line, wipErr))
+		}
+	}
+	return wipErrors, warnings, nil
+}
+
+// csvDependencyCycles reports rows whose blocked_by links lead back to them
+// through other rows of the file
+func csvDependencyCycles(planned []csvImportRow) []string {
+	numbers := make(map[string]int, len(planned))
+	refs := make(map[int]string, len(planned))
+	for i, row := range planned {
+		if row.ref != "" {
+			numbers[row.ref] = i + 1
+			refs[i+1] = row.ref
+		}
+	}
+
+	graph := make(domain.DependencyGraph, len(planned))
+	for i, row := range planned {
+		for _, ref := range row.localBlockers {
+			graph[i+1] = append(graph[i+1], numbers[ref])
+		}
+	}
+
+	var cycleErrors []string
+	for i, row := range planned {
+		cycle := graph.FindCycle(i+1, graph[i+1])
+		if cycle == nil {
+			continue
+		}
+		steps := make([]string, len(cycle))
+		for j, number := range cycle {
+			steps[j] = refs[number]
+		}
+		cycleErrors = append(cycleErrors, fmt.Sprintf("line %d: dependency cycle between ids %s", row.line, strings.Join(steps, " → ")))
+	}
+	return cycleErrors
+}
+
+func planCSVRow(env *Env, project *domain.Project, row formats.TaskCSVRow, refs map[string]bool) (csvImportRow, error) {
+	item := csvImportRow{line: row.Line, ref: strings.TrimPrefix(row.Get("id"), "#")}
+
+	task := domain.NewTask(row.Get("name"), row.Get("description"), project.ID)
+	var err error
+	if value := row.Get("status"); value != "" {
+		if task.Status, err = project.Workflow.Parse(value); err != nil {
+			return item, err
+		}
+	}
+	if value := row.Get("type"); value != "" {
+		if task.Type, err = domain.ParseTaskType(value); err != nil {
+			return item, err
+		}
+	}
+	if value := row.Get("priority"); value != "" {
+		if task.Priority, err = domain.ParsePriority(value); err != nil {
+			return item, err
+		}
+	}
+	if err := task.Validate(); err != nil {
+		return item, err
+	}
+
+	// A blocked_by entry matching another row's id links within the file; anything
+	// else must name an existing task in the target project
+	var existing []string
+	for _, value := range strings.FieldsFunc(row.Get("blocked_by"), func(r rune) bool { return r == ',' || r == ' ' }) {
+		value = strings.TrimPrefix(value, "#")
+		switch {
+		case value == item.ref:
+			return item, domain.NewValidationError("blocked_by", "task cannot block itself")
+		case refs[value]:
+			item.localBlockers = append(item.localBlockers, value)
+		default:
+			existing = append(existing, value)
+		}
+	}
+	if task.BlockedBy, err = resolveBlockers(env, existing, project.ID); err != nil {
+		return item, err
+	}
+
+	item.task = task
+	return item, nil
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"kahn/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVExport(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "First", "--priority", "high")
+	mustRunCLI(t, env, "task", "add", "Second", "--blocked-by", "1")
+
+	out := mustRunCLI(t, env, "csv", "export")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "id,name,description,status,type,priority,blocked_by,created_at,updated_at", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "1,First,,Not Started,Task,High,,"))
+	assert.True(t, strings.HasPrefix(lines[2], "2,Second,,Not Started,Task,Low,1,"))
+}
+
+func TestCSVImport(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+
+	path := writeTestFile(t, "tasks.csv", "name,status,type,priority,blocked_by,id\n"+
+		"Design,Done,feature,high,,10\n"+
+		"Build,In Progress,,medium,10,11\n"+
+		"Ship,,bug,,11,12\n")
+
+	out := mustRunCLI(t, env, "csv", "import", path)
+	assert.Contains(t, out, "Imported 3 task(s) into Alpha")
+
+	ship, err := env.TaskService.GetTaskByIntID(3)
+	require.NoError(t, err)
+	assert.Equal(t, "Ship", ship.Name)
+	assert.Equal(t, domain.Bug, ship.Type)
+	assert.Equal(t, []int{2}, ship.BlockedBy, "blocked_by is remapped from the file's ids")
+
+	build, err := env.TaskService.GetTaskByIntID(2)
+	require.NoError(t, err)
+	assert.Equal(t, domain.InProgress, build.Status)
+	assert.Empty(t, build.BlockedBy, "Moving the blocker to Done unblocks its dependents")
+}
+
+func TestCSVImport_ValidationReportsEveryRow(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+
+	longName := strings.Repeat("x", domain.MaxTaskNameLength+1)
+	path := writeTestFile(t, "tasks.csv", "name,priority,blocked_by\n"+
+		"Valid,low,\n"+
+		longName+",low,\n"+
+		"Urgent,urgent,\n"+
+		"Orphan,,99\n")
+
+	for _, args := range [][]string{{"csv", "import", path, "--dry-run"}, {"csv", "import", path}} {
+		code, out, stderr := runCLI(t, env, args...)
+		assert.Equal(t, ExitValidation, code)
+		assert.Contains(t, out, "line 3:")
+		assert.Contains(t, out, "too long")
+		assert.Contains(t, out, "line 4:")
+		assert.Contains(t, out, "unknown priority")
+		assert.Contains(t, out, "line 5:")
+		assert.NotContains(t, out, "line 2:")
+		assert.Contains(t, stderr, "3 of 4 row(s) failed validation")
+	}
+
+	tasks, err := env.TaskService.GetTasksByProject(mustResolveProject(t, env).ID)
+	require.NoError(t, err)
+	assert.Empty(t, tasks, "Nothing is created when any row is invalid")
+}
+
+func TestCSVImport_MultipleBlockers(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Existing")
+
+	path := writeTestFile(t, "tasks.csv", "id,name,blocked_by\n"+
+		"a,Design,\n"+
+		"b,Ship,a #1\n")
+	mustRunCLI(t, env, "csv", "import", path)
+
+	ship, err := env.TaskService.GetTaskByIntID(3)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ship.BlockedBy, "File ids and existing task numbers can be mixed")
+
+	path = writeTestFile(t, "cycle.csv", "id,name,blocked_by\n"+
+		"a,Design,b\n"+
+		"b,Ship,a\n")
+	code, out, _ := runCLI(t, env, "csv", "import", path)
+	assert.Equal(t, ExitValidation, code)
+	assert.Contains(t, out, "dependency cycle between ids a → b → a")
+}
+
+func TestCSVImport_DryRunCreatesNothing(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	path := writeTestFile(t, "tasks.csv", "name\nOne\nTwo\n")
+
+	out := mustRunCLI(t, env, "csv", "import", path, "--dry-run")
+	assert.Contains(t, out, "Dry run: 2 row(s) are valid")
+
+	tasks, err := env.TaskService.GetTasksByProject(mustResolveProject(t, env).ID)
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func TestCSVImport_WIPLimits(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "project", "wip", "Alpha", "in-progress", "1")
+	path := writeTestFile(t, "tasks.csv", "name,status\n"+
+		"Design,In Progress\n"+
+		"Build,In Progress\n")
+
+	for _, args := range [][]string{{"csv", "import", path, "--dry-run"}, {"csv", "import", path}} {
+		code, out, _ := runCLI(t, env, args...)
+		assert.Equal(t, ExitValidation, code)
+		assert.Contains(t, out, "line 3: moving the task would put 'In Progress' over its WIP limit (2/1)")
+		assert.NotContains(t, out, "line 2:")
+	}
+	tasks, err := env.TaskService.GetTasksByProject(mustResolveProject(t, env).ID)
+	require.NoError(t, err)
+	assert.Empty(t, tasks, "An enforced WIP limit stops the import before anything is written")
+
+	env.TaskService.SetWIPEnforcement(domain.WIPWarn)
+	out := mustRunCLI(t, env, "csv", "import", path)
+	assert.Contains(t, out, "Warning: line 3: 'In Progress' is over its WIP limit (2/1)")
+	assert.Contains(t, out, "Imported 2 task(s) into Alpha")
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"kahn/internal/domain"
+	"kahn/internal/formats"
+
+	"github.com/spf13/pflag"
+)
+
+func graphCommands() []*command {
+	return []*command{
+		{
+			name:    "graph",
+			args:    "[file]",
+			summary: "Export a project's task dependencies as Graphviz DOT or Mermaid",
+			flags: func(fs *pflag.FlagSet) {
+				fs.StringP("project", "p", "", "Project ID or name (optional when only one project exists)")
+				fs.StringP("format", "f", "", "dot or mermaid (default: mermaid for .mmd files, dot otherwise)")
+				fs.StringP("task", "t", "", "Only draw this task, the tasks it waits on and the tasks waiting on it")
+				fs.Bool("all", false, "Include tasks that neither block nor wait on another task")
+			},
+			run: runGraph,
+		},
+	}
+}
+
+func runGraph(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 0, 1)
+	if err != nil {
+		return err
+	}
+	path := ""
+	if len(args) == 1 && args[0] != "-" {
+		path = args[0]
+	}
+
+	format, _ := fs.GetString("format")
+	if format == "" {
+		format = graphFormatForPath(path)
+	}
+	format = strings.ToLower(format)
+	if format != formats.GraphDOT && format != formats.GraphMermaid {
+		return newUsageError("unknown --format %q; use %s or %s", format, formats.GraphDOT, formats.GraphMermaid)
+	}
+
+	projectRef, _ := fs.GetString("project")
+	taskRef, _ := fs.GetString("task")
+	var root *domain.Task
+	if taskRef != "" {
+		if root, err = resolveTask(env, taskRef); err != nil {
+			return err
+		}
+		// The task names its project unless one was given explicitly
+		if projectRef == "" {
+			projectRef = root.ProjectID
+		}
+	}
+
+	project, err := resolveProject(env, projectRef)
+	if err != nil {
+		return err
+	}
+	if root != nil && root.ProjectID != project.ID {
+		return domain.NewValidationError("task", fmt.Sprintf("task #%d is not in project %s", root.IntID, project.Name))
+	}
+
+	tasks, err := env.TaskService.GetTasksByProject(project.ID)
+	if err != nil {
+		return err
+	}
+	all, _ := fs.GetBool("all")
+	switch {
+	case root != nil:
+		project.Tasks = formats.GraphTasks(tasks, root.IntID)
+	case all:
+		project.Tasks = tasks
+		sort.Slice(project.Tasks, func(i, j int) bool {
+			return project.Tasks[i].IntID < project.Tasks[j].IntID
+		})
+	default:
+		project.Tasks = formats.GraphTasks(tasks, 0)
+	}
+
+	if path == "" {
+		return formats.WriteDependencyGraph(env.Out, *project, format)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := formats.WriteDependencyGraph(file, *project, format); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Exported %d task(s) of %s to %s\n", len(project.Tasks), project.Name, path)
+	return nil
+}
+
+// graphFormatForPath picks the format from the file extension
+func graphFormatForPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".mmd", ".mermaid":
+		return formats.GraphMermaid
+	default:
+		return formats.GraphDOT
+	}
+}
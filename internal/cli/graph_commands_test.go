@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphExport(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Design")
+	mustRunCLI(t, env, "task", "add", "Build", "--blocked-by", "1", "--priority", "high")
+	mustRunCLI(t, env, "task", "add", "Unrelated")
+
+	out := mustRunCLI(t, env, "graph")
+	assert.Contains(t, out, "digraph \"Alpha\" {")
+	assert.Contains(t, out, "t1 -> t2;")
+	assert.Contains(t, out, `color="#d20f39", penwidth=3`, "High priority tasks get a thick red border")
+	assert.NotContains(t, out, "Unrelated", "Tasks without links are left out by default")
+
+	out = mustRunCLI(t, env, "graph", "--all", "--format", "mermaid")
+	assert.Contains(t, out, "flowchart LR\n")
+	assert.Contains(t, out, "t1 --> t2")
+	assert.Contains(t, out, "Unrelated")
+
+	out = mustRunCLI(t, env, "graph", "--task", "3")
+	assert.Contains(t, out, "t3 [")
+	assert.NotContains(t, out, "t1 [")
+
+	path := filepath.Join(t.TempDir(), "deps.mmd")
+	out = mustRunCLI(t, env, "graph", path)
+	assert.Contains(t, out, "Exported 2 task(s) of Alpha to "+path)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "flowchart LR", "The .mmd extension selects Mermaid")
+
+	code, _, _ := runCLI(t, env, "graph", "--format", "svg")
+	assert.Equal(t, ExitUsage, code)
+}
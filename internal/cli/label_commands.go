@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"kahn/internal/domain"
+	"kahn/internal/formats"
+
+	"github.com/spf13/pflag"
+)
+
+func labelCommands() []*command {
+	projectFlag := func(fs *pflag.FlagSet) {
+		fs.StringP("project", "p", "", "Project ID or name (optional when only one project exists)")
+	}
+
+	return []*command{
+		{
+			name:    "label list",
+			summary: "List a project's labels with their task counts",
+			flags: func(fs *pflag.FlagSet) {
+				projectFlag(fs)
+				addOutputFlag(fs)
+			},
+			run: runLabelList,
+		},
+		{
+			name:    "label add",
+			args:    "<name>",
+			summary: "Create a label",
+			flags: func(fs *pflag.FlagSet) {
+				projectFlag(fs)
+				fs.StringP("color", "c", "", "Label color as #rrggbb (default: the next palette color)")
+			},
+			run: runLabelAdd,
+		},
+		{
+			name:    "label edit",
+			args:    "<label>",
+			summary: "Rename or recolor a label",
+			flags: func(fs *pflag.FlagSet) {
+				projectFlag(fs)
+				fs.StringP("name", "n", "", "New label name")
+				fs.StringP("color", "c", "", "New label color as #rrggbb")
+			},
+			run: runLabelEdit,
+		},
+		{
+			name:    "label rm",
+			args:    "<label>",
+			summary: "Delete a label and remove it from its tasks",
+			flags:   projectFlag,
+			run:     runLabelRemove,
+		},
+	}
+}
+
+func runLabelList(env *Env, fs *pflag.FlagSet) error {
+	if _, err := requireArgs(fs, 0, 0); err != nil {
+		return err
+	}
+	format, err := outputFormat(fs)
+	if err != nil {
+		return err
+	}
+
+	projectRef, _ := fs.GetString("project")
+	project, err := resolveProject(env, projectRef)
+	if err != nil {
+		return err
+	}
+
+	labels, err := env.LabelService.GetLabels(project.ID)
+	if err != nil {
+		return err
+	}
+	tasks, err := env.TaskService.GetTasksByProject(project.ID)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case outputJSON:
+		return writeJSON(env.Out, formats.NewLabelListDocument(labels))
+	case outputNDJSON:
+		return writeNDJSON(env.Out, formats.NewLabelListDocument(labels).Labels)
+	}
+
+	rows := make([][]string, len(labels))
+	for i, label := range labels {
+		count := 0
+		for _, task := range tasks {
+			if task.HasLabel(label.Name) {
+				count++
+			}
+		}
+		rows[i] = []string{label.Name, label.Color, strconv.Itoa(count)}
+	}
+	return writeRows(env.Out, format, []string{"NAME", "COLOR", "TASKS"}, rows)
+}
+
+func runLabelAdd(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	projectRef, _ := fs.GetString("project")
+	project, err := resolveProject(env, projectRef)
+	if err != nil {
+		return err
+	}
+	color, _ := fs.GetString("color")
+
+	label, err := env.LabelService.CreateLabel(project.ID, args[0], color)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Created label %s (%s) in %s\n", label.Name, label.Color, project.Name)
+	return nil
+}
+
+func runLabelEdit(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 1, 1)
+	if err != nil {
+		return err
+	}
+	if !fs.Changed("name") && !fs.Changed("color") {
+		return newUsageError("nothing to change; pass at least one of --name, --color")
+	}
+
+	label, err := resolveLabel(env, fs, args[0])
+	if err != nil {
+		return err
+	}
+
+	name, color := label.Name, label.Color
+	if fs.Changed("name") {
+		name, _ = fs.GetString("name")
+	}
+	if fs.Changed("color") {
+		color, _ = fs.GetString("color")
+	}
+
+	updated, err := env.LabelService.UpdateLabel(label.ID, name, color)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Updated label %s (%s)\n", updated.Name, updated.Color)
+	return nil
+}
+
+func runLabelRemove(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	label, err := resolveLabel(env, fs, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := env.LabelService.DeleteLabel(label.ID); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Deleted label %s\n", label.Name)
+	return nil
+}
+
+// resolveLabel finds a label by name in the project chosen with --project
+func resolveLabel(env *Env, fs *pflag.FlagSet, name string) (*domain.Label, error) {
+	projectRef, _ := fs.GetString("project")
+	project, err := resolveProject(env, projectRef)
+	if err != nil {
+		return nil, err
+	}
+	return env.LabelService.GetLabelByName(project.ID, name)
+}
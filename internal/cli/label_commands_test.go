@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelCommands(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+
+	out := mustRunCLI(t, env, "label", "add", "backend", "--color", "#f38ba8")
+	assert.Equal(t, "Created label backend (#f38ba8) in Alpha\n", out)
+
+	code, _, _ := runCLI(t, env, "label", "add", "Backend")
+	assert.Equal(t, ExitValidation, code, "Label names are unique ignoring case")
+	code, _, _ = runCLI(t, env, "label", "add", "ops", "--color", "red")
+	assert.Equal(t, ExitValidation, code)
+
+	mustRunCLI(t, env, "task", "add", "Fix API", "--label", "backend,urgent")
+	out = mustRunCLI(t, env, "label", "list")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 3, "Header plus two labels")
+	assert.Contains(t, lines[1], "backend")
+	assert.Contains(t, lines[2], "urgent", "Labels named by a task are created")
+
+	out = mustRunCLI(t, env, "label", "edit", "BACKEND", "--name", "api", "--color", "#a6e3a1")
+	assert.Equal(t, "Updated label api (#a6e3a1)\n", out)
+	code, _, _ = runCLI(t, env, "label", "edit", "api")
+	assert.Equal(t, ExitUsage, code, "Editing without changes is a usage error")
+
+	out = mustRunCLI(t, env, "label", "rm", "api")
+	assert.Equal(t, "Deleted label api\n", out)
+	task, err := env.TaskService.GetTaskByIntID(1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"urgent"}, task.LabelNames(), "Deleting a label removes it from its tasks")
+
+	code, _, _ = runCLI(t, env, "label", "rm", "missing")
+	assert.Equal(t, ExitValidation, code)
+}
+
+func TestTaskLabels(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Fix API", "--label", "backend", "--label", "tech-debt")
+	mustRunCLI(t, env, "task", "add", "Polish UI", "-l", "frontend")
+
+	out := mustRunCLI(t, env, "task", "show", "1")
+	assert.Contains(t, out, "backend,tech-debt")
+
+	out = mustRunCLI(t, env, "task", "list", "--label", "backend")
+	assert.Contains(t, out, "Fix API")
+	assert.NotContains(t, out, "Polish UI")
+
+	mustRunCLI(t, env, "task", "edit", "2", "--label", "frontend,backend")
+	out = mustRunCLI(t, env, "task", "list", "--label", "backend", "--label", "frontend", "--output", "plain")
+	assert.Equal(t, "#2\tNot Started\tTask\tLow\t-\tPolish UI\tbackend,frontend\t-\n", out)
+
+	mustRunCLI(t, env, "task", "edit", "2", "--label", "none")
+	task, err := env.TaskService.GetTaskByIntID(2)
+	require.NoError(t, err)
+	assert.Empty(t, task.Labels)
+
+	code, _, _ := runCLI(t, env, "task", "add", "Bad", "--label", "has/slash")
+	assert.Equal(t, ExitValidation, code)
+	tasks, err := env.TaskService.GetTasksByProject(task.ProjectID)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 2, "An invalid label stops the task from being created")
+}
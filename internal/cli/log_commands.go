@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"kahn/internal/domain"
+	"kahn/internal/formats"
+
+	"github.com/spf13/pflag"
+)
+
+// The log reads the events recorded by the services; tasks and projects that
+// were deleted since keep their history, so --task takes a bare number
+func logCommands() []*command {
+	return []*command{
+		{
+			name:    "log",
+			summary: "Show the history of task and project changes, oldest first",
+			flags: func(fs *pflag.FlagSet) {
+				fs.StringP("project", "p", "", "Only show changes in this project (ID or name)")
+				fs.StringP("task", "t", "", "Only show changes to this task number, e.g. 12 or #12")
+				fs.String("since", "", "Only show changes on or after this day: YYYY-MM-DD, today, yesterday, -7d, ...")
+				fs.String("until", "", "Only show changes on or before this day, in the same forms as --since")
+				fs.IntP("limit", "n", 0, "Only show the newest N changes; 0 shows all")
+				addOutputFlag(fs)
+			},
+			run: runLog,
+		},
+	}
+}
+
+func runLog(env *Env, fs *pflag.FlagSet) error {
+	if _, err := requireArgs(fs, 0, 0); err != nil {
+		return err
+	}
+	format, err := outputFormat(fs)
+	if err != nil {
+		return err
+	}
+
+	filter, err := logFilter(env, fs)
+	if err != nil {
+		return err
+	}
+	events, err := env.EventLog.List(filter)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case outputJSON:
+		return writeJSON(env.Out, formats.NewEventListDocument(events))
+	case outputNDJSON:
+		return writeNDJSON(env.Out, formats.NewEventListDocument(events).Events)
+	}
+
+	if len(events) == 0 && format == outputTable {
+		fmt.Fprintln(env.Out, "No changes recorded")
+		return nil
+	}
+	rows := make([][]string, len(events))
+	for i, event := range events {
+		rows[i] = []string{event.Timestamp(), event.Actor, event.Target(), strings.Join(strings.Fields(event.Describe()), " ")}
+	}
+	return writeRows(env.Out, format, []string{"TIME", "ACTOR", "TARGET", "CHANGE"}, rows)
+}
+
+// logFilter turns the flags of the log command into an event filter. Dates are
+// whole local days, so --until includes the day it names.
+func logFilter(env *Env, fs *pflag.FlagSet) (domain.EventFilter, error) {
+	var filter domain.EventFilter
+
+	if ref, _ := fs.GetString("project"); ref != "" {
+		project, err := resolveProject(env, ref)
+		if err != nil {
+			return filter, err
+		}
+		filter.ProjectID = project.ID
+	}
+	if ref, _ := fs.GetString("task"); ref != "" {
+		intID, err := parseTaskNumber(ref)
+		if err != nil {
+			return filter, err
+		}
+		filter.TaskIntID = *intID
+	}
+
+	since, err := dateFlag(fs, "since")
+	if err != nil {
+		return filter, err
+	}
+	if since != nil {
+		filter.Since = *since
+	}
+	until, err := dateFlag(fs, "until")
+	if err != nil {
+		return filter, err
+	}
+	if until != nil {
+		filter.Until = until.AddDate(0, 0, 1)
+	}
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Since.Before(filter.Until) {
+		return filter, domain.NewValidationError("since", "--since must not be after --until")
+	}
+
+	limit, _ := fs.GetInt("limit")
+	if limit < 0 {
+		return filter, newUsageError("--limit must not be negative")
+	}
+	filter.Limit = limit
+	return filter, nil
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"kahn/internal/formats"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLog(t *testing.T) {
+	env := setupTestEnv(t)
+	env.EventLog.SetActor("alice")
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Design schema")
+	mustRunCLI(t, env, "task", "add", "Write migration", "--priority", "high")
+	mustRunCLI(t, env, "task", "block", "2", "1")
+	mustRunCLI(t, env, "task", "edit", "2", "--priority", "low")
+	mustRunCLI(t, env, "task", "move", "1", "next")
+	mustRunCLI(t, env, "task", "rm", "1")
+
+	out := mustRunCLI(t, env, "log")
+	assert.Contains(t, out, "TIME")
+	assert.Contains(t, out, "project Alpha")
+	assert.Contains(t, out, "created in Not Started")
+	assert.Contains(t, out, "#1 Design schema")
+
+	out = mustRunCLI(t, env, "log", "--task", "#2", "-o", "plain")
+	assert.Contains(t, out, "alice\t#2 Write migration\tcreated in Not Started\n")
+	assert.Contains(t, out, "\tnow waits on #1\n")
+	assert.Contains(t, out, "\tpriority: High → Low\n")
+	assert.Contains(t, out, "\tno longer waits on #1\n", "Deleting the blocker clears it")
+
+	// A deleted task keeps its history
+	out = mustRunCLI(t, env, "log", "--task", "1", "-o", "plain")
+	assert.Contains(t, out, "moved Not Started → In Progress")
+	assert.Contains(t, out, "\tdeleted\n")
+
+	var document formats.EventListDocument
+	require.NoError(t, json.Unmarshal([]byte(mustRunCLI(t, env, "log", "--project", "Alpha", "-n", "2", "-o", "json")), &document))
+	require.Len(t, document.Events, 2, "The limit keeps the newest events")
+	assert.Equal(t, "deleted", document.Events[0].Kind)
+	assert.Equal(t, 1, document.Events[0].TaskIntID)
+	assert.Equal(t, "no longer waits on #1", document.Events[1].Summary)
+
+	assert.Contains(t, mustRunCLI(t, env, "log", "--since", "today", "--until", "today"), "project Alpha")
+	assert.Contains(t, mustRunCLI(t, env, "log", "--until", "yesterday"), "No changes recorded")
+
+	code, _, _ := runCLI(t, env, "log", "--since", "tomorrow", "--until", "today")
+	assert.Equal(t, ExitValidation, code)
+	code, _, _ = runCLI(t, env, "log", "--task", "abc")
+	assert.Equal(t, ExitValidation, code)
+	code, _, _ = runCLI(t, env, "log", "extra")
+	assert.Equal(t, ExitUsage, code)
+}
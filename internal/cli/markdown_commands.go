@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"kahn/internal/formats"
+
+	"github.com/spf13/pflag"
+)
+
+func markdownCommands() []*command {
+	return []*command{
+		{
+			name:    "markdown export",
+			args:    "[file]",
+			summary: "Render a project's board as GitHub-flavored Markdown",
+			flags: func(fs *pflag.FlagSet) {
+				fs.StringP("project", "p", "", "Project ID or name (optional when only one project exists)")
+			},
+			run: runMarkdownExport,
+		},
+	}
+}
+
+func runMarkdownExport(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 0, 1)
+	if err != nil {
+		return err
+	}
+
+	projectRef, _ := fs.GetString("project")
+	project, err := resolveProject(env, projectRef)
+	if err != nil {
+		return err
+	}
+
+	if project.Tasks, err = env.TaskService.GetTasksByProject(project.ID); err != nil {
+		return err
+	}
+
+	if len(args) == 0 || args[0] == "-" {
+		return formats.WriteBoardMarkdown(env.Out, *project)
+	}
+
+	file, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+	if err := formats.WriteBoardMarkdown(file, *project); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Exported %s to %s\n", project.Name, args[0])
+	return nil
+}
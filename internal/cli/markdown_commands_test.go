@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownExport(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Blocker", "--type", "bug")
+	mustRunCLI(t, env, "task", "add", "Blocked", "--blocked-by", "1")
+
+	out := mustRunCLI(t, env, "markdown", "export")
+	assert.Contains(t, out, "# Alpha\n")
+	assert.Contains(t, out, "## Not Started (2)")
+	assert.Contains(t, out, "- [ ] 🐛 `#1` Blocker · Low priority\n")
+	assert.Contains(t, out, "blocked by `#1`")
+
+	path := filepath.Join(t.TempDir(), "board.md")
+	out = mustRunCLI(t, env, "markdown", "export", path)
+	assert.Contains(t, out, "Exported Alpha to "+path)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "## Done (0)")
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/pflag"
+)
+
+// Output formats accepted by --output on read commands
+const (
+	outputTable  = "table"
+	outputPlain  = "plain"
+	outputJSON   = "json"
+	outputNDJSON = "ndjson"
+)
+
+func addOutputFlag(fs *pflag.FlagSet) {
+	fs.StringP("output", "o", outputTable, "Output format: table, plain, json or ndjson")
+}
+
+func outputFormat(fs *pflag.FlagSet) (string, error) {
+	format, _ := fs.GetString("output")
+	format = strings.ToLower(format)
+	switch format {
+	case outputTable, outputPlain, outputJSON, outputNDJSON:
+		return format, nil
+	default:
+		return "", newUsageError("unknown output format %q; use table, plain, json or ndjson", format)
+	}
+}
+
+// writeRows renders tabular data. Table output is aligned with a header row; plain
+// output is tab separated without a header so it can be piped into cut or awk.
+func writeRows(w io.Writer, format string, header []string, rows [][]string) error {
+	if format == outputPlain {
+		for _, row := range rows {
+			if _, err := fmt.Fprintln(w, strings.Join(row, "\t")); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func writeJSON(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// writeNDJSON writes one compact JSON document per line
+func writeNDJSON[T any](w io.Writer, records []T) error {
+	encoder := json.NewEncoder(w)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
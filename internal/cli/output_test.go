@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"kahn/internal/formats"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput_TaskListJSON(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Blocker")
+	mustRunCLI(t, env, "task", "add", "Blocked", "--type", "bug", "--priority", "high", "--blocked-by", "1")
+
+	out := mustRunCLI(t, env, "task", "list", "--output", "json")
+
+	var doc formats.TaskListDocument
+	require.NoError(t, json.Unmarshal([]byte(out), &doc))
+	assert.Equal(t, formats.SchemaVersion, doc.SchemaVersion)
+	require.Len(t, doc.Tasks, 2)
+
+	blocked := doc.Tasks[0]
+	assert.Equal(t, "Blocked", blocked.Name, "Board order puts the high priority task first")
+	assert.Equal(t, 2, blocked.IntID)
+	require.NotNil(t, blocked.BlockedBy)
+	assert.Equal(t, 1, *blocked.BlockedBy)
+	assert.Equal(t, []int{1}, blocked.Blockers)
+	assert.Equal(t, "Bug", blocked.TypeName)
+	assert.Equal(t, "High", blocked.PriorityName)
+	assert.Equal(t, 0, blocked.Status)
+	assert.Equal(t, "Not Started", blocked.StatusName)
+}
+
+func TestOutput_TaskListNDJSON(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "One")
+	mustRunCLI(t, env, "task", "add", "Two")
+
+	out := mustRunCLI(t, env, "task", "list", "-o", "ndjson")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2)
+
+	for _, line := range lines {
+		var record formats.TaskRecord
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		assert.Equal(t, formats.SchemaVersion, record.SchemaVersion)
+	}
+}
+
+func TestOutput_TaskListPlainHasNoHeader(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Only task")
+
+	out := mustRunCLI(t, env, "task", "list", "--output", "plain")
+	assert.Equal(t, "#1\tNot Started\tTask\tLow\t-\tOnly task\t-\t-\n", out)
+}
+
+func TestOutput_TaskShowJSON(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Fix login", "--desc", "Crashes on submit")
+
+	out := mustRunCLI(t, env, "task", "show", "1", "--output", "json")
+
+	var record formats.TaskRecord
+	require.NoError(t, json.Unmarshal([]byte(out), &record))
+	assert.Equal(t, 1, record.IntID)
+	assert.Equal(t, "Crashes on submit", record.Description)
+	assert.Nil(t, record.BlockedBy)
+}
+
+func TestOutput_ProjectListJSON(t *testing.T) {
+	env := setupTestEnv(t)
+
+	out := mustRunCLI(t, env, "project", "list", "--output", "json")
+	assert.JSONEq(t, `{"schema_version":2,"projects":[]}`, out)
+
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "One")
+
+	out = mustRunCLI(t, env, "project", "list", "--output", "json")
+	var doc formats.ProjectListDocument
+	require.NoError(t, json.Unmarshal([]byte(out), &doc))
+	require.Len(t, doc.Projects, 1)
+	assert.Equal(t, "Alpha", doc.Projects[0].Name)
+	assert.Equal(t, 1, doc.Projects[0].TaskCount)
+}
+
+func TestOutput_UnknownFormatIsUsageError(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+
+	code, _, stderr := runCLI(t, env, "task", "list", "--output", "yaml")
+	assert.Equal(t, ExitUsage, code)
+	assert.Contains(t, stderr, "unknown output format")
+}
@@ -0,0 +1,293 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"kahn/internal/domain"
+	"kahn/internal/formats"
+
+	"github.com/spf13/pflag"
+)
+
+func projectCommands() []*command {
+	return []*command{
+		{
+			name:    "project add",
+			args:    "<name>",
+			summary: "Create a project",
+			flags: func(fs *pflag.FlagSet) {
+				fs.StringP("desc", "d", "", "Project description")
+			},
+			run: runProjectAdd,
+		},
+		{
+			name:    "project list",
+			summary: "List projects with their task counts",
+			flags:   addOutputFlag,
+			run:     runProjectList,
+		},
+		{
+			name:    "project rm",
+			args:    "<project>",
+			summary: "Move a project and all of its tasks to the trash",
+			run:     runProjectRemove,
+		},
+		{
+			name:    "project rename",
+			args:    "<project> <new name>",
+			summary: "Rename a project",
+			run:     runProjectRename,
+		},
+		{
+			name:    "project workflow",
+			args:    "<project> [status...]",
+			summary: "Show or replace a project's status columns",
+			flags: func(fs *pflag.FlagSet) {
+				fs.String("done", "", "Status that counts as done and unblocks dependents (default: the last one)")
+				addOutputFlag(fs)
+			},
+			run: runProjectWorkflow,
+		},
+		{
+			name:    "project wip",
+			args:    "<project> <status> <limit>",
+			summary: "Limit the number of tasks in a status column (0 removes the limit)",
+			run:     runProjectWIP,
+		},
+	}
+}
+
+func runProjectAdd(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 1, 1)
+	if err != nil {
+		return err
+	}
+	desc, _ := fs.GetString("desc")
+
+	project, err := env.ProjectService.CreateProject(args[0], desc)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Created project %s (%s)\n", project.Name, project.ID)
+	return nil
+}
+
+func runProjectList(env *Env, fs *pflag.FlagSet) error {
+	if _, err := requireArgs(fs, 0, 0); err != nil {
+		return err
+	}
+	format, err := outputFormat(fs)
+	if err != nil {
+		return err
+	}
+
+	projects, err := env.ProjectService.GetAllProjects()
+	if err != nil {
+		return err
+	}
+
+	records := make([]formats.ProjectRecord, 0, len(projects))
+	for _, project := range projects {
+		tasks, err := env.TaskService.GetTasksByProject(project.ID)
+		if err != nil {
+			return err
+		}
+		records = append(records, formats.NewProjectRecord(project, len(tasks)))
+	}
+
+	switch format {
+	case outputJSON:
+		return writeJSON(env.Out, formats.NewProjectListDocument(records))
+	case outputNDJSON:
+		return writeNDJSON(env.Out, records)
+	}
+
+	rows := make([][]string, len(records))
+	for i, record := range records {
+		rows[i] = []string{record.ID, record.Name, strconv.Itoa(record.TaskCount), record.Description}
+	}
+	return writeRows(env.Out, format, []string{"ID", "NAME", "TASKS", "DESCRIPTION"}, rows)
+}
+
+func runProjectRemove(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	project, err := resolveProject(env, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := env.ProjectService.DeleteProject(project.ID); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Moved project %s (%s) to the trash\n", project.Name, project.ID)
+	return nil
+}
+
+func runProjectRename(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 2, 2)
+	if err != nil {
+		return err
+	}
+
+	project, err := resolveProject(env, args[0])
+	if err != nil {
+		return err
+	}
+
+	updated, err := env.ProjectService.UpdateProject(project.ID, args[1], project.Description)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Renamed project %s to %s\n", project.Name, updated.Name)
+	return nil
+}
+
+// runProjectWorkflow prints the project's columns, or replaces them when statuses
+// are given. Tasks keep their column when its name is still present.
+func runProjectWorkflow(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 1, -1)
+	if err != nil {
+		return err
+	}
+
+	project, err := resolveProject(env, args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		if fs.Changed("done") {
+			return newUsageError("--done needs the list of statuses")
+		}
+		format, err := outputFormat(fs)
+		if err != nil {
+			return err
+		}
+		switch format {
+		case outputJSON:
+			return writeJSON(env.Out, formats.NewWorkflowDocument(*project))
+		case outputNDJSON:
+			return writeNDJSON(env.Out, formats.NewWorkflowDocument(*project).Columns)
+		}
+
+		for _, status := range project.Workflow.Statuses() {
+			line := project.Workflow.Name(status)
+			if project.Workflow.IsDone(status) {
+				line += " (done)"
+			}
+			if limit := project.Workflow.WIPLimit(status); limit > 0 {
+				line += fmt.Sprintf(" (limit %d)", limit)
+			}
+			fmt.Fprintln(env.Out, line)
+		}
+		return nil
+	}
+
+	if fs.Changed("output") {
+		return newUsageError("--output only applies when showing the workflow")
+	}
+	doneName, _ := fs.GetString("done")
+	workflow, err := domain.NewWorkflow(args[1:], doneName)
+	if err != nil {
+		return err
+	}
+
+	// Columns that keep their name keep their WIP limit
+	updated, err := env.ProjectService.SetWorkflow(project.ID, workflow.WithWIPLimitsFrom(project.Workflow))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Set workflow of %s to %s\n", updated.Name, strings.Join(updated.Workflow.Names(), " → "))
+	return nil
+}
+
+func runProjectWIP(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 3, 3)
+	if err != nil {
+		return err
+	}
+
+	project, err := resolveProject(env, args[0])
+	if err != nil {
+		return err
+	}
+	status, err := project.Workflow.Parse(args[1])
+	if err != nil {
+		return err
+	}
+	limit, err := strconv.Atoi(args[2])
+	if err != nil {
+		return domain.NewValidationError("wip_limit", fmt.Sprintf("invalid WIP limit %q", args[2]))
+	}
+
+	updated, err := env.ProjectService.SetWIPLimit(project.ID, status, limit)
+	if err != nil {
+		return err
+	}
+
+	name := updated.Workflow.Name(status)
+	if limit == 0 {
+		fmt.Fprintf(env.Out, "Removed the WIP limit of %s in %s\n", name, updated.Name)
+		return nil
+	}
+	fmt.Fprintf(env.Out, "Limited %s in %s to %d task(s)\n", name, updated.Name, limit)
+
+	// Existing tasks are not moved, so say when the column starts over its limit
+	tasks, err := env.TaskService.GetTasksByStatus(updated.ID, status)
+	if err != nil {
+		return err
+	}
+	if updated.Workflow.OverWIPLimit(status, len(tasks)) {
+		fmt.Fprintf(env.Out, "Warning: %s already holds %d task(s)\n", name, len(tasks))
+	}
+	return nil
+}
+
+// resolveProject finds a project by exact ID or case-insensitive name.
+// An empty ref selects the only project when exactly one exists.
+func resolveProject(env *Env, ref string) (*domain.Project, error) {
+	projects, err := env.ProjectService.GetAllProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	if ref == "" {
+		switch len(projects) {
+		case 0:
+			return nil, domain.NewValidationError("project", "no projects exist; create one with 'kahn project add'")
+		case 1:
+			return &projects[0], nil
+		default:
+			return nil, newUsageError("%d projects exist; choose one with --project", len(projects))
+		}
+	}
+
+	var matches []domain.Project
+	for _, project := range projects {
+		if project.ID == ref {
+			return &project, nil
+		}
+		if strings.EqualFold(project.Name, ref) {
+			matches = append(matches, project)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, domain.NewValidationError("project", fmt.Sprintf("project %q not found", ref))
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, domain.NewValidationError("project", fmt.Sprintf("project name %q is ambiguous; use the project ID", ref))
+	}
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"kahn/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectCommands(t *testing.T) {
+	env := setupTestEnv(t)
+
+	out := mustRunCLI(t, env, "project", "add", "Alpha", "--desc", "First project")
+	assert.Contains(t, out, "Created project Alpha")
+	mustRunCLI(t, env, "task", "add", "Task one")
+
+	out = mustRunCLI(t, env, "project", "list")
+	assert.Contains(t, out, "Alpha")
+	assert.Contains(t, out, "First project")
+
+	out = mustRunCLI(t, env, "project", "rename", "alpha", "Beta")
+	assert.Contains(t, out, "Renamed project Alpha to Beta")
+
+	code, _, _ := runCLI(t, env, "project", "rename", "Beta", strings.Repeat("x", domain.MaxProjectNameLength+1))
+	assert.Equal(t, ExitValidation, code)
+
+	mustRunCLI(t, env, "project", "rm", "Beta")
+	projects, err := env.ProjectService.GetAllProjects()
+	require.NoError(t, err)
+	assert.Empty(t, projects)
+
+	code, _, stderr := runCLI(t, env, "project", "rm", "Beta")
+	assert.Equal(t, ExitValidation, code)
+	assert.Contains(t, stderr, "not found")
+}
+
+func TestProjectAdd_EmptyNameIsValidationError(t *testing.T) {
+	env := setupTestEnv(t)
+
+	code, _, _ := runCLI(t, env, "project", "add", "  ")
+	assert.Equal(t, ExitValidation, code)
+}
+
+func TestProjectWorkflow(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Shipped")
+	mustRunCLI(t, env, "task", "move", "1", "done")
+
+	out := mustRunCLI(t, env, "project", "workflow", "Alpha")
+	assert.Equal(t, "Not Started\nIn Progress\nDone (done)\n", out)
+
+	out = mustRunCLI(t, env, "project", "workflow", "Alpha", "Backlog", "Ready", "In Progress", "In Review", "QA", "Done")
+	assert.Contains(t, out, "Backlog → Ready → In Progress → In Review → QA → Done")
+
+	out = mustRunCLI(t, env, "task", "list", "--status", "done")
+	assert.Contains(t, out, "Shipped", "Tasks follow their column by name")
+
+	mustRunCLI(t, env, "task", "add", "Review me")
+	out = mustRunCLI(t, env, "task", "move", "2", "in-review")
+	assert.Contains(t, out, "Moved task #2 Review me to In Review")
+	out = mustRunCLI(t, env, "task", "move", "2", "next")
+	assert.Contains(t, out, "to QA")
+
+	code, _, stderr := runCLI(t, env, "task", "move", "2", "todo")
+	assert.Equal(t, ExitValidation, code)
+	assert.Contains(t, stderr, "expected one of Backlog")
+
+	code, _, _ = runCLI(t, env, "project", "workflow", "Alpha", "Open", "Closed", "--done", "Released")
+	assert.Equal(t, ExitValidation, code)
+	code, _, _ = runCLI(t, env, "project", "workflow", "Alpha", "--done", "Done")
+	assert.Equal(t, ExitUsage, code)
+}
+
+func TestProjectWIP(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "First")
+	mustRunCLI(t, env, "task", "add", "Second")
+
+	out := mustRunCLI(t, env, "project", "wip", "Alpha", "in-progress", "1")
+	assert.Equal(t, "Limited In Progress in Alpha to 1 task(s)\n", out)
+	out = mustRunCLI(t, env, "project", "workflow", "Alpha")
+	assert.Contains(t, out, "In Progress (limit 1)")
+
+	mustRunCLI(t, env, "task", "move", "1", "next")
+	code, _, stderr := runCLI(t, env, "task", "move", "2", "in-progress")
+	assert.Equal(t, ExitValidation, code)
+	assert.Contains(t, stderr, "over its WIP limit (2/1)")
+
+	env.TaskService.SetWIPEnforcement(domain.WIPWarn)
+	out = mustRunCLI(t, env, "task", "move", "2", "next")
+	assert.Contains(t, out, "Moved task #2 Second to In Progress")
+	assert.Contains(t, out, "Warning: 'In Progress' is over its WIP limit (2/1)")
+
+	out = mustRunCLI(t, env, "project", "wip", "Alpha", "in-progress", "1")
+	assert.Contains(t, out, "Warning: In Progress already holds 2 task(s)")
+
+	mustRunCLI(t, env, "project", "workflow", "Alpha", "Backlog", "In Progress", "Done")
+	out = mustRunCLI(t, env, "project", "workflow", "Alpha")
+	assert.Contains(t, out, "In Progress (limit 1)", "Renamed workflows keep the limits of kept columns")
+
+	out = mustRunCLI(t, env, "project", "workflow", "Alpha", "-o", "json")
+	assert.JSONEq(t, `{"schema_version": 2, "project_id": "`+mustResolveProject(t, env).ID+`", "columns": [
+		{"status": 0, "name": "Backlog", "is_done": false, "wip_limit": 0},
+		{"status": 1, "name": "In Progress", "is_done": false, "wip_limit": 1},
+		{"status": 2, "name": "Done", "is_done": true, "wip_limit": 0}
+	], "done_column": "Done"}`, out)
+	out = mustRunCLI(t, env, "project", "workflow", "Alpha", "-o", "ndjson")
+	assert.Len(t, strings.Split(strings.TrimSpace(out), "\n"), 3, "One column per line")
+
+	out = mustRunCLI(t, env, "project", "wip", "Alpha", "in-progress", "0")
+	assert.Contains(t, out, "Removed the WIP limit of In Progress")
+
+	code, _, _ = runCLI(t, env, "project", "wip", "Alpha", "in-progress", "many")
+	assert.Equal(t, ExitValidation, code)
+}
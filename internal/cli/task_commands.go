@@ -0,0 +1,673 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"kahn/internal/domain"
+	"kahn/internal/formats"
+
+	"github.com/spf13/pflag"
+)
+
+func taskCommands() []*command {
+	return []*command{
+		{
+			name:    "task add",
+			args:    "<name>",
+			summary: "Create a task",
+			flags: func(fs *pflag.FlagSet) {
+				fs.StringP("project", "p", "", "Project ID or name (optional when only one project exists)")
+				fs.StringP("desc", "d", "", "Task description")
+				fs.StringP("type", "t", "task", "Task type: task, bug or feature")
+				fs.String("priority", "low", "Priority: low, medium or high")
+				fs.StringSliceP("blocked-by", "b", nil, "Number of a task blocking this one; repeat or comma separate for several")
+				fs.StringSliceP("label", "l", nil, "Label to attach; repeat or comma separate for several")
+				fs.String("due", "", "Due date: YYYY-MM-DD, today, tomorrow, +3d, +2w, +1m or a weekday")
+				fs.String("start", "", "Start date, in the same forms as --due")
+			},
+			run: runTaskAdd,
+		},
+		{
+			name:    "task list",
+			summary: "List the tasks of a project in board order",
+			flags: func(fs *pflag.FlagSet) {
+				fs.StringP("project", "p", "", "Project ID or name (optional when only one project exists)")
+				fs.StringP("status", "s", "", "Only list tasks with this status")
+				fs.StringSliceP("label", "l", nil, "Only list tasks carrying this label; repeat to require several")
+				fs.StringP("query", "q", "", `Only list tasks matching a search query, as typed after / on the board (e.g. "type:bug priority:high -flaky")`)
+				fs.String("view", "", "Only list tasks matching a saved view of the project or a global one")
+				fs.Bool("archived", false, "List the archived tasks instead, most recently archived first")
+				addOutputFlag(fs)
+			},
+			run: runTaskList,
+		},
+		{
+			name:    "task show",
+			args:    "<task>",
+			summary: "Show every field of a task",
+			flags:   addOutputFlag,
+			run:     runTaskShow,
+		},
+		{
+			name:    "task edit",
+			args:    "<task>",
+			summary: "Change the fields of a task",
+			flags: func(fs *pflag.FlagSet) {
+				fs.StringP("name", "n", "", "New task name")
+				fs.StringP("desc", "d", "", "New task description")
+				fs.StringP("type", "t", "", "New task type: task, bug or feature")
+				fs.String("priority", "", "New priority: low, medium or high")
+				fs.StringSliceP("blocked-by", "b", nil, "Replace the blocking tasks; repeat or comma separate, or 'none' to clear")
+				fs.StringSliceP("label", "l", nil, "Replace the task's labels; repeat or comma separate, or 'none' to clear")
+				fs.String("due", "", "New due date (YYYY-MM-DD, today, +3d, fri, ...), or 'none' to clear")
+				fs.String("start", "", "New start date, in the same forms as --due, or 'none' to clear")
+			},
+			run: runTaskEdit,
+		},
+		{
+			name:    "task block",
+			args:    "<task> <blocker>...",
+			summary: "Make a task wait on other tasks",
+			run:     runTaskBlock,
+		},
+		{
+			name:    "task unblock",
+			args:    "<task> <blocker>...",
+			summary: "Stop a task waiting on other tasks",
+			run:     runTaskUnblock,
+		},
+		{
+			name:    "task move",
+			args:    "<task> <status|next|prev>",
+			summary: "Move a task to another status column",
+			run:     runTaskMove,
+		},
+		{
+			name:    "task rm",
+			args:    "<task>",
+			summary: "Move a task to the trash",
+			run:     runTaskRemove,
+		},
+	}
+}
+
+func runTaskAdd(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	projectRef, _ := fs.GetString("project")
+	project, err := resolveProject(env, projectRef)
+	if err != nil {
+		return err
+	}
+
+	desc, _ := fs.GetString("desc")
+	typeName, _ := fs.GetString("type")
+	taskType, err := domain.ParseTaskType(typeName)
+	if err != nil {
+		return err
+	}
+	priorityName, _ := fs.GetString("priority")
+	priority, err := domain.ParsePriority(priorityName)
+	if err != nil {
+		return err
+	}
+
+	blockedByRefs, _ := fs.GetStringSlice("blocked-by")
+	blockedBy, err := resolveBlockers(env, blockedByRefs, project.ID)
+	if err != nil {
+		return err
+	}
+
+	labels, _ := fs.GetStringSlice("label")
+	labels = domain.ParseLabelNames(strings.Join(labels, ","))
+	for _, label := range labels {
+		if err := domain.ValidateLabelName(label); err != nil {
+			return err
+		}
+	}
+
+	startDate, err := dateFlag(fs, "start")
+	if err != nil {
+		return err
+	}
+	dueDate, err := dateFlag(fs, "due")
+	if err != nil {
+		return err
+	}
+	if err := domain.ValidateDateRange(startDate, dueDate); err != nil {
+		return err
+	}
+
+	task, err := env.TaskService.CreateTask(args[0], desc, project.ID, taskType, priority, blockedBy)
+	if err != nil {
+		return err
+	}
+	if len(labels) > 0 {
+		if _, err := env.LabelService.SetTaskLabels(task.ID, labels); err != nil {
+			return err
+		}
+	}
+	if startDate != nil || dueDate != nil {
+		if _, err := env.TaskService.SetTaskDates(task.ID, startDate, dueDate); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(env.Out, "Created task #%d %s\n", task.IntID, task.Name)
+	return nil
+}
+
+func runTaskList(env *Env, fs *pflag.FlagSet) error {
+	if _, err := requireArgs(fs, 0, 0); err != nil {
+		return err
+	}
+	format, err := outputFormat(fs)
+	if err != nil {
+		return err
+	}
+
+	projectRef, _ := fs.GetString("project")
+	project, err := resolveProject(env, projectRef)
+	if err != nil {
+		return err
+	}
+
+	statuses := project.Workflow.Statuses()
+	if statusName, _ := fs.GetString("status"); statusName != "" {
+		status, err := project.Workflow.Parse(statusName)
+		if err != nil {
+			return err
+		}
+		statuses = []domain.Status{status}
+	}
+
+	var ordered []domain.Task
+	if archived, _ := fs.GetBool("archived"); archived {
+		ordered, err = archivedTasks(env, project, statuses)
+	} else {
+		ordered, err = tasksInBoardOrder(env, project, statuses)
+	}
+	if err != nil {
+		return err
+	}
+	if labels, _ := fs.GetStringSlice("label"); len(labels) > 0 {
+		ordered = filterByLabels(ordered, labels)
+	}
+	if viewName, _ := fs.GetString("view"); viewName != "" {
+		view, err := env.ViewService.GetViewByName(project.ID, viewName)
+		if err != nil {
+			return err
+		}
+		filter, err := domain.ParseTaskQuery(view.Query, project.Workflow, time.Now())
+		if err != nil {
+			return err
+		}
+		ordered = filter.Filter(ordered)
+	}
+	if query, _ := fs.GetString("query"); query != "" {
+		filter, err := domain.ParseTaskQuery(query, project.Workflow, time.Now())
+		if err != nil {
+			return err
+		}
+		ordered = filter.Filter(ordered)
+	}
+
+	switch format {
+	case outputJSON:
+		return writeJSON(env.Out, formats.NewTaskListDocument(ordered, project.Workflow))
+	case outputNDJSON:
+		return writeNDJSON(env.Out, formats.NewTaskListDocument(ordered, project.Workflow).Tasks)
+	}
+
+	rows := make([][]string, len(ordered))
+	for i, task := range ordered {
+		rows[i] = []string{
+			fmt.Sprintf("#%d", task.IntID), project.Workflow.Name(task.Status), task.Type.String(),
+			task.Priority.String(), formatBlockedBy(task.BlockedBy), task.Name, formatLabels(task.LabelNames()),
+			formatDueDate(task, project.Workflow),
+		}
+	}
+	return writeRows(env.Out, format, []string{"ID", "STATUS", "TYPE", "PRIORITY", "BLOCKED BY", "NAME", "LABELS", "DUE"}, rows)
+}
+
+func runTaskShow(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 1, 1)
+	if err != nil {
+		return err
+	}
+	format, err := outputFormat(fs)
+	if err != nil {
+		return err
+	}
+
+	task, err := resolveTask(env, args[0])
+	if err != nil {
+		return err
+	}
+
+	project, err := env.ProjectService.GetProject(task.ProjectID)
+	if err != nil {
+		return err
+	}
+	projectName := task.ProjectID
+	var workflow domain.Workflow
+	if project != nil {
+		projectName = project.Name
+		workflow = project.Workflow
+	}
+
+	switch format {
+	case outputJSON:
+		return writeJSON(env.Out, formats.NewTaskRecord(*task, workflow))
+	case outputNDJSON:
+		return writeNDJSON(env.Out, []formats.TaskRecord{formats.NewTaskRecord(*task, workflow)})
+	}
+
+	fields := [][]string{
+		{"Task:", fmt.Sprintf("#%d (%s)", task.IntID, task.ID)},
+		{"Name:", task.Name},
+		{"Project:", projectName},
+		{"Status:", workflow.Name(task.Status)},
+		{"Type:", task.Type.String()},
+		{"Priority:", task.Priority.String()},
+		{"Blocked by:", formatBlockedBy(task.BlockedBy)},
+		{"Labels:", formatLabels(task.LabelNames())},
+		{"Checklist:", formatChecklist(*task)},
+		{"Start:", formatDate(task.StartDate)},
+		{"Due:", formatDueDate(*task, workflow)},
+		{"Created:", task.CreatedAt.Format(time.RFC3339)},
+		{"Updated:", task.UpdatedAt.Format(time.RFC3339)},
+	}
+
+	w := io.Writer(env.Out)
+	var tw *tabwriter.Writer
+	if format == outputTable {
+		tw = tabwriter.NewWriter(env.Out, 0, 4, 1, ' ', 0)
+		w = tw
+	}
+	for _, field := range fields {
+		fmt.Fprintln(w, strings.Join(field, "\t"))
+	}
+	if tw != nil {
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if task.Desc != "" {
+		fmt.Fprintf(env.Out, "\n%s\n", task.Desc)
+	}
+	if len(task.Comments) > 0 {
+		fmt.Fprintf(env.Out, "\nComments (%d):\n", len(task.Comments))
+		writeComments(env.Out, task.Comments)
+	}
+	return nil
+}
+
+// taskEditFlags are the flags of task edit that change the task
+var taskEditFlags = []string{"name", "desc", "type", "priority", "blocked-by", "label", "due", "start"}
+
+func runTaskEdit(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	task, err := resolveTask(env, args[0])
+	if err != nil {
+		return err
+	}
+
+	if !changedAny(fs, taskEditFlags...) {
+		return newUsageError("nothing to change; pass at least one of --name, --desc, --type, --priority, --blocked-by, --label, --due, --start")
+	}
+
+	// Parse every flag before writing, so a bad value changes nothing
+	taskType, priority := task.Type, task.Priority
+	if fs.Changed("type") {
+		typeName, _ := fs.GetString("type")
+		if taskType, err = domain.ParseTaskType(typeName); err != nil {
+			return err
+		}
+	}
+	if fs.Changed("priority") {
+		priorityName, _ := fs.GetString("priority")
+		if priority, err = domain.ParsePriority(priorityName); err != nil {
+			return err
+		}
+	}
+	blockedBy := task.BlockedBy
+	if fs.Changed("blocked-by") {
+		blockedByRefs, _ := fs.GetStringSlice("blocked-by")
+		blockedBy = nil
+		if len(blockedByRefs) != 1 || !strings.EqualFold(blockedByRefs[0], "none") {
+			if blockedBy, err = parseTaskNumbers(blockedByRefs); err != nil {
+				return err
+			}
+		}
+	}
+	startDate, dueDate := task.StartDate, task.DueDate
+	if fs.Changed("start") {
+		if startDate, err = dateFlag(fs, "start"); err != nil {
+			return err
+		}
+	}
+	if fs.Changed("due") {
+		if dueDate, err = dateFlag(fs, "due"); err != nil {
+			return err
+		}
+	}
+	var labelNames []string
+	if fs.Changed("label") {
+		labels, _ := fs.GetStringSlice("label")
+		labelNames = domain.ParseLabelNames(strings.Join(labels, ","))
+		if len(labelNames) == 1 && strings.EqualFold(labelNames[0], "none") {
+			labelNames = nil
+		}
+		if len(labelNames) > domain.MaxLabelsPerTask {
+			return domain.NewValidationError("labels", fmt.Sprintf("too many labels (max %d)", domain.MaxLabelsPerTask))
+		}
+		for _, name := range labelNames {
+			if err := domain.ValidateLabelName(name); err != nil {
+				return err
+			}
+		}
+	}
+
+	updated, err := env.TaskService.EditTask(task.ID, func(edited *domain.Task) {
+		if fs.Changed("name") {
+			edited.Name, _ = fs.GetString("name")
+		}
+		if fs.Changed("desc") {
+			edited.Desc, _ = fs.GetString("desc")
+		}
+		edited.Type, edited.Priority = taskType, priority
+		edited.BlockedBy = blockedBy
+		edited.StartDate, edited.DueDate = startDate, dueDate
+	})
+	if err != nil {
+		return err
+	}
+
+	if fs.Changed("label") {
+		if _, err := env.LabelService.SetTaskLabels(task.ID, labelNames); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(env.Out, "Updated task #%d %s\n", updated.IntID, updated.Name)
+	return nil
+}
+
+func runTaskBlock(env *Env, fs *pflag.FlagSet) error {
+	return changeTaskBlockers(env, fs, "now waits on", env.TaskService.AddTaskBlockers)
+}
+
+func runTaskUnblock(env *Env, fs *pflag.FlagSet) error {
+	return changeTaskBlockers(env, fs, "no longer waits on", env.TaskService.RemoveTaskBlockers)
+}
+
+// changeTaskBlockers applies change to the task named by the first argument with
+// the blockers named by the rest
+func changeTaskBlockers(env *Env, fs *pflag.FlagSet, verb string, change func(taskID string, blockers ...int) (*domain.Task, error)) error {
+	args, err := requireArgs(fs, 2, -1)
+	if err != nil {
+		return err
+	}
+
+	task, err := resolveTask(env, args[0])
+	if err != nil {
+		return err
+	}
+	blockers, err := parseTaskNumbers(args[1:])
+	if err != nil {
+		return err
+	}
+
+	updated, err := change(task.ID, blockers...)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Task #%d %s %s; blocked by: %s\n", updated.IntID, verb, domain.FormatBlockers(blockers), formatBlockedBy(updated.BlockedBy))
+	return nil
+}
+
+func runTaskMove(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 2, 2)
+	if err != nil {
+		return err
+	}
+
+	task, err := resolveTask(env, args[0])
+	if err != nil {
+		return err
+	}
+
+	project, err := env.ProjectService.GetProject(task.ProjectID)
+	if err != nil {
+		return err
+	}
+	var workflow domain.Workflow
+	if project != nil {
+		workflow = project.Workflow
+	}
+
+	var moved *domain.Task
+	switch strings.ToLower(args[1]) {
+	case "next":
+		moved, err = env.TaskService.MoveTaskToNextStatus(task.ID)
+	case "prev", "previous":
+		moved, err = env.TaskService.MoveTaskToPreviousStatus(task.ID)
+	default:
+		status, parseErr := workflow.Parse(args[1])
+		if parseErr != nil {
+			return parseErr
+		}
+		moved, err = env.TaskService.UpdateTaskStatus(task.ID, status)
+	}
+	if moved == nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Moved task #%d %s to %s\n", moved.IntID, moved.Name, workflow.Name(moved.Status))
+	if err != nil {
+		// With wip_enforcement = "warn" the move is saved and err is the warning
+		fmt.Fprintf(env.Out, "Warning: %v\n", err)
+	}
+	return nil
+}
+
+func runTaskRemove(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	task, err := resolveTask(env, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := env.TaskService.DeleteTask(task.ID); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Moved task #%d %s to the trash\n", task.IntID, task.Name)
+	return nil
+}
+
+// tasksInBoardOrder returns the project's tasks column by column, sorted the way the board shows them
+func tasksInBoardOrder(env *Env, project *domain.Project, statuses []domain.Status) ([]domain.Task, error) {
+	tasks, err := env.TaskService.GetTasksByProject(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	project.Tasks = tasks
+
+	var ordered []domain.Task
+	for _, status := range statuses {
+		ordered = append(ordered, project.GetTasksByStatus(status)...)
+	}
+	return ordered, nil
+}
+
+// archivedTasks returns the project's archived tasks with one of statuses
+func archivedTasks(env *Env, project *domain.Project, statuses []domain.Status) ([]domain.Task, error) {
+	tasks, err := env.TaskService.GetArchivedTasks(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	var archived []domain.Task
+	for _, task := range tasks {
+		if slices.Contains(statuses, task.Status) {
+			archived = append(archived, task)
+		}
+	}
+	return archived, nil
+}
+
+// resolveTask accepts either the numeric task number ("12" or "#12") or the full task ID
+func resolveTask(env *Env, ref string) (*domain.Task, error) {
+	if intID, err := parseTaskNumber(ref); err == nil {
+		return env.TaskService.GetTaskByIntID(*intID)
+	}
+
+	task, err := env.TaskService.GetTask(ref)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, domain.NewValidationError("id", fmt.Sprintf("task %q not found", ref))
+	}
+	return task, nil
+}
+
+// resolveBlockers validates --blocked-by references up front so a task is never
+// created pointing at a missing task or one from another project
+func resolveBlockers(env *Env, refs []string, projectID string) ([]int, error) {
+	intIDs, err := parseTaskNumbers(refs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, intID := range intIDs {
+		blocker, err := env.TaskService.GetTaskByIntID(intID)
+		if err != nil {
+			return nil, err
+		}
+		if blocker.ProjectID != projectID {
+			return nil, domain.NewValidationError("blocked_by", "blocking task must be in the same project")
+		}
+	}
+	return intIDs, nil
+}
+
+// parseTaskNumbers reads task numbers given as separate values or separated by
+// commas or spaces within one
+func parseTaskNumbers(refs []string) ([]int, error) {
+	var intIDs []int
+	for _, ref := range refs {
+		for _, field := range strings.FieldsFunc(ref, func(r rune) bool { return r == ',' || r == ' ' }) {
+			intID, err := parseTaskNumber(field)
+			if err != nil {
+				return nil, err
+			}
+			intIDs = append(intIDs, *intID)
+		}
+	}
+	return domain.NormalizeBlockers(intIDs), nil
+}
+
+func parseTaskNumber(ref string) (*int, error) {
+	intID, err := strconv.Atoi(strings.TrimPrefix(ref, "#"))
+	if err != nil || intID <= 0 {
+		return nil, domain.NewValidationError("id", fmt.Sprintf("invalid task number %q", ref))
+	}
+	return &intID, nil
+}
+
+// filterByLabels keeps the tasks that carry every one of the named labels
+func filterByLabels(tasks []domain.Task, labels []string) []domain.Task {
+	filtered := make([]domain.Task, 0, len(tasks))
+	for _, task := range tasks {
+		matches := true
+		for _, label := range labels {
+			if !task.HasLabel(label) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, task)
+		}
+	}
+	return filtered
+}
+
+func formatLabels(labels []string) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+	return strings.Join(labels, ",")
+}
+
+// changedAny reports whether any of the named flags was given
+func changedAny(fs *pflag.FlagSet, names ...string) bool {
+	for _, name := range names {
+		if fs.Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// dateFlag parses a date flag relative to today; "none" and an empty value
+// both mean no date
+func dateFlag(fs *pflag.FlagSet, name string) (*time.Time, error) {
+	value, _ := fs.GetString(name)
+	if strings.EqualFold(value, "none") {
+		return nil, nil
+	}
+	return domain.ParseDate(value, time.Now())
+}
+
+func formatDate(date *time.Time) string {
+	if date == nil {
+		return "-"
+	}
+	return domain.FormatDate(date)
+}
+
+// formatDueDate prints the due date, flagging it when the task is late. Tasks
+// in the done column are never late.
+func formatDueDate(task domain.Task, workflow domain.Workflow) string {
+	due := formatDate(task.DueDate)
+	if task.IsOverdue(time.Now()) && !workflow.IsDone(task.Status) {
+		due += " (overdue)"
+	}
+	return due
+}
+
+func formatChecklist(task domain.Task) string {
+	if task.ChecklistTotal == 0 {
+		return "-"
+	}
+	return task.ChecklistProgress() + " done"
+}
+
+func formatBlockedBy(blockedBy []int) string {
+	if len(blockedBy) == 0 {
+		return "-"
+	}
+	return domain.FormatBlockers(blockedBy)
+}
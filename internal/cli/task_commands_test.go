@@ -0,0 +1,257 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"kahn/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskAdd(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+
+	t.Run("creates task with flags", func(t *testing.T) {
+		out := mustRunCLI(t, env, "task", "add", "Fix login", "--type", "bug", "--priority", "high", "--desc", "Crashes on submit")
+		assert.Contains(t, out, "Created task #1 Fix login")
+
+		task, err := env.TaskService.GetTaskByIntID(1)
+		require.NoError(t, err)
+		assert.Equal(t, domain.Bug, task.Type)
+		assert.Equal(t, domain.High, task.Priority)
+		assert.Equal(t, "Crashes on submit", task.Desc)
+	})
+
+	t.Run("blocked-by must reference an existing task", func(t *testing.T) {
+		code, _, stderr := runCLI(t, env, "task", "add", "Orphan", "--blocked-by", "99")
+		assert.Equal(t, ExitValidation, code)
+		assert.Contains(t, stderr, "not found")
+	})
+
+	t.Run("name over max length is a validation error", func(t *testing.T) {
+		longName := strings.Repeat("x", domain.MaxTaskNameLength+1)
+		code, _, _ := runCLI(t, env, "task", "add", longName)
+		assert.Equal(t, ExitValidation, code)
+	})
+
+	t.Run("unknown priority is a validation error", func(t *testing.T) {
+		code, _, stderr := runCLI(t, env, "task", "add", "Task", "--priority", "urgent")
+		assert.Equal(t, ExitValidation, code)
+		assert.Contains(t, stderr, "unknown priority")
+	})
+
+	t.Run("missing name is a usage error", func(t *testing.T) {
+		code, _, stderr := runCLI(t, env, "task", "add")
+		assert.Equal(t, ExitUsage, code)
+		assert.Contains(t, stderr, "usage: kahn task add")
+	})
+}
+
+func TestTaskAdd_RequiresProjectWhenSeveralExist(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "project", "add", "Beta")
+
+	code, _, stderr := runCLI(t, env, "task", "add", "Ambiguous")
+	assert.Equal(t, ExitUsage, code)
+	assert.Contains(t, stderr, "--project")
+
+	out := mustRunCLI(t, env, "task", "add", "Scoped", "--project", "beta")
+	assert.Contains(t, out, "Created task")
+}
+
+func TestTaskList(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Low task")
+	mustRunCLI(t, env, "task", "add", "High task", "--priority", "high")
+	mustRunCLI(t, env, "task", "add", "Started task")
+	mustRunCLI(t, env, "task", "move", "3", "inprogress")
+
+	out := mustRunCLI(t, env, "task", "list")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 4, "Header plus three tasks")
+	assert.Contains(t, lines[1], "High task", "Not Started tasks are sorted by priority first")
+	assert.Contains(t, lines[2], "Low task")
+	assert.Contains(t, lines[3], "Started task", "In Progress column follows Not Started")
+
+	out = mustRunCLI(t, env, "task", "list", "--status", "in-progress")
+	assert.Contains(t, out, "Started task")
+	assert.NotContains(t, out, "Low task")
+}
+
+func TestTaskList_Query(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Login times out", "--type", "bug", "--priority", "high")
+	mustRunCLI(t, env, "task", "add", "Flaky login test", "--type", "bug", "--desc", "Fails on CI")
+	mustRunCLI(t, env, "task", "add", "Dark mode", "--type", "feature", "--blocked-by", "#1")
+
+	out := mustRunCLI(t, env, "task", "list", "--query", "type:bug -flaky")
+	assert.Contains(t, out, "Login times out")
+	assert.NotContains(t, out, "Flaky login test")
+
+	out = mustRunCLI(t, env, "task", "list", "-q", `blocked:yes OR desc:"on ci"`)
+	assert.Contains(t, out, "Dark mode")
+	assert.Contains(t, out, "Flaky login test")
+	assert.NotContains(t, out, "Login times out")
+
+	code, _, stderr := runCLI(t, env, "task", "list", "--query", "priority:urgent")
+	assert.Equal(t, ExitValidation, code)
+	assert.Contains(t, stderr, `unknown priority "urgent"`)
+}
+
+func TestTaskShow(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Blocker")
+	mustRunCLI(t, env, "task", "add", "Blocked", "--blocked-by", "#1", "--desc", "Waiting on the blocker")
+
+	out := mustRunCLI(t, env, "task", "show", "2")
+	assert.Contains(t, out, "Blocked")
+	assert.Contains(t, out, "Alpha")
+	assert.Contains(t, out, "#1")
+	assert.Contains(t, out, "Waiting on the blocker")
+
+	code, _, _ := runCLI(t, env, "task", "show", "task_missing")
+	assert.Equal(t, ExitValidation, code)
+}
+
+func TestTaskEdit(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Blocker")
+	mustRunCLI(t, env, "task", "add", "Original", "--desc", "Keep me")
+
+	mustRunCLI(t, env, "task", "edit", "2", "--name", "Renamed", "--priority", "medium", "--blocked-by", "1")
+	task, err := env.TaskService.GetTaskByIntID(2)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", task.Name)
+	assert.Equal(t, "Keep me", task.Desc, "Unspecified fields are left unchanged")
+	assert.Equal(t, domain.Medium, task.Priority)
+	assert.Equal(t, []int{1}, task.BlockedBy)
+
+	mustRunCLI(t, env, "task", "edit", "2", "--blocked-by", "none")
+	task, err = env.TaskService.GetTaskByIntID(2)
+	require.NoError(t, err)
+	assert.Empty(t, task.BlockedBy)
+
+	code, _, _ := runCLI(t, env, "task", "edit", "2", "--blocked-by", "2")
+	assert.Equal(t, ExitValidation, code, "A task cannot block itself")
+
+	code, _, _ = runCLI(t, env, "task", "edit", "2")
+	assert.Equal(t, ExitUsage, code, "Editing without changes is a usage error")
+
+	code, _, _ = runCLI(t, env, "task", "edit", "2", "--db-path", "other.db")
+	assert.Equal(t, ExitUsage, code, "Global flags are not changes")
+
+	mustRunCLI(t, env, "task", "edit", "1", "--blocked-by", "2")
+	code, _, _ = runCLI(t, env, "task", "edit", "2", "--name", "Cyclic", "--blocked-by", "1")
+	assert.Equal(t, ExitValidation, code)
+	task, err = env.TaskService.GetTaskByIntID(2)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", task.Name, "A refused edit changes nothing")
+
+	code, _, _ = runCLI(t, env, "task", "edit", "2", "--name", "Late", "--due", "not a date")
+	assert.Equal(t, ExitValidation, code)
+	task, err = env.TaskService.GetTaskByIntID(2)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", task.Name, "A bad value changes nothing")
+}
+
+func TestTaskBlockers(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Design")
+	mustRunCLI(t, env, "task", "add", "Build")
+	mustRunCLI(t, env, "task", "add", "Ship", "--blocked-by", "1,2")
+
+	task, err := env.TaskService.GetTaskByIntID(3)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, task.BlockedBy)
+
+	out := mustRunCLI(t, env, "task", "unblock", "3", "1")
+	assert.Contains(t, out, "blocked by: #2")
+
+	out = mustRunCLI(t, env, "task", "block", "2", "#1")
+	assert.Contains(t, out, "Task #2 now waits on #1")
+
+	code, _, stderr := runCLI(t, env, "task", "block", "1", "3")
+	assert.Equal(t, ExitValidation, code, "#1 → #3 → #2 → #1 is a cycle")
+	assert.Contains(t, stderr, "dependency cycle: #1 → #3 → #2 → #1")
+
+	code, _, _ = runCLI(t, env, "task", "block", "3")
+	assert.Equal(t, ExitUsage, code, "At least one blocker is required")
+}
+
+func TestTaskDates(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Late", "--due", "2020-01-01")
+	mustRunCLI(t, env, "task", "add", "Planned", "--start", "2030-01-01", "--due", "2030-02-01")
+
+	task, err := env.TaskService.GetTaskByIntID(2)
+	require.NoError(t, err)
+	assert.Equal(t, "2030-01-01", domain.FormatDate(task.StartDate))
+	assert.Equal(t, "2030-02-01", domain.FormatDate(task.DueDate))
+
+	out := mustRunCLI(t, env, "task", "show", "1")
+	assert.Contains(t, out, "2020-01-01 (overdue)")
+
+	out = mustRunCLI(t, env, "task", "list", "-o", "plain")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasSuffix(lines[0], "\t2020-01-01 (overdue)"), "Overdue tasks float to the top: %q", lines[0])
+
+	// Editing one date keeps the other; "none" clears
+	mustRunCLI(t, env, "task", "edit", "2", "--due", "none")
+	task, err = env.TaskService.GetTaskByIntID(2)
+	require.NoError(t, err)
+	assert.Equal(t, "2030-01-01", domain.FormatDate(task.StartDate))
+	assert.Nil(t, task.DueDate)
+
+	code, _, stderr := runCLI(t, env, "task", "add", "Bad", "--due", "someday")
+	assert.Equal(t, ExitValidation, code)
+	assert.Contains(t, stderr, "invalid date")
+
+	code, _, _ = runCLI(t, env, "task", "edit", "2", "--due", "2029-12-31")
+	assert.Equal(t, ExitValidation, code, "Start date cannot be after the due date")
+}
+
+func TestTaskMove(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Blocker")
+	mustRunCLI(t, env, "task", "add", "Blocked", "--blocked-by", "1")
+
+	out := mustRunCLI(t, env, "task", "move", "1", "next")
+	assert.Contains(t, out, "In Progress")
+
+	out = mustRunCLI(t, env, "task", "move", "1", "done")
+	assert.Contains(t, out, "Done")
+
+	blocked, err := env.TaskService.GetTaskByIntID(2)
+	require.NoError(t, err)
+	assert.Empty(t, blocked.BlockedBy, "Moving the blocker to Done unblocks dependents")
+
+	out = mustRunCLI(t, env, "task", "move", "1", "prev")
+	assert.Contains(t, out, "In Progress")
+
+	code, _, _ := runCLI(t, env, "task", "move", "1", "sideways")
+	assert.Equal(t, ExitValidation, code)
+}
+
+func TestTaskRemove(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Doomed")
+
+	out := mustRunCLI(t, env, "task", "rm", "1")
+	assert.Contains(t, out, "Moved task #1 Doomed to the trash")
+
+	code, _, _ := runCLI(t, env, "task", "rm", "1")
+	assert.Equal(t, ExitValidation, code)
+}
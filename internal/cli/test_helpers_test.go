@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"kahn/internal/config"
+	"kahn/internal/database"
+	"kahn/internal/domain"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTestEnv creates an Env backed by an in-memory database
+func setupTestEnv(t *testing.T) *Env {
+	t.Helper()
+
+	cfg := &config.Config{}
+	cfg.Database.Path = ":memory:"
+	cfg.Database.BusyTimeout = 5000
+	cfg.Database.JournalMode = "WAL"
+	cfg.Database.CacheSize = 10000
+	cfg.Database.ForeignKeys = true
+
+	db, err := database.NewDatabase(cfg)
+	require.NoError(t, err, "Failed to create test database")
+	t.Cleanup(func() { db.Close() })
+
+	return NewEnv(db, io.Discard)
+}
+
+// runCLI executes args against env and returns the exit code with captured output
+func runCLI(t *testing.T, env *Env, args ...string) (int, string, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	open := func(_ *pflag.FlagSet, out io.Writer) (*Env, func(), error) {
+		env.Out = out
+		return env, func() {}, nil
+	}
+	code := run(args, &stdout, &stderr, open)
+	return code, stdout.String(), stderr.String()
+}
+
+// mustRunCLI executes args and fails the test unless the command succeeds
+func mustRunCLI(t *testing.T, env *Env, args ...string) string {
+	t.Helper()
+
+	code, stdout, stderr := runCLI(t, env, args...)
+	require.Equal(t, ExitOK, code, "command %v failed: %s", args, stderr)
+	return stdout
+}
+
+// mustResolveProject returns the only project in env
+func mustResolveProject(t *testing.T, env *Env) *domain.Project {
+	t.Helper()
+
+	project, err := resolveProject(env, "")
+	require.NoError(t, err)
+	return project
+}
+
+// writeTestFile writes content to a file in a temporary directory and returns its path
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
@@ -0,0 +1,288 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"kahn/internal/domain"
+	"kahn/internal/formats"
+
+	"github.com/spf13/pflag"
+)
+
+func todoTxtCommands() []*command {
+	return []*command{
+		{
+			name:    "todotxt export",
+			args:    "[file]",
+			summary: "Write a project's tasks in todo.txt format (stdout by default)",
+			flags: func(fs *pflag.FlagSet) {
+				fs.StringP("project", "p", "", "Project ID or name (optional when only one project exists)")
+			},
+			run: runTodoTxtExport,
+		},
+		{
+			name:    "todotxt import",
+			args:    "<file>",
+			summary: "Create or update a project's tasks from a todo.txt file",
+			flags: func(fs *pflag.FlagSet) {
+				fs.StringP("project", "p", "", "Project ID or name (optional when only one project exists)")
+				fs.Bool("dry-run", false, "Validate every line and report what would change without writing")
+			},
+			run: runTodoTxtImport,
+		},
+	}
+}
+
+func runTodoTxtExport(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 0, 1)
+	if err != nil {
+		return err
+	}
+
+	projectRef, _ := fs.GetString("project")
+	project, err := resolveProject(env, projectRef)
+	if err != nil {
+		return err
+	}
+
+	if project.Tasks, err = env.TaskService.GetTasksByProject(project.ID); err != nil {
+		return err
+	}
+
+	if len(args) == 0 || args[0] == "-" {
+		return formats.WriteTodoTxt(env.Out, *project)
+	}
+
+	file, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+	if err := formats.WriteTodoTxt(file, *project); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Exported %d task(s) from %s to %s\n", len(project.Tasks), project.Name, args[0])
+	return nil
+}
+
+// todoTxtImportItem is a validated todo.txt line; existing is nil for lines that
+// create a new task
+type todoTxtImportItem struct {
+	item     formats.TodoItem
+	existing *domain.Task
+}
+
+func runTodoTxtImport(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	projectRef, _ := fs.GetString("project")
+	project, err := resolveProject(env, projectRef)
+	if err != nil {
+		return err
+	}
+
+	existing, err := env.TaskService.GetTasksByProject(project.ID)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	items, err := formats.ParseTodoTxt(file, project.Workflow)
+	if err != nil {
+		return err
+	}
+
+	planned, skipped, lineErrors := planTodoTxtImport(project, existing, items)
+	wipErrors, wipWarnings, err := todoTxtImportWIP(env, project.ID, planned)
+	if err != nil {
+		return err
+	}
+	lineErrors = append(lineErrors, wipErrors...)
+	for _, lineErr := range lineErrors {
+		fmt.Fprintln(env.Out, lineErr)
+	}
+	for _, warning := range wipWarnings {
+		fmt.Fprintf(env.Out, "Warning: %s\n", warning)
+	}
+	if len(lineErrors) > 0 {
+		return domain.NewValidationError("todo.txt", fmt.Sprintf("%d of %d line(s) failed validation; nothing was imported", len(lineErrors), len(items)))
+	}
+
+	var creates, updates int
+	for _, planItem := range planned {
+		if planItem.existing == nil {
+			creates++
+		} else if todoTxtChanged(planItem) {
+			updates++
+		}
+	}
+
+	if dryRun, _ := fs.GetBool("dry-run"); dryRun {
+		fmt.Fprintf(env.Out, "Dry run: would create %d and update %d task(s) in %s; %d line(s) belong to other projects\n", creates, updates, project.Name, skipped)
+		return nil
+	}
+
+	for _, planItem := range planned {
+		if err := applyTodoTxtItem(env, project.ID, planItem); err != nil {
+			return fmt.Errorf("line %d: %w", planItem.item.Line, err)
+		}
+	}
+
+	fmt.Fprintf(env.Out, "Created %d and updated %d task(s) in %s; skipped %d line(s) for other projects\n", creates, updates, project.Name, skipped)
+	return nil
+}
+
+// planTodoTxtImport matches lines to existing tasks by their id: key. A line without
+// a known one, such as a line added by hand, matches a task with the same name in
+// the same column that no other line claims, so importing the file again does not duplicate
+// it. Lines tagged only with other +projects are skipped so a shared todo.txt can
+// be imported per project.
+func planTodoTxtImport(project *domain.Project, existing []domain.Task, items []formats.TodoItem) ([]todoTxtImportItem, int, []string) {
+	byID := make(map[string]*domain.Task, len(existing))
+	for i := range existing {
+		byID[existing[i].ID] = &existing[i]
+	}
+	claimed := make(map[string]bool, len(items))
+	for _, item := range items {
+		if byID[item.ID] != nil {
+			claimed[item.ID] = true
+		}
+	}
+	matchByName := func(item formats.TodoItem) *domain.Task {
+		for i := range existing {
+			task := &existing[i]
+			if !claimed[task.ID] && task.Name == item.Name && task.Status == item.Status {
+				claimed[task.ID] = true
+				return task
+			}
+		}
+		return nil
+	}
+
+	var planned []todoTxtImportItem
+	var lineErrors []string
+	skipped := 0
+	seen := make(map[string]bool, len(items))
+
+	for _, item := range items {
+		if len(item.Projects) > 0 && !item.HasProject(project.Name) {
+			skipped++
+			continue
+		}
+
+		if item.ID != "" && seen[item.ID] {
+			lineErrors = append(lineErrors, fmt.Sprintf("line %d: %v", item.Line, domain.NewValidationError("id", fmt.Sprintf("duplicate id %q", item.ID))))
+			continue
+		}
+		if item.ID != "" {
+			seen[item.ID] = true
+		}
+		match := byID[item.ID]
+		if match == nil {
+			match = matchByName(item)
+		}
+
+		planItem := todoTxtImportItem{item: item, existing: match}
+		result := planItem.result(project.ID)
+		if err := result.Validate(); err != nil {
+			lineErrors = append(lineErrors, fmt.Sprintf("line %d: %v", item.Line, err))
+			continue
+		}
+		planned = append(planned, planItem)
+	}
+	return planned, skipped, lineErrors
+}
+
+// result returns the task as the import leaves it
+func (planItem todoTxtImportItem) result(projectID string) domain.Task {
+	item := planItem.item
+	task := domain.NewTask(item.Name, "", projectID)
+	if planItem.existing != nil {
+		task = planItem.existing
+	}
+	result := *task
+	result.Name, result.Type, result.Priority = item.Name, item.Type, item.TaskPriority()
+	result.DueDate = item.Due
+	return result
+}
+
+// todoTxtImportWIP follows the planned lines into their columns, in the order
+// the import applies them, and reports each line that takes a column over its
+// WIP limit. Enforced limits are errors, so the import stops before it writes
+// anything rather than halfway through.
+func todoTxtImportWIP(env *Env, projectID string, planned []todoTxtImportItem) (wipErrors, warnings []string, err error) {
+	plan, err := env.TaskService.PlanWIP(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, planItem := range planned {
+		from := domain.NotStarted
+		if planItem.existing != nil {
+			from = planItem.existing.Status
+		} else {
+			plan.Create()
+		}
+		wipErr := plan.Move(from, planItem.item.Status)
+		switch {
+		case wipErr == nil:
+		case wipErr.Enforced:
+			wipErrors = append(wipErrors, fmt.Sprintf("line %d: %v", planItem.item.Line, wipErr))
+		default:
+			warnings = append(warnings, fmt.Sprintf("line %d: %v", planItem.item.Line, wipErr))
+		}
+	}
+	return wipErrors, warnings, nil
+}
+
+func todoTxtChanged(planItem todoTxtImportItem) bool {
+	task, item := planItem.existing, planItem.item
+	return task.Name != item.Name || task.Type != item.Type ||
+		task.Priority != item.TaskPriority() || task.Status != item.Status ||
+		domain.FormatDate(task.DueDate) != domain.FormatDate(item.Due)
+}
+
+func applyTodoTxtItem(env *Env, projectID string, planItem todoTxtImportItem) error {
+	item := planItem.item
+	task := planItem.existing
+
+	if task == nil {
+		created, err := env.TaskService.CreateTask(item.Name, "", projectID, item.Type, item.TaskPriority(), nil)
+		if err != nil {
+			return err
+		}
+		task = created
+	}
+
+	// todo.txt has no description or start date; the task keeps its own
+	result := planItem.result(projectID)
+	if task.Name != result.Name || task.Type != result.Type || task.Priority != result.Priority ||
+		domain.FormatDate(task.DueDate) != domain.FormatDate(result.DueDate) {
+		_, err := env.TaskService.EditTask(task.ID, func(edited *domain.Task) {
+			edited.Name, edited.Type, edited.Priority = result.Name, result.Type, result.Priority
+			edited.DueDate = result.DueDate
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if task.Status != item.Status {
+		if _, err := env.TaskService.UpdateTaskStatus(task.ID, item.Status); withoutWIPWarning(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
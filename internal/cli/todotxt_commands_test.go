@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"kahn/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoTxtExport(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Web Site")
+	mustRunCLI(t, env, "task", "add", "Fix login", "--type", "bug", "--priority", "high")
+	mustRunCLI(t, env, "task", "add", "Dark mode", "--type", "feature")
+	mustRunCLI(t, env, "task", "move", "2", "done")
+
+	out := mustRunCLI(t, env, "todotxt", "export")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2)
+	assert.Regexp(t, `^\(A\) \d{4}-\d{2}-\d{2} Fix login \+Web-Site @bug id:task_\d+$`, lines[0])
+	assert.Regexp(t, `^x \d{4}-\d{2}-\d{2} \d{4}-\d{2}-\d{2} Dark mode \+Web-Site @feature pri:C id:task_\d+$`, lines[1])
+}
+
+func TestTodoTxtImport_ReimportUpdatesByID(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Fix login")
+
+	path := filepath.Join(t.TempDir(), "todo.txt")
+	mustRunCLI(t, env, "todotxt", "export", path)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	edited := strings.Replace(string(content), "(C)", "x 2026-10-01", 1)
+	edited = strings.Replace(edited, "Fix login", "Fix login redirect @bug", 1)
+	edited += "(B) Write changelog +Alpha\n(A) Buy milk +Home\n"
+	require.NoError(t, os.WriteFile(path, []byte(edited), 0644))
+
+	out := mustRunCLI(t, env, "todotxt", "import", path)
+	assert.Contains(t, out, "Created 1 and updated 1 task(s) in Alpha; skipped 1 line(s)")
+
+	tasks, err := env.TaskService.GetTasksByProject(mustResolveProject(t, env).ID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2, "The exported task is updated rather than duplicated")
+
+	fixed, err := env.TaskService.GetTaskByIntID(1)
+	require.NoError(t, err)
+	assert.Equal(t, "Fix login redirect", fixed.Name)
+	assert.Equal(t, domain.Bug, fixed.Type)
+	assert.Equal(t, domain.Done, fixed.Status)
+
+	changelog, err := env.TaskService.GetTaskByIntID(2)
+	require.NoError(t, err)
+	assert.Equal(t, "Write changelog", changelog.Name)
+	assert.Equal(t, domain.Medium, changelog.Priority)
+
+	out = mustRunCLI(t, env, "todotxt", "import", path, "--dry-run")
+	assert.Contains(t, out, "would create 0 and update 0 task(s)", "A line without an id: matches the task it created")
+}
+
+func TestTodoTxtImport_ReimportMatchesLinesWithoutID(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Fix login")
+
+	path := filepath.Join(t.TempDir(), "todo.txt")
+	mustRunCLI(t, env, "todotxt", "export", path)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	edited := string(content) + "x 2026-10-10 2026-10-01 closed item\n(B) closed item\n"
+	require.NoError(t, os.WriteFile(path, []byte(edited), 0644))
+
+	out := mustRunCLI(t, env, "todotxt", "import", path)
+	assert.Contains(t, out, "Created 2 and updated 0 task(s)")
+	out = mustRunCLI(t, env, "todotxt", "import", path)
+	assert.Contains(t, out, "Created 0 and updated 0 task(s)", "Hand-added lines are matched by name and column")
+
+	tasks, err := env.TaskService.GetTasksByProject(mustResolveProject(t, env).ID)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 3, "Both same-named lines keep their own task")
+}
+
+func TestTodoTxtImport_DueDates(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Renew domain", "--start", "2030-01-01", "--due", "2030-02-01")
+
+	path := filepath.Join(t.TempDir(), "todo.txt")
+	mustRunCLI(t, env, "todotxt", "export", path)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), " due:2030-02-01 ")
+
+	edited := strings.Replace(string(content), "due:2030-02-01", "due:2030-03-15", 1)
+	require.NoError(t, os.WriteFile(path, []byte(edited), 0644))
+	mustRunCLI(t, env, "todotxt", "import", path)
+
+	task, err := env.TaskService.GetTaskByIntID(1)
+	require.NoError(t, err)
+	assert.Equal(t, "Renew domain", task.Name, "due: is not part of the name")
+	assert.Equal(t, "2030-03-15", domain.FormatDate(task.DueDate))
+	assert.Equal(t, "2030-01-01", domain.FormatDate(task.StartDate), "The start date is kept")
+}
+
+func TestTodoTxtImport_ValidationReportsEveryLine(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+
+	path := writeTestFile(t, "todo.txt", "Valid\n+Alpha @bug\nOne id:task_1\nTwo id:task_1\n")
+
+	code, out, stderr := runCLI(t, env, "todotxt", "import", path)
+	assert.Equal(t, ExitValidation, code)
+	assert.Contains(t, out, "line 2:")
+	assert.Contains(t, out, "line 4:")
+	assert.Contains(t, out, "duplicate id")
+	assert.Contains(t, stderr, "2 of 4 line(s) failed validation")
+
+	tasks, err := env.TaskService.GetTasksByProject(mustResolveProject(t, env).ID)
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func TestTodoTxtImport_RefusedLineChangesNothing(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "project", "wip", "Alpha", "in-progress", "1")
+	mustRunCLI(t, env, "task", "add", "Started")
+	mustRunCLI(t, env, "task", "move", "1", "in-progress")
+	mustRunCLI(t, env, "task", "add", "Renew domain", "--start", "2030-01-01")
+
+	path := filepath.Join(t.TempDir(), "todo.txt")
+	mustRunCLI(t, env, "todotxt", "export", path)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name, line, message string
+	}{
+		{"enforced WIP limit", "(A) Also started +Alpha status:inprogress\n", "over its WIP limit (2/1)"},
+		{"due before start", "", "due date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edited := strings.Replace(string(content), "Renew domain", "Renew the domain", 1) + tt.line
+			if tt.line == "" {
+				edited = strings.Replace(edited, "Renew the domain", "Renew the domain due:2029-12-01", 1)
+			}
+			require.NoError(t, os.WriteFile(path, []byte(edited), 0644))
+
+			code, out, _ := runCLI(t, env, "todotxt", "import", path)
+			assert.Equal(t, ExitValidation, code)
+			assert.Contains(t, out, tt.message)
+
+			task, err := env.TaskService.GetTaskByIntID(2)
+			require.NoError(t, err)
+			assert.Equal(t, "Renew domain", task.Name, "Lines before the refused one are not applied")
+			tasks, err := env.TaskService.GetTasksByProject(mustResolveProject(t, env).ID)
+			require.NoError(t, err)
+			assert.Len(t, tasks, 2)
+		})
+	}
+}
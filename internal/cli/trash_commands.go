@@ -0,0 +1,310 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"kahn/internal/domain"
+	"kahn/internal/formats"
+
+	"github.com/spf13/pflag"
+)
+
+// Deleted tasks and projects wait in the trash until they are restored or
+// purged; archived tasks stay out of the board until they are unarchived
+func trashCommands() []*command {
+	return []*command{
+		{
+			name:    "task archive",
+			args:    "<task>... | --auto",
+			summary: "Hide finished tasks from the board without deleting them",
+			flags: func(fs *pflag.FlagSet) {
+				fs.Bool("auto", false, "Archive every task done for more than board.auto_archive_days days")
+			},
+			run: runTaskArchive,
+		},
+		{
+			name:    "task unarchive",
+			args:    "<task>...",
+			summary: "Put archived tasks back on the board",
+			run:     runTaskUnarchive,
+		},
+		{
+			name:    "trash",
+			summary: "List the deleted projects and tasks, most recently deleted first",
+			flags:   addOutputFlag,
+			run:     runTrashList,
+		},
+		{
+			name:    "trash restore",
+			args:    "[<task>]",
+			summary: "Take a task, or a project with --project, out of the trash",
+			flags:   addTrashProjectFlag,
+			run:     runTrashRestore,
+		},
+		{
+			name:    "trash purge",
+			args:    "[<task>]",
+			summary: "Delete a task, or a project with --project, in the trash for good",
+			flags:   addTrashProjectFlag,
+			run:     runTrashPurge,
+		},
+		{
+			name:    "trash empty",
+			summary: "Delete everything in the trash for good",
+			run:     runTrashEmpty,
+		},
+	}
+}
+
+func addTrashProjectFlag(fs *pflag.FlagSet) {
+	fs.StringP("project", "p", "", "ID or name of a project in the trash, instead of a task")
+}
+
+func runTaskArchive(env *Env, fs *pflag.FlagSet) error {
+	if auto, _ := fs.GetBool("auto"); auto {
+		return runTaskAutoArchive(env, fs)
+	}
+	args, err := requireArgs(fs, 1, -1)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range args {
+		task, err := resolveTask(env, ref)
+		if err != nil {
+			return err
+		}
+		if _, err := env.TaskService.ArchiveTask(task.ID); err != nil {
+			return err
+		}
+		fmt.Fprintf(env.Out, "Archived task #%d %s\n", task.IntID, task.Name)
+	}
+	return nil
+}
+
+// runTaskAutoArchive archives the tasks of every project left in a done column
+// for longer than the configured number of days
+func runTaskAutoArchive(env *Env, fs *pflag.FlagSet) error {
+	if _, err := requireArgs(fs, 0, 0); err != nil {
+		return newUsageError("--auto archives by age and takes no tasks")
+	}
+	if env.AutoArchiveDays <= 0 {
+		return domain.NewValidationError("auto_archive_days", "set auto_archive_days under [board] in the config to archive tasks by age")
+	}
+
+	archived, err := env.TaskService.AutoArchive(env.AutoArchiveDays)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(env.Out, "Archived %d task(s) done for more than %d days\n", archived, env.AutoArchiveDays)
+	return nil
+}
+
+func runTaskUnarchive(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 1, -1)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range args {
+		task, err := resolveTask(env, ref)
+		if err != nil {
+			return err
+		}
+		if _, err := env.TaskService.UnarchiveTask(task.ID); err != nil {
+			return err
+		}
+		fmt.Fprintf(env.Out, "Unarchived task #%d %s\n", task.IntID, task.Name)
+	}
+	return nil
+}
+
+func runTrashList(env *Env, fs *pflag.FlagSet) error {
+	if _, err := requireArgs(fs, 0, 0); err != nil {
+		return err
+	}
+	format, err := outputFormat(fs)
+	if err != nil {
+		return err
+	}
+
+	projects, err := env.ProjectService.GetTrashedProjects()
+	if err != nil {
+		return err
+	}
+	tasks, err := env.TaskService.GetTrashedTasks("")
+	if err != nil {
+		return err
+	}
+	live, err := env.ProjectService.GetAllProjects()
+	if err != nil {
+		return err
+	}
+	projectsByID := make(map[string]domain.Project, len(live))
+	for _, project := range live {
+		projectsByID[project.ID] = project
+	}
+
+	switch format {
+	case outputJSON, outputNDJSON:
+		projectRecords := make([]formats.ProjectRecord, len(projects))
+		for i, project := range projects {
+			projectRecords[i] = formats.NewProjectRecord(project, 0)
+		}
+		taskRecords := make([]formats.TaskRecord, len(tasks))
+		for i, task := range tasks {
+			taskRecords[i] = formats.NewTaskRecord(task, projectsByID[task.ProjectID].Workflow)
+		}
+		if format == outputNDJSON {
+			return writeNDJSON(env.Out, taskRecords)
+		}
+		return writeJSON(env.Out, formats.NewTrashDocument(projectRecords, taskRecords))
+	}
+
+	var rows [][]string
+	for _, project := range projects {
+		rows = append(rows, []string{"project", project.ID, project.Name, "-", formatDate(project.DeletedAt)})
+	}
+	for _, task := range tasks {
+		rows = append(rows, []string{"task", fmt.Sprintf("#%d", task.IntID), task.Name, projectsByID[task.ProjectID].Name, formatDate(task.DeletedAt)})
+	}
+	return writeRows(env.Out, format, []string{"KIND", "ID", "NAME", "PROJECT", "DELETED"}, rows)
+}
+
+func runTrashRestore(env *Env, fs *pflag.FlagSet) error {
+	if projectRef, _ := fs.GetString("project"); projectRef != "" {
+		if _, err := requireArgs(fs, 0, 0); err != nil {
+			return err
+		}
+		project, err := resolveTrashedProject(env, projectRef)
+		if err != nil {
+			return err
+		}
+		if _, err := env.ProjectService.RestoreProject(project.ID); err != nil {
+			return err
+		}
+		fmt.Fprintf(env.Out, "Restored project %s (%s)\n", project.Name, project.ID)
+		return nil
+	}
+
+	args, err := requireArgs(fs, 1, 1)
+	if err != nil {
+		return err
+	}
+	task, err := resolveTrashedTask(env, args[0])
+	if err != nil {
+		return err
+	}
+	restored, err := env.TaskService.RestoreTask(task.ID)
+	if restored == nil {
+		return err
+	}
+	fmt.Fprintf(env.Out, "Restored task #%d %s\n", task.IntID, task.Name)
+	if err != nil {
+		// With wip_enforcement = "warn" the task is restored and err is the warning
+		fmt.Fprintf(env.Out, "Warning: %v\n", err)
+	}
+	return nil
+}
+
+func runTrashPurge(env *Env, fs *pflag.FlagSet) error {
+	if projectRef, _ := fs.GetString("project"); projectRef != "" {
+		if _, err := requireArgs(fs, 0, 0); err != nil {
+			return err
+		}
+		project, err := resolveTrashedProject(env, projectRef)
+		if err != nil {
+			return err
+		}
+		if err := env.ProjectService.PurgeProject(project.ID); err != nil {
+			return err
+		}
+		fmt.Fprintf(env.Out, "Permanently deleted project %s (%s)\n", project.Name, project.ID)
+		return nil
+	}
+
+	args, err := requireArgs(fs, 1, 1)
+	if err != nil {
+		return err
+	}
+	task, err := resolveTrashedTask(env, args[0])
+	if err != nil {
+		return err
+	}
+	if err := env.TaskService.PurgeTask(task.ID); err != nil {
+		return err
+	}
+	fmt.Fprintf(env.Out, "Permanently deleted task #%d %s\n", task.IntID, task.Name)
+	return nil
+}
+
+func runTrashEmpty(env *Env, fs *pflag.FlagSet) error {
+	if _, err := requireArgs(fs, 0, 0); err != nil {
+		return err
+	}
+
+	tasks, err := env.TaskService.GetTrashedTasks("")
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if err := env.TaskService.PurgeTask(task.ID); err != nil {
+			return err
+		}
+	}
+	projects, err := env.ProjectService.GetTrashedProjects()
+	if err != nil {
+		return err
+	}
+	for _, project := range projects {
+		if err := env.ProjectService.PurgeProject(project.ID); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(env.Out, "Permanently deleted %d projects and %d tasks\n", len(projects), len(tasks))
+	return nil
+}
+
+// resolveTrashedTask finds a task in the trash by number ("12" or "#12") or full ID
+func resolveTrashedTask(env *Env, ref string) (*domain.Task, error) {
+	tasks, err := env.TaskService.GetTrashedTasks("")
+	if err != nil {
+		return nil, err
+	}
+	intID, numErr := parseTaskNumber(ref)
+	for i, task := range tasks {
+		if task.ID == ref || (numErr == nil && task.IntID == *intID) {
+			return &tasks[i], nil
+		}
+	}
+	return nil, domain.NewValidationError("id", fmt.Sprintf("task %q is not in the trash", ref))
+}
+
+// resolveTrashedProject finds a project in the trash by ID or, ignoring case, name
+func resolveTrashedProject(env *Env, ref string) (*domain.Project, error) {
+	projects, err := env.ProjectService.GetTrashedProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []domain.Project
+	for _, project := range projects {
+		if project.ID == ref {
+			return &project, nil
+		}
+		if strings.EqualFold(project.Name, ref) {
+			matches = append(matches, project)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, domain.NewValidationError("project", fmt.Sprintf("project %q is not in the trash", ref))
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, domain.NewValidationError("project", fmt.Sprintf("project name %q is ambiguous; use the project ID", ref))
+	}
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"kahn/internal/domain"
+	"kahn/internal/formats"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskArchive(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Shipped")
+	mustRunCLI(t, env, "task", "add", "Pending")
+	mustRunCLI(t, env, "task", "move", "1", "done")
+
+	code, _, stderr := runCLI(t, env, "task", "archive", "2")
+	assert.Equal(t, ExitValidation, code)
+	assert.Contains(t, stderr, "only tasks in 'Done' can be archived")
+
+	out := mustRunCLI(t, env, "task", "archive", "#1")
+	assert.Contains(t, out, "Archived task #1 Shipped")
+	out = mustRunCLI(t, env, "task", "list")
+	assert.NotContains(t, out, "Shipped")
+	out = mustRunCLI(t, env, "task", "list", "--archived")
+	assert.Contains(t, out, "Shipped")
+	assert.NotContains(t, out, "Pending")
+	out = mustRunCLI(t, env, "task", "show", "1")
+	assert.Contains(t, out, "Shipped", "Archived tasks can still be looked up")
+
+	code, _, _ = runCLI(t, env, "task", "move", "1", "next")
+	assert.Equal(t, ExitValidation, code, "Archived tasks cannot be moved")
+
+	out = mustRunCLI(t, env, "task", "unarchive", "1")
+	assert.Contains(t, out, "Unarchived task #1 Shipped")
+	out = mustRunCLI(t, env, "task", "list", "--status", "done")
+	assert.Contains(t, out, "Shipped")
+}
+
+func TestTaskArchive_Auto(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Old")
+	mustRunCLI(t, env, "task", "add", "Recent")
+	mustRunCLI(t, env, "task", "move", "1", "done")
+	mustRunCLI(t, env, "task", "move", "2", "done")
+	_, err := env.Database.GetDB().Exec(`UPDATE tasks SET updated_at = ? WHERE int_id = 1`, time.Now().AddDate(0, 0, -30))
+	require.NoError(t, err)
+
+	code, _, stderr := runCLI(t, env, "task", "archive", "--auto")
+	assert.Equal(t, ExitValidation, code)
+	assert.Contains(t, stderr, "auto_archive_days")
+
+	env.AutoArchiveDays = 14
+	code, _, _ = runCLI(t, env, "task", "archive", "--auto", "1")
+	assert.Equal(t, ExitUsage, code)
+
+	out := mustRunCLI(t, env, "task", "archive", "--auto")
+	assert.Contains(t, out, "Archived 1 task(s) done for more than 14 days")
+	out = mustRunCLI(t, env, "task", "list", "--archived")
+	assert.Contains(t, out, "Old")
+	assert.NotContains(t, out, "Recent")
+}
+
+func TestTrashCommands(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Blocker")
+	mustRunCLI(t, env, "task", "add", "Blocked", "--blocked-by", "1")
+	mustRunCLI(t, env, "task", "rm", "1")
+
+	out := mustRunCLI(t, env, "task", "show", "2")
+	assert.NotContains(t, out, "#1", "A trashed blocker does not block")
+
+	out = mustRunCLI(t, env, "trash")
+	assert.Contains(t, out, "Blocker")
+	assert.Contains(t, out, "Alpha")
+
+	out = mustRunCLI(t, env, "trash", "restore", "1")
+	assert.Contains(t, out, "Restored task #1 Blocker")
+	out = mustRunCLI(t, env, "task", "show", "2")
+	assert.Contains(t, out, "#1", "Restoring a blocker blocks its dependents again")
+
+	code, _, stderr := runCLI(t, env, "trash", "restore", "1")
+	assert.Equal(t, ExitValidation, code)
+	assert.Contains(t, stderr, "not in the trash")
+
+	mustRunCLI(t, env, "task", "rm", "1")
+	out = mustRunCLI(t, env, "trash", "purge", "1")
+	assert.Contains(t, out, "Permanently deleted task #1 Blocker")
+	code, _, _ = runCLI(t, env, "trash", "restore", "1")
+	assert.Equal(t, ExitValidation, code)
+
+	out = mustRunCLI(t, env, "project", "rm", "Alpha")
+	assert.Contains(t, out, "to the trash")
+	out = mustRunCLI(t, env, "trash", "-o", "json")
+	var document formats.TrashDocument
+	require.NoError(t, json.Unmarshal([]byte(out), &document))
+	require.Len(t, document.Projects, 1)
+	assert.NotNil(t, document.Projects[0].DeletedAt)
+	assert.Empty(t, document.Tasks, "Tasks of a trashed project go with it")
+
+	out = mustRunCLI(t, env, "trash", "restore", "--project", "alpha")
+	assert.Contains(t, out, "Restored project Alpha")
+	out = mustRunCLI(t, env, "task", "list")
+	assert.Contains(t, out, "Blocked")
+
+	mustRunCLI(t, env, "task", "rm", "2")
+	mustRunCLI(t, env, "project", "rm", "Alpha")
+	out = mustRunCLI(t, env, "trash", "empty")
+	assert.Contains(t, out, "Permanently deleted 1 projects and 0 tasks")
+	out = mustRunCLI(t, env, "trash", "-o", "plain")
+	assert.Empty(t, out)
+}
+
+func TestTrashRestore_ChecksWIPLimit(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "project", "wip", "Alpha", "in-progress", "1")
+	mustRunCLI(t, env, "task", "add", "First")
+	mustRunCLI(t, env, "task", "move", "1", "in-progress")
+	mustRunCLI(t, env, "task", "rm", "1")
+	mustRunCLI(t, env, "task", "add", "Second")
+	mustRunCLI(t, env, "task", "move", "2", "in-progress")
+
+	code, _, stderr := runCLI(t, env, "trash", "restore", "1")
+	assert.Equal(t, ExitValidation, code)
+	assert.Contains(t, stderr, "over its WIP limit (2/1)")
+	out := mustRunCLI(t, env, "trash")
+	assert.Contains(t, out, "First", "The task stays in the trash")
+
+	env.TaskService.SetWIPEnforcement(domain.WIPWarn)
+	out = mustRunCLI(t, env, "trash", "restore", "1")
+	assert.Contains(t, out, "Restored task #1 First")
+	assert.Contains(t, out, "Warning: 'In Progress' is over its WIP limit (2/1)")
+}
+
+func TestTrashRestore_RefusesCycle(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "First")
+	mustRunCLI(t, env, "task", "add", "Second")
+	mustRunCLI(t, env, "task", "add", "Third")
+	mustRunCLI(t, env, "task", "block", "1", "2")
+	mustRunCLI(t, env, "task", "block", "2", "3")
+	mustRunCLI(t, env, "task", "rm", "2")
+	mustRunCLI(t, env, "task", "block", "3", "1")
+
+	code, _, stderr := runCLI(t, env, "trash", "restore", "2")
+	assert.Equal(t, ExitValidation, code)
+	assert.Contains(t, stderr, "restoring #2 would close a dependency cycle: #2 → #3 → #1 → #2")
+	out := mustRunCLI(t, env, "trash")
+	assert.Contains(t, out, "Second", "The task stays in the trash")
+
+	mustRunCLI(t, env, "task", "unblock", "3", "1")
+	out = mustRunCLI(t, env, "trash", "restore", "2")
+	assert.Contains(t, out, "Restored task #2 Second")
+}
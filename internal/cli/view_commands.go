@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"fmt"
+
+	"kahn/internal/domain"
+	"kahn/internal/formats"
+
+	"github.com/spf13/pflag"
+)
+
+func viewCommands() []*command {
+	scopeFlags := func(fs *pflag.FlagSet) {
+		fs.StringP("project", "p", "", "Project ID or name (optional when only one project exists)")
+		fs.BoolP("global", "g", false, "Use the views of every project instead of one project's")
+	}
+
+	return []*command{
+		{
+			name:    "view list",
+			summary: "List the saved views of a project and the global ones",
+			flags: func(fs *pflag.FlagSet) {
+				scopeFlags(fs)
+				addOutputFlag(fs)
+			},
+			run: runViewList,
+		},
+		{
+			name:    "view save",
+			args:    "<name> <query>",
+			summary: "Save a search query as a named view, replacing a view of the same name",
+			flags:   scopeFlags,
+			run:     runViewSave,
+		},
+		{
+			name:    "view rm",
+			args:    "<name>",
+			summary: "Delete a saved view",
+			flags:   scopeFlags,
+			run:     runViewRemove,
+		},
+	}
+}
+
+func runViewList(env *Env, fs *pflag.FlagSet) error {
+	if _, err := requireArgs(fs, 0, 0); err != nil {
+		return err
+	}
+	format, err := outputFormat(fs)
+	if err != nil {
+		return err
+	}
+
+	project, err := resolveViewScope(env, fs)
+	if err != nil {
+		return err
+	}
+	projectID := ""
+	if project != nil {
+		projectID = project.ID
+	}
+	views, err := env.ViewService.GetViews(projectID)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case outputJSON:
+		return writeJSON(env.Out, formats.NewSavedViewListDocument(views))
+	case outputNDJSON:
+		return writeNDJSON(env.Out, formats.NewSavedViewListDocument(views).Views)
+	}
+
+	rows := make([][]string, len(views))
+	for i, view := range views {
+		scope := "global"
+		if !view.IsGlobal() {
+			scope = project.Name
+		}
+		rows[i] = []string{view.Name, scope, view.Query}
+	}
+	return writeRows(env.Out, format, []string{"NAME", "SCOPE", "QUERY"}, rows)
+}
+
+func runViewSave(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 2, 2)
+	if err != nil {
+		return err
+	}
+
+	project, err := resolveViewScope(env, fs)
+	if err != nil {
+		return err
+	}
+	projectID, scope := "", "every project"
+	if project != nil {
+		projectID, scope = project.ID, project.Name
+	}
+
+	view, err := env.ViewService.SaveView(projectID, args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Saved view %s for %s\n", view.Name, scope)
+	return nil
+}
+
+func runViewRemove(env *Env, fs *pflag.FlagSet) error {
+	args, err := requireArgs(fs, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	view, err := resolveView(env, fs, args[0])
+	if err != nil {
+		return err
+	}
+	if err := env.ViewService.DeleteView(view.ID); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.Out, "Deleted view %s\n", view.Name)
+	return nil
+}
+
+// resolveViewScope returns the project chosen with --project, or nil with --global
+func resolveViewScope(env *Env, fs *pflag.FlagSet) (*domain.Project, error) {
+	if global, _ := fs.GetBool("global"); global {
+		if fs.Changed("project") {
+			return nil, newUsageError("--global and --project cannot be combined")
+		}
+		return nil, nil
+	}
+	projectRef, _ := fs.GetString("project")
+	return resolveProject(env, projectRef)
+}
+
+// resolveView finds a view by name among those of the project chosen with
+// --project and the global ones, or only the global ones with --global
+func resolveView(env *Env, fs *pflag.FlagSet, name string) (*domain.SavedView, error) {
+	project, err := resolveViewScope(env, fs)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return env.ViewService.GetViewByName("", name)
+	}
+	return env.ViewService.GetViewByName(project.ID, name)
+}
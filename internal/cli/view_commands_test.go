@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewCommands(t *testing.T) {
+	env := setupTestEnv(t)
+	mustRunCLI(t, env, "project", "add", "Alpha")
+	mustRunCLI(t, env, "task", "add", "Login times out", "--type", "bug", "--priority", "high")
+	mustRunCLI(t, env, "task", "add", "Dark mode", "--type", "feature")
+
+	out := mustRunCLI(t, env, "view", "save", "Bugs", "type:bug")
+	assert.Equal(t, "Saved view Bugs for Alpha\n", out)
+	out = mustRunCLI(t, env, "view", "save", "Urgent", "priority:high", "--global")
+	assert.Equal(t, "Saved view Urgent for every project\n", out)
+
+	code, _, stderr := runCLI(t, env, "view", "save", "Broken", "priority:urgent")
+	assert.Equal(t, ExitValidation, code, "Queries that do not parse are rejected")
+	assert.Contains(t, stderr, `unknown priority "urgent"`)
+	code, _, _ = runCLI(t, env, "view", "save", "Both", "type:bug", "--global", "--project", "Alpha")
+	assert.Equal(t, ExitUsage, code)
+
+	out = mustRunCLI(t, env, "view", "list")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 3, "Header plus the project view and the global one")
+	assert.Contains(t, lines[1], "Bugs")
+	assert.Contains(t, lines[1], "Alpha")
+	assert.Contains(t, lines[2], "global")
+
+	out = mustRunCLI(t, env, "view", "list", "--global", "--output", "json")
+	assert.Contains(t, out, `"name": "Urgent"`)
+	assert.NotContains(t, out, "Bugs")
+
+	out = mustRunCLI(t, env, "task", "list", "--view", "bugs")
+	assert.Contains(t, out, "Login times out")
+	assert.NotContains(t, out, "Dark mode")
+	out = mustRunCLI(t, env, "task", "list", "--view", "Urgent", "--query", "type:feature")
+	assert.NotContains(t, out, "Login times out", "--view and --query must both match")
+	assert.NotContains(t, out, "Dark mode")
+
+	mustRunCLI(t, env, "view", "save", "bugs", "type:bug priority:high")
+	out = mustRunCLI(t, env, "view", "list", "--output", "plain")
+	assert.Contains(t, out, "bugs\tAlpha\ttype:bug priority:high", "Saving an existing name replaces the view")
+
+	out = mustRunCLI(t, env, "view", "rm", "BUGS")
+	assert.Equal(t, "Deleted view bugs\n", out)
+	code, _, _ = runCLI(t, env, "task", "list", "--view", "Bugs")
+	assert.Equal(t, ExitValidation, code)
+}
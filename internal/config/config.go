@@ -18,6 +18,14 @@ const (
 	DefaultJournalMode  = "WAL"
 	DefaultCacheSize    = 10000 // number of pages
 	DefaultForeignKeys  = true
+
+	DefaultWIPEnforcement  = "reject" // "reject" or "warn"
+	DefaultAutoArchiveDays = 0        // days a task stays done before it is archived; 0 never archives
+	DefaultHyperlinks      = "auto"   // "auto", "always" or "never"
+	DefaultExportDir       = "."      // directory the board's Markdown export key writes into
+
+	// DefaultAuthor signs comments when neither user.name nor $USER is set
+	DefaultAuthor = "anonymous"
 )
 
 type Config struct {
@@ -28,6 +36,23 @@ type Config struct {
 		CacheSize   int    `mapstructure:"cache_size"`
 		ForeignKeys bool   `mapstructure:"foreign_keys"`
 	} `mapstructure:"database"`
+	Board struct {
+		// WIPEnforcement is "reject" to refuse moves over a column's WIP limit or
+		// "warn" to allow them with a warning
+		WIPEnforcement string `mapstructure:"wip_enforcement"`
+		// AutoArchiveDays archives tasks left in a done column for more than this
+		// many days whenever kahn starts; 0 turns it off
+		AutoArchiveDays int `mapstructure:"auto_archive_days"`
+		// Hyperlinks makes links in task descriptions clickable: "auto" when the
+		// terminal is known to support it, "always" or "never"
+		Hyperlinks string `mapstructure:"hyperlinks"`
+		// ExportDir is the directory the Markdown export key writes into
+		ExportDir string `mapstructure:"export_dir"`
+	} `mapstructure:"board"`
+	User struct {
+		// Name signs the comments written from this machine; defaults to $USER
+		Name string `mapstructure:"name"`
+	} `mapstructure:"user"`
 }
 
 /*
@@ -38,6 +63,21 @@ type Config struct {
 * 4. Default values (lowest priority)
  */
 func LoadConfig() (*Config, error) {
+	RegisterFlags(pflag.CommandLine)
+	pflag.Parse()
+
+	return LoadConfigFromFlags(pflag.CommandLine)
+}
+
+// RegisterFlags adds the configuration flags shared by the TUI and every CLI subcommand to fs
+func RegisterFlags(fs *pflag.FlagSet) {
+	fs.String("config", "", "Path to config file")
+	fs.String("db-path", "", "Path to database file")
+}
+
+// LoadConfigFromFlags loads configuration using a flag set that has already been
+// populated by RegisterFlags and parsed. The source priority matches LoadConfig.
+func LoadConfigFromFlags(fs *pflag.FlagSet) (*Config, error) {
 	config := &Config{}
 
 	// Set default values
@@ -46,20 +86,20 @@ func LoadConfig() (*Config, error) {
 	viper.SetDefault("database.journal_mode", DefaultJournalMode)
 	viper.SetDefault("database.cache_size", DefaultCacheSize)
 	viper.SetDefault("database.foreign_keys", DefaultForeignKeys)
-
-	// Set up command-line flags
-	pflag.String("config", "", "Path to config file")
-	pflag.String("db-path", "", "Path to database file")
-	pflag.Parse()
+	viper.SetDefault("board.wip_enforcement", DefaultWIPEnforcement)
+	viper.SetDefault("board.auto_archive_days", DefaultAutoArchiveDays)
+	viper.SetDefault("board.hyperlinks", DefaultHyperlinks)
+	viper.SetDefault("board.export_dir", DefaultExportDir)
+	viper.SetDefault("user.name", "")
 
 	// Bind command-line flags to viper
-	err := viper.BindPFlags(pflag.CommandLine)
+	err := viper.BindPFlag("config", fs.Lookup("config"))
 	if err != nil {
 		return nil, fmt.Errorf("failed to bind flags: %w", err)
 	}
 
 	// Bind db-path flag to database.path
-	err = viper.BindPFlag("database.path", pflag.Lookup("db-path"))
+	err = viper.BindPFlag("database.path", fs.Lookup("db-path"))
 	if err != nil {
 		return nil, fmt.Errorf("failed to bind db-path flag: %w", err)
 	}
@@ -95,10 +135,24 @@ func LoadConfig() (*Config, error) {
 	}
 
 	config.Database.Path = expandPath(config.Database.Path)
+	config.Board.ExportDir = expandPath(config.Board.ExportDir)
+	if strings.TrimSpace(config.User.Name) == "" {
+		config.User.Name = systemUser()
+	}
 
 	return config, nil
 }
 
+// systemUser returns the login name from the environment, or DefaultAuthor
+func systemUser() string {
+	for _, key := range []string{"USER", "USERNAME"} {
+		if name := strings.TrimSpace(os.Getenv(key)); name != "" {
+			return name
+		}
+	}
+	return DefaultAuthor
+}
+
 func expandPath(path string) string {
 	if strings.HasPrefix(path, "~/") {
 		home, err := os.UserHomeDir()
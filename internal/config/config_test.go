@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -49,6 +50,26 @@ cache_size = 10000
 
 # Enable foreign key constraints
 foreign_keys = true
+
+[board]
+# What happens when a move takes a column over its WIP limit
+# Options: reject, warn
+wip_enforcement = "reject"
+
+# Archive tasks left in the done column for more than this many days whenever
+# kahn starts; 0 never archives
+auto_archive_days = 0
+
+# Make links in task descriptions clickable
+# Options: auto, always, never
+hyperlinks = "auto"
+
+# Directory the board's Markdown export (x) writes into
+export_dir = "."
+
+[user]
+# Name that signs your task comments; defaults to $USER
+# name = "alice"
 `
 
 	if _, err := os.Stat(configPath); err == nil {
@@ -86,6 +107,10 @@ func TestLoadConfig_DefaultValues(t *testing.T) {
 	assert.Equal(t, DefaultJournalMode, config.Database.JournalMode, "Default journal mode should match")
 	assert.Equal(t, DefaultCacheSize, config.Database.CacheSize, "Default cache size should match")
 	assert.Equal(t, DefaultForeignKeys, config.Database.ForeignKeys, "Default foreign keys should be true")
+	assert.Equal(t, DefaultWIPEnforcement, config.Board.WIPEnforcement, "Default WIP enforcement should reject")
+	assert.Equal(t, DefaultAutoArchiveDays, config.Board.AutoArchiveDays, "Auto-archive should be off by default")
+	assert.Equal(t, DefaultHyperlinks, config.Board.Hyperlinks, "Hyperlinks should follow the terminal by default")
+	assert.Equal(t, DefaultExportDir, config.Board.ExportDir, "Exports should go to the current directory by default")
 }
 
 func TestExpandPath(t *testing.T) {
@@ -220,3 +245,38 @@ func TestWriteDefaultConfig_ExistingFile(t *testing.T) {
 	require.NoError(t, err, "Should be able to read config file")
 	assert.Equal(t, existingContent, string(content), "Original content should be unchanged")
 }
+
+func TestLoadConfigFromFlags_DBPathFlag(t *testing.T) {
+	os.Unsetenv("KAHN_DATABASE_PATH")
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	RegisterFlags(fs)
+	fs.String("priority", "", "Subcommand-specific flag")
+
+	dbPath := filepath.Join(t.TempDir(), "flag.db")
+	err := fs.Parse([]string{"--db-path", dbPath, "--priority", "high"})
+	require.NoError(t, err, "Shared flags should parse alongside subcommand flags")
+
+	config, err := LoadConfigFromFlags(fs)
+	require.NoError(t, err, "LoadConfigFromFlags should not return error")
+	assert.Equal(t, dbPath, config.Database.Path, "db-path flag should override the default path")
+	assert.Equal(t, DefaultBusyTimeout, config.Database.BusyTimeout, "Unset values should keep defaults")
+}
+
+func TestLoadConfigFromFlags_UserName(t *testing.T) {
+	os.Unsetenv("KAHN_USER_NAME")
+	t.Setenv("USER", "alice")
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	RegisterFlags(fs)
+	require.NoError(t, fs.Parse(nil))
+
+	config, err := LoadConfigFromFlags(fs)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", config.User.Name, "Comment author should fall back to $USER")
+
+	t.Setenv("KAHN_USER_NAME", "Alice Liddell")
+	config, err = LoadConfigFromFlags(fs)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice Liddell", config.User.Name, "user.name should win over $USER")
+}
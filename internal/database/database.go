@@ -255,3 +255,22 @@ func (d *Database) RunMigrations() error {
 
 	return nil
 }
+
+// AppliedMigrations returns the names of the migrations recorded in this database, in execution order
+func (d *Database) AppliedMigrations() ([]string, error) {
+	rows, err := d.Db.Query("SELECT name FROM migrations ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to read migrations: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
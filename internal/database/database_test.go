@@ -150,6 +150,17 @@ func TestDatabase_BeginTransaction(t *testing.T) {
 	assert.NoError(t, err, "Transaction rollback should not return error")
 }
 
+func TestDatabase_AppliedMigrations(t *testing.T) {
+	config := createTestConfig()
+	database, err := NewDatabase(config)
+	require.NoError(t, err, "NewDatabase should not return error")
+	defer database.Close()
+
+	applied, err := database.AppliedMigrations()
+	require.NoError(t, err, "AppliedMigrations should not return error")
+	assert.Equal(t, MigrationNames(), applied, "Every known migration should be recorded in order")
+}
+
 func TestDatabase_GetDB(t *testing.T) {
 	config := createTestConfig()
 	database, err := NewDatabase(config)
@@ -93,5 +93,254 @@ func getMigrations() []Migration {
 				CREATE INDEX idx_tasks_blocked_by ON tasks(blocked_by);
 			`,
 		},
+		{
+			name: "007_create_workflow_statuses",
+			sql: `
+				-- tasks.status is the position of the task's column in its project's workflow
+				CREATE TABLE workflow_statuses (
+					project_id TEXT NOT NULL,
+					position INTEGER NOT NULL,
+					name TEXT NOT NULL,
+					is_done INTEGER NOT NULL DEFAULT 0,
+					PRIMARY KEY (project_id, position),
+					FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+				);
+
+				-- Existing projects keep the three built-in columns
+				INSERT INTO workflow_statuses (project_id, position, name, is_done)
+				SELECT id, 0, 'Not Started', 0 FROM projects
+				UNION ALL SELECT id, 1, 'In Progress', 0 FROM projects
+				UNION ALL SELECT id, 2, 'Done', 1 FROM projects;
+			`,
+		},
+		{
+			name: "008_add_workflow_wip_limits",
+			sql: `
+				-- 0 means the column has no work-in-progress limit
+				ALTER TABLE workflow_statuses ADD COLUMN wip_limit INTEGER NOT NULL DEFAULT 0;
+			`,
+		},
+		{
+			name: "009_create_labels",
+			sql: `
+				CREATE TABLE labels (
+					id TEXT PRIMARY KEY,
+					project_id TEXT NOT NULL,
+					name TEXT NOT NULL COLLATE NOCASE,
+					color TEXT NOT NULL,
+					created_at DATETIME NOT NULL,
+					UNIQUE (project_id, name),
+					FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+				);
+
+				CREATE TABLE task_labels (
+					task_id TEXT NOT NULL,
+					label_id TEXT NOT NULL,
+					PRIMARY KEY (task_id, label_id),
+					FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+					FOREIGN KEY (label_id) REFERENCES labels(id) ON DELETE CASCADE
+				);
+
+				CREATE INDEX idx_task_labels_label_id ON task_labels(label_id);
+			`,
+		},
+		{
+			name: "010_add_task_dates",
+			sql: `
+				-- Calendar dates stored as YYYY-MM-DD so they sort and compare as text
+				ALTER TABLE tasks ADD COLUMN start_date TEXT;
+				ALTER TABLE tasks ADD COLUMN due_date TEXT;
+
+				CREATE INDEX idx_tasks_due_date ON tasks(due_date);
+			`,
+		},
+		{
+			name: "011_create_task_dependencies",
+			sql: `
+				-- A task may wait on several others; both columns hold tasks.int_id
+				CREATE TABLE task_dependencies (
+					task_id INTEGER NOT NULL,
+					blocker_id INTEGER NOT NULL,
+					PRIMARY KEY (task_id, blocker_id),
+					CHECK (task_id <> blocker_id),
+					FOREIGN KEY (task_id) REFERENCES tasks(int_id) ON DELETE CASCADE,
+					FOREIGN KEY (blocker_id) REFERENCES tasks(int_id) ON DELETE CASCADE
+				);
+
+				CREATE INDEX idx_task_dependencies_blocker_id ON task_dependencies(blocker_id);
+
+				INSERT INTO task_dependencies (task_id, blocker_id)
+				SELECT int_id, blocked_by FROM tasks
+				WHERE blocked_by IS NOT NULL AND blocked_by <> int_id
+					AND blocked_by IN (SELECT int_id FROM tasks);
+
+				-- tasks.blocked_by is no longer read. SQLite cannot drop a column that is
+				-- part of a foreign key, so it stays behind, emptied.
+				UPDATE tasks SET blocked_by = NULL;
+			`,
+		},
+		{
+			name: "012_create_checklist_items",
+			sql: `
+				CREATE TABLE checklist_items (
+					id TEXT PRIMARY KEY,
+					task_id TEXT NOT NULL,
+					position INTEGER NOT NULL,
+					text TEXT NOT NULL,
+					done BOOLEAN NOT NULL DEFAULT 0,
+					created_at DATETIME NOT NULL,
+					updated_at DATETIME NOT NULL,
+					FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+				);
+
+				CREATE INDEX idx_checklist_items_task_id ON checklist_items(task_id, position);
+			`,
+		},
+		{
+			name: "013_create_task_comments",
+			sql: `
+				CREATE TABLE task_comments (
+					id TEXT PRIMARY KEY,
+					task_id TEXT NOT NULL,
+					author TEXT NOT NULL,
+					body TEXT NOT NULL,
+					created_at DATETIME NOT NULL,
+					FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+				);
+
+				CREATE INDEX idx_task_comments_task_id ON task_comments(task_id, created_at);
+			`,
+		},
+		{
+			name: "014_create_events",
+			sql: `
+				-- The audit log outlives the tasks and projects it describes, so it
+				-- has no foreign keys
+				CREATE TABLE events (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					project_id TEXT NOT NULL,
+					task_id TEXT,
+					task_int_id INTEGER,
+					subject TEXT NOT NULL,
+					kind TEXT NOT NULL,
+					field TEXT,
+					old_value TEXT,
+					new_value TEXT,
+					actor TEXT NOT NULL,
+					created_at DATETIME NOT NULL
+				);
+
+				CREATE INDEX idx_events_task_int_id ON events(task_int_id);
+				CREATE INDEX idx_events_project_id ON events(project_id, created_at);
+			`,
+		},
+		{
+			name: "015_create_undo_operations",
+			sql: `
+				-- Undo and redo actions are JSON documents that may restore deleted
+				-- tasks and projects, so they have no foreign keys either
+				CREATE TABLE undo_operations (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					project_id TEXT NOT NULL,
+					task_id TEXT,
+					task_int_id INTEGER,
+					subject TEXT NOT NULL,
+					description TEXT NOT NULL,
+					undo_actions TEXT NOT NULL,
+					redo_actions TEXT NOT NULL,
+					undone BOOLEAN NOT NULL DEFAULT 0,
+					created_at DATETIME NOT NULL
+				);
+			`,
+		},
+		{
+			name: "016_add_trash_and_archive",
+			sql: `
+				-- Deleting moves tasks and projects to the trash; archiving hides
+				-- finished tasks from the board
+				ALTER TABLE tasks ADD COLUMN archived_at DATETIME;
+				ALTER TABLE tasks ADD COLUMN deleted_at DATETIME;
+				ALTER TABLE projects ADD COLUMN deleted_at DATETIME;
+
+				CREATE INDEX idx_tasks_deleted_at ON tasks(deleted_at);
+				CREATE INDEX idx_projects_deleted_at ON projects(deleted_at);
+			`,
+		},
+		{
+			name: "017_create_task_search",
+			sql: `
+				-- Full-text index over task names, descriptions and comments, kept
+				-- in step with tasks and task_comments by triggers. Rows carry the
+				-- task ID rather than relying on rowids, which VACUUM may renumber.
+				CREATE VIRTUAL TABLE task_search USING fts5(
+					name, description, comments, task_id UNINDEXED,
+					tokenize = 'unicode61 remove_diacritics 2'
+				);
+
+				INSERT INTO task_search (name, description, comments, task_id)
+				SELECT t.name, t.desc,
+					COALESCE((SELECT group_concat(c.body, char(10)) FROM task_comments c WHERE c.task_id = t.id), ''),
+					t.id
+				FROM tasks t;
+
+				CREATE TRIGGER task_search_insert AFTER INSERT ON tasks BEGIN
+					INSERT INTO task_search (name, description, comments, task_id)
+					VALUES (new.name, new.desc, '', new.id);
+				END;
+
+				CREATE TRIGGER task_search_update AFTER UPDATE OF name, desc ON tasks BEGIN
+					UPDATE task_search SET name = new.name, description = new.desc
+					WHERE task_id = new.id;
+				END;
+
+				CREATE TRIGGER task_search_delete AFTER DELETE ON tasks BEGIN
+					DELETE FROM task_search WHERE task_id = old.id;
+				END;
+
+				CREATE TRIGGER task_search_comment_insert AFTER INSERT ON task_comments BEGIN
+					UPDATE task_search SET comments = COALESCE((SELECT group_concat(body, char(10))
+						FROM task_comments WHERE task_id = new.task_id), '')
+					WHERE task_id = new.task_id;
+				END;
+
+				CREATE TRIGGER task_search_comment_update AFTER UPDATE OF body ON task_comments BEGIN
+					UPDATE task_search SET comments = COALESCE((SELECT group_concat(body, char(10))
+						FROM task_comments WHERE task_id = new.task_id), '')
+					WHERE task_id = new.task_id;
+				END;
+
+				CREATE TRIGGER task_search_comment_delete AFTER DELETE ON task_comments BEGIN
+					UPDATE task_search SET comments = COALESCE((SELECT group_concat(body, char(10))
+						FROM task_comments WHERE task_id = old.task_id), '')
+					WHERE task_id = old.task_id;
+				END;
+			`,
+		},
+		{
+			name: "018_create_saved_views",
+			sql: `
+				-- Named search queries; a view without a project applies to every project
+				CREATE TABLE saved_views (
+					id TEXT PRIMARY KEY,
+					project_id TEXT,
+					name TEXT NOT NULL,
+					query TEXT NOT NULL,
+					created_at DATETIME NOT NULL,
+					FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+				);
+
+				CREATE INDEX idx_saved_views_project_id ON saved_views(project_id);
+			`,
+		},
+	}
+}
+
+// MigrationNames returns the names of every migration this build knows about
+func MigrationNames() []string {
+	migrations := getMigrations()
+	names := make([]string, len(migrations))
+	for i, migration := range migrations {
+		names[i] = migration.name
 	}
+	return names
 }
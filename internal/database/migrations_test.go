@@ -12,7 +12,7 @@ import (
 func TestGetMigrations(t *testing.T) {
 	migrations := getMigrations()
 
-	assert.Len(t, migrations, 5, "Should have 5 migrations")
+	assert.Len(t, migrations, 17, "Should have 17 migrations")
 
 	// Test migration names
 	expectedNames := []string{
@@ -21,6 +21,18 @@ func TestGetMigrations(t *testing.T) {
 		"003_add_type_to_tasks",
 		"005_create_indexes",
 		"006_add_integer_pk_and_blocked_by",
+		"007_create_workflow_statuses",
+		"008_add_workflow_wip_limits",
+		"009_create_labels",
+		"010_add_task_dates",
+		"011_create_task_dependencies",
+		"012_create_checklist_items",
+		"013_create_task_comments",
+		"014_create_events",
+		"015_create_undo_operations",
+		"016_add_trash_and_archive",
+		"017_create_task_search",
+		"018_create_saved_views",
 	}
 
 	for i, expectedName := range expectedNames {
@@ -48,10 +60,10 @@ func TestRunMigrations(t *testing.T) {
 	// Test that migrations table exists and has records
 	err = db.QueryRow("SELECT COUNT(*) FROM migrations").Scan(&count)
 	assert.NoError(t, err, "Should be able to query migrations table")
-	assert.Equal(t, 5, count, "Should have 5 migration records")
+	assert.Equal(t, 17, count, "Should have 17 migration records")
 
 	// Test that all expected tables exist
-	tables := []string{"projects", "tasks", "migrations"}
+	tables := []string{"projects", "tasks", "workflow_statuses", "labels", "task_labels", "task_dependencies", "checklist_items", "task_comments", "events", "undo_operations", "migrations"}
 	for _, table := range tables {
 		err = db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count)
 		assert.NoError(t, err, "Table %s should exist", table)
@@ -61,10 +73,10 @@ func TestRunMigrations(t *testing.T) {
 	err = database.RunMigrations()
 	assert.NoError(t, err, "Running migrations again should not return error")
 
-	// Test that migration count is still 5 (no duplicates)
+	// Test that migration count is still 17 (no duplicates)
 	err = db.QueryRow("SELECT COUNT(*) FROM migrations").Scan(&count)
 	assert.NoError(t, err, "Should be able to query migrations table")
-	assert.Equal(t, 5, count, "Should still have 5 migration records (no duplicates)")
+	assert.Equal(t, 17, count, "Should still have 17 migration records (no duplicates)")
 }
 
 func TestMigration_ProjectsTable(t *testing.T) {
@@ -188,6 +200,81 @@ func TestMigration_IntegerPKAndBlockedBy(t *testing.T) {
 	assert.Equal(t, intID1, intID, "Integer ID should match")
 }
 
+func TestMigration_WorkflowStatusesSeedsExistingProjects(t *testing.T) {
+	db := setupTestDB(t)
+	defer cleanupTestDB(t, db)
+
+	// Roll back 007 and 008 so they run against a database that already has a project
+	_, err := db.Exec(`DROP TABLE workflow_statuses; DELETE FROM migrations WHERE name IN ('007_create_workflow_statuses', '008_add_workflow_wip_limits')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`
+		INSERT INTO projects (id, name, description, color, created_at, updated_at)
+		VALUES ('test_proj', 'Test Project', '', 'blue', datetime('now'), datetime('now'))
+	`)
+	require.NoError(t, err)
+
+	database := &Database{Db: db}
+	require.NoError(t, database.RunMigrations())
+
+	rows, err := db.Query("SELECT name, is_done, wip_limit FROM workflow_statuses WHERE project_id = 'test_proj' ORDER BY position")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var name string
+		var isDone bool
+		var wipLimit int
+		require.NoError(t, rows.Scan(&name, &isDone, &wipLimit))
+		assert.Equal(t, name == "Done", isDone, "Only Done should be the done status")
+		assert.Zero(t, wipLimit, "Existing columns have no WIP limit")
+		columns = append(columns, name)
+	}
+	assert.Equal(t, []string{"Not Started", "In Progress", "Done"}, columns)
+}
+
+func TestMigration_TaskDependenciesKeepsBlockedBy(t *testing.T) {
+	db := setupTestDB(t)
+	defer cleanupTestDB(t, db)
+
+	// Roll back 011 so it runs against tasks that still use blocked_by
+	_, err := db.Exec(`DROP TABLE task_dependencies; DELETE FROM migrations WHERE name = '011_create_task_dependencies'`)
+	require.NoError(t, err)
+	_, err = db.Exec(`
+		INSERT INTO projects (id, name, description, color, created_at, updated_at)
+		VALUES ('test_proj', 'Test Project', '', 'blue', datetime('now'), datetime('now'))
+	`)
+	require.NoError(t, err)
+	for i, name := range []string{"task_1", "task_2", "task_3"} {
+		_, err = db.Exec(`
+			INSERT INTO tasks (int_id, id, project_id, name, desc, status, priority, created_at, updated_at)
+			VALUES (?, ?, 'test_proj', ?, '', 0, 0, datetime('now'), datetime('now'))
+		`, i+1, name, name)
+		require.NoError(t, err)
+	}
+	_, err = db.Exec(`UPDATE tasks SET blocked_by = 1 WHERE int_id IN (2, 3)`)
+	require.NoError(t, err)
+
+	database := &Database{Db: db}
+	require.NoError(t, database.RunMigrations())
+
+	rows, err := db.Query("SELECT task_id, blocker_id FROM task_dependencies ORDER BY task_id")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var dependencies [][2]int
+	for rows.Next() {
+		var dependency [2]int
+		require.NoError(t, rows.Scan(&dependency[0], &dependency[1]))
+		dependencies = append(dependencies, dependency)
+	}
+	assert.Equal(t, [][2]int{{2, 1}, {3, 1}}, dependencies)
+
+	var leftover int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM tasks WHERE blocked_by IS NOT NULL").Scan(&leftover))
+	assert.Zero(t, leftover, "blocked_by is emptied once copied")
+}
+
 func setupTestDB(t *testing.T) *sql.DB {
 	db, err := sql.Open("sqlite", ":memory:?_foreign_keys=true")
 	require.NoError(t, err, "Failed to open in-memory database")
@@ -203,6 +290,66 @@ func setupTestDB(t *testing.T) *sql.DB {
 
 	return db
 }
+
+func TestMigration_TaskSearchIndexesExistingTasks(t *testing.T) {
+	db := setupTestDB(t)
+	defer cleanupTestDB(t, db)
+
+	// Roll back 017 so it runs against a task that already has a comment
+	_, err := db.Exec(`
+		DROP TRIGGER task_search_insert; DROP TRIGGER task_search_update; DROP TRIGGER task_search_delete;
+		DROP TRIGGER task_search_comment_insert; DROP TRIGGER task_search_comment_update; DROP TRIGGER task_search_comment_delete;
+		DROP TABLE task_search; DELETE FROM migrations WHERE name = '017_create_task_search'
+	`)
+	require.NoError(t, err)
+	_, err = db.Exec(`
+		INSERT INTO projects (id, name, description, color, created_at, updated_at)
+		VALUES ('test_proj', 'Test Project', '', 'blue', datetime('now'), datetime('now'));
+		INSERT INTO tasks (id, project_id, name, desc, status, priority, created_at, updated_at)
+		VALUES ('task_1', 'test_proj', 'Fix login', 'Sessions expire early', 0, 0, datetime('now'), datetime('now'));
+		INSERT INTO task_comments (id, task_id, author, body, created_at)
+		VALUES ('comment_1', 'task_1', 'ann', 'Seen on staging', datetime('now'));
+	`)
+	require.NoError(t, err)
+
+	database := &Database{Db: db}
+	require.NoError(t, database.RunMigrations())
+
+	matches := func(query string) []string {
+		rows, err := db.Query("SELECT task_id FROM task_search WHERE task_search MATCH ? ORDER BY task_id", query)
+		require.NoError(t, err)
+		defer rows.Close()
+		var ids []string
+		for rows.Next() {
+			var id string
+			require.NoError(t, rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"task_1"}, matches("staging"), "Existing comments are indexed")
+	assert.Equal(t, []string{"task_1"}, matches("sessions"), "Existing descriptions are indexed")
+
+	// Triggers keep the index in step from here on
+	_, err = db.Exec(`
+		INSERT INTO tasks (id, project_id, name, desc, status, priority, created_at, updated_at)
+		VALUES ('task_2', 'test_proj', 'Write docs', '', 0, 0, datetime('now'), datetime('now'));
+		UPDATE tasks SET name = 'Fix signup' WHERE id = 'task_1';
+		DELETE FROM task_comments WHERE id = 'comment_1';
+		INSERT INTO task_comments (id, task_id, author, body, created_at)
+		VALUES ('comment_2', 'task_2', 'ann', 'Mention staging too', datetime('now'));
+	`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"task_2"}, matches("docs"))
+	assert.Equal(t, []string{"task_1"}, matches("signup"))
+	assert.Empty(t, matches("login"), "Renamed tasks drop their old name")
+	assert.Equal(t, []string{"task_2"}, matches("staging"), "Comments follow inserts and deletes")
+
+	_, err = db.Exec(`DELETE FROM tasks WHERE id = 'task_2'`)
+	require.NoError(t, err)
+	assert.Empty(t, matches("docs"), "Deleted tasks leave the index")
+}
+
 func cleanupTestDB(t *testing.T, db *sql.DB) {
 	err := db.Close()
 	require.NoError(t, err, "Failed to close test database")
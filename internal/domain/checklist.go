@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ChecklistItem is one small step of a task that does not deserve its own card.
+// Items are ordered by Position, starting at 1.
+type ChecklistItem struct {
+	ID        string    `json:"id"`
+	TaskID    string    `json:"task_id"`
+	Text      string    `json:"text"`
+	Done      bool      `json:"done"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validation constants for checklists
+const (
+	MaxChecklistItemLength = 200
+	MaxChecklistItems      = 50
+)
+
+func NewChecklistItem(taskID, text string, position int) *ChecklistItem {
+	now := time.Now()
+	return &ChecklistItem{
+		ID:        generateChecklistItemID(),
+		TaskID:    taskID,
+		Text:      strings.TrimSpace(text),
+		Position:  position,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func generateChecklistItemID() string {
+	return fmt.Sprintf("check_%d", time.Now().UnixNano())
+}
+
+func (c *ChecklistItem) Validate() error {
+	validator := NewFieldValidator()
+
+	if err := validator.ValidateNotEmpty("text", c.Text, "checklist item"); err != nil {
+		return err
+	}
+	if err := validator.ValidateMaxLength("text", c.Text, MaxChecklistItemLength, "checklist item"); err != nil {
+		return err
+	}
+	if strings.ContainsAny(c.Text, "\r\n") {
+		return NewValidationError("text", "checklist item text must be a single line")
+	}
+	if err := validator.ValidateRequiredID(c.TaskID, "task"); err != nil {
+		return NewValidationError("task_id", "task ID cannot be empty")
+	}
+	if c.Position < 1 {
+		return NewValidationError("position", "checklist position must be at least 1")
+	}
+	return nil
+}
+
+// ChecklistProgress prints how many checklist items are done, e.g. "2/5", or ""
+// for a task without a checklist
+func (t Task) ChecklistProgress() string {
+	if t.ChecklistTotal == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", t.ChecklistDone, t.ChecklistTotal)
+}
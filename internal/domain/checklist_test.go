@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecklistItem_Validate(t *testing.T) {
+	item := NewChecklistItem("task_1", "  Write migration  ", 1)
+	assert.Equal(t, "Write migration", item.Text)
+	assert.NoError(t, item.Validate())
+
+	for name, invalid := range map[string]*ChecklistItem{
+		"empty text":    NewChecklistItem("task_1", "   ", 1),
+		"long text":     NewChecklistItem("task_1", strings.Repeat("x", MaxChecklistItemLength+1), 1),
+		"several lines": NewChecklistItem("task_1", "one\ntwo", 1),
+		"missing task":  NewChecklistItem("", "step", 1),
+		"zero position": NewChecklistItem("task_1", "step", 0),
+	} {
+		assert.Error(t, invalid.Validate(), name)
+	}
+}
+
+func TestTask_ChecklistProgress(t *testing.T) {
+	assert.Empty(t, Task{}.ChecklistProgress())
+	assert.Equal(t, "2/5", Task{ChecklistDone: 2, ChecklistTotal: 5}.ChecklistProgress())
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Comment is a timestamped note on a task. Comments are only ever appended, so
+// together they keep the history the single description field overwrites.
+type Comment struct {
+	ID        string    `json:"id"`
+	TaskID    string    `json:"task_id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Validation constants for comments
+const (
+	MaxCommentLength       = 2000
+	MaxCommentAuthorLength = 50
+)
+
+// CommentTimeLayout is how comment timestamps are shown, in local time
+const CommentTimeLayout = "2006-01-02 15:04"
+
+func NewComment(taskID, author, body string) *Comment {
+	return &Comment{
+		ID:        generateCommentID(),
+		TaskID:    taskID,
+		Author:    strings.TrimSpace(author),
+		Body:      strings.TrimSpace(body),
+		CreatedAt: time.Now(),
+	}
+}
+
+func generateCommentID() string {
+	return fmt.Sprintf("comment_%d", time.Now().UnixNano())
+}
+
+// Timestamp formats CreatedAt with CommentTimeLayout
+func (c Comment) Timestamp() string {
+	return c.CreatedAt.Local().Format(CommentTimeLayout)
+}
+
+func (c *Comment) Validate() error {
+	validator := NewFieldValidator()
+
+	if err := validator.ValidateNotEmpty("body", c.Body, "comment"); err != nil {
+		return err
+	}
+	if err := validator.ValidateMaxLength("body", c.Body, MaxCommentLength, "comment"); err != nil {
+		return err
+	}
+	if err := validator.ValidateNotEmpty("author", c.Author, "comment author"); err != nil {
+		return err
+	}
+	if err := validator.ValidateMaxLength("author", c.Author, MaxCommentAuthorLength, "comment author"); err != nil {
+		return err
+	}
+	if err := validator.ValidateRequiredID(c.TaskID, "task"); err != nil {
+		return NewValidationError("task_id", "task ID cannot be empty")
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComment_Validate(t *testing.T) {
+	comment := NewComment("task_1", " alice ", "  Tried the staging fix;\nstill flaky  ")
+	assert.Equal(t, "alice", comment.Author)
+	assert.Equal(t, "Tried the staging fix;\nstill flaky", comment.Body)
+	assert.NoError(t, comment.Validate())
+
+	for name, invalid := range map[string]*Comment{
+		"empty body":     NewComment("task_1", "alice", "   "),
+		"long body":      NewComment("task_1", "alice", strings.Repeat("x", MaxCommentLength+1)),
+		"missing author": NewComment("task_1", "", "note"),
+		"long author":    NewComment("task_1", strings.Repeat("a", MaxCommentAuthorLength+1), "note"),
+		"missing task":   NewComment("", "alice", "note"),
+	} {
+		assert.Error(t, invalid.Validate(), name)
+	}
+}
@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MaxBlockersPerTask caps how many tasks a single task can wait on
+const MaxBlockersPerTask = 20
+
+// IsBlocked reports whether the task waits on at least one unfinished task
+func (t Task) IsBlocked() bool {
+	return len(t.BlockedBy) > 0
+}
+
+// IsBlockedBy reports whether the task waits on the task numbered intID
+func (t Task) IsBlockedBy(intID int) bool {
+	for _, blocker := range t.BlockedBy {
+		if blocker == intID {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeBlockers sorts blocker numbers and drops duplicates
+func NormalizeBlockers(blockers []int) []int {
+	if len(blockers) == 0 {
+		return nil
+	}
+	sorted := append([]int(nil), blockers...)
+	sort.Ints(sorted)
+
+	normalized := sorted[:1]
+	for _, blocker := range sorted[1:] {
+		if blocker != normalized[len(normalized)-1] {
+			normalized = append(normalized, blocker)
+		}
+	}
+	return normalized
+}
+
+// DependencyGraph maps each task number to the numbers of the tasks blocking it
+type DependencyGraph map[int][]int
+
+// NewDependencyGraph builds the graph of the tasks' blockers
+func NewDependencyGraph(tasks []Task) DependencyGraph {
+	graph := make(DependencyGraph, len(tasks))
+	for _, task := range tasks {
+		if task.IntID != 0 {
+			graph[task.IntID] = task.BlockedBy
+		}
+	}
+	return graph
+}
+
+// Dependents inverts the graph, mapping each task number to the sorted numbers
+// of the tasks waiting on it
+func (g DependencyGraph) Dependents() DependencyGraph {
+	dependents := make(DependencyGraph, len(g))
+	for intID, blockers := range g {
+		for _, blocker := range blockers {
+			dependents[blocker] = append(dependents[blocker], intID)
+		}
+	}
+	for intID, waiting := range dependents {
+		dependents[intID] = NormalizeBlockers(waiting)
+	}
+	return dependents
+}
+
+// Without returns a copy of the graph leaving out the tasks numbered intIDs
+// and every link to them
+func (g DependencyGraph) Without(intIDs ...int) DependencyGraph {
+	left := make(map[int]bool, len(intIDs))
+	for _, intID := range intIDs {
+		left[intID] = true
+	}
+
+	graph := make(DependencyGraph, len(g))
+	for intID, blockers := range g {
+		if left[intID] {
+			continue
+		}
+		var kept []int
+		for _, blocker := range blockers {
+			if !left[blocker] {
+				kept = append(kept, blocker)
+			}
+		}
+		graph[intID] = kept
+	}
+	return graph
+}
+
+// DependencyTree is a task and the tasks it leads to in a DependencyGraph
+type DependencyTree struct {
+	IntID    int
+	Children []DependencyTree
+	Repeated bool // already expanded elsewhere in the tree, so Children is empty
+}
+
+// Tree unfolds the graph from root. A task reachable along several paths is
+// expanded the first time it is met and marked Repeated afterwards.
+func (g DependencyGraph) Tree(root int) DependencyTree {
+	expanded := make(map[int]bool)
+
+	var grow func(intID int) DependencyTree
+	grow = func(intID int) DependencyTree {
+		node := DependencyTree{IntID: intID}
+		if expanded[intID] {
+			node.Repeated = true
+			return node
+		}
+		expanded[intID] = true
+		for _, next := range g[intID] {
+			node.Children = append(node.Children, grow(next))
+		}
+		return node
+	}
+	return grow(root)
+}
+
+// Reachable returns the sorted numbers of the tasks reachable from root,
+// root included
+func (g DependencyGraph) Reachable(root int) []int {
+	seen := map[int]bool{root: true}
+	queue := []int{root}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range g[current] {
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	reachable := make([]int, 0, len(seen))
+	for intID := range seen {
+		reachable = append(reachable, intID)
+	}
+	sort.Ints(reachable)
+	return reachable
+}
+
+// FindCycle reports the cycle that making taskID wait on blockers would create,
+// as the chain of task numbers from taskID back to itself, e.g. [1 3 2 1] for
+// "#1 waits on #3, which waits on #2, which waits on #1". It returns nil when
+// the change keeps the graph acyclic.
+func (g DependencyGraph) FindCycle(taskID int, blockers []int) []int {
+	visited := make(map[int]bool)
+
+	var walk func(current int, path []int) []int
+	walk = func(current int, path []int) []int {
+		path = append(path, current)
+		if current == taskID {
+			return path
+		}
+		if visited[current] {
+			return nil
+		}
+		visited[current] = true
+		for _, next := range g[current] {
+			if cycle := walk(next, path); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+
+	for _, blocker := range NormalizeBlockers(blockers) {
+		if cycle := walk(blocker, []int{taskID}); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// NewDependencyCycleError describes a cycle found by FindCycle
+func NewDependencyCycleError(cycle []int) *ValidationError {
+	steps := make([]string, len(cycle))
+	for i, intID := range cycle {
+		steps[i] = fmt.Sprintf("#%d", intID)
+	}
+	return NewValidationError("blocked_by", "dependency cycle: "+strings.Join(steps, " → "))
+}
+
+// FormatBlockers prints blocker numbers as "#1, #3"
+func FormatBlockers(blockers []int) string {
+	refs := make([]string, len(blockers))
+	for i, blocker := range blockers {
+		refs[i] = fmt.Sprintf("#%d", blocker)
+	}
+	return strings.Join(refs, ", ")
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeBlockers(t *testing.T) {
+	assert.Nil(t, NormalizeBlockers(nil))
+	assert.Equal(t, []int{1, 3, 7}, NormalizeBlockers([]int{7, 3, 1, 3, 7}))
+}
+
+func TestDependencyGraph_FindCycle(t *testing.T) {
+	// #2 waits on #1, #3 waits on #2, #5 waits on #3 and #4
+	tasks := []Task{
+		{IntID: 1},
+		{IntID: 2, BlockedBy: []int{1}},
+		{IntID: 3, BlockedBy: []int{2}},
+		{IntID: 4},
+		{IntID: 5, BlockedBy: []int{3, 4}},
+	}
+	graph := NewDependencyGraph(tasks)
+
+	tests := []struct {
+		name     string
+		taskID   int
+		blockers []int
+		expected []int
+	}{
+		{"no blockers", 1, nil, nil},
+		{"unrelated blocker", 4, []int{1}, nil},
+		{"diamond is not a cycle", 5, []int{2, 3, 4}, nil},
+		{"direct cycle", 1, []int{2}, []int{1, 2, 1}},
+		{"transitive cycle", 1, []int{5}, []int{1, 5, 3, 2, 1}},
+		{"self reference", 4, []int{4}, []int{4, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, graph.FindCycle(tt.taskID, tt.blockers))
+		})
+	}
+}
+
+func TestDependencyGraph_TreeAndReachable(t *testing.T) {
+	// #2 and #3 wait on #1, #4 waits on both
+	graph := NewDependencyGraph([]Task{
+		{IntID: 1},
+		{IntID: 2, BlockedBy: []int{1}},
+		{IntID: 3, BlockedBy: []int{1}},
+		{IntID: 4, BlockedBy: []int{2, 3}},
+		{IntID: 5},
+	})
+
+	blockers := graph.Tree(4)
+	assert.Equal(t, DependencyTree{IntID: 4, Children: []DependencyTree{
+		{IntID: 2, Children: []DependencyTree{{IntID: 1}}},
+		{IntID: 3, Children: []DependencyTree{{IntID: 1, Repeated: true}}},
+	}}, blockers, "The second path to #1 is not expanded again")
+
+	dependents := graph.Dependents()
+	assert.Equal(t, []int{2, 3}, dependents[1])
+	assert.Equal(t, []int{1, 2, 3, 4}, dependents.Reachable(1))
+	assert.Equal(t, []int{5}, graph.Reachable(5))
+
+	without := graph.Without(2)
+	assert.NotContains(t, without, 2)
+	assert.Equal(t, []int{3}, without[4], "Links to the left out task go with it")
+	assert.Equal(t, []int{2, 3}, graph[4], "The graph is left untouched")
+}
+
+func TestNewDependencyCycleError(t *testing.T) {
+	err := NewDependencyCycleError([]int{1, 2, 1})
+	assert.Equal(t, "blocked_by", err.Field)
+	assert.Contains(t, err.Error(), "#1 → #2 → #1")
+}
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateLayout is how start and due dates are stored and printed
+const DateLayout = "2006-01-02"
+
+// DueSoonDays is how many days ahead of its due date a task counts as due soon
+const DueSoonDays = 2
+
+// DueState describes how close a task is to its due date
+type DueState int
+
+const (
+	NoDueDate DueState = iota
+	DueLater
+	DueSoon // due today or within DueSoonDays
+	Overdue // due before today
+)
+
+var relativeDatePattern = regexp.MustCompile(`^([+-])(\d{1,3})([dwm])$`)
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// ParseDate reads a date typed by the user relative to now:
+//   - "2026-11-01"
+//   - "today", "tomorrow" or "yesterday"
+//   - "+3d", "+2w", "+1m" (or "-" for the past)
+//   - a weekday such as "fri", meaning the next one after today
+//
+// An empty value means no date and returns nil.
+func ParseDate(value string, now time.Time) (*time.Time, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return nil, nil
+	}
+
+	today := StartOfDay(now)
+	var date time.Time
+
+	switch value {
+	case "today":
+		date = today
+	case "tomorrow", "tmr":
+		date = today.AddDate(0, 0, 1)
+	case "yesterday":
+		date = today.AddDate(0, 0, -1)
+	default:
+		if match := relativeDatePattern.FindStringSubmatch(value); match != nil {
+			n, _ := strconv.Atoi(match[2])
+			if match[1] == "-" {
+				n = -n
+			}
+			switch match[3] {
+			case "d":
+				date = today.AddDate(0, 0, n)
+			case "w":
+				date = today.AddDate(0, 0, 7*n)
+			case "m":
+				date = today.AddDate(0, n, 0)
+			}
+		} else if weekday, ok := weekdayNames[value]; ok {
+			days := (int(weekday) - int(today.Weekday()) + 7) % 7
+			if days == 0 {
+				days = 7
+			}
+			date = today.AddDate(0, 0, days)
+		} else {
+			parsed, err := time.ParseInLocation(DateLayout, value, now.Location())
+			if err != nil {
+				return nil, NewValidationError("date", fmt.Sprintf("invalid date %q; use YYYY-MM-DD, today, tomorrow, +3d, +2w, +1m or a weekday", value))
+			}
+			date = parsed
+		}
+	}
+
+	return &date, nil
+}
+
+// StartOfDay returns midnight of t's calendar day in t's location
+func StartOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// FormatDate prints a date as YYYY-MM-DD, or "" for no date
+func FormatDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(DateLayout)
+}
+
+// ValidateDateRange rejects a start date that falls after the due date
+func ValidateDateRange(startDate, dueDate *time.Time) error {
+	if startDate != nil && dueDate != nil && FormatDate(startDate) > FormatDate(dueDate) {
+		return NewValidationError("start_date", "start date cannot be after the due date")
+	}
+	return nil
+}
+
+// DueState reports how close the task is to its due date on now's day. Callers
+// decide whether it matters, since a finished task is never late.
+func (t Task) DueState(now time.Time) DueState {
+	if t.DueDate == nil {
+		return NoDueDate
+	}
+
+	// Compare calendar days, whatever location the date was read in
+	year, month, day := t.DueDate.Date()
+	due := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	today := StartOfDay(now)
+	switch {
+	case due.Before(today):
+		return Overdue
+	case !due.After(today.AddDate(0, 0, DueSoonDays)):
+		return DueSoon
+	default:
+		return DueLater
+	}
+}
+
+// IsOverdue reports whether the task's due date is before now's day
+func (t Task) IsOverdue(now time.Time) bool {
+	return t.DueState(now) == Overdue
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDate(t *testing.T) {
+	// Wednesday
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"2026-11-01", "2026-11-01"},
+		{"today", "2026-10-14"},
+		{"Tomorrow", "2026-10-15"},
+		{"yesterday", "2026-10-13"},
+		{"+3d", "2026-10-17"},
+		{"-1d", "2026-10-13"},
+		{"+2w", "2026-10-28"},
+		{"+1m", "2026-11-14"},
+		{"fri", "2026-10-16"},
+		{"friday", "2026-10-16"},
+		{"wed", "2026-10-21"}, // a weekday means the next one, never today
+		{"mon", "2026-10-19"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			date, err := ParseDate(tt.input, now)
+			require.NoError(t, err)
+			require.NotNil(t, date)
+			assert.Equal(t, tt.expected, FormatDate(date))
+		})
+	}
+
+	date, err := ParseDate("  ", now)
+	assert.NoError(t, err)
+	assert.Nil(t, date, "An empty value means no date")
+
+	for _, input := range []string{"next week", "2026-13-01", "+3y", "11/01/2026"} {
+		_, err := ParseDate(input, now)
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr, input)
+	}
+}
+
+func TestTask_DueState(t *testing.T) {
+	now := time.Date(2026, 10, 14, 23, 59, 0, 0, time.UTC)
+	due := func(value string) *time.Time {
+		date, err := ParseDate(value, now)
+		require.NoError(t, err)
+		return date
+	}
+
+	tests := []struct {
+		name     string
+		dueDate  *time.Time
+		expected DueState
+	}{
+		{"no due date", nil, NoDueDate},
+		{"yesterday", due("-1d"), Overdue},
+		{"today", due("today"), DueSoon},
+		{"in two days", due("+2d"), DueSoon},
+		{"in three days", due("+3d"), DueLater},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := Task{DueDate: tt.dueDate}
+			assert.Equal(t, tt.expected, task.DueState(now))
+			assert.Equal(t, tt.expected == Overdue, task.IsOverdue(now))
+		})
+	}
+}
+
+func TestTask_ValidateDates(t *testing.T) {
+	now := time.Now()
+	start, _ := ParseDate("+3d", now)
+	due, _ := ParseDate("+1d", now)
+
+	task := NewTask("Task", "", "proj_1")
+	task.StartDate = start
+	task.DueDate = due
+	assert.Error(t, task.Validate(), "Start date after the due date")
+
+	task.StartDate = due
+	assert.NoError(t, task.Validate(), "Starting on the due date is fine")
+}
+
+func TestSortTasks_OverdueFirst(t *testing.T) {
+	baseTime := time.Now()
+	longAgo, _ := ParseDate("-10d", baseTime)
+	lastWeek, _ := ParseDate("-7d", baseTime)
+	nextWeek, _ := ParseDate("+7d", baseTime)
+
+	tasks := []Task{
+		{Name: "High Priority", Priority: High, CreatedAt: baseTime},
+		{Name: "Overdue Low", Priority: Low, DueDate: lastWeek, CreatedAt: baseTime},
+		{Name: "Due Later", Priority: Medium, DueDate: nextWeek, CreatedAt: baseTime},
+		{Name: "Most Overdue", Priority: Low, DueDate: longAgo, CreatedAt: baseTime.Add(time.Hour)},
+	}
+
+	sorted := SortTasks(tasks, NotStarted)
+	var names []string
+	for _, task := range sorted {
+		names = append(names, task.Name)
+	}
+	assert.Equal(t, []string{"Most Overdue", "Overdue Low", "High Priority", "Due Later"}, names)
+}
@@ -52,3 +52,13 @@ func NewNotFoundError(entity, id string, cause error) *RepositoryError {
 		Cause:     cause,
 	}
 }
+
+// NewWIPLimitError creates a WIPLimitError for a move that puts count tasks into a column
+func NewWIPLimitError(status string, limit, count int, enforced bool) *WIPLimitError {
+	return &WIPLimitError{
+		Status:   status,
+		Limit:    limit,
+		Count:    count,
+		Enforced: enforced,
+	}
+}
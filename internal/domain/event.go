@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventKind says what an audit log event records
+type EventKind string
+
+const (
+	EventCreated    EventKind = "created"
+	EventChanged    EventKind = "changed"   // Field went from OldValue to NewValue
+	EventMoved      EventKind = "moved"     // the task changed column; values are column names
+	EventBlocked    EventKind = "blocked"   // the task now waits on NewValue, e.g. "#3"
+	EventUnblocked  EventKind = "unblocked" // the task no longer waits on OldValue
+	EventDeleted    EventKind = "deleted"   // moved to the trash
+	EventRestored   EventKind = "restored"  // brought back from the trash
+	EventPurged     EventKind = "purged"    // deleted from the trash for good
+	EventArchived   EventKind = "archived"
+	EventUnarchived EventKind = "unarchived"
+	EventUndone     EventKind = "undone" // NewValue describes the change that was reverted
+	EventRedone     EventKind = "redone" // NewValue describes the change that was made again
+)
+
+// Event is one entry of the append-only audit log. Events outlive what they
+// describe, so they carry the number and name the task or project had at the
+// time. Project events have no TaskID.
+type Event struct {
+	ID        int64     `json:"id"`
+	ProjectID string    `json:"project_id"`
+	TaskID    string    `json:"task_id,omitempty"`
+	TaskIntID int       `json:"task_int_id,omitempty"`
+	Subject   string    `json:"subject"`
+	Kind      EventKind `json:"kind"`
+	Field     string    `json:"field,omitempty"`
+	OldValue  string    `json:"old_value,omitempty"`
+	NewValue  string    `json:"new_value,omitempty"`
+	Actor     string    `json:"actor"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EventFilter narrows a listing of the audit log. Zero fields do not filter;
+// Since is inclusive and Until exclusive. A positive Limit keeps the newest events.
+type EventFilter struct {
+	ProjectID string
+	TaskIntID int
+	Since     time.Time
+	Until     time.Time
+	Limit     int
+}
+
+// maxEventValueLength is how much of a changed value Describe shows
+const maxEventValueLength = 40
+
+// IsTaskEvent reports whether the event is about a task rather than a project
+func (e Event) IsTaskEvent() bool {
+	return e.TaskIntID != 0
+}
+
+// Target names what the event is about, e.g. "#12 Fix login" or "project Website"
+func (e Event) Target() string {
+	if e.IsTaskEvent() {
+		return fmt.Sprintf("#%d %s", e.TaskIntID, e.Subject)
+	}
+	return "project " + e.Subject
+}
+
+// Timestamp formats CreatedAt like comment timestamps, in local time
+func (e Event) Timestamp() string {
+	return e.CreatedAt.Local().Format(CommentTimeLayout)
+}
+
+// Describe prints what happened, e.g. "priority: Low → High"
+func (e Event) Describe() string {
+	switch e.Kind {
+	case EventCreated:
+		if e.NewValue != "" {
+			return "created in " + e.NewValue
+		}
+		return "created"
+	case EventChanged:
+		return fmt.Sprintf("%s: %s → %s", e.Field, eventValue(e.OldValue), eventValue(e.NewValue))
+	case EventMoved:
+		return fmt.Sprintf("moved %s → %s", e.OldValue, e.NewValue)
+	case EventBlocked:
+		return "now waits on " + e.NewValue
+	case EventUnblocked:
+		return "no longer waits on " + e.OldValue
+	case EventDeleted:
+		return "deleted"
+	case EventRestored:
+		return "restored from the trash"
+	case EventPurged:
+		return "deleted permanently"
+	case EventArchived:
+		return "archived"
+	case EventUnarchived:
+		return "unarchived"
+	case EventUndone:
+		return "undid " + e.NewValue
+	case EventRedone:
+		return "redid " + e.NewValue
+	}
+	return string(e.Kind)
+}
+
+// eventValue shortens a value to one line for Describe
+func eventValue(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	runes := []rune(value)
+	for i, r := range runes {
+		if r == '\n' || r == '\r' {
+			runes = append(runes[:i:i], []rune("…")...)
+			break
+		}
+	}
+	if len(runes) > maxEventValueLength {
+		runes = append(runes[:maxEventValueLength-1:maxEventValueLength-1], '…')
+	}
+	return string(runes)
+}
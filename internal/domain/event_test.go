@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_Describe(t *testing.T) {
+	task := Event{TaskIntID: 12, Subject: "Fix login"}
+	assert.Equal(t, "#12 Fix login", task.Target())
+	assert.Equal(t, "project Website", Event{Subject: "Website"}.Target())
+
+	tests := map[string]struct {
+		event    Event
+		expected string
+	}{
+		"created":   {Event{Kind: EventCreated, NewValue: "Backlog"}, "created in Backlog"},
+		"changed":   {Event{Kind: EventChanged, Field: "priority", OldValue: "Low", NewValue: "High"}, "priority: Low → High"},
+		"cleared":   {Event{Kind: EventChanged, Field: "due_date", OldValue: "2026-03-01"}, "due_date: 2026-03-01 → (none)"},
+		"multiline": {Event{Kind: EventChanged, Field: "description", NewValue: "first\nsecond"}, "description: (none) → first…"},
+		"moved":     {Event{Kind: EventMoved, OldValue: "Doing", NewValue: "Done"}, "moved Doing → Done"},
+		"blocked":   {Event{Kind: EventBlocked, NewValue: "#3"}, "now waits on #3"},
+		"unblocked": {Event{Kind: EventUnblocked, OldValue: "#3"}, "no longer waits on #3"},
+		"deleted":   {Event{Kind: EventDeleted}, "deleted"},
+		"restored":  {Event{Kind: EventRestored}, "restored from the trash"},
+		"purged":    {Event{Kind: EventPurged}, "deleted permanently"},
+		"archived":  {Event{Kind: EventArchived}, "archived"},
+		"undone":    {Event{Kind: EventUndone, NewValue: "move to Done"}, "undid move to Done"},
+		"redone":    {Event{Kind: EventRedone, NewValue: "delete"}, "redid delete"},
+	}
+	for name, tt := range tests {
+		assert.Equal(t, tt.expected, tt.event.Describe(), name)
+	}
+
+	long := Event{Kind: EventChanged, Field: "name", OldValue: strings.Repeat("é", 60), NewValue: "short"}
+	assert.Equal(t, "name: "+strings.Repeat("é", maxEventValueLength-1)+"… → short", long.Describe())
+}
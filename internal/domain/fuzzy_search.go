@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/sahilm/fuzzy"
+)
+
+// FuzzyMatch is a task whose name contains the characters of a fuzzy search in
+// order, such as "fix lgn" in "Fix login redirect"
+type FuzzyMatch struct {
+	Task  Task
+	Score int
+	// MatchedIndexes are the byte offsets in Task.Name of the matched characters
+	MatchedIndexes []int
+}
+
+// taskNames lets fuzzy.FindFrom read task names without copying them
+type taskNames []Task
+
+func (t taskNames) String(i int) string { return t[i].Name }
+func (t taskNames) Len() int            { return len(t) }
+
+// FuzzyFilter returns the tasks whose name fuzzily matches pattern, best score
+// first and in their given order on a tie. A blank pattern matches every task,
+// in its given order, with nothing highlighted.
+func FuzzyFilter(tasks []Task, pattern string) []FuzzyMatch {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		matches := make([]FuzzyMatch, len(tasks))
+		for i, task := range tasks {
+			matches[i] = FuzzyMatch{Task: task}
+		}
+		return matches
+	}
+
+	found := fuzzy.FindFrom(pattern, taskNames(tasks))
+	matches := make([]FuzzyMatch, len(found))
+	for i, match := range found {
+		matches[i] = FuzzyMatch{
+			Task:           tasks[match.Index],
+			Score:          match.Score,
+			MatchedIndexes: match.MatchedIndexes,
+		}
+	}
+	return matches
+}
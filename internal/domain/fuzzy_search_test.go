@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuzzyFilter(t *testing.T) {
+	tasks := []Task{
+		{ID: "t1", Name: "Update docs"},
+		{ID: "t2", Name: "Log slow queries in"},
+		{ID: "t3", Name: "Fix login redirect"},
+		{ID: "t4", Name: "Login page"},
+	}
+
+	matches := FuzzyFilter(tasks, "fix lgn")
+	require.Len(t, matches, 1, "Characters must appear in order")
+	assert.Equal(t, "t3", matches[0].Task.ID)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 6, 8}, matches[0].MatchedIndexes)
+
+	matches = FuzzyFilter(tasks, "LOGIN")
+	require.Len(t, matches, 3, "Matching ignores case")
+	assert.Equal(t, "t4", matches[0].Task.ID, "A match at the start scores best")
+	assert.Equal(t, "t3", matches[1].Task.ID, "then one in a word")
+	assert.Equal(t, "t2", matches[2].Task.ID, "then scattered characters")
+	assert.Greater(t, matches[0].Score, matches[1].Score)
+
+	assert.Empty(t, FuzzyFilter(tasks, "xyz"))
+
+	matches = FuzzyFilter(tasks, "  ")
+	require.Len(t, matches, 4, "A blank pattern matches every task")
+	assert.Equal(t, "t1", matches[0].Task.ID, "in the given order")
+	assert.Empty(t, matches[0].MatchedIndexes)
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Label is a free-form, project-scoped tag such as "backend" or "tech-debt".
+// Names are unique within a project, ignoring case.
+type Label struct {
+	ID        string    `json:"id"`
+	ProjectID string    `json:"project_id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Validation constants for labels
+const (
+	MaxLabelNameLength = 20
+	MaxLabelsPerTask   = 8
+)
+
+// LabelColors are assigned in turn to labels created without a color
+var LabelColors = []string{
+	"#89b4fa", // blue
+	"#a6e3a1", // green
+	"#f9e2af", // yellow
+	"#fab387", // peach
+	"#cba6f7", // mauve
+	"#94e2d5", // teal
+	"#f5c2e7", // pink
+	"#74c7ec", // sapphire
+}
+
+var (
+	labelNamePattern  = regexp.MustCompile(`^[\p{L}\p{N}._-]+$`)
+	labelColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+func NewLabel(projectID, name, color string) *Label {
+	return &Label{
+		ID:        generateLabelID(),
+		ProjectID: projectID,
+		Name:      strings.TrimSpace(name),
+		Color:     color,
+		CreatedAt: time.Now(),
+	}
+}
+
+func generateLabelID() string {
+	return fmt.Sprintf("label_%d", time.Now().UnixNano())
+}
+
+func (l *Label) Validate() error {
+	if err := ValidateLabelName(l.Name); err != nil {
+		return err
+	}
+	if err := NewFieldValidator().ValidateRequiredID(l.ProjectID, "label"); err != nil {
+		return NewValidationError("project_id", "project ID cannot be empty")
+	}
+	if !labelColorPattern.MatchString(l.Color) {
+		return NewValidationError("color", fmt.Sprintf("invalid label color %q; expected #rrggbb", l.Color))
+	}
+	return nil
+}
+
+// ValidateLabelName checks that name can be typed as a single word, as in the
+// search filter "label:backend"
+func ValidateLabelName(name string) error {
+	validator := NewFieldValidator()
+
+	if err := validator.ValidateNotEmpty("name", name, "label"); err != nil {
+		return err
+	}
+	if err := validator.ValidateMaxLength("name", name, MaxLabelNameLength, "label"); err != nil {
+		return err
+	}
+	if !labelNamePattern.MatchString(name) {
+		return NewValidationError("name", fmt.Sprintf("label %q may only contain letters, digits, '.', '-' and '_'", name))
+	}
+	return nil
+}
+
+// ParseLabelNames splits a comma or space separated list of label names, dropping
+// blanks and repeats that differ only in case
+func ParseLabelNames(value string) []string {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+
+	names := make([]string, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+	for _, name := range fields {
+		key := strings.ToLower(name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// SortLabels orders labels by name, ignoring case, as tasks list them
+func SortLabels(labels []Label) {
+	sort.Slice(labels, func(i, j int) bool {
+		return strings.ToLower(labels[i].Name) < strings.ToLower(labels[j].Name)
+	})
+}
+
+// HasLabel reports whether the task carries a label with the given name, ignoring case
+func (t Task) HasLabel(name string) bool {
+	for _, label := range t.Labels {
+		if strings.EqualFold(label.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// LabelNames returns the names of the task's labels in display order
+func (t Task) LabelNames() []string {
+	names := make([]string, len(t.Labels))
+	for i, label := range t.Labels {
+		names[i] = label.Name
+	}
+	return names
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabel_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		label   Label
+		wantErr string
+	}{
+		{"valid", Label{Name: "tech-debt", ProjectID: "proj_1", Color: "#89b4fa"}, ""},
+		{"empty name", Label{Name: "", ProjectID: "proj_1", Color: "#89b4fa"}, "cannot be empty"},
+		{"space in name", Label{Name: "tech debt", ProjectID: "proj_1", Color: "#89b4fa"}, "may only contain"},
+		{"colon in name", Label{Name: "label:x", ProjectID: "proj_1", Color: "#89b4fa"}, "may only contain"},
+		{"long name", Label{Name: "abcdefghijklmnopqrstu", ProjectID: "proj_1", Color: "#89b4fa"}, "too long"},
+		{"no project", Label{Name: "backend", Color: "#89b4fa"}, "project ID"},
+		{"bad color", Label{Name: "backend", ProjectID: "proj_1", Color: "blue"}, "invalid label color"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.label.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseLabelNames(t *testing.T) {
+	assert.Equal(t, []string{"backend", "customer-x", "tech-debt"}, ParseLabelNames(" backend, customer-x  tech-debt,,Backend "))
+	assert.Empty(t, ParseLabelNames("  , "))
+}
+
+func TestTask_HasLabel(t *testing.T) {
+	task := Task{Labels: []Label{{Name: "Backend"}, {Name: "urgent"}}}
+
+	assert.True(t, task.HasLabel("backend"))
+	assert.False(t, task.HasLabel("frontend"))
+	assert.Equal(t, []string{"Backend", "urgent"}, task.LabelNames())
+}
@@ -0,0 +1,61 @@
+package domain
+
+// Columns of the My Work board, which gathers from every project the tasks
+// being worked on and the urgent ones waiting to start
+const (
+	MyWorkUpNext     = 0 // high-priority tasks in a project's first column
+	MyWorkInProgress = 1 // tasks in any later column but the done one
+)
+
+// MyWorkColumnNames are the titles of the My Work columns, in order
+var MyWorkColumnNames = []string{"Up Next", "In Progress"}
+
+// MyWorkTask is a card on the My Work board: a task with the project it
+// belongs to, whose Tasks are left empty
+type MyWorkTask struct {
+	Task    Task
+	Project Project
+}
+
+// MyWorkColumn returns the My Work column of a task on a board with the
+// workflow, or false when the task is not shown there
+func MyWorkColumn(task Task, workflow Workflow) (int, bool) {
+	switch {
+	case workflow.IsDone(task.Status):
+		return 0, false
+	case task.Status == NotStarted:
+		return MyWorkUpNext, task.Priority == High
+	default:
+		return MyWorkInProgress, true
+	}
+}
+
+// CollectMyWork gathers the My Work columns from projects with their tasks
+// loaded. Up Next is ordered as a first column, overdue tasks first, and In
+// Progress as later columns, most recently updated first.
+func CollectMyWork(projects []Project) [][]MyWorkTask {
+	columns := make([][]Task, len(MyWorkColumnNames))
+	projectOf := make(map[string]Project, len(projects))
+	for _, project := range projects {
+		tasks := project.Tasks
+		project.Tasks = nil
+		projectOf[project.ID] = project
+		for _, task := range tasks {
+			if column, ok := MyWorkColumn(task, project.Workflow); ok {
+				columns[column] = append(columns[column], task)
+			}
+		}
+	}
+
+	cards := make([][]MyWorkTask, len(columns))
+	for column, tasks := range columns {
+		status := NotStarted
+		if column == MyWorkInProgress {
+			status = InProgress
+		}
+		for _, task := range SortTasks(tasks, status) {
+			cards[column] = append(cards[column], MyWorkTask{Task: task, Project: projectOf[task.ProjectID]})
+		}
+	}
+	return cards
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMyWorkColumn(t *testing.T) {
+	team := teamWorkflow(t)
+
+	tests := []struct {
+		name     string
+		task     Task
+		workflow Workflow
+		column   int
+		shown    bool
+	}{
+		{"High priority not started", Task{Status: NotStarted, Priority: High}, nil, MyWorkUpNext, true},
+		{"Medium priority not started", Task{Status: NotStarted, Priority: Medium}, nil, MyWorkUpNext, false},
+		{"In progress", Task{Status: InProgress, Priority: Low}, nil, MyWorkInProgress, true},
+		{"Done", Task{Status: Done, Priority: High}, nil, 0, false},
+		{"Custom middle column", Task{Status: Status(3)}, team, MyWorkInProgress, true},
+		{"Custom Done column", Task{Status: Status(5), Priority: High}, team, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			column, shown := MyWorkColumn(tt.task, tt.workflow)
+			assert.Equal(t, tt.shown, shown)
+			if shown {
+				assert.Equal(t, tt.column, column)
+			}
+		})
+	}
+}
+
+func TestCollectMyWork(t *testing.T) {
+	baseTime := time.Now()
+	website := Project{ID: "proj_web", Name: "Website", Tasks: []Task{
+		{ID: "1", ProjectID: "proj_web", Name: "Fix login", Status: NotStarted, Priority: High, CreatedAt: baseTime.Add(time.Hour)},
+		{ID: "2", ProjectID: "proj_web", Name: "Tweak footer", Status: NotStarted, Priority: Low},
+		{ID: "3", ProjectID: "proj_web", Name: "Redesign", Status: InProgress, UpdatedAt: baseTime},
+		{ID: "4", ProjectID: "proj_web", Name: "Launch", Status: Done, Priority: High},
+	}}
+	api := Project{ID: "proj_api", Name: "API", Workflow: teamWorkflow(t), Tasks: []Task{
+		{ID: "5", ProjectID: "proj_api", Name: "Rate limits", Status: NotStarted, Priority: High, CreatedAt: baseTime},
+		{ID: "6", ProjectID: "proj_api", Name: "Review auth", Status: Status(3), UpdatedAt: baseTime.Add(time.Hour)},
+		{ID: "7", ProjectID: "proj_api", Name: "Release", Status: Status(5)},
+	}}
+
+	columns := CollectMyWork([]Project{website, api})
+	require.Len(t, columns, len(MyWorkColumnNames))
+
+	names := func(cards []MyWorkTask) []string {
+		var names []string
+		for _, card := range cards {
+			names = append(names, card.Task.Name)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"Rate limits", "Fix login"}, names(columns[MyWorkUpNext]), "Oldest first among high priority tasks")
+	assert.Equal(t, []string{"Review auth", "Redesign"}, names(columns[MyWorkInProgress]), "Most recently updated first")
+
+	card := columns[MyWorkInProgress][0]
+	assert.Equal(t, "API", card.Project.Name)
+	assert.Equal(t, "In Review", card.Project.Workflow.Name(card.Task.Status))
+	assert.Empty(t, card.Project.Tasks, "Cards do not carry their project's tasks")
+	assert.Len(t, website.Tasks, 4, "The given projects are left untouched")
+}
@@ -6,13 +6,15 @@ import (
 )
 
 type Project struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	Color       string    `json:"color"`
-	Tasks       []Task    `json:"tasks"`
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	Color       string     `json:"color"`
+	Workflow    Workflow   `json:"workflow"`
+	Tasks       []Task     `json:"tasks"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"` // set while the project is in the trash
 }
 
 func NewProject(name, description, color string) *Project {
@@ -24,6 +26,7 @@ func NewProject(name, description, color string) *Project {
 		CreatedAt:   now,
 		UpdatedAt:   now,
 		Color:       color,
+		Workflow:    DefaultWorkflow(),
 		Tasks:       []Task{},
 	}
 }
@@ -90,5 +93,8 @@ func (p *Project) Validate() error {
 	if err := validator.ValidateMaxLength("description", p.Description, MaxProjectDescriptionLength, "project"); err != nil {
 		return err
 	}
+	if len(p.Workflow) > 0 {
+		return p.Workflow.Validate()
+	}
 	return nil
 }
@@ -5,12 +5,52 @@ import "fmt"
 type TaskRepository interface {
 	Create(task *Task) error
 	GetByID(id string) (*Task, error)
+	GetByIntID(intID int) (*Task, error)
 	GetByProjectID(projectID string) ([]Task, error)
 	GetByStatus(projectID string, status Status) ([]Task, error)
 	Update(task *Task) error
 	UpdateStatus(taskID string, status Status) error
 	ClearBlockersForIntID(intID int) error
+	// GetDependencyGraph returns every stored link between the project's tasks,
+	// those of archived and trashed tasks included
+	GetDependencyGraph(projectID string) (DependencyGraph, error)
+
+	// Delete moves a task to the trash. The getters above leave out trashed
+	// tasks and the tasks of trashed projects, and GetByProjectID and
+	// GetByStatus archived ones too. Tasks waiting on a trashed task keep the
+	// link, which counts again once it is restored.
 	Delete(id string) error
+	Restore(id string) error
+	// Purge deletes a task for good with its checklist, comments and links
+	Purge(id string) error
+	// GetTrashed lists the trashed tasks of a project, or of every project
+	// outside the trash when projectID is empty, most recently deleted first
+	GetTrashed(projectID string) ([]Task, error)
+	Archive(id string) error
+	Unarchive(id string) error
+	// GetArchived lists a project's archived tasks, most recently archived first
+	GetArchived(projectID string) ([]Task, error)
+
+	// Checklist items belong to their task and are deleted with it
+	GetChecklist(taskID string) ([]ChecklistItem, error)
+	GetChecklistItem(id string) (*ChecklistItem, error)
+	CreateChecklistItem(item *ChecklistItem) error
+	UpdateChecklistItem(item *ChecklistItem) error
+	// DeleteChecklistItem removes an item and closes the gap in the positions
+	DeleteChecklistItem(id string) error
+	// ReorderChecklist renumbers a task's items in the order of itemIDs
+	ReorderChecklist(taskID string, itemIDs []string) error
+
+	// Comments are append-only, listed oldest first and deleted with their task.
+	// Tasks returned by the getters above carry their comments.
+	GetComments(taskID string) ([]Comment, error)
+	CreateComment(comment *Comment) error
+
+	// Search finds the tasks on the boards of every project whose name,
+	// description or comments match query, best match first and at most limit.
+	// Each word matches as a prefix and "quoted phrases" as written. The tasks
+	// carry their labels and comments.
+	Search(query string, limit int) ([]SearchResult, error)
 }
 
 type ProjectRepository interface {
@@ -18,9 +58,65 @@ type ProjectRepository interface {
 	GetByID(id string) (*Project, error)
 	GetAll() ([]Project, error)
 	Update(project *Project) error
+	// SaveWorkflow replaces a project's workflow and moves each task from its old
+	// status to remap[status] in the same transaction
+	SaveWorkflow(projectID string, workflow Workflow, remap map[Status]Status) error
+	// Delete moves a project to the trash, where GetByID and GetAll no longer find it
+	Delete(id string) error
+	Restore(id string) error
+	// Purge deletes a project for good with its workflow, labels and tasks
+	Purge(id string) error
+	// GetTrashed lists the trashed projects, most recently deleted first
+	GetTrashed() ([]Project, error)
+}
+
+type LabelRepository interface {
+	Create(label *Label) error
+	GetByID(id string) (*Label, error)
+	GetByProjectID(projectID string) ([]Label, error)
+	Update(label *Label) error
+	Delete(id string) error
+	// SetTaskLabels replaces the labels of a task
+	SetTaskLabels(taskID string, labelIDs []string) error
+}
+
+// SavedViewRepository stores the named search queries of the board
+type SavedViewRepository interface {
+	Create(view *SavedView) error
+	GetByID(id string) (*SavedView, error)
+	// GetByProjectID lists a project's views and the global ones, or only the
+	// global ones when projectID is empty
+	GetByProjectID(projectID string) ([]SavedView, error)
+	// GetAll lists the views of every project outside the trash and the global ones
+	GetAll() ([]SavedView, error)
+	Update(view *SavedView) error
 	Delete(id string) error
 }
 
+// EventRepository stores the audit log. Events are never updated or deleted.
+type EventRepository interface {
+	// Append stores the event and fills in its ID
+	Append(event *Event) error
+	// List returns the events matching filter, oldest first
+	List(filter EventFilter) ([]Event, error)
+}
+
+// UndoRepository stores the undo and redo stacks and applies their actions
+type UndoRepository interface {
+	// Push stores a new operation and fills in its ID. It discards the undone
+	// operations and the oldest beyond MaxUndoOperations.
+	Push(op *UndoOperation) error
+	// LastDone returns the operation to undo next, or nil
+	LastDone() (*UndoOperation, error)
+	// FirstUndone returns the operation to redo next, or nil
+	FirstUndone() (*UndoOperation, error)
+	// Apply runs the Undo actions of op, or its Redo actions when undo is false,
+	// and stores op as undone or done, all in one transaction
+	Apply(op *UndoOperation, undo bool) error
+	// Drop forgets an operation that can no longer be applied
+	Drop(id int64) error
+}
+
 type ValidationError struct {
 	Field   string
 	Message string
@@ -47,3 +143,19 @@ func (e *RepositoryError) Error() string {
 func (e *RepositoryError) Unwrap() error {
 	return e.Cause
 }
+
+// WIPLimitError reports a move that takes a column over its work-in-progress limit.
+// Enforced is false when the move was saved anyway and the error is only a warning.
+type WIPLimitError struct {
+	Status   string
+	Limit    int
+	Count    int // tasks in the column including the moved one
+	Enforced bool
+}
+
+func (e *WIPLimitError) Error() string {
+	if e.Enforced {
+		return fmt.Sprintf("moving the task would put '%s' over its WIP limit (%d/%d)", e.Status, e.Count, e.Limit)
+	}
+	return fmt.Sprintf("'%s' is over its WIP limit (%d/%d)", e.Status, e.Count, e.Limit)
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SavedView is a named search query, such as "my high-priority bugs", applied to
+// the board in one keystroke. A view belongs to a project, or to every project
+// when ProjectID is empty. Names are unique within that scope, ignoring case.
+type SavedView struct {
+	ID        string    `json:"id"`
+	ProjectID string    `json:"project_id"`
+	Name      string    `json:"name"`
+	Query     string    `json:"query"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Validation constants for saved views
+const (
+	MaxSavedViewNameLength  = 30
+	MaxSavedViewQueryLength = 500
+)
+
+func NewSavedView(projectID, name, query string) *SavedView {
+	return &SavedView{
+		ID:        generateSavedViewID(),
+		ProjectID: projectID,
+		Name:      strings.TrimSpace(name),
+		Query:     strings.TrimSpace(query),
+		CreatedAt: time.Now(),
+	}
+}
+
+func generateSavedViewID() string {
+	return fmt.Sprintf("view_%d", time.Now().UnixNano())
+}
+
+// IsGlobal reports whether the view applies to every project
+func (v SavedView) IsGlobal() bool {
+	return v.ProjectID == ""
+}
+
+// Validate checks the name and query. Whether the query parses depends on the
+// workflow it is applied to, so it is checked by ParseTaskQuery separately.
+func (v *SavedView) Validate() error {
+	validator := NewFieldValidator()
+
+	if err := validator.ValidateNotEmpty("name", v.Name, "view"); err != nil {
+		return err
+	}
+	if err := validator.ValidateMaxLength("name", v.Name, MaxSavedViewNameLength, "view"); err != nil {
+		return err
+	}
+	if err := validator.ValidateNotEmpty("query", v.Query, "view"); err != nil {
+		return err
+	}
+	return validator.ValidateMaxLength("query", v.Query, MaxSavedViewQueryLength, "view")
+}
+
+// SortSavedViews orders a project's views before the global ones, each by name
+// ignoring case, as the picker lists them
+func SortSavedViews(views []SavedView) {
+	sort.SliceStable(views, func(i, j int) bool {
+		if views[i].IsGlobal() != views[j].IsGlobal() {
+			return !views[i].IsGlobal()
+		}
+		return strings.ToLower(views[i].Name) < strings.ToLower(views[j].Name)
+	})
+}
+
+// FindSavedView returns the view with the given name, ignoring case, or nil
+func FindSavedView(views []SavedView, name string) *SavedView {
+	name = strings.TrimSpace(name)
+	for i := range views {
+		if strings.EqualFold(views[i].Name, name) {
+			return &views[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedView_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		view    SavedView
+		wantErr string
+	}{
+		{"valid", SavedView{Name: "My bugs", Query: "type:bug"}, ""},
+		{"empty name", SavedView{Name: " ", Query: "type:bug"}, "view name cannot be empty"},
+		{"long name", SavedView{Name: strings.Repeat("a", MaxSavedViewNameLength+1), Query: "type:bug"}, "too long"},
+		{"empty query", SavedView{Name: "My bugs"}, "view query cannot be empty"},
+		{"long query", SavedView{Name: "My bugs", Query: strings.Repeat("a", MaxSavedViewQueryLength+1)}, "too long"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.view.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSortSavedViews(t *testing.T) {
+	views := []SavedView{
+		{Name: "stale", ProjectID: ""},
+		{Name: "blocked", ProjectID: "proj_1"},
+		{Name: "Bugs", ProjectID: ""},
+		{Name: "Api", ProjectID: "proj_1"},
+	}
+	SortSavedViews(views)
+
+	var names []string
+	for _, view := range views {
+		names = append(names, view.Name)
+	}
+	assert.Equal(t, []string{"Api", "blocked", "Bugs", "stale"}, names, "Project views come first")
+	assert.Equal(t, "Bugs", FindSavedView(views, " bugs ").Name)
+	assert.Nil(t, FindSavedView(views, "missing"))
+}
+
+func TestTaskQuery_And(t *testing.T) {
+	bugs, err := ParseTaskQuery("type:bug", DefaultWorkflow(), time.Now())
+	assert.NoError(t, err)
+	urgent, err := ParseTaskQuery("priority:high", DefaultWorkflow(), time.Now())
+	assert.NoError(t, err)
+
+	tasks := []Task{
+		{Name: "a", Type: Bug, Priority: High},
+		{Name: "b", Type: Bug, Priority: Low},
+		{Name: "c", Type: Feature, Priority: High},
+	}
+	assert.Equal(t, 1, bugs.And(urgent).Count(tasks))
+	assert.Equal(t, 2, TaskQuery{}.And(bugs).Count(tasks), "The zero query matches every task")
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "strings"
+
+// SearchResult is a task found by a full-text search of names, descriptions and
+// comments across every project
+type SearchResult struct {
+	Task        Task
+	ProjectName string
+	// Name is the task name and Snippet the passage of its name, description or
+	// comments that matches best, each match wrapped in HighlightStart and
+	// HighlightEnd
+	Name    string
+	Snippet string
+}
+
+// Markers around the matched words in SearchResult.Name and Snippet. They are
+// control characters, which task text rarely holds; the search pane drops any
+// left between the markers.
+const (
+	HighlightStart = "\x02"
+	HighlightEnd   = "\x03"
+)
+
+// MaxSearchResults caps how many results a full-text search returns
+const MaxSearchResults = 50
+
+// HighlightSpan is a run of highlighted text, or of the text between matches
+type HighlightSpan struct {
+	Text        string
+	Highlighted bool
+}
+
+// SplitHighlights splits marked text into its highlighted and plain runs, in order
+func SplitHighlights(text string) []HighlightSpan {
+	var spans []HighlightSpan
+	for text != "" {
+		start := strings.Index(text, HighlightStart)
+		if start < 0 {
+			spans = append(spans, HighlightSpan{Text: text})
+			break
+		}
+		if start > 0 {
+			spans = append(spans, HighlightSpan{Text: text[:start]})
+		}
+		text = text[start+len(HighlightStart):]
+
+		end := strings.Index(text, HighlightEnd)
+		if end < 0 {
+			end = len(text)
+		}
+		if end > 0 {
+			spans = append(spans, HighlightSpan{Text: text[:end], Highlighted: true})
+		}
+		text = strings.TrimPrefix(text[end:], HighlightEnd)
+	}
+	return spans
+}
+
+// StripHighlights removes the highlight markers from text
+func StripHighlights(text string) string {
+	return strings.NewReplacer(HighlightStart, "", HighlightEnd, "").Replace(text)
+}
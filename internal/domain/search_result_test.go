@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitHighlights(t *testing.T) {
+	marked := "Fix " + HighlightStart + "login" + HighlightEnd + " on " + HighlightStart + "staging" + HighlightEnd
+	assert.Equal(t, []HighlightSpan{
+		{Text: "Fix "},
+		{Text: "login", Highlighted: true},
+		{Text: " on "},
+		{Text: "staging", Highlighted: true},
+	}, SplitHighlights(marked))
+	assert.Equal(t, "Fix login on staging", StripHighlights(marked))
+
+	assert.Equal(t, []HighlightSpan{{Text: "plain"}}, SplitHighlights("plain"))
+	assert.Empty(t, SplitHighlights(""))
+	assert.Equal(t, []HighlightSpan{{Text: "a "}, {Text: "open", Highlighted: true}},
+		SplitHighlights("a "+HighlightStart+"open"), "An unclosed highlight runs to the end")
+}
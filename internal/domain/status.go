@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"fmt"
+	"strings"
+)
+
 type Status int
 
 const (
@@ -20,3 +25,22 @@ func (s Status) ToString() string {
 		return "Placeholder"
 	}
 }
+
+// ParseStatus converts a user-supplied status name such as "in-progress" or "Done" into a Status.
+// Matching ignores case, spaces, hyphens and underscores.
+func ParseStatus(value string) (Status, error) {
+	switch normalizeEnumName(value) {
+	case "notstarted", "todo":
+		return NotStarted, nil
+	case "inprogress", "doing":
+		return InProgress, nil
+	case "done":
+		return Done, nil
+	}
+	return NotStarted, NewValidationError("status", fmt.Sprintf("unknown status %q", value))
+}
+
+func normalizeEnumName(value string) string {
+	replacer := strings.NewReplacer(" ", "", "-", "", "_", "")
+	return replacer.Replace(strings.ToLower(strings.TrimSpace(value)))
+}
@@ -55,3 +55,34 @@ func TestStatus_Ordering(t *testing.T) {
 	assert.True(t, InProgress < Done, "InProgress should come before Done")
 	assert.True(t, NotStarted < Done, "NotStarted should come before Done")
 }
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Status
+		wantErr  bool
+	}{
+		{input: "notstarted", expected: NotStarted},
+		{input: "Not Started", expected: NotStarted},
+		{input: "todo", expected: NotStarted},
+		{input: "in-progress", expected: InProgress},
+		{input: "IN_PROGRESS", expected: InProgress},
+		{input: "done", expected: Done},
+		{input: "finished", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			status, err := ParseStatus(tt.input)
+			if tt.wantErr {
+				var validationErr *ValidationError
+				assert.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, "status", validationErr.Field)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, status)
+		})
+	}
+}
@@ -7,17 +7,29 @@ import (
 )
 
 type Task struct {
-	IntID     int       `json:"int_id"`
-	ID        string    `json:"id"`
-	ProjectID string    `json:"project_id"`
-	Name      string    `json:"name"`
-	Desc      string    `json:"desc"`
-	Status    Status    `json:"status"`
-	Type      TaskType  `json:"type"`
-	BlockedBy *int      `json:"blocked_by,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	Priority  Priority  `json:"priority,omitempty"`
+	IntID     int        `json:"int_id"`
+	ID        string     `json:"id"`
+	ProjectID string     `json:"project_id"`
+	Name      string     `json:"name"`
+	Desc      string     `json:"desc"`
+	Status    Status     `json:"status"`
+	Type      TaskType   `json:"type"`
+	BlockedBy []int      `json:"blocked_by,omitempty"` // int IDs of unfinished blockers, sorted
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	Priority  Priority   `json:"priority,omitempty"`
+	Labels    []Label    `json:"labels,omitempty"` // sorted by name
+	StartDate *time.Time `json:"start_date,omitempty"`
+	DueDate   *time.Time `json:"due_date,omitempty"`
+	Comments  []Comment  `json:"comments,omitempty"` // oldest first
+
+	// Checklist counts, kept up to date by the repository
+	ChecklistDone  int `json:"checklist_done,omitempty"`
+	ChecklistTotal int `json:"checklist_total,omitempty"`
+
+	// Archived tasks are hidden from the board; deleted ones are in the trash
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
 }
 
 type Priority int
@@ -62,6 +74,32 @@ func (tt TaskType) String() string {
 	}
 }
 
+// ParsePriority converts a user-supplied priority name ("low", "medium", "high") into a Priority
+func ParsePriority(value string) (Priority, error) {
+	switch normalizeEnumName(value) {
+	case "low":
+		return Low, nil
+	case "medium", "med":
+		return Medium, nil
+	case "high":
+		return High, nil
+	}
+	return Low, NewValidationError("priority", fmt.Sprintf("unknown priority %q", value))
+}
+
+// ParseTaskType converts a user-supplied type name ("task", "bug", "feature") into a TaskType
+func ParseTaskType(value string) (TaskType, error) {
+	switch normalizeEnumName(value) {
+	case "task", "regular", "regulartask":
+		return RegularTask, nil
+	case "bug":
+		return Bug, nil
+	case "feature":
+		return Feature, nil
+	}
+	return RegularTask, NewValidationError("type", fmt.Sprintf("unknown task type %q", value))
+}
+
 // Validation length constants for tasks
 const (
 	MaxTaskNameLength        = 100
@@ -105,17 +143,21 @@ func (t *Task) Validate() error {
 	if err := validator.ValidateEnum("priority", int(t.Priority), int(Low), int(High), "task"); err != nil {
 		return err
 	}
-	if err := validator.ValidateEnum("status", int(t.Status), int(NotStarted), int(Done), "task"); err != nil {
+	// The upper bound depends on the project's workflow and is checked by TaskService
+	if err := validator.ValidateEnum("status", int(t.Status), int(NotStarted), MaxWorkflowStatuses-1, "task"); err != nil {
 		return err
 	}
 	if err := validator.ValidateEnum("type", int(t.Type), int(RegularTask), int(Feature), "task"); err != nil {
 		return err
 	}
 	// Validate that a task cannot block itself
-	if t.BlockedBy != nil && t.IntID != 0 && *t.BlockedBy == t.IntID {
+	if t.IntID != 0 && t.IsBlockedBy(t.IntID) {
 		return NewValidationError("blocked_by", "task cannot block itself")
 	}
-	return nil
+	if len(t.BlockedBy) > MaxBlockersPerTask {
+		return NewValidationError("blocked_by", fmt.Sprintf("a task can have at most %d blockers", MaxBlockersPerTask))
+	}
+	return ValidateDateRange(t.StartDate, t.DueDate)
 }
 
 func (t Task) Title() string         { return t.Name }
@@ -124,15 +166,24 @@ func (t Task) GetPriority() Priority { return t.Priority }
 func (t Task) FilterValue() string   { return t.Name }
 
 // SortTasks sorts a slice of tasks based on the status
-// For NotStarted: priority DESC, then created_at ASC (oldest highest priority first)
-// For InProgress and Done: updated_at DESC (newest changes first)
+// For the first column of a workflow (NotStarted): overdue tasks first, most overdue first,
+// then priority DESC, then created_at ASC (oldest highest priority first)
+// For every later column: updated_at DESC (newest changes first)
 func SortTasks(tasks []Task, status Status) []Task {
 	sorted := make([]Task, len(tasks))
 	copy(sorted, tasks)
 
 	if status == NotStarted {
-		// Sort by priority DESC, then created_at ASC
+		now := time.Now()
+		// Sort overdue tasks first by due date ASC, then by priority DESC, then created_at ASC
 		sort.Slice(sorted, func(i, j int) bool {
+			overdueI, overdueJ := sorted[i].IsOverdue(now), sorted[j].IsOverdue(now)
+			if overdueI != overdueJ {
+				return overdueI
+			}
+			if overdueI && !sorted[i].DueDate.Equal(*sorted[j].DueDate) {
+				return sorted[i].DueDate.Before(*sorted[j].DueDate)
+			}
 			if sorted[i].Priority != sorted[j].Priority {
 				return sorted[i].Priority > sorted[j].Priority // Higher priority first
 			}
@@ -1,41 +1,437 @@
 package domain
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
-// SearchTasks filters tasks whose Name contains the query using case-insensitive substring matching.
-// Returns all tasks if query is empty.
-func SearchTasks(tasks []Task, query string) []Task {
-	if query == "" {
-		return tasks
+// Search queries are a list of terms that must all match:
+//
+//	login                 name or a comment contains "login" (ignoring case)
+//	"exact phrase"        name or a comment contains the phrase, spaces included
+//	name:, desc:, comment: the name, the description or a comment contains the value
+//	label:backend         the task carries the label
+//	type:bug              bug, feature or task
+//	status:inprogress     a column of the project's workflow
+//	priority:high         low, medium or high; also priority:>=medium
+//	blocked:yes           the task waits on another task (yes or no)
+//	created:, updated:, due: a day in any form ParseDate reads, compared with
+//	                      =, >, >=, < or <=, as in created:>2026-09-01 or due:<=+3d
+//
+// Terms combine with AND (implied between terms) and OR, and are negated with
+// NOT or a leading "-". AND binds tighter than OR; parentheses group terms.
+// Field values may be quoted, as in desc:"time out". A field with no value, such
+// as "type:", is an error like any value the field does not know. A word whose
+// prefix is not a known field, such as a URL, is plain text.
+
+// taskMatcher reports whether a task matches a query or a part of one
+type taskMatcher func(task Task) bool
+
+func matchAll(Task) bool { return true }
+
+// TaskQuery is a parsed search query, ready to match tasks
+type TaskQuery struct {
+	matches taskMatcher
+}
+
+// ParseTaskQuery parses a search query. Status names are those of workflow and
+// relative dates count from now. Errors are ValidationErrors on the "query" field.
+func ParseTaskQuery(query string, workflow Workflow, now time.Time) (TaskQuery, error) {
+	tokens, err := tokenizeQuery(query)
+	if err != nil {
+		return TaskQuery{}, err
+	}
+	if len(tokens) == 0 {
+		return TaskQuery{matches: matchAll}, nil
 	}
 
-	lowerQuery := strings.ToLower(query)
-	filtered := make([]Task, 0, len(tasks))
+	p := queryParser{tokens: tokens, workflow: workflow, now: now}
+	matcher, err := p.parseOr()
+	if err != nil {
+		return TaskQuery{}, err
+	}
+	if p.pos < len(p.tokens) {
+		// Only an unmatched ")" stops the parser before the end
+		return TaskQuery{}, queryError("unexpected \")\"")
+	}
+	return TaskQuery{matches: matcher}, nil
+}
 
+// Matches reports whether the task matches the query
+func (q TaskQuery) Matches(task Task) bool {
+	if q.matches == nil {
+		return true
+	}
+	return q.matches(task)
+}
+
+// And returns a query matching the tasks that both q and other match
+func (q TaskQuery) And(other TaskQuery) TaskQuery {
+	return TaskQuery{matches: func(task Task) bool { return q.Matches(task) && other.Matches(task) }}
+}
+
+// Filter returns the tasks matching the query, in order
+func (q TaskQuery) Filter(tasks []Task) []Task {
+	filtered := make([]Task, 0, len(tasks))
 	for _, task := range tasks {
-		if strings.Contains(strings.ToLower(task.Name), lowerQuery) {
+		if q.Matches(task) {
 			filtered = append(filtered, task)
 		}
 	}
-
 	return filtered
 }
 
-// CountSearchMatches returns the number of tasks whose Name contains the query.
-// Returns total count if query is empty.
-func CountSearchMatches(tasks []Task, query string) int {
-	if query == "" {
-		return len(tasks)
-	}
-
-	lowerQuery := strings.ToLower(query)
+// Count returns how many of the tasks match the query
+func (q TaskQuery) Count(tasks []Task) int {
 	count := 0
-
 	for _, task := range tasks {
-		if strings.Contains(strings.ToLower(task.Name), lowerQuery) {
+		if q.Matches(task) {
 			count++
 		}
 	}
-
 	return count
 }
+
+func queryError(message string) *ValidationError {
+	return NewValidationError("query", message)
+}
+
+type queryTokenKind int
+
+const (
+	tokenTerm queryTokenKind = iota
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+type queryToken struct {
+	kind   queryTokenKind
+	text   string // the term, without the quotes of a phrase
+	quoted bool   // the whole term is a quoted phrase
+}
+
+// tokenizeQuery splits a query into terms, operators and parentheses. Quotes
+// may open a term or a field value and run to the closing quote.
+func tokenizeQuery(query string) ([]queryToken, error) {
+	var tokens []queryToken
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		switch r := runes[i]; {
+		case r == ' ' || r == '\t' || r == '\n':
+			i++
+		case r == '(':
+			tokens = append(tokens, queryToken{kind: tokenOpen})
+			i++
+		case r == ')':
+			tokens = append(tokens, queryToken{kind: tokenClose})
+			i++
+		case r == '|':
+			tokens = append(tokens, queryToken{kind: tokenOr})
+			i++
+		case r == '-' && i+1 < len(runes) && !strings.ContainsRune(" \t\n)|", runes[i+1]):
+			tokens = append(tokens, queryToken{kind: tokenNot})
+			i++
+		default:
+			start := i
+			quoted := r == '"'
+			inQuotes := false
+			for ; i < len(runes); i++ {
+				if runes[i] == '"' {
+					inQuotes = !inQuotes
+					continue
+				}
+				if !inQuotes && strings.ContainsRune(" \t\n()|", runes[i]) {
+					break
+				}
+			}
+			if inQuotes {
+				return nil, queryError("missing closing quote")
+			}
+
+			word := string(runes[start:i])
+			switch {
+			case quoted && strings.Count(word, `"`) == 2 && strings.HasSuffix(word, `"`):
+				tokens = append(tokens, queryToken{kind: tokenTerm, text: word[1 : len(word)-1], quoted: true})
+			case word == "AND" || word == "&&":
+				tokens = append(tokens, queryToken{kind: tokenAnd})
+			case word == "OR":
+				tokens = append(tokens, queryToken{kind: tokenOr})
+			case word == "NOT":
+				tokens = append(tokens, queryToken{kind: tokenNot})
+			default:
+				tokens = append(tokens, queryToken{kind: tokenTerm, text: strings.ReplaceAll(word, `"`, "")})
+			}
+		}
+	}
+	return tokens, nil
+}
+
+type queryParser struct {
+	tokens   []queryToken
+	pos      int
+	workflow Workflow
+	now      time.Time
+}
+
+func (p *queryParser) peek() (queryToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return queryToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// parseOr reads terms joined by OR
+func (p *queryParser) parseOr() (taskMatcher, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	alternatives := []taskMatcher{first}
+	for {
+		token, ok := p.peek()
+		if !ok || token.kind != tokenOr {
+			break
+		}
+		p.pos++
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		alternatives = append(alternatives, next)
+	}
+	if len(alternatives) == 1 {
+		return first, nil
+	}
+	return func(task Task) bool {
+		for _, matches := range alternatives {
+			if matches(task) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// parseAnd reads terms up to the next OR, closing parenthesis or the end
+func (p *queryParser) parseAnd() (taskMatcher, error) {
+	var all []taskMatcher
+	for {
+		token, ok := p.peek()
+		if !ok || token.kind == tokenOr || token.kind == tokenClose {
+			break
+		}
+		if token.kind == tokenAnd {
+			p.pos++
+			continue
+		}
+		matcher, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, matcher)
+	}
+	switch len(all) {
+	case 0:
+		return nil, queryError("expected a search term")
+	case 1:
+		return all[0], nil
+	}
+	return func(task Task) bool {
+		for _, matches := range all {
+			if !matches(task) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// parseUnary reads a term, a parenthesised group or the negation of either
+func (p *queryParser) parseUnary() (taskMatcher, error) {
+	token, ok := p.peek()
+	if !ok {
+		return nil, queryError("expected a search term")
+	}
+	p.pos++
+
+	switch token.kind {
+	case tokenNot:
+		negated, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(task Task) bool { return !negated(task) }, nil
+	case tokenOpen:
+		group, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing, ok := p.peek(); !ok || closing.kind != tokenClose {
+			return nil, queryError("missing \")\"")
+		}
+		p.pos++
+		return group, nil
+	case tokenTerm:
+		if token.quoted {
+			return textMatcher(token.text), nil
+		}
+		return p.termMatcher(token.text)
+	default:
+		return nil, queryError("expected a search term")
+	}
+}
+
+// termMatcher matches a field term such as "priority:high", or plain text
+func (p *queryParser) termMatcher(term string) (taskMatcher, error) {
+	field, value, found := strings.Cut(term, ":")
+	if !found {
+		return textMatcher(term), nil
+	}
+	field = strings.ToLower(field)
+	if _, text := textFields[field]; text && value == "" {
+		return nil, queryError(fmt.Sprintf("%s: needs a value", field))
+	}
+
+	switch field {
+	case "name":
+		return containsMatcher(value, func(task Task) []string { return []string{task.Name} }), nil
+	case "desc", "description":
+		return containsMatcher(value, func(task Task) []string { return []string{task.Desc} }), nil
+	case "comment", "comments":
+		return containsMatcher(value, commentBodies), nil
+	case "label":
+		return func(task Task) bool { return task.HasLabel(value) }, nil
+	case "type":
+		taskType, err := ParseTaskType(value)
+		if err != nil {
+			return nil, queryError(fmt.Sprintf("unknown task type %q; expected task, bug or feature", value))
+		}
+		return func(task Task) bool { return task.Type == taskType }, nil
+	case "status":
+		status, err := p.workflow.Parse(value)
+		if err != nil {
+			return nil, queryError(fmt.Sprintf("unknown status %q; expected one of %s", value, strings.Join(p.workflow.Names(), ", ")))
+		}
+		return func(task Task) bool { return task.Status == status }, nil
+	case "priority":
+		op, rest := splitComparison(value)
+		priority, err := ParsePriority(rest)
+		if err != nil {
+			return nil, queryError(fmt.Sprintf("unknown priority %q; expected low, medium or high", rest))
+		}
+		return func(task Task) bool { return compareWith(op, int(task.Priority)-int(priority)) }, nil
+	case "blocked":
+		switch strings.ToLower(value) {
+		case "yes", "true":
+			return func(task Task) bool { return task.IsBlocked() }, nil
+		case "no", "false":
+			return func(task Task) bool { return !task.IsBlocked() }, nil
+		}
+		return nil, queryError(fmt.Sprintf("blocked takes yes or no, not %q", value))
+	case "created":
+		return p.dateMatcher(field, value, func(task Task) *time.Time { return &task.CreatedAt })
+	case "updated":
+		return p.dateMatcher(field, value, func(task Task) *time.Time { return &task.UpdatedAt })
+	case "due":
+		return p.dateMatcher(field, value, func(task Task) *time.Time { return task.DueDate })
+	}
+	return textMatcher(term), nil
+}
+
+// textFields are the fields matching any text, which need some text to match;
+// the other fields reject an empty value as one they do not know
+var textFields = map[string]struct{}{
+	"name": {}, "desc": {}, "description": {}, "comment": {}, "comments": {}, "label": {},
+}
+
+// dateMatcher compares the calendar day of the date a task has for field with
+// the day in value. Tasks without that date never match.
+func (p *queryParser) dateMatcher(field, value string, dateOf func(Task) *time.Time) (taskMatcher, error) {
+	op, rest := splitComparison(value)
+	day, err := ParseDate(rest, p.now)
+	if err != nil || day == nil {
+		return nil, queryError(fmt.Sprintf("invalid %s date %q; use YYYY-MM-DD, today, +3d or a weekday", field, rest))
+	}
+	return func(task Task) bool {
+		date := dateOf(task)
+		if date == nil || date.IsZero() {
+			return false
+		}
+		taskDay := StartOfDay(date.In(p.now.Location()))
+		return compareWith(op, taskDay.Compare(*day))
+	}, nil
+}
+
+// splitComparison splits the operator, if any, off a value such as ">=medium"
+func splitComparison(value string) (op, rest string) {
+	for _, candidate := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(value, candidate) {
+			return candidate, value[len(candidate):]
+		}
+	}
+	return "=", value
+}
+
+// compareWith applies op to the sign of a comparison
+func compareWith(op string, cmp int) bool {
+	switch op {
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	default:
+		return cmp == 0
+	}
+}
+
+// textMatcher matches tasks whose name or one of whose comments contains text,
+// ignoring case
+func textMatcher(text string) taskMatcher {
+	return containsMatcher(text, func(task Task) []string {
+		return append([]string{task.Name}, commentBodies(task)...)
+	})
+}
+
+func containsMatcher(text string, fieldsOf func(Task) []string) taskMatcher {
+	text = strings.ToLower(text)
+	return func(task Task) bool {
+		for _, field := range fieldsOf(task) {
+			if strings.Contains(strings.ToLower(field), text) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func commentBodies(task Task) []string {
+	bodies := make([]string, len(task.Comments))
+	for i, comment := range task.Comments {
+		bodies[i] = comment.Body
+	}
+	return bodies
+}
+
+// SearchTasks filters tasks matching the query, as parsed by ParseTaskQuery for
+// the default workflow. Returns all tasks if the query is empty or does not parse.
+func SearchTasks(tasks []Task, query string) []Task {
+	parsed, err := ParseTaskQuery(query, DefaultWorkflow(), time.Now())
+	if err != nil {
+		return tasks
+	}
+	return parsed.Filter(tasks)
+}
+
+// CountSearchMatches returns the number of tasks matching the query as SearchTasks does.
+// Returns total count if query is empty.
+func CountSearchMatches(tasks []Task, query string) int {
+	return len(SearchTasks(tasks, query))
+}
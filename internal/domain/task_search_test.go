@@ -2,8 +2,10 @@ package domain
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSearchTasks_EmptyQuery_ReturnsAll(t *testing.T) {
@@ -149,3 +151,144 @@ func TestCountSearchMatches_CaseInsensitive(t *testing.T) {
 
 	assert.Equal(t, 2, count)
 }
+
+func TestSearchTasks_LabelFilter(t *testing.T) {
+	backend := Label{Name: "backend"}
+	debt := Label{Name: "tech-debt"}
+	tasks := []Task{
+		{Name: "Fix API timeout", Labels: []Label{backend}},
+		{Name: "Refactor API client", Labels: []Label{backend, debt}},
+		{Name: "Fix CSS", Labels: []Label{debt}},
+	}
+
+	assert.Len(t, SearchTasks(tasks, "label:backend"), 2)
+	assert.Len(t, SearchTasks(tasks, "LABEL:Backend"), 2, "Labels match case-insensitively")
+	assert.Len(t, SearchTasks(tasks, "label:backend label:tech-debt"), 1, "Every label must match")
+
+	result := SearchTasks(tasks, "fix label:tech-debt")
+	assert.Len(t, result, 1)
+	assert.Equal(t, "Fix CSS", result[0].Name)
+
+	assert.Len(t, SearchTasks(tasks, "label:"), 3, "A query that does not parse filters nothing")
+	assert.Equal(t, 1, CountSearchMatches(tasks, "api label:tech-debt"))
+}
+
+func TestSearchTasks_MatchesComments(t *testing.T) {
+	tasks := []Task{
+		{Name: "Flaky test", Comments: []Comment{{Body: "Only fails under the race detector"}}},
+		{Name: "Race condition in cache", Labels: []Label{{Name: "backend"}}},
+		{Name: "Docs"},
+	}
+
+	result := SearchTasks(tasks, "RACE")
+	assert.Equal(t, 2, len(result))
+	assert.Equal(t, 2, CountSearchMatches(tasks, "race"))
+
+	result = SearchTasks(tasks, "race label:backend")
+	assert.Equal(t, 1, len(result))
+	assert.Equal(t, "Race condition in cache", result[0].Name)
+}
+
+func TestParseTaskQuery(t *testing.T) {
+	now := time.Date(2026, 9, 10, 12, 0, 0, 0, time.Local)
+	due := time.Date(2026, 9, 12, 0, 0, 0, 0, time.Local)
+	tasks := []Task{
+		{IntID: 1, Name: "Login times out", Type: Bug, Priority: High, Status: InProgress,
+			CreatedAt: time.Date(2026, 8, 20, 9, 0, 0, 0, time.Local), Labels: []Label{{Name: "backend"}}},
+		{IntID: 2, Name: "Flaky login test", Desc: "Fails with a timeout on CI", Type: Bug, Priority: Medium,
+			CreatedAt: time.Date(2026, 9, 2, 9, 0, 0, 0, time.Local), BlockedBy: []int{1}, DueDate: &due},
+		{IntID: 3, Name: "Dark mode", Type: Feature, Priority: Low, Status: Done,
+			CreatedAt: time.Date(2026, 9, 5, 9, 0, 0, 0, time.Local),
+			Comments:  []Comment{{Body: "Needs the exact phrase from design"}}},
+	}
+
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"", []int{1, 2, 3}},
+		{"login", []int{1, 2}},
+		{"LOGIN test", []int{2}},
+		{"type:bug priority:high", []int{1}},
+		{"priority:>=medium", []int{1, 2}},
+		{"priority:<high", []int{2, 3}},
+		{"status:inprogress", []int{1}},
+		{"status:Done", []int{3}},
+		{"blocked:yes", []int{2}},
+		{"blocked:no", []int{1, 3}},
+		{`"exact phrase"`, []int{3}},
+		{`"phrase exact"`, nil},
+		{"-login", []int{3}},
+		{"NOT type:bug", []int{3}},
+		{"desc:timeout", []int{2}},
+		{`desc:"on ci"`, []int{2}},
+		{"comment:design", []int{3}},
+		{"name:mode", []int{3}},
+		{"label:backend", []int{1}},
+		{"created:>2026-09-01", []int{2, 3}},
+		{"created:2026-09-02", []int{2}},
+		{"created:<=-7d", []int{1, 2}},
+		{"due:<=+3d", []int{2}},
+		{"type:feature OR priority:high", []int{1, 3}},
+		{"type:bug AND -blocked:yes OR dark", []int{1, 3}},
+		{"login (status:done | blocked:yes)", []int{2}},
+		{"-(type:bug priority:high)", []int{2, 3}},
+		{"https://example.com", nil},
+	}
+	for _, tt := range tests {
+		parsed, err := ParseTaskQuery(tt.query, DefaultWorkflow(), now)
+		require.NoError(t, err, tt.query)
+
+		var got []int
+		for _, task := range parsed.Filter(tasks) {
+			got = append(got, task.IntID)
+		}
+		assert.Equal(t, tt.want, got, tt.query)
+		assert.Equal(t, len(tt.want), parsed.Count(tasks), tt.query)
+	}
+}
+
+func TestParseTaskQuery_CustomWorkflow(t *testing.T) {
+	workflow, err := NewWorkflow([]string{"Backlog", "Review", "Shipped"}, "Shipped")
+	require.NoError(t, err)
+
+	parsed, err := ParseTaskQuery("status:review", workflow, time.Now())
+	require.NoError(t, err)
+	assert.True(t, parsed.Matches(Task{Status: 1}))
+	assert.False(t, parsed.Matches(Task{Status: 0}))
+
+	_, err = ParseTaskQuery("status:inprogress", workflow, time.Now())
+	assert.ErrorContains(t, err, "expected one of Backlog, Review, Shipped")
+}
+
+func TestParseTaskQuery_Errors(t *testing.T) {
+	for query, message := range map[string]string{
+		"priority:urgent":  `unknown priority "urgent"`,
+		"type:chore":       `unknown task type "chore"`,
+		"type:":            `unknown task type ""`,
+		`priority:""`:      `unknown priority ""`,
+		"status:":          `unknown status ""`,
+		"due:":             `invalid due date ""`,
+		"label:":           "label: needs a value",
+		"blocked:maybe":    "blocked takes yes or no",
+		"created:>someday": `invalid created date "someday"`,
+		`"unfinished`:      "missing closing quote",
+		"(login":           `missing ")"`,
+		"login)":           `unexpected ")"`,
+		"login OR":         "expected a search term",
+		"NOT":              "expected a search term",
+		"()":               "expected a search term",
+	} {
+		_, err := ParseTaskQuery(query, DefaultWorkflow(), time.Now())
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr, query)
+		assert.Equal(t, "query", validationErr.Field, query)
+		assert.Contains(t, validationErr.Message, message, query)
+	}
+}
+
+func TestSearchTasks_InvalidQuery_ReturnsAll(t *testing.T) {
+	tasks := []Task{{Name: "Task 1"}, {Name: "Task 2"}}
+
+	assert.Len(t, SearchTasks(tasks, "(task"), 2)
+}
@@ -224,6 +224,7 @@ func TestTask_Validate(t *testing.T) {
 		{"valid task", NewTask("Valid Task", "Description", "proj_123"), false, "", ""},
 		{"empty description", NewTask("Task", "", "proj_123"), false, "", ""},
 		{"max length name", NewTask(strings.Repeat("a", MaxTaskNameLength), "Description", "proj_123"), false, "", ""},
+		{"max length name in characters", NewTask(strings.Repeat("é", MaxTaskNameLength), "Description", "proj_123"), false, "", ""},
 		{"max length description", NewTask("Task", strings.Repeat("a", MaxTaskDescriptionLength), "proj_123"), false, "", ""},
 		{"all valid priorities", NewTask("Task", "Description", "proj_123"), false, "", ""}, // Default Low is valid
 
@@ -231,6 +232,7 @@ func TestTask_Validate(t *testing.T) {
 		{"empty name", NewTask("", "Description", "proj_123"), true, "name", "cannot be empty"},
 		{"whitespace name", NewTask("   ", "Description", "proj_123"), true, "name", "cannot be empty"},
 		{"name too long", NewTask(strings.Repeat("a", MaxTaskNameLength+1), "Description", "proj_123"), true, "name", "too long"},
+		{"name too long in characters", NewTask(strings.Repeat("é", MaxTaskNameLength+1), "Description", "proj_123"), true, "name", "too long"},
 		{"description too long", NewTask("Task", strings.Repeat("a", MaxTaskDescriptionLength+1), "proj_123"), true, "description", "too long"},
 		{"empty project ID", NewTask("Task", "Description", ""), true, "project_id", "cannot be empty"},
 		{"invalid priority low", &Task{Name: "Task", Desc: "Description", ProjectID: "proj_123", Priority: Priority(-1), Status: NotStarted}, true, "priority", "invalid priority"},
@@ -241,8 +243,7 @@ func TestTask_Validate(t *testing.T) {
 		{"task blocks itself", func() *Task {
 			task := NewTask("Task", "Description", "proj_123")
 			task.IntID = 5
-			blockedBy := 5
-			task.BlockedBy = &blockedBy
+			task.BlockedBy = []int{5}
 			return task
 		}(), true, "blocked_by", "cannot block itself"},
 	}
@@ -384,19 +385,38 @@ func TestTask_BlockedBy(t *testing.T) {
 			setupTask: func() *Task {
 				task := NewTask("Task", "Description", "proj_123")
 				task.IntID = 1
-				blockedBy := 2
-				task.BlockedBy = &blockedBy
+				task.BlockedBy = []int{2}
 				return task
 			},
 			wantErr: false,
 		},
+		{
+			name: "task blocked by several tasks is valid",
+			setupTask: func() *Task {
+				task := NewTask("Task", "Description", "proj_123")
+				task.IntID = 1
+				task.BlockedBy = []int{2, 3, 4}
+				return task
+			},
+			wantErr: false,
+		},
+		{
+			name: "task cannot block itself among other blockers",
+			setupTask: func() *Task {
+				task := NewTask("Task", "Description", "proj_123")
+				task.IntID = 3
+				task.BlockedBy = []int{2, 3}
+				return task
+			},
+			wantErr: true,
+			errMsg:  "cannot block itself",
+		},
 		{
 			name: "task cannot block itself",
 			setupTask: func() *Task {
 				task := NewTask("Task", "Description", "proj_123")
 				task.IntID = 5
-				blockedBy := 5
-				task.BlockedBy = &blockedBy
+				task.BlockedBy = []int{5}
 				return task
 			},
 			wantErr: true,
@@ -407,8 +427,7 @@ func TestTask_BlockedBy(t *testing.T) {
 			setupTask: func() *Task {
 				task := NewTask("Task", "Description", "proj_123")
 				// IntID is 0 (not yet assigned by DB)
-				blockedBy := 5
-				task.BlockedBy = &blockedBy
+				task.BlockedBy = []int{5}
 				return task
 			},
 			wantErr: false, // Should not error because IntID is 0
@@ -428,3 +447,56 @@ func TestTask_BlockedBy(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePriority(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Priority
+		wantErr  bool
+	}{
+		{input: "low", expected: Low},
+		{input: "Medium", expected: Medium},
+		{input: "med", expected: Medium},
+		{input: "HIGH", expected: High},
+		{input: "urgent", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			priority, err := ParsePriority(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "unknown priority")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, priority)
+		})
+	}
+}
+
+func TestParseTaskType(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected TaskType
+		wantErr  bool
+	}{
+		{input: "task", expected: RegularTask},
+		{input: "Bug", expected: Bug},
+		{input: "feature", expected: Feature},
+		{input: "epic", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			taskType, err := ParseTaskType(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "unknown task type")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, taskType)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// MaxUndoOperations is how many changes can be undone; older ones are forgotten
+const MaxUndoOperations = 100
+
+// UndoActionKind names one step of reverting or repeating a change
+type UndoActionKind string
+
+const (
+	UndoRestoreTask    UndoActionKind = "restore_task"    // insert Task again with its number, labels, comments, checklist and blockers
+	UndoDeleteTask     UndoActionKind = "delete_task"     // delete the task with ID for good, as TaskRepository.Purge does
+	UndoUpdateTask     UndoActionKind = "update_task"     // write the fields, labels and blockers of Task over the stored task
+	UndoLinkBlockers   UndoActionKind = "link_blockers"   // make each of Links wait on its blocker again
+	UndoUnlinkBlockers UndoActionKind = "unlink_blockers" // stop each of Links waiting on its blocker
+	UndoTrashTask      UndoActionKind = "trash_task"      // move the task with ID to the trash
+	UndoUntrashTask    UndoActionKind = "untrash_task"    // take the task with ID out of the trash
+	UndoArchiveTask    UndoActionKind = "archive_task"    // archive the task with ID
+	UndoUnarchiveTask  UndoActionKind = "unarchive_task"  // put the archived task with ID back on the board
+	UndoTrashProject   UndoActionKind = "trash_project"   // move the project with ID to the trash
+	UndoUntrashProject UndoActionKind = "untrash_project" // take the project with ID out of the trash
+)
+
+// TaskSnapshot is everything stored about a task, enough to insert it again
+// under the same ID and number
+type TaskSnapshot struct {
+	Task      Task            `json:"task"`
+	Checklist []ChecklistItem `json:"checklist,omitempty"`
+}
+
+// BlockerLink says that the task numbered TaskIntID waits on BlockerIntID
+type BlockerLink struct {
+	TaskIntID    int `json:"task_int_id"`
+	BlockerIntID int `json:"blocker_int_id"`
+}
+
+// UndoAction is one step of an UndoOperation; which fields are set depends on Kind
+type UndoAction struct {
+	Kind  UndoActionKind `json:"kind"`
+	ID    string         `json:"id,omitempty"`
+	Task  *TaskSnapshot  `json:"task,omitempty"`
+	Links []BlockerLink  `json:"links,omitempty"`
+}
+
+// UndoOperation is one change made on the board, stored with the actions that
+// revert it (Undo) and those that make it again (Redo). Operations form a stack
+// ordered by ID; the undone ones at its top are the redo stack, and recording a
+// new change discards them. The target fields name what changed, like an Event.
+type UndoOperation struct {
+	ID          int64
+	ProjectID   string
+	TaskID      string
+	TaskIntID   int
+	Subject     string
+	Description string // what was done, e.g. "move to Done"
+	Undo        []UndoAction
+	Redo        []UndoAction
+	Undone      bool
+	CreatedAt   time.Time
+}
+
+// Target names what the operation changed, e.g. "#12 Fix login" or "project Website"
+func (op UndoOperation) Target() string {
+	return Event{TaskIntID: op.TaskIntID, Subject: op.Subject}.Target()
+}
+
+// Summary describes the operation, e.g. "move to Done on #12 Fix login"
+func (op UndoOperation) Summary() string {
+	return op.Description + " on " + op.Target()
+}
+
+// NewTaskCreatedUndo records that the task was created
+func NewTaskCreatedUndo(snapshot TaskSnapshot) UndoOperation {
+	op := newTaskUndo(snapshot.Task, "create")
+	op.Undo = []UndoAction{{Kind: UndoDeleteTask, ID: snapshot.Task.ID}}
+	op.Redo = []UndoAction{{Kind: UndoRestoreTask, Task: &snapshot}}
+	return op
+}
+
+// NewTaskChangedUndo records that the task went from before to after. Unlinked
+// lists the tasks that stopped waiting on it with the change, as happens when
+// it reaches the done column.
+func NewTaskChangedUndo(description string, before, after Task, unlinked []BlockerLink) UndoOperation {
+	op := newTaskUndo(after, description)
+	op.Undo = []UndoAction{{Kind: UndoUpdateTask, Task: &TaskSnapshot{Task: before}}}
+	op.Redo = []UndoAction{{Kind: UndoUpdateTask, Task: &TaskSnapshot{Task: after}}}
+	if len(unlinked) > 0 {
+		op.Undo = append(op.Undo, UndoAction{Kind: UndoLinkBlockers, Links: unlinked})
+		op.Redo = append(op.Redo, UndoAction{Kind: UndoUnlinkBlockers, Links: unlinked})
+	}
+	return op
+}
+
+// NewTaskDeletedUndo records that the task was moved to the trash. Its
+// dependents keep their links, so taking it out makes them wait on it again.
+func NewTaskDeletedUndo(task Task) UndoOperation {
+	op := newTaskUndo(task, "delete")
+	op.Undo = []UndoAction{{Kind: UndoUntrashTask, ID: task.ID}}
+	op.Redo = []UndoAction{{Kind: UndoTrashTask, ID: task.ID}}
+	return op
+}
+
+// NewTaskArchivedUndo records that the task was archived
+func NewTaskArchivedUndo(task Task) UndoOperation {
+	op := newTaskUndo(task, "archive")
+	op.Undo = []UndoAction{{Kind: UndoUnarchiveTask, ID: task.ID}}
+	op.Redo = []UndoAction{{Kind: UndoArchiveTask, ID: task.ID}}
+	return op
+}
+
+// NewProjectDeletedUndo records that the project was moved to the trash with
+// taskCount tasks
+func NewProjectDeletedUndo(project Project, taskCount int) UndoOperation {
+	return UndoOperation{
+		ProjectID:   project.ID,
+		Subject:     project.Name,
+		Description: fmt.Sprintf("delete (%d tasks)", taskCount),
+		Undo:        []UndoAction{{Kind: UndoUntrashProject, ID: project.ID}},
+		Redo:        []UndoAction{{Kind: UndoTrashProject, ID: project.ID}},
+	}
+}
+
+func newTaskUndo(task Task, description string) UndoOperation {
+	return UndoOperation{
+		ProjectID:   task.ProjectID,
+		TaskID:      task.ID,
+		TaskIntID:   task.IntID,
+		Subject:     task.Name,
+		Description: description,
+	}
+}
+
+// DependentLinks returns a link for every task waiting on the task numbered intID
+func DependentLinks(tasks []Task, intID int) []BlockerLink {
+	var links []BlockerLink
+	for _, task := range tasks {
+		if task.IsBlockedBy(intID) {
+			links = append(links, BlockerLink{TaskIntID: task.IntID, BlockerIntID: intID})
+		}
+	}
+	return links
+}
+
+// SameTaskFields reports whether the two versions of a task agree on everything
+// an UndoUpdateTask action writes
+func SameTaskFields(a, b Task) bool {
+	return a.Name == b.Name && a.Desc == b.Desc && a.Status == b.Status && a.Type == b.Type &&
+		a.Priority == b.Priority && FormatDate(a.StartDate) == FormatDate(b.StartDate) &&
+		FormatDate(a.DueDate) == FormatDate(b.DueDate) &&
+		slices.Equal(a.BlockedBy, b.BlockedBy) && slices.Equal(a.LabelNames(), b.LabelNames())
+}
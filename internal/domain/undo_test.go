@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndoOperation_Constructors(t *testing.T) {
+	task := Task{ID: "task_1", IntID: 1, ProjectID: "project_1", Name: "Design"}
+	dependents := DependentLinks([]Task{
+		task,
+		{IntID: 2, BlockedBy: []int{1}},
+		{IntID: 3, BlockedBy: []int{4}},
+	}, 1)
+	assert.Equal(t, []BlockerLink{{TaskIntID: 2, BlockerIntID: 1}}, dependents)
+
+	deleted := NewTaskDeletedUndo(task)
+	assert.Equal(t, "delete on #1 Design", deleted.Summary())
+	assert.Equal(t, []UndoAction{{Kind: UndoUntrashTask, ID: "task_1"}}, deleted.Undo)
+	assert.Equal(t, []UndoAction{{Kind: UndoTrashTask, ID: "task_1"}}, deleted.Redo)
+
+	archived := NewTaskArchivedUndo(task)
+	assert.Equal(t, "archive on #1 Design", archived.Summary())
+	assert.Equal(t, UndoUnarchiveTask, archived.Undo[0].Kind)
+
+	moved := task
+	moved.Status = Done
+	changed := NewTaskChangedUndo("move to Done", task, moved, dependents)
+	assert.Equal(t, Status(0), changed.Undo[0].Task.Task.Status)
+	assert.Equal(t, UndoUnlinkBlockers, changed.Redo[1].Kind)
+	assert.Len(t, NewTaskChangedUndo("edit", task, moved, nil).Undo, 1)
+
+	project := NewProjectDeletedUndo(Project{ID: "project_1", Name: "Website"}, 1)
+	assert.Equal(t, "delete (1 tasks) on project Website", project.Summary())
+	assert.Equal(t, []UndoAction{{Kind: UndoUntrashProject, ID: "project_1"}}, project.Undo)
+}
+
+func TestSameTaskFields(t *testing.T) {
+	task := Task{Name: "Design", BlockedBy: []int{2}, Labels: []Label{{Name: "backend"}}}
+	same := task
+	same.Comments = []Comment{{Body: "Not compared"}}
+	assert.True(t, SameTaskFields(task, same))
+
+	relabelled := task
+	relabelled.Labels = nil
+	assert.False(t, SameTaskFields(task, relabelled))
+	unblocked := task
+	unblocked.BlockedBy = nil
+	assert.False(t, SameTaskFields(task, unblocked))
+}
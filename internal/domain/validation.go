@@ -3,6 +3,7 @@ package domain
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
 // FieldValidator provides common validation utilities for domain entities
@@ -24,9 +25,9 @@ func (v *FieldValidator) ValidateNotEmpty(field, value, entityName string) error
 	return nil
 }
 
-// ValidateMaxLength checks if a string field doesn't exceed maximum length
+// ValidateMaxLength checks that a string field is at most maxLen characters long
 func (v *FieldValidator) ValidateMaxLength(field, value string, maxLen int, entityName string) error {
-	if len(value) > maxLen {
+	if utf8.RuneCountInString(value) > maxLen {
 		return &ValidationError{
 			Field:   field,
 			Message: fmt.Sprintf("%s %s too long (max %d characters)", entityName, field, maxLen),
@@ -0,0 +1,286 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WorkflowStatus is one named column of a project's board
+type WorkflowStatus struct {
+	Name     string `json:"name"`
+	IsDone   bool   `json:"is_done"`
+	WIPLimit int    `json:"wip_limit,omitempty"` // maximum number of tasks in the column; 0 means no limit
+}
+
+// Workflow is a project's ordered list of columns. A task's Status is the position of
+// its column, so the default workflow keeps the NotStarted/InProgress/Done values.
+// Exactly one column is the done state, which unblocks dependents. A nil Workflow
+// behaves as DefaultWorkflow.
+type Workflow []WorkflowStatus
+
+// Validation constants for workflows; the column limit keeps the board readable
+const (
+	MaxWorkflowStatuses         = 8
+	MaxWorkflowStatusNameLength = 20
+	MaxWIPLimit                 = 99
+)
+
+func DefaultWorkflow() Workflow {
+	return Workflow{
+		{Name: NotStarted.ToString()},
+		{Name: InProgress.ToString()},
+		{Name: Done.ToString(), IsDone: true},
+	}
+}
+
+func (w Workflow) resolved() Workflow {
+	if len(w) == 0 {
+		return DefaultWorkflow()
+	}
+	return w
+}
+
+func (w Workflow) Len() int {
+	return len(w.resolved())
+}
+
+// Statuses returns every status of the workflow in board order
+func (w Workflow) Statuses() []Status {
+	statuses := make([]Status, w.Len())
+	for i := range statuses {
+		statuses[i] = Status(i)
+	}
+	return statuses
+}
+
+func (w Workflow) Contains(status Status) bool {
+	return status >= 0 && int(status) < w.Len()
+}
+
+// Name returns the column name for status
+func (w Workflow) Name(status Status) string {
+	if !w.Contains(status) {
+		return fmt.Sprintf("Status %d", status)
+	}
+	return w.resolved()[status].Name
+}
+
+// DoneStatus returns the column marked as done
+func (w Workflow) DoneStatus() Status {
+	for i, column := range w.resolved() {
+		if column.IsDone {
+			return Status(i)
+		}
+	}
+	return Status(w.Len() - 1)
+}
+
+func (w Workflow) IsDone(status Status) bool {
+	return status == w.DoneStatus()
+}
+
+// WIPLimit returns the work-in-progress limit of status, or 0 when it has none
+func (w Workflow) WIPLimit(status Status) int {
+	if !w.Contains(status) {
+		return 0
+	}
+	return w.resolved()[status].WIPLimit
+}
+
+// OverWIPLimit reports whether count tasks are more than status allows
+func (w Workflow) OverWIPLimit(status Status, count int) bool {
+	limit := w.WIPLimit(status)
+	return limit > 0 && count > limit
+}
+
+// Next returns the column after status, wrapping from the last column to the first
+func (w Workflow) Next(status Status) Status {
+	return Status((int(status) + 1) % w.Len())
+}
+
+// Previous returns the column before status, wrapping from the first column to the last
+func (w Workflow) Previous(status Status) Status {
+	return Status((int(status) - 1 + w.Len()) % w.Len())
+}
+
+// Parse converts a column name into a Status, ignoring case, spaces, hyphens and
+// underscores. The default workflow also accepts ParseStatus aliases such as "todo".
+func (w Workflow) Parse(value string) (Status, error) {
+	normalized := normalizeEnumName(value)
+	for i, column := range w.resolved() {
+		if normalizeEnumName(column.Name) == normalized {
+			return Status(i), nil
+		}
+	}
+	if w.isDefault() {
+		return ParseStatus(value)
+	}
+	return NotStarted, NewValidationError("status", fmt.Sprintf("unknown status %q; expected one of %s", value, strings.Join(w.Names(), ", ")))
+}
+
+// Names returns the column names in board order
+func (w Workflow) Names() []string {
+	names := make([]string, w.Len())
+	for i, column := range w.resolved() {
+		names[i] = column.Name
+	}
+	return names
+}
+
+func (w Workflow) isDefault() bool {
+	defaults := DefaultWorkflow()
+	if w.Len() != len(defaults) {
+		return false
+	}
+	for i, column := range w.resolved() {
+		if column != defaults[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (w Workflow) Validate() error {
+	validator := NewFieldValidator()
+
+	if len(w) == 0 {
+		return NewValidationError("workflow", "workflow needs at least one status")
+	}
+	if len(w) > MaxWorkflowStatuses {
+		return NewValidationError("workflow", fmt.Sprintf("workflow has too many statuses (max %d)", MaxWorkflowStatuses))
+	}
+
+	seen := make(map[string]bool, len(w))
+	doneCount := 0
+	for _, column := range w {
+		if err := validator.ValidateNotEmpty("name", column.Name, "status"); err != nil {
+			return err
+		}
+		if err := validator.ValidateMaxLength("name", column.Name, MaxWorkflowStatusNameLength, "status"); err != nil {
+			return err
+		}
+		key := normalizeEnumName(column.Name)
+		if seen[key] {
+			return NewValidationError("workflow", fmt.Sprintf("duplicate status %q", column.Name))
+		}
+		seen[key] = true
+		if column.WIPLimit < 0 || column.WIPLimit > MaxWIPLimit {
+			return NewValidationError("wip_limit", fmt.Sprintf("WIP limit of %q must be between 0 and %d", column.Name, MaxWIPLimit))
+		}
+		if column.IsDone {
+			doneCount++
+		}
+	}
+
+	if doneCount != 1 {
+		return NewValidationError("workflow", "exactly one status must be marked as done")
+	}
+	return nil
+}
+
+// NewWorkflow builds a workflow from column names in board order, marking doneName
+// as the done column. An empty doneName marks the last column.
+func NewWorkflow(names []string, doneName string) (Workflow, error) {
+	workflow := make(Workflow, len(names))
+	for i, name := range names {
+		workflow[i] = WorkflowStatus{Name: strings.TrimSpace(name)}
+	}
+	if len(workflow) == 0 {
+		return nil, workflow.Validate()
+	}
+
+	doneIndex := len(workflow) - 1
+	if doneName != "" {
+		doneIndex = -1
+		for i, column := range workflow {
+			if normalizeEnumName(column.Name) == normalizeEnumName(doneName) {
+				doneIndex = i
+				break
+			}
+		}
+		if doneIndex < 0 {
+			return nil, NewValidationError("done", fmt.Sprintf("done status %q is not one of the workflow's statuses", doneName))
+		}
+	}
+	workflow[doneIndex].IsDone = true
+
+	if err := workflow.Validate(); err != nil {
+		return nil, err
+	}
+	return workflow, nil
+}
+
+// RemapStatuses decides where tasks go when a project switches from w to next.
+// A column keeps its tasks when next has a column of the same name; otherwise the
+// old done column maps to the new done column and any other column to the column
+// at the same position, or the last column if next is shorter.
+func (w Workflow) RemapStatuses(next Workflow) map[Status]Status {
+	positions := make(map[string]Status, next.Len())
+	for i, column := range next.resolved() {
+		positions[normalizeEnumName(column.Name)] = Status(i)
+	}
+
+	remap := make(map[Status]Status, w.Len())
+	for i, column := range w.resolved() {
+		from := Status(i)
+		to, ok := positions[normalizeEnumName(column.Name)]
+		if !ok && column.IsDone {
+			to = next.DoneStatus()
+		} else if !ok {
+			to = Status(min(i, next.Len()-1))
+		}
+		if to != from {
+			remap[from] = to
+		}
+	}
+	return remap
+}
+
+// WithWIPLimit returns a copy of w in which status allows at most limit tasks
+func (w Workflow) WithWIPLimit(status Status, limit int) Workflow {
+	result := make(Workflow, w.Len())
+	copy(result, w.resolved())
+	if w.Contains(status) {
+		result[status].WIPLimit = limit
+	}
+	return result
+}
+
+// WithWIPLimitsFrom copies the WIP limits of previous onto the columns of w that
+// share a name with one of its columns
+func (w Workflow) WithWIPLimitsFrom(previous Workflow) Workflow {
+	limits := make(map[string]int, previous.Len())
+	for _, column := range previous.resolved() {
+		limits[normalizeEnumName(column.Name)] = column.WIPLimit
+	}
+
+	result := make(Workflow, len(w))
+	copy(result, w)
+	for i := range result {
+		if limit, ok := limits[normalizeEnumName(result[i].Name)]; ok {
+			result[i].WIPLimit = limit
+		}
+	}
+	return result
+}
+
+// WIPEnforcement decides what happens to a move that takes a column over its WIP limit
+type WIPEnforcement int
+
+const (
+	WIPReject WIPEnforcement = iota // the move fails with a WIPLimitError
+	WIPWarn                         // the move is saved and the WIPLimitError is returned as a warning
+)
+
+// ParseWIPEnforcement converts "reject" or "warn" into a WIPEnforcement; an empty
+// value is WIPReject
+func ParseWIPEnforcement(value string) (WIPEnforcement, error) {
+	switch normalizeEnumName(value) {
+	case "", "reject":
+		return WIPReject, nil
+	case "warn":
+		return WIPWarn, nil
+	default:
+		return WIPReject, NewValidationError("wip_enforcement", fmt.Sprintf("unknown WIP enforcement %q; expected reject or warn", value))
+	}
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func teamWorkflow(t *testing.T) Workflow {
+	t.Helper()
+
+	workflow, err := NewWorkflow([]string{"Backlog", "Ready", "In Progress", "In Review", "QA", "Done"}, "")
+	require.NoError(t, err)
+	return workflow
+}
+
+func TestWorkflow_NilBehavesAsDefault(t *testing.T) {
+	var workflow Workflow
+
+	assert.Equal(t, 3, workflow.Len())
+	assert.Equal(t, []string{"Not Started", "In Progress", "Done"}, workflow.Names())
+	assert.Equal(t, Done, workflow.DoneStatus())
+	assert.Equal(t, InProgress, workflow.Next(NotStarted))
+	assert.Equal(t, Done, workflow.Previous(NotStarted), "Moving back from the first column wraps to the last")
+
+	status, err := workflow.Parse("todo")
+	require.NoError(t, err)
+	assert.Equal(t, NotStarted, status, "The default workflow keeps the ParseStatus aliases")
+}
+
+func TestWorkflow_CustomColumns(t *testing.T) {
+	workflow := teamWorkflow(t)
+
+	assert.Equal(t, 6, workflow.Len())
+	assert.Equal(t, Status(5), workflow.DoneStatus())
+	assert.True(t, workflow.IsDone(Status(5)))
+	assert.False(t, workflow.IsDone(Done), "Status 2 is In Progress in this workflow")
+	assert.Equal(t, "In Review", workflow.Name(Status(3)))
+	assert.Equal(t, NotStarted, workflow.Next(Status(5)))
+	assert.False(t, workflow.Contains(Status(6)))
+
+	status, err := workflow.Parse("in-review")
+	require.NoError(t, err)
+	assert.Equal(t, Status(3), status)
+
+	_, err = workflow.Parse("todo")
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, err.Error(), "Backlog, Ready")
+}
+
+func TestNewWorkflow_DoneName(t *testing.T) {
+	workflow, err := NewWorkflow([]string{"Open", "Shipped", "Archived"}, "shipped")
+	require.NoError(t, err)
+	assert.Equal(t, Status(1), workflow.DoneStatus())
+
+	_, err = NewWorkflow([]string{"Open", "Closed"}, "Released")
+	assert.Error(t, err)
+}
+
+func TestWorkflow_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		workflow Workflow
+		message  string
+	}{
+		{"empty", Workflow{}, "at least one status"},
+		{"too many", make(Workflow, MaxWorkflowStatuses+1), "too many statuses"},
+		{"blank name", Workflow{{Name: " ", IsDone: true}}, "cannot be empty"},
+		{"long name", Workflow{{Name: strings.Repeat("x", MaxWorkflowStatusNameLength+1), IsDone: true}}, "too long"},
+		{"duplicate", Workflow{{Name: "QA"}, {Name: "qa", IsDone: true}}, "duplicate status"},
+		{"no done", Workflow{{Name: "Open"}, {Name: "Closed"}}, "exactly one"},
+		{"two done", Workflow{{Name: "Open", IsDone: true}, {Name: "Closed", IsDone: true}}, "exactly one"},
+		{"negative WIP limit", Workflow{{Name: "Open", WIPLimit: -1}, {Name: "Closed", IsDone: true}}, "WIP limit"},
+		{"WIP limit too large", Workflow{{Name: "Open", WIPLimit: MaxWIPLimit + 1}, {Name: "Closed", IsDone: true}}, "WIP limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.workflow.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+
+	assert.NoError(t, DefaultWorkflow().Validate())
+}
+
+func TestWorkflow_RemapStatuses(t *testing.T) {
+	next, err := NewWorkflow([]string{"Backlog", "In Progress", "Review", "Shipped"}, "")
+	require.NoError(t, err)
+
+	remap := DefaultWorkflow().RemapStatuses(next)
+	assert.Equal(t, map[Status]Status{Done: Status(3)}, remap,
+		"Renamed and unchanged columns keep their tasks in place; done follows the new done column")
+
+	shrunk, err := NewWorkflow([]string{"Open", "Closed"}, "")
+	require.NoError(t, err)
+	remap = next.RemapStatuses(shrunk)
+	assert.Equal(t, map[Status]Status{Status(2): Status(1), Status(3): Status(1)}, remap,
+		"Removed columns past the end land in the last column")
+}
+
+func TestWorkflow_WIPLimits(t *testing.T) {
+	var workflow Workflow
+	assert.Zero(t, workflow.WIPLimit(InProgress))
+	assert.False(t, workflow.OverWIPLimit(InProgress, 100), "Columns without a limit are never over it")
+
+	limited := workflow.WithWIPLimit(InProgress, 2)
+	assert.Nil(t, workflow, "WithWIPLimit returns a copy")
+	assert.Equal(t, 2, limited.WIPLimit(InProgress))
+	assert.False(t, limited.OverWIPLimit(InProgress, 2))
+	assert.True(t, limited.OverWIPLimit(InProgress, 3))
+
+	next, err := NewWorkflow([]string{"Backlog", "In Progress", "Review", "Done"}, "")
+	require.NoError(t, err)
+	carried := next.WithWIPLimitsFrom(limited)
+	assert.Equal(t, 2, carried.WIPLimit(Status(1)), "Limits follow the column name")
+	assert.Zero(t, carried.WIPLimit(Status(2)))
+	assert.Zero(t, next.WIPLimit(Status(1)), "WithWIPLimitsFrom returns a copy")
+}
+
+func TestParseWIPEnforcement(t *testing.T) {
+	for value, want := range map[string]WIPEnforcement{"": WIPReject, "reject": WIPReject, "Warn": WIPWarn} {
+		got, err := ParseWIPEnforcement(value)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, value)
+	}
+
+	_, err := ParseWIPEnforcement("ignore")
+	assert.ErrorContains(t, err, "expected reject or warn")
+}
@@ -0,0 +1,232 @@
+package formats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"kahn/internal/domain"
+)
+
+// ArchiveFormat identifies a full database export produced by `kahn export`
+const ArchiveFormat = "kahn-archive"
+
+// Archive is a portable snapshot of every project and task in a database.
+// Blocker relations are carried by TaskRecord.Blockers, which refer to the
+// int_ids of other tasks in the same archive. Tasks name their labels; Labels
+// carries the colors and may be absent in older archives, as may Views. Comments
+// travel inside the record of their task. Archived tasks are included; the trash
+// is not.
+type Archive struct {
+	Format        string                `json:"format"`
+	SchemaVersion int                   `json:"schema_version"`
+	ExportedAt    time.Time             `json:"exported_at"`
+	Migrations    []string              `json:"migrations"`
+	Projects      []ProjectRecord       `json:"projects"`
+	Labels        []LabelRecord         `json:"labels"`
+	Tasks         []TaskRecord          `json:"tasks"`
+	Checklist     []ChecklistItemRecord `json:"checklist"` // items of every task, by task and position
+	Views         []SavedViewRecord     `json:"views"`
+}
+
+func NewArchive(migrations []string, projects []ProjectRecord, labels []LabelRecord, tasks []TaskRecord, checklist []ChecklistItemRecord, views []SavedViewRecord) *Archive {
+	if projects == nil {
+		projects = []ProjectRecord{}
+	}
+	if labels == nil {
+		labels = []LabelRecord{}
+	}
+	if tasks == nil {
+		tasks = []TaskRecord{}
+	}
+	if checklist == nil {
+		checklist = []ChecklistItemRecord{}
+	}
+	if views == nil {
+		views = []SavedViewRecord{}
+	}
+	return &Archive{
+		Format:        ArchiveFormat,
+		SchemaVersion: SchemaVersion,
+		ExportedAt:    time.Now().UTC(),
+		Migrations:    migrations,
+		Projects:      projects,
+		Labels:        labels,
+		Tasks:         tasks,
+		Checklist:     checklist,
+		Views:         views,
+	}
+}
+
+func WriteArchive(w io.Writer, archive *Archive) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(archive)
+}
+
+// ReadArchive decodes an archive and rejects documents that are not Kahn archives
+// or were written with a newer record schema than this build understands
+func ReadArchive(r io.Reader) (*Archive, error) {
+	var archive Archive
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return nil, domain.NewValidationError("archive", fmt.Sprintf("invalid archive: %v", err))
+	}
+	if archive.Format != ArchiveFormat {
+		return nil, domain.NewValidationError("archive", fmt.Sprintf("unexpected format %q, expected %q", archive.Format, ArchiveFormat))
+	}
+	if archive.SchemaVersion < 1 || archive.SchemaVersion > SchemaVersion {
+		return nil, domain.NewValidationError("archive", fmt.Sprintf("unsupported schema version %d", archive.SchemaVersion))
+	}
+	if archive.SchemaVersion == 1 {
+		upgradeArchiveV1(&archive)
+	}
+	return &archive, nil
+}
+
+// upgradeArchiveV1 maps the statuses of a version 1 archive onto the columns of
+// each task's project workflow
+func upgradeArchiveV1(archive *Archive) {
+	workflows := make(map[string]domain.Workflow, len(archive.Projects))
+	for _, project := range archive.Projects {
+		workflows[project.ID] = project.Workflow
+	}
+	for i := range archive.Tasks {
+		archive.Tasks[i].Status = int(v1Status(archive.Tasks[i], workflows[archive.Tasks[i].ProjectID]))
+	}
+	archive.SchemaVersion = SchemaVersion
+}
+
+// v1Status maps a status written under schema version 1, where it was the fixed
+// Not Started/In Progress/Done enum, onto a column of workflow. The status name is
+// tried first, since the builds that introduced workflows already wrote positions.
+func v1Status(record TaskRecord, workflow domain.Workflow) domain.Status {
+	if record.StatusName != "" {
+		if status, err := workflow.Parse(record.StatusName); err == nil {
+			return status
+		}
+	}
+	switch domain.Status(record.Status) {
+	case domain.Done:
+		return workflow.DoneStatus()
+	case domain.InProgress:
+		return workflow.Next(domain.NotStarted)
+	default:
+		return domain.NotStarted
+	}
+}
+
+// Task converts the record back into a domain task. Dates that do not parse are
+// dropped; call Dates to report them.
+func (r TaskRecord) Task() domain.Task {
+	startDate, dueDate, _ := r.Dates()
+	return domain.Task{
+		IntID:      r.IntID,
+		ID:         r.ID,
+		ProjectID:  r.ProjectID,
+		Name:       r.Name,
+		Desc:       r.Description,
+		Status:     domain.Status(r.Status),
+		Type:       domain.TaskType(r.Type),
+		Priority:   domain.Priority(r.Priority),
+		BlockedBy:  r.BlockerIDs(),
+		StartDate:  startDate,
+		DueDate:    dueDate,
+		CreatedAt:  r.CreatedAt,
+		UpdatedAt:  r.UpdatedAt,
+		ArchivedAt: r.ArchivedAt,
+	}
+}
+
+// Item converts the record back into a domain checklist item
+func (r ChecklistItemRecord) Item() domain.ChecklistItem {
+	return domain.ChecklistItem{
+		ID:        r.ID,
+		TaskID:    r.TaskID,
+		Text:      r.Text,
+		Done:      r.Done,
+		Position:  r.Position,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+// Comment converts the record back into a domain comment on taskID
+func (r CommentRecord) Comment(taskID string) domain.Comment {
+	return domain.Comment{
+		ID:        r.ID,
+		TaskID:    taskID,
+		Author:    r.Author,
+		Body:      r.Body,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+// BlockerIDs returns the int_ids of the blocking tasks. Records written before
+// tasks could have several blockers only carry blocked_by.
+func (r TaskRecord) BlockerIDs() []int {
+	if len(r.Blockers) > 0 {
+		return domain.NormalizeBlockers(r.Blockers)
+	}
+	if r.BlockedBy != nil {
+		return []int{*r.BlockedBy}
+	}
+	return nil
+}
+
+// Dates parses the record's start and due dates
+func (r TaskRecord) Dates() (startDate, dueDate *time.Time, err error) {
+	if startDate, err = parseDateRecord("start_date", r.StartDate); err != nil {
+		return nil, nil, err
+	}
+	if dueDate, err = parseDateRecord("due_date", r.DueDate); err != nil {
+		return nil, nil, err
+	}
+	return startDate, dueDate, nil
+}
+
+func parseDateRecord(field string, value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	date, err := time.ParseInLocation(domain.DateLayout, *value, time.Local)
+	if err != nil {
+		return nil, domain.NewValidationError(field, fmt.Sprintf("invalid date %q, expected YYYY-MM-DD", *value))
+	}
+	return &date, nil
+}
+
+// View converts the record back into a domain saved view
+func (r SavedViewRecord) View() domain.SavedView {
+	return domain.SavedView{
+		ID:        r.ID,
+		ProjectID: r.ProjectID,
+		Name:      r.Name,
+		Query:     r.Query,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+// Label converts the record back into a domain label
+func (r LabelRecord) Label() domain.Label {
+	return domain.Label{
+		ID:        r.ID,
+		ProjectID: r.ProjectID,
+		Name:      r.Name,
+		Color:     r.Color,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+// Project converts the record back into a domain project
+func (r ProjectRecord) Project() domain.Project {
+	return domain.Project{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Color:       r.Color,
+		Workflow:    r.Workflow,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
@@ -0,0 +1,169 @@
+package formats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"kahn/internal/domain"
+)
+
+// ExternalBoard is a board read from another tool's export before it is mapped onto
+// a Kahn project
+type ExternalBoard struct {
+	Source      string
+	Name        string
+	Description string
+	Cards       []ExternalCard
+	Skipped     []string // items left out while reading, e.g. archived cards
+}
+
+// ExternalCard is a card or issue in an ExternalBoard
+type ExternalCard struct {
+	Ref    string // identifier used in reports, e.g. "#12"
+	Name   string
+	Desc   string
+	Column string
+	Labels []string
+}
+
+// ColumnMapping maps external column names onto statuses. Names are compared
+// ignoring case, spaces, hyphens and underscores.
+type ColumnMapping map[string]domain.Status
+
+// DefaultColumnMapping covers the column names used by Trello's and GitHub's templates
+func DefaultColumnMapping() ColumnMapping {
+	mapping := ColumnMapping{}
+	for _, name := range []string{"To Do", "Todo", "Backlog", "Not Started", "Open", "No Status"} {
+		mapping.Set(name, domain.NotStarted)
+	}
+	for _, name := range []string{"In Progress", "Doing", "In Review", "Review"} {
+		mapping.Set(name, domain.InProgress)
+	}
+	for _, name := range []string{"Done", "Closed", "Complete", "Completed"} {
+		mapping.Set(name, domain.Done)
+	}
+	return mapping
+}
+
+// ParseColumnMapping extends the default mapping with "Column=status" entries
+func ParseColumnMapping(entries []string) (ColumnMapping, error) {
+	mapping := DefaultColumnMapping()
+	for _, entry := range entries {
+		separator := strings.LastIndex(entry, "=")
+		if separator <= 0 {
+			return nil, domain.NewValidationError("map", fmt.Sprintf("invalid column mapping %q; expected Column=status", entry))
+		}
+		status, err := domain.ParseStatus(entry[separator+1:])
+		if err != nil {
+			return nil, err
+		}
+		mapping.Set(entry[:separator], status)
+	}
+	return mapping, nil
+}
+
+func (m ColumnMapping) Set(column string, status domain.Status) {
+	m[normalizeColumnName(column)] = status
+}
+
+func (m ColumnMapping) Lookup(column string) (domain.Status, bool) {
+	status, ok := m[normalizeColumnName(column)]
+	return status, ok
+}
+
+func normalizeColumnName(name string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// BoardImportPlan is an ExternalBoard mapped onto a project and its tasks, with a
+// warning for everything that could not be carried over as-is
+type BoardImportPlan struct {
+	Project  domain.Project
+	Tasks    []domain.Task
+	Warnings []string
+}
+
+// PlanBoardImport maps cards onto tasks. Cards in unmapped columns start as Not
+// Started, "bug" and "enhancement"/"feature" labels set the task type, and text
+// longer than Kahn allows is truncated.
+func PlanBoardImport(board ExternalBoard, mapping ColumnMapping) BoardImportPlan {
+	plan := BoardImportPlan{Warnings: append([]string(nil), board.Skipped...)}
+	plan.Project = domain.Project{
+		Name:        truncateField(&plan, "project name", board.Name, domain.MaxProjectNameLength),
+		Description: truncateField(&plan, "project description", board.Description, domain.MaxProjectDescriptionLength),
+	}
+
+	unmappedColumns := map[string]int{}
+	unmappedLabels := map[string]int{}
+
+	for _, card := range board.Cards {
+		name := strings.Join(strings.Fields(card.Name), " ")
+		if name == "" {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("card %s has no name and was skipped", card.Ref))
+			continue
+		}
+
+		task := domain.Task{Name: name, Desc: strings.TrimSpace(card.Desc), Priority: domain.Low}
+		task.Name = truncateField(&plan, "name of card "+card.Ref, task.Name, domain.MaxTaskNameLength)
+		task.Desc = truncateField(&plan, "description of card "+card.Ref, task.Desc, domain.MaxTaskDescriptionLength)
+
+		status, ok := mapping.Lookup(card.Column)
+		if !ok {
+			unmappedColumns[card.Column]++
+		}
+		task.Status = status
+
+		task.Type = domain.RegularTask
+		for _, label := range card.Labels {
+			taskType, ok := labelTaskType(label)
+			switch {
+			case !ok:
+				unmappedLabels[label]++
+			case task.Type != domain.RegularTask && task.Type != taskType:
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("card %s is labelled both bug and enhancement; imported as %s", card.Ref, task.Type))
+			default:
+				task.Type = taskType
+			}
+		}
+
+		plan.Tasks = append(plan.Tasks, task)
+	}
+
+	for _, column := range sortedKeys(unmappedColumns) {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("column %q is not mapped; %d card(s) imported as Not Started", column, unmappedColumns[column]))
+	}
+	for _, label := range sortedKeys(unmappedLabels) {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("label %q on %d card(s) has no Kahn equivalent and was dropped", label, unmappedLabels[label]))
+	}
+	return plan
+}
+
+func labelTaskType(label string) (domain.TaskType, bool) {
+	switch normalizeColumnName(label) {
+	case "bug":
+		return domain.Bug, true
+	case "enhancement", "feature":
+		return domain.Feature, true
+	}
+	return domain.RegularTask, false
+}
+
+// truncateField cuts value to at most maxLen characters, recording a warning
+func truncateField(plan *BoardImportPlan, field, value string, maxLen int) string {
+	if utf8.RuneCountInString(value) <= maxLen {
+		return value
+	}
+	plan.Warnings = append(plan.Warnings, fmt.Sprintf("%s was truncated to %d characters", field, maxLen))
+	return strings.TrimSpace(string([]rune(value)[:maxLen]))
+}
+
+func sortedKeys(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
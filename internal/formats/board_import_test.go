@@ -0,0 +1,138 @@
+package formats
+
+import (
+	"strings"
+	"testing"
+
+	"kahn/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const trelloExport = `{
+  "name": "Launch",
+  "desc": "Launch plan",
+  "lists": [
+    {"id": "l2", "name": "Doing", "pos": 2},
+    {"id": "l1", "name": "To Do", "pos": 1},
+    {"id": "l3", "name": "QA", "pos": 3},
+    {"id": "l4", "name": "Old", "pos": 4, "closed": true}
+  ],
+  "cards": [
+    {"idShort": 1, "name": "Fix crash", "idList": "l2", "pos": 1, "labels": [{"name": "bug", "color": "red"}]},
+    {"idShort": 2, "name": "Landing page", "desc": "Hero copy", "idList": "l1", "pos": 2, "labels": [{"name": "enhancement"}, {"name": "", "color": "green"}]},
+    {"idShort": 3, "name": "Smoke test", "idList": "l3", "pos": 1},
+    {"idShort": 4, "name": "Archived", "idList": "l1", "pos": 1, "closed": true},
+    {"idShort": 5, "name": "Forgotten", "idList": "l4", "pos": 1}
+  ]
+}`
+
+func TestReadTrelloBoard(t *testing.T) {
+	board, err := ReadTrelloBoard(strings.NewReader(trelloExport))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Launch", board.Name)
+	assert.Equal(t, "Launch plan", board.Description)
+	require.Len(t, board.Cards, 3)
+	assert.Equal(t, "Landing page", board.Cards[0].Name, "Cards follow list order")
+	assert.Equal(t, "To Do", board.Cards[0].Column)
+	assert.Equal(t, []string{"enhancement", "(green)"}, board.Cards[0].Labels)
+	assert.Equal(t, "Doing", board.Cards[1].Column)
+	require.Len(t, board.Skipped, 2)
+	assert.Contains(t, board.Skipped[0], "archived")
+	assert.Contains(t, board.Skipped[1], `archived list "Old"`)
+}
+
+func TestReadTrelloBoard_Invalid(t *testing.T) {
+	for _, input := range []string{"not json", `{"items": []}`} {
+		_, err := ReadTrelloBoard(strings.NewReader(input))
+		var validationErr *domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr, input)
+	}
+}
+
+func TestReadGitHubBoard_IssueList(t *testing.T) {
+	input := `[
+  {"number": 7, "title": "Crash on start", "body": "Stack trace", "state": "OPEN", "labels": [{"name": "bug"}]},
+  {"number": 8, "title": "Old idea", "state": "closed", "labels": []},
+  {"number": 9, "title": "Bump deps", "state": "open", "pull_request": {}}
+]`
+	board, err := ReadGitHubBoard(strings.NewReader(input))
+	require.NoError(t, err)
+
+	assert.Empty(t, board.Name)
+	require.Len(t, board.Cards, 2)
+	assert.Equal(t, ExternalCard{Ref: "#7", Name: "Crash on start", Desc: "Stack trace", Column: "OPEN", Labels: []string{"bug"}}, board.Cards[0])
+	assert.Equal(t, "closed", board.Cards[1].Column)
+	require.Len(t, board.Skipped, 1)
+	assert.Contains(t, board.Skipped[0], "pull request")
+}
+
+func TestReadGitHubBoard_ProjectItems(t *testing.T) {
+	input := `{"items": [
+  {"title": "Dark mode", "status": "In Progress", "labels": ["enhancement"], "content": {"type": "Issue", "number": 3, "body": "Please"}},
+  {"title": "Draft idea", "content": {"type": "DraftIssue", "title": "Draft idea"}}
+], "totalCount": 2}`
+	board, err := ReadGitHubBoard(strings.NewReader(input))
+	require.NoError(t, err)
+
+	require.Len(t, board.Cards, 2)
+	assert.Equal(t, ExternalCard{Ref: "#3", Name: "Dark mode", Desc: "Please", Column: "In Progress", Labels: []string{"enhancement"}}, board.Cards[0])
+	assert.Equal(t, "item 2", board.Cards[1].Ref)
+	assert.Equal(t, "No Status", board.Cards[1].Column)
+}
+
+func TestParseColumnMapping(t *testing.T) {
+	mapping, err := ParseColumnMapping([]string{"QA=in-progress", "Done=notstarted"})
+	require.NoError(t, err)
+
+	status, ok := mapping.Lookup("qa")
+	assert.True(t, ok)
+	assert.Equal(t, domain.InProgress, status)
+	status, _ = mapping.Lookup("DONE")
+	assert.Equal(t, domain.NotStarted, status, "Entries override the defaults")
+	status, _ = mapping.Lookup("in_review")
+	assert.Equal(t, domain.InProgress, status)
+
+	for _, entry := range []string{"QA", "=done", "QA=someday"} {
+		_, err := ParseColumnMapping([]string{entry})
+		assert.Error(t, err, entry)
+	}
+}
+
+func TestPlanBoardImport(t *testing.T) {
+	board := ExternalBoard{
+		Name: "Launch",
+		Cards: []ExternalCard{
+			{Ref: "#1", Name: "Fix crash", Column: "Doing", Labels: []string{"Bug"}},
+			{Ref: "#2", Name: "Landing page", Column: "Done", Labels: []string{"enhancement", "design"}},
+			{Ref: "#3", Name: "Smoke test", Column: "QA", Labels: []string{"design"}},
+			{Ref: "#4", Name: "Both", Column: "To Do", Labels: []string{"bug", "feature"}},
+			{Ref: "#5", Name: "   ", Column: "To Do"},
+			{Ref: "#6", Name: strings.Repeat("é", domain.MaxTaskNameLength+1), Column: "To Do"},
+		},
+		Skipped: []string{"card #9 is archived and was skipped"},
+	}
+
+	plan := PlanBoardImport(board, DefaultColumnMapping())
+
+	assert.Equal(t, "Launch", plan.Project.Name)
+	require.Len(t, plan.Tasks, 5)
+	assert.Equal(t, domain.InProgress, plan.Tasks[0].Status)
+	assert.Equal(t, domain.Bug, plan.Tasks[0].Type)
+	assert.Equal(t, domain.Done, plan.Tasks[1].Status)
+	assert.Equal(t, domain.Feature, plan.Tasks[1].Type)
+	assert.Equal(t, domain.NotStarted, plan.Tasks[2].Status, "Unmapped columns start as Not Started")
+	assert.Equal(t, domain.Bug, plan.Tasks[3].Type, "The first type label wins")
+	assert.Equal(t, strings.Repeat("é", domain.MaxTaskNameLength), plan.Tasks[4].Name, "Truncation counts characters, not bytes")
+
+	assert.Equal(t, []string{
+		"card #9 is archived and was skipped",
+		"card #4 is labelled both bug and enhancement; imported as Bug",
+		"card #5 has no name and was skipped",
+		"name of card #6 was truncated to 100 characters",
+		`column "QA" is not mapped; 1 card(s) imported as Not Started`,
+		`label "design" on 2 card(s) has no Kahn equivalent and was dropped`,
+	}, plan.Warnings)
+}
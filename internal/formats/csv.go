@@ -0,0 +1,126 @@
+package formats
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"kahn/internal/domain"
+)
+
+// TaskCSVColumns is the header written by WriteTaskCSV. ReadTaskCSV accepts the
+// same names in any order, ignoring case, spaces and hyphens. blocked_by holds
+// the space separated numbers of every blocking task.
+var TaskCSVColumns = []string{"id", "name", "description", "status", "type", "priority", "blocked_by", "created_at", "updated_at"}
+
+// TaskCSVRow holds the raw cell values of one imported row keyed by column
+type TaskCSVRow struct {
+	Line   int
+	Values map[string]string
+}
+
+// Get returns the value of column, or "" when the file has no such column
+func (r TaskCSVRow) Get(column string) string {
+	return strings.TrimSpace(r.Values[column])
+}
+
+// WriteTaskCSV writes tasks with their status named after the columns of workflow
+func WriteTaskCSV(w io.Writer, tasks []domain.Task, workflow domain.Workflow) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(TaskCSVColumns); err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		blockers := make([]string, len(task.BlockedBy))
+		for i, blocker := range task.BlockedBy {
+			blockers[i] = strconv.Itoa(blocker)
+		}
+		record := []string{
+			strconv.Itoa(task.IntID),
+			task.Name,
+			task.Desc,
+			workflow.Name(task.Status),
+			task.Type.String(),
+			task.Priority.String(),
+			strings.Join(blockers, " "),
+			task.CreatedAt.Format(time.RFC3339),
+			task.UpdatedAt.Format(time.RFC3339),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadTaskCSV parses a CSV file with a header row. Columns are matched by name so
+// spreadsheets may reorder, omit or add columns; only "name" is required.
+func ReadTaskCSV(r io.Reader) ([]TaskCSVRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, domain.NewValidationError("csv", "file is empty")
+	}
+	if err != nil {
+		return nil, domain.NewValidationError("csv", fmt.Sprintf("invalid header: %v", err))
+	}
+
+	columns := make([]string, len(header))
+	hasName := false
+	for i, name := range header {
+		columns[i] = normalizeCSVColumn(name)
+		if columns[i] == "name" {
+			hasName = true
+		}
+	}
+	if !hasName {
+		return nil, domain.NewValidationError("csv", "missing required column \"name\"")
+	}
+
+	var rows []TaskCSVRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, domain.NewValidationError("csv", err.Error())
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := TaskCSVRow{Line: line, Values: make(map[string]string, len(columns))}
+		for i, value := range record {
+			if i < len(columns) {
+				row.Values[columns[i]] = value
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func normalizeCSVColumn(name string) string {
+	// Spreadsheet exports often start with a UTF-8 byte order mark
+	name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+	name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+	switch name {
+	case "desc":
+		return "description"
+	case "blockedby", "blocker":
+		return "blocked_by"
+	case "created", "createdat":
+		return "created_at"
+	case "updated", "updatedat":
+		return "updated_at"
+	}
+	return name
+}
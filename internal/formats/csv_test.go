@@ -0,0 +1,85 @@
+package formats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"kahn/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTaskCSV(t *testing.T) {
+	created := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	tasks := []domain.Task{{
+		IntID:     2,
+		Name:      "Fix, then ship",
+		Desc:      "Line one\nLine two",
+		Status:    domain.InProgress,
+		Type:      domain.Bug,
+		Priority:  domain.High,
+		BlockedBy: []int{1, 3},
+		CreatedAt: created,
+		UpdatedAt: created,
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteTaskCSV(&buf, tasks, nil))
+
+	expected := "id,name,description,status,type,priority,blocked_by,created_at,updated_at\n" +
+		"2,\"Fix, then ship\",\"Line one\nLine two\",In Progress,Bug,High,1 3,2026-09-01T10:00:00Z,2026-09-01T10:00:00Z\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestReadTaskCSV_RoundTrip(t *testing.T) {
+	tasks := []domain.Task{*domain.NewTask("Multi\nline", "with \"quotes\"", "proj_1")}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteTaskCSV(&buf, tasks, nil))
+
+	rows, err := ReadTaskCSV(&buf)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "Multi\nline", rows[0].Get("name"))
+	assert.Equal(t, "with \"quotes\"", rows[0].Get("description"))
+	assert.Equal(t, "Not Started", rows[0].Get("status"))
+}
+
+func TestReadTaskCSV_MapsColumnsByHeader(t *testing.T) {
+	input := "\ufeffPriority,Task Name,Name,Blocked-By,Notes\n" +
+		"high,ignored,First,3,extra\n" +
+		"low,ignored,Second\n"
+
+	rows, err := ReadTaskCSV(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Equal(t, "First", rows[0].Get("name"))
+	assert.Equal(t, "high", rows[0].Get("priority"))
+	assert.Equal(t, "3", rows[0].Get("blocked_by"))
+	assert.Equal(t, "", rows[1].Get("blocked_by"), "Short rows leave missing columns empty")
+	assert.Equal(t, "", rows[1].Get("status"), "Absent columns read as empty")
+}
+
+func TestReadTaskCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty file", ""},
+		{"missing name column", "id,description\n1,desc\n"},
+		{"malformed quoting", "name\n\"unterminated\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadTaskCSV(strings.NewReader(tt.input))
+			var validationErr *domain.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"kahn/internal/domain"
+)
+
+// githubIssue covers both `gh issue list --json number,title,body,state,labels`
+// and the REST API's issue list
+type githubIssue struct {
+	Number      int           `json:"number"`
+	Title       string        `json:"title"`
+	Body        string        `json:"body"`
+	State       string        `json:"state"`
+	Labels      []githubLabel `json:"labels"`
+	PullRequest *struct{}     `json:"pull_request"`
+}
+
+// githubProjectItems is the output of `gh project item-list --format json`
+type githubProjectItems struct {
+	Items []githubProjectItem `json:"items"`
+}
+
+type githubProjectItem struct {
+	Title   string         `json:"title"`
+	Status  string         `json:"status"`
+	Labels  []githubLabel  `json:"labels"`
+	Content *githubContent `json:"content"`
+}
+
+type githubContent struct {
+	Type   string `json:"type"`
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+}
+
+// githubLabel accepts both label objects ({"name": "bug"}) and the plain strings
+// used by project item exports
+type githubLabel string
+
+func (l *githubLabel) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*l = githubLabel(name)
+		return nil
+	}
+	var label struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &label); err != nil {
+		return err
+	}
+	*l = githubLabel(label.Name)
+	return nil
+}
+
+// ReadGitHubBoard reads either an issue list, whose open/closed state is used as the
+// column, or a GitHub Projects item list, whose Status field is used as the column.
+// Neither format carries a board name, so the returned board's Name is empty.
+func ReadGitHubBoard(r io.Reader) (ExternalBoard, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return ExternalBoard{}, err
+	}
+
+	board := ExternalBoard{Source: "GitHub"}
+	trimmed := bytes.TrimSpace(data)
+
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var issues []githubIssue
+		if err := json.Unmarshal(trimmed, &issues); err != nil {
+			return ExternalBoard{}, domain.NewValidationError("github", fmt.Sprintf("invalid issue list JSON: %v", err))
+		}
+		for _, issue := range issues {
+			ref := fmt.Sprintf("#%d", issue.Number)
+			if issue.PullRequest != nil {
+				board.Skipped = append(board.Skipped, fmt.Sprintf("%s %q is a pull request and was skipped", ref, issue.Title))
+				continue
+			}
+			board.Cards = append(board.Cards, ExternalCard{
+				Ref:    ref,
+				Name:   issue.Title,
+				Desc:   issue.Body,
+				Column: issue.State,
+				Labels: labelNames(issue.Labels),
+			})
+		}
+		return board, nil
+	}
+
+	var project githubProjectItems
+	if err := json.Unmarshal(trimmed, &project); err != nil {
+		return ExternalBoard{}, domain.NewValidationError("github", fmt.Sprintf("invalid project JSON: %v", err))
+	}
+	if project.Items == nil {
+		return ExternalBoard{}, domain.NewValidationError("github", "file is neither an issue list nor a project item list")
+	}
+
+	for i, item := range project.Items {
+		card := ExternalCard{
+			Ref:    fmt.Sprintf("item %d", i+1),
+			Name:   item.Title,
+			Column: item.Status,
+			Labels: labelNames(item.Labels),
+		}
+		if item.Content != nil {
+			if item.Content.Number > 0 {
+				card.Ref = fmt.Sprintf("#%d", item.Content.Number)
+			}
+			if card.Name == "" {
+				card.Name = item.Content.Title
+			}
+			card.Desc = item.Content.Body
+		}
+		if card.Column == "" {
+			card.Column = "No Status"
+		}
+		board.Cards = append(board.Cards, card)
+	}
+	return board, nil
+}
+
+func labelNames(labels []githubLabel) []string {
+	names := make([]string, len(labels))
+	for i, label := range labels {
+		names[i] = string(label)
+	}
+	return names
+}
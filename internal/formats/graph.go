@@ -0,0 +1,165 @@
+package formats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"kahn/internal/domain"
+)
+
+// Dependency graph output formats accepted by WriteDependencyGraph
+const (
+	GraphDOT     = "dot"
+	GraphMermaid = "mermaid"
+)
+
+// Node fill by workflow position and border by priority. The colors are the
+// light Catppuccin palette so the graphs read well on a white design doc.
+const (
+	graphFillNotStarted = "#e6e9ef"
+	graphFillInProgress = "#a6c8ff"
+	graphFillDone       = "#a6e3a1"
+)
+
+var graphPriorityStroke = map[domain.Priority]struct {
+	color string
+	width int
+}{
+	domain.Low:    {"#7c7f93", 1},
+	domain.Medium: {"#df8e1d", 2},
+	domain.High:   {"#d20f39", 3},
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", " ", "\n", " ")
+
+// Mermaid reads # as the start of an entity code, so it is escaped along with
+// the characters that would end the quoted label
+var mermaidEscaper = strings.NewReplacer(
+	"#", "#35;", `"`, "#quot;", "<", "#lt;", ">", "#gt;", "\r", " ", "\n", " ",
+)
+
+// GraphTasks picks the tasks worth drawing: with root 0, every task that blocks
+// or waits on another one; otherwise the tasks root waits on, directly or not,
+// the tasks waiting on it and root itself. Tasks are returned by number.
+func GraphTasks(tasks []domain.Task, root int) []domain.Task {
+	graph := domain.NewDependencyGraph(tasks)
+	dependents := graph.Dependents()
+
+	keep := make(map[int]bool)
+	if root != 0 {
+		for _, intID := range graph.Reachable(root) {
+			keep[intID] = true
+		}
+		for _, intID := range dependents.Reachable(root) {
+			keep[intID] = true
+		}
+	} else {
+		for intID, blockers := range graph {
+			if len(blockers) > 0 || len(dependents[intID]) > 0 {
+				keep[intID] = true
+			}
+		}
+	}
+
+	var picked []domain.Task
+	for _, task := range tasks {
+		if keep[task.IntID] {
+			picked = append(picked, task)
+		}
+	}
+	sort.Slice(picked, func(i, j int) bool {
+		return picked[i].IntID < picked[j].IntID
+	})
+	return picked
+}
+
+// WriteDependencyGraph draws project.Tasks and the blocker links between them in
+// format, an arrow pointing from each blocker to the task waiting on it. Links to
+// tasks outside project.Tasks are left out.
+func WriteDependencyGraph(w io.Writer, project domain.Project, format string) error {
+	switch format {
+	case GraphDOT:
+		return writeDependencyDOT(w, project)
+	case GraphMermaid:
+		return writeDependencyMermaid(w, project)
+	default:
+		return domain.NewValidationError("format", fmt.Sprintf("unknown graph format %q; use %s or %s", format, GraphDOT, GraphMermaid))
+	}
+}
+
+func writeDependencyDOT(w io.Writer, project domain.Project) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "digraph \"%s\" {\n", dotEscaper.Replace(project.Name))
+	fmt.Fprintln(bw, "  rankdir=LR;")
+	fmt.Fprintln(bw, `  node [shape=box, style="rounded,filled", fontname="Helvetica"];`)
+
+	for _, task := range project.Tasks {
+		stroke := graphPriorityStroke[task.Priority]
+		fmt.Fprintf(bw, "  t%d [label=\"#%d %s\\n%s · %s\", fillcolor=\"%s\", color=\"%s\", penwidth=%d];\n",
+			task.IntID, task.IntID, dotEscaper.Replace(task.Name),
+			dotEscaper.Replace(project.Workflow.Name(task.Status)), task.Priority,
+			graphFill(task, project.Workflow), stroke.color, stroke.width)
+	}
+	for _, edge := range graphEdges(project.Tasks) {
+		fmt.Fprintf(bw, "  t%d -> t%d;\n", edge[0], edge[1])
+	}
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+func writeDependencyMermaid(w io.Writer, project domain.Project) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "flowchart LR")
+	fmt.Fprintf(bw, "  %%%% %s\n", strings.Join(strings.Fields(project.Name), " "))
+
+	for _, task := range project.Tasks {
+		fmt.Fprintf(bw, "  t%d[\"%s<br/>%s\"]\n", task.IntID,
+			mermaidEscaper.Replace(fmt.Sprintf("#%d %s", task.IntID, task.Name)),
+			mermaidEscaper.Replace(fmt.Sprintf("%s · %s", project.Workflow.Name(task.Status), task.Priority)))
+	}
+	for _, edge := range graphEdges(project.Tasks) {
+		fmt.Fprintf(bw, "  t%d --> t%d\n", edge[0], edge[1])
+	}
+	for _, task := range project.Tasks {
+		stroke := graphPriorityStroke[task.Priority]
+		fmt.Fprintf(bw, "  style t%d fill:%s,stroke:%s,stroke-width:%dpx\n",
+			task.IntID, graphFill(task, project.Workflow), stroke.color, stroke.width)
+	}
+
+	return bw.Flush()
+}
+
+// graphEdges lists blocker → dependent pairs between the given tasks
+func graphEdges(tasks []domain.Task) [][2]int {
+	present := make(map[int]bool, len(tasks))
+	for _, task := range tasks {
+		present[task.IntID] = true
+	}
+
+	var edges [][2]int
+	for _, task := range tasks {
+		for _, blocker := range task.BlockedBy {
+			if present[blocker] {
+				edges = append(edges, [2]int{blocker, task.IntID})
+			}
+		}
+	}
+	return edges
+}
+
+func graphFill(task domain.Task, workflow domain.Workflow) string {
+	switch {
+	case workflow.IsDone(task.Status):
+		return graphFillDone
+	case task.Status == workflow.Statuses()[0]:
+		return graphFillNotStarted
+	default:
+		return graphFillInProgress
+	}
+}
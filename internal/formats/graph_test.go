@@ -0,0 +1,83 @@
+package formats
+
+import (
+	"bytes"
+	"testing"
+
+	"kahn/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func graphTestProject() domain.Project {
+	return domain.Project{
+		Name:     `Web "site"`,
+		Workflow: domain.DefaultWorkflow(),
+		Tasks: []domain.Task{
+			{IntID: 3, Name: "Ship", Status: domain.NotStarted, Priority: domain.High, BlockedBy: []int{1, 2}},
+			{IntID: 1, Name: "Design <v2>", Status: domain.Done, Priority: domain.Low},
+			{IntID: 2, Name: "Build", Status: domain.InProgress, Priority: domain.Medium, BlockedBy: []int{1}},
+			{IntID: 4, Name: "Unrelated", Status: domain.NotStarted, Priority: domain.Low},
+		},
+	}
+}
+
+func TestGraphTasks(t *testing.T) {
+	tasks := graphTestProject().Tasks
+
+	ids := func(tasks []domain.Task) []int {
+		var intIDs []int
+		for _, task := range tasks {
+			intIDs = append(intIDs, task.IntID)
+		}
+		return intIDs
+	}
+
+	assert.Equal(t, []int{1, 2, 3}, ids(GraphTasks(tasks, 0)), "Tasks without links are left out")
+	assert.Equal(t, []int{1, 2, 3}, ids(GraphTasks(tasks, 2)), "Both blockers and dependents of #2")
+	assert.Equal(t, []int{4}, ids(GraphTasks(tasks, 4)))
+}
+
+func TestWriteDependencyGraph_DOT(t *testing.T) {
+	project := graphTestProject()
+	project.Tasks = GraphTasks(project.Tasks, 0)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteDependencyGraph(&buf, project, GraphDOT))
+
+	expected := "digraph \"Web \\\"site\\\"\" {\n" +
+		"  rankdir=LR;\n" +
+		"  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n" +
+		"  t1 [label=\"#1 Design <v2>\\nDone · Low\", fillcolor=\"#a6e3a1\", color=\"#7c7f93\", penwidth=1];\n" +
+		"  t2 [label=\"#2 Build\\nIn Progress · Medium\", fillcolor=\"#a6c8ff\", color=\"#df8e1d\", penwidth=2];\n" +
+		"  t3 [label=\"#3 Ship\\nNot Started · High\", fillcolor=\"#e6e9ef\", color=\"#d20f39\", penwidth=3];\n" +
+		"  t1 -> t2;\n" +
+		"  t1 -> t3;\n" +
+		"  t2 -> t3;\n" +
+		"}\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWriteDependencyGraph_Mermaid(t *testing.T) {
+	project := graphTestProject()
+	project.Tasks = GraphTasks(project.Tasks, 0)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteDependencyGraph(&buf, project, GraphMermaid))
+
+	expected := "flowchart LR\n" +
+		"  %% Web \"site\"\n" +
+		"  t1[\"#35;1 Design #lt;v2#gt;<br/>Done · Low\"]\n" +
+		"  t2[\"#35;2 Build<br/>In Progress · Medium\"]\n" +
+		"  t3[\"#35;3 Ship<br/>Not Started · High\"]\n" +
+		"  t1 --> t2\n" +
+		"  t1 --> t3\n" +
+		"  t2 --> t3\n" +
+		"  style t1 fill:#a6e3a1,stroke:#7c7f93,stroke-width:1px\n" +
+		"  style t2 fill:#a6c8ff,stroke:#df8e1d,stroke-width:2px\n" +
+		"  style t3 fill:#e6e9ef,stroke:#d20f39,stroke-width:3px\n"
+	assert.Equal(t, expected, buf.String())
+
+	assert.Error(t, WriteDependencyGraph(&buf, project, "svg"))
+}
@@ -0,0 +1,94 @@
+package formats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"kahn/internal/domain"
+)
+
+// markdownTypeIcons uses emoji rather than the Nerd Font glyphs of the TUI so the
+// output renders anywhere GitHub-flavored Markdown does
+var markdownTypeIcons = map[domain.TaskType]string{
+	domain.RegularTask: "📋",
+	domain.Bug:         "🐛",
+	domain.Feature:     "✨",
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "#", `\#`, "|", `\|`, "~", `\~`,
+)
+
+// WriteBoardMarkdown renders a project as a GitHub-flavored Markdown snapshot with one
+// section per workflow column and each task's comments nested under it. project.Tasks
+// must already be loaded. Task numbers are wrapped in code spans so GitHub does not
+// turn them into issue links.
+func WriteBoardMarkdown(w io.Writer, project domain.Project) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "# %s\n", escapeMarkdown(project.Name))
+	if project.Description != "" {
+		fmt.Fprintf(bw, "\n%s\n", escapeMarkdown(project.Description))
+	}
+
+	for _, status := range project.Workflow.Statuses() {
+		tasks := project.GetTasksByStatus(status)
+		fmt.Fprintf(bw, "\n## %s (%d)\n\n", escapeMarkdown(project.Workflow.Name(status)), len(tasks))
+
+		if len(tasks) == 0 {
+			fmt.Fprintln(bw, "_No tasks_")
+			continue
+		}
+		for _, task := range tasks {
+			fmt.Fprintln(bw, markdownTaskLine(task, project.Workflow))
+			for _, comment := range task.Comments {
+				fmt.Fprintln(bw, markdownCommentLine(comment))
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+func markdownTaskLine(task domain.Task, workflow domain.Workflow) string {
+	checkbox := "[ ]"
+	if workflow.IsDone(task.Status) {
+		checkbox = "[x]"
+	}
+
+	var line strings.Builder
+	fmt.Fprintf(&line, "- %s %s `#%d` %s · %s priority",
+		checkbox, markdownTypeIcons[task.Type], task.IntID, escapeMarkdown(task.Name), task.Priority)
+	if len(task.Labels) > 0 {
+		line.WriteString(" ·")
+		for _, label := range task.Labels {
+			fmt.Fprintf(&line, " `%s`", label.Name)
+		}
+	}
+	if task.DueDate != nil {
+		fmt.Fprintf(&line, " · 📅 due %s", domain.FormatDate(task.DueDate))
+	}
+	if task.IsBlocked() {
+		line.WriteString(" · ⛔ blocked by")
+		for _, blocker := range task.BlockedBy {
+			fmt.Fprintf(&line, " `#%d`", blocker)
+		}
+	}
+	return line.String()
+}
+
+// markdownCommentLine nests a comment under its task's list item
+func markdownCommentLine(comment domain.Comment) string {
+	return fmt.Sprintf("  - 💬 **%s** · %s: %s",
+		escapeMarkdown(comment.Author), comment.Timestamp(), escapeMarkdown(comment.Body))
+}
+
+// escapeMarkdown backslash-escapes inline Markdown syntax and folds newlines so
+// user text cannot break out of its list item
+func escapeMarkdown(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	return markdownEscaper.Replace(text)
+}
@@ -0,0 +1,117 @@
+package formats
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"kahn/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteBoardMarkdown(t *testing.T) {
+	base := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	project := domain.Project{
+		Name:        "Website",
+		Description: "Marketing site",
+		Tasks: []domain.Task{
+			{IntID: 1, Name: "Low first", Status: domain.NotStarted, Type: domain.RegularTask, Priority: domain.Low, CreatedAt: base},
+			{IntID: 2, Name: "Fix *login*", Status: domain.NotStarted, Type: domain.Bug, Priority: domain.High, CreatedAt: base.Add(time.Hour), BlockedBy: []int{1}},
+			{IntID: 3, Name: "Shipped", Status: domain.Done, Type: domain.Feature, Priority: domain.Medium, UpdatedAt: base},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteBoardMarkdown(&buf, project))
+
+	expected := "# Website\n" +
+		"\n" +
+		"Marketing site\n" +
+		"\n" +
+		"## Not Started (2)\n" +
+		"\n" +
+		"- [ ] 🐛 `#2` Fix \\*login\\* · High priority · ⛔ blocked by `#1`\n" +
+		"- [ ] 📋 `#1` Low first · Low priority\n" +
+		"\n" +
+		"## In Progress (0)\n" +
+		"\n" +
+		"_No tasks_\n" +
+		"\n" +
+		"## Done (1)\n" +
+		"\n" +
+		"- [x] ✨ `#3` Shipped · Medium priority\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWriteBoardMarkdown_CustomWorkflow(t *testing.T) {
+	workflow, err := domain.NewWorkflow([]string{"Backlog", "Shipped", "Archived"}, "Shipped")
+	require.NoError(t, err)
+	project := domain.Project{
+		Name:     "Website",
+		Workflow: workflow,
+		Tasks: []domain.Task{
+			{IntID: 1, Name: "Launch", Status: domain.Status(1), Type: domain.RegularTask, Priority: domain.Low},
+			{IntID: 2, Name: "Old", Status: domain.Status(2), Type: domain.RegularTask, Priority: domain.Low},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteBoardMarkdown(&buf, project))
+
+	assert.Contains(t, buf.String(), "## Backlog (0)")
+	assert.Contains(t, buf.String(), "## Shipped (1)\n\n- [x] 📋 `#1` Launch")
+	assert.Contains(t, buf.String(), "## Archived (1)\n\n- [ ] 📋 `#2` Old", "Only the done column is checked")
+}
+
+func TestMarkdownTaskLine_Labels(t *testing.T) {
+	task := domain.Task{
+		IntID: 4, Name: "Fix API", Type: domain.Bug, Priority: domain.Medium,
+		Labels: []domain.Label{{Name: "backend"}, {Name: "tech-debt"}},
+	}
+
+	assert.Equal(t, "- [ ] 🐛 `#4` Fix API · Medium priority · `backend` `tech-debt`", markdownTaskLine(task, domain.DefaultWorkflow()))
+}
+
+func TestMarkdownTaskLine_DueDate(t *testing.T) {
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local)
+	task := domain.Task{IntID: 5, Name: "Renew domain", Priority: domain.Low, DueDate: &due}
+
+	assert.Equal(t, "- [ ] 📋 `#5` Renew domain · Low priority · 📅 due 2026-11-01", markdownTaskLine(task, domain.DefaultWorkflow()))
+}
+
+func TestWriteBoardMarkdown_Comments(t *testing.T) {
+	created := time.Date(2026, 9, 2, 14, 30, 0, 0, time.Local)
+	project := domain.Project{
+		Name: "Website",
+		Tasks: []domain.Task{{
+			IntID: 1, Name: "Fix login", Type: domain.Bug, Priority: domain.High,
+			Comments: []domain.Comment{{Author: "alice", Body: "Fails on *Safari*\nonly", CreatedAt: created}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteBoardMarkdown(&buf, project))
+
+	assert.Contains(t, buf.String(), "- [ ] 🐛 `#1` Fix login · High priority\n"+
+		"  - 💬 **alice** · 2026-09-02 14:30: Fails on \\*Safari\\* only\n")
+}
+
+func TestEscapeMarkdown(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"plain text", "plain text"},
+		{"a_b [c](d)", `a\_b \[c\](d)`},
+		{"#12 <b>", `\#12 \<b\>`},
+		{"multi\nline  text", "multi line text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, escapeMarkdown(tt.input))
+		})
+	}
+}
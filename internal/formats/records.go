@@ -0,0 +1,405 @@
+package formats
+
+import (
+	"time"
+
+	"kahn/internal/domain"
+)
+
+// SchemaVersion identifies the shape of the JSON records below. It is bumped only when
+// a field is removed, renamed or changes meaning; adding fields keeps the same version,
+// so consumers should ignore keys they do not recognise.
+//
+// Version 2: a task's status is the position of its column in the project's
+// workflow; in version 1 it was the fixed Not Started/In Progress/Done enum.
+const SchemaVersion = 2
+
+// TaskRecord is the stable JSON representation of a task. Enumerations are emitted
+// both as their integer value and as a display name; the status name comes from
+// the project's workflow.
+type TaskRecord struct {
+	SchemaVersion  int             `json:"schema_version"`
+	IntID          int             `json:"int_id"`
+	ID             string          `json:"id"`
+	ProjectID      string          `json:"project_id"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description"`
+	Status         int             `json:"status"`
+	StatusName     string          `json:"status_name"`
+	Type           int             `json:"type"`
+	TypeName       string          `json:"type_name"`
+	Priority       int             `json:"priority"`
+	PriorityName   string          `json:"priority_name"`
+	BlockedBy      *int            `json:"blocked_by"` // lowest of Blockers, kept for older consumers
+	Blockers       []int           `json:"blockers"`   // int_ids of every blocking task, sorted
+	Labels         []string        `json:"labels"`     // label names, sorted
+	StartDate      *string         `json:"start_date"` // YYYY-MM-DD, null when unset
+	DueDate        *string         `json:"due_date"`   // YYYY-MM-DD, null when unset
+	ChecklistDone  int             `json:"checklist_done"`
+	ChecklistTotal int             `json:"checklist_total"`
+	Comments       []CommentRecord `json:"comments"` // oldest first
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	ArchivedAt     *time.Time      `json:"archived_at"` // null while the task is on the board
+	DeletedAt      *time.Time      `json:"deleted_at"`  // null unless the task is in the trash
+}
+
+// CommentRecord is the stable JSON representation of a comment, nested in the
+// record of its task
+type CommentRecord struct {
+	ID        string    `json:"id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ChecklistItemRecord is the stable JSON representation of a checklist item
+type ChecklistItemRecord struct {
+	SchemaVersion int       `json:"schema_version"`
+	ID            string    `json:"id"`
+	TaskID        string    `json:"task_id"`
+	Position      int       `json:"position"` // 1-based
+	Text          string    `json:"text"`
+	Done          bool      `json:"done"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// LabelRecord is the stable JSON representation of a project's label
+type LabelRecord struct {
+	SchemaVersion int       `json:"schema_version"`
+	ID            string    `json:"id"`
+	ProjectID     string    `json:"project_id"`
+	Name          string    `json:"name"`
+	Color         string    `json:"color"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// SavedViewRecord is the stable JSON representation of a saved search query.
+// ProjectID is empty for a view of every project.
+type SavedViewRecord struct {
+	SchemaVersion int       `json:"schema_version"`
+	ID            string    `json:"id"`
+	ProjectID     string    `json:"project_id"`
+	Name          string    `json:"name"`
+	Query         string    `json:"query"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ProjectRecord is the stable JSON representation of a project
+type ProjectRecord struct {
+	SchemaVersion int             `json:"schema_version"`
+	ID            string          `json:"id"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	Color         string          `json:"color"`
+	Workflow      domain.Workflow `json:"workflow"`
+	TaskCount     int             `json:"task_count"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+	DeletedAt     *time.Time      `json:"deleted_at"` // null unless the project is in the trash
+}
+
+// TaskListDocument wraps a list of tasks for single-document JSON output
+type TaskListDocument struct {
+	SchemaVersion int          `json:"schema_version"`
+	Tasks         []TaskRecord `json:"tasks"`
+}
+
+// ProjectListDocument wraps a list of projects for single-document JSON output
+type ProjectListDocument struct {
+	SchemaVersion int             `json:"schema_version"`
+	Projects      []ProjectRecord `json:"projects"`
+}
+
+func NewTaskRecord(task domain.Task, workflow domain.Workflow) TaskRecord {
+	return TaskRecord{
+		SchemaVersion:  SchemaVersion,
+		IntID:          task.IntID,
+		ID:             task.ID,
+		ProjectID:      task.ProjectID,
+		Name:           task.Name,
+		Description:    task.Desc,
+		Status:         int(task.Status),
+		StatusName:     workflow.Name(task.Status),
+		Type:           int(task.Type),
+		TypeName:       task.Type.String(),
+		Priority:       int(task.Priority),
+		PriorityName:   task.Priority.String(),
+		BlockedBy:      firstBlocker(task.BlockedBy),
+		Blockers:       blockersRecord(task.BlockedBy),
+		Labels:         task.LabelNames(),
+		StartDate:      dateRecord(task.StartDate),
+		DueDate:        dateRecord(task.DueDate),
+		ChecklistDone:  task.ChecklistDone,
+		ChecklistTotal: task.ChecklistTotal,
+		Comments:       commentRecords(task.Comments),
+		CreatedAt:      task.CreatedAt,
+		UpdatedAt:      task.UpdatedAt,
+		ArchivedAt:     task.ArchivedAt,
+		DeletedAt:      task.DeletedAt,
+	}
+}
+
+func firstBlocker(blockers []int) *int {
+	if len(blockers) == 0 {
+		return nil
+	}
+	first := blockers[0]
+	return &first
+}
+
+// blockersRecord never returns nil so the key is always an array
+func blockersRecord(blockers []int) []int {
+	if blockers == nil {
+		return []int{}
+	}
+	return blockers
+}
+
+// commentRecords never returns nil so the key is always an array
+func commentRecords(comments []domain.Comment) []CommentRecord {
+	records := make([]CommentRecord, len(comments))
+	for i, comment := range comments {
+		records[i] = CommentRecord{
+			ID:        comment.ID,
+			Author:    comment.Author,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt,
+		}
+	}
+	return records
+}
+
+func dateRecord(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := domain.FormatDate(date)
+	return &formatted
+}
+
+func NewLabelRecord(label domain.Label) LabelRecord {
+	return LabelRecord{
+		SchemaVersion: SchemaVersion,
+		ID:            label.ID,
+		ProjectID:     label.ProjectID,
+		Name:          label.Name,
+		Color:         label.Color,
+		CreatedAt:     label.CreatedAt,
+	}
+}
+
+func NewSavedViewRecord(view domain.SavedView) SavedViewRecord {
+	return SavedViewRecord{
+		SchemaVersion: SchemaVersion,
+		ID:            view.ID,
+		ProjectID:     view.ProjectID,
+		Name:          view.Name,
+		Query:         view.Query,
+		CreatedAt:     view.CreatedAt,
+	}
+}
+
+// NewProjectRecord converts a project; taskCount is passed separately because
+// project listings do not load every task into Project.Tasks
+func NewProjectRecord(project domain.Project, taskCount int) ProjectRecord {
+	return ProjectRecord{
+		SchemaVersion: SchemaVersion,
+		ID:            project.ID,
+		Name:          project.Name,
+		Description:   project.Description,
+		Color:         project.Color,
+		Workflow:      project.Workflow,
+		TaskCount:     taskCount,
+		CreatedAt:     project.CreatedAt,
+		UpdatedAt:     project.UpdatedAt,
+		DeletedAt:     project.DeletedAt,
+	}
+}
+
+func NewChecklistItemRecord(item domain.ChecklistItem) ChecklistItemRecord {
+	return ChecklistItemRecord{
+		SchemaVersion: SchemaVersion,
+		ID:            item.ID,
+		TaskID:        item.TaskID,
+		Position:      item.Position,
+		Text:          item.Text,
+		Done:          item.Done,
+		CreatedAt:     item.CreatedAt,
+		UpdatedAt:     item.UpdatedAt,
+	}
+}
+
+// ChecklistDocument wraps a task's checklist for single-document JSON output
+type ChecklistDocument struct {
+	SchemaVersion int                   `json:"schema_version"`
+	TaskID        int                   `json:"task_int_id"`
+	Items         []ChecklistItemRecord `json:"items"`
+}
+
+func NewChecklistDocument(task domain.Task, items []domain.ChecklistItem) ChecklistDocument {
+	records := make([]ChecklistItemRecord, len(items))
+	for i, item := range items {
+		records[i] = NewChecklistItemRecord(item)
+	}
+	return ChecklistDocument{SchemaVersion: SchemaVersion, TaskID: task.IntID, Items: records}
+}
+
+// CommentListDocument wraps a task's comments for single-document JSON output
+type CommentListDocument struct {
+	SchemaVersion int             `json:"schema_version"`
+	TaskID        int             `json:"task_int_id"`
+	Comments      []CommentRecord `json:"comments"`
+}
+
+func NewCommentListDocument(task domain.Task, comments []domain.Comment) CommentListDocument {
+	return CommentListDocument{SchemaVersion: SchemaVersion, TaskID: task.IntID, Comments: commentRecords(comments)}
+}
+
+// LabelListDocument wraps a project's labels for single-document JSON output
+type LabelListDocument struct {
+	SchemaVersion int           `json:"schema_version"`
+	Labels        []LabelRecord `json:"labels"`
+}
+
+func NewLabelListDocument(labels []domain.Label) LabelListDocument {
+	records := make([]LabelRecord, len(labels))
+	for i, label := range labels {
+		records[i] = NewLabelRecord(label)
+	}
+	return LabelListDocument{SchemaVersion: SchemaVersion, Labels: records}
+}
+
+// SavedViewListDocument wraps saved views for single-document JSON output
+type SavedViewListDocument struct {
+	SchemaVersion int               `json:"schema_version"`
+	Views         []SavedViewRecord `json:"views"`
+}
+
+func NewSavedViewListDocument(views []domain.SavedView) SavedViewListDocument {
+	records := make([]SavedViewRecord, len(views))
+	for i, view := range views {
+		records[i] = NewSavedViewRecord(view)
+	}
+	return SavedViewListDocument{SchemaVersion: SchemaVersion, Views: records}
+}
+
+func NewTaskListDocument(tasks []domain.Task, workflow domain.Workflow) TaskListDocument {
+	records := make([]TaskRecord, len(tasks))
+	for i, task := range tasks {
+		records[i] = NewTaskRecord(task, workflow)
+	}
+	return TaskListDocument{SchemaVersion: SchemaVersion, Tasks: records}
+}
+
+func NewProjectListDocument(projects []ProjectRecord) ProjectListDocument {
+	if projects == nil {
+		projects = []ProjectRecord{}
+	}
+	return ProjectListDocument{SchemaVersion: SchemaVersion, Projects: projects}
+}
+
+// TrashDocument lists what is in the trash for single-document JSON output
+type TrashDocument struct {
+	SchemaVersion int             `json:"schema_version"`
+	Projects      []ProjectRecord `json:"projects"`
+	Tasks         []TaskRecord    `json:"tasks"`
+}
+
+func NewTrashDocument(projects []ProjectRecord, tasks []TaskRecord) TrashDocument {
+	if projects == nil {
+		projects = []ProjectRecord{}
+	}
+	if tasks == nil {
+		tasks = []TaskRecord{}
+	}
+	return TrashDocument{SchemaVersion: SchemaVersion, Projects: projects, Tasks: tasks}
+}
+
+// EventRecord is the stable JSON representation of an audit log event. Task
+// fields are empty for project events; Summary is the one-line description
+// shown by the table output.
+type EventRecord struct {
+	SchemaVersion int       `json:"schema_version"`
+	ID            int64     `json:"id"`
+	ProjectID     string    `json:"project_id"`
+	TaskID        string    `json:"task_id"`
+	TaskIntID     int       `json:"task_int_id"`
+	Subject       string    `json:"subject"`
+	Kind          string    `json:"kind"`
+	Field         string    `json:"field"`
+	OldValue      string    `json:"old_value"`
+	NewValue      string    `json:"new_value"`
+	Summary       string    `json:"summary"`
+	Actor         string    `json:"actor"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// EventListDocument wraps audit log events for single-document JSON output
+type EventListDocument struct {
+	SchemaVersion int           `json:"schema_version"`
+	Events        []EventRecord `json:"events"`
+}
+
+func NewEventRecord(event domain.Event) EventRecord {
+	return EventRecord{
+		SchemaVersion: SchemaVersion,
+		ID:            event.ID,
+		ProjectID:     event.ProjectID,
+		TaskID:        event.TaskID,
+		TaskIntID:     event.TaskIntID,
+		Subject:       event.Subject,
+		Kind:          string(event.Kind),
+		Field:         event.Field,
+		OldValue:      event.OldValue,
+		NewValue:      event.NewValue,
+		Summary:       event.Describe(),
+		Actor:         event.Actor,
+		CreatedAt:     event.CreatedAt,
+	}
+}
+
+func NewEventListDocument(events []domain.Event) EventListDocument {
+	records := make([]EventRecord, len(events))
+	for i, event := range events {
+		records[i] = NewEventRecord(event)
+	}
+	return EventListDocument{SchemaVersion: SchemaVersion, Events: records}
+}
+
+// WorkflowColumnRecord is the stable JSON representation of a workflow column
+type WorkflowColumnRecord struct {
+	Status   int    `json:"status"` // position in board order, as in a task's status
+	Name     string `json:"name"`
+	IsDone   bool   `json:"is_done"`
+	WIPLimit int    `json:"wip_limit"` // 0 when the column has no limit
+}
+
+// WorkflowDocument wraps a project's workflow columns, in board order, for
+// single-document JSON output
+type WorkflowDocument struct {
+	SchemaVersion int                    `json:"schema_version"`
+	ProjectID     string                 `json:"project_id"`
+	Columns       []WorkflowColumnRecord `json:"columns"`
+	DoneColumn    string                 `json:"done_column"`
+}
+
+func NewWorkflowDocument(project domain.Project) WorkflowDocument {
+	workflow := project.Workflow
+	columns := make([]WorkflowColumnRecord, workflow.Len())
+	for i, status := range workflow.Statuses() {
+		columns[i] = WorkflowColumnRecord{
+			Status:   int(status),
+			Name:     workflow.Name(status),
+			IsDone:   workflow.IsDone(status),
+			WIPLimit: workflow.WIPLimit(status),
+		}
+	}
+	return WorkflowDocument{
+		SchemaVersion: SchemaVersion,
+		ProjectID:     project.ID,
+		Columns:       columns,
+		DoneColumn:    workflow.Name(workflow.DoneStatus()),
+	}
+}
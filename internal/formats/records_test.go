@@ -0,0 +1,129 @@
+package formats
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"kahn/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTaskRecord_JSONShape(t *testing.T) {
+	created := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	task := domain.Task{
+		IntID:     7,
+		ID:        "task_1",
+		ProjectID: "proj_1",
+		Name:      "Fix login",
+		Desc:      "Crashes on submit",
+		Status:    domain.InProgress,
+		Type:      domain.Bug,
+		Priority:  domain.High,
+		BlockedBy: []int{3, 5},
+		CreatedAt: created,
+		UpdatedAt: created.Add(time.Hour),
+	}
+
+	data, err := json.Marshal(NewTaskRecord(task, nil))
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	assert.Equal(t, float64(SchemaVersion), decoded["schema_version"])
+	assert.Equal(t, float64(7), decoded["int_id"])
+	assert.Equal(t, float64(3), decoded["blocked_by"], "blocked_by keeps the lowest blocker for older consumers")
+	assert.Equal(t, []any{float64(3), float64(5)}, decoded["blockers"])
+	assert.Equal(t, float64(domain.InProgress), decoded["status"])
+	assert.Equal(t, "In Progress", decoded["status_name"])
+	assert.Equal(t, float64(domain.Bug), decoded["type"])
+	assert.Equal(t, "Bug", decoded["type_name"])
+	assert.Equal(t, float64(domain.High), decoded["priority"])
+	assert.Equal(t, "High", decoded["priority_name"])
+	assert.Equal(t, "2026-09-01T10:00:00Z", decoded["created_at"])
+}
+
+func TestNewTaskRecord_UnblockedTaskHasNullBlocker(t *testing.T) {
+	task := domain.NewTask("Task", "", "proj_1")
+
+	data, err := json.Marshal(NewTaskRecord(*task, nil))
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	value, present := decoded["blocked_by"]
+	assert.True(t, present, "blocked_by is always present so consumers can rely on the key")
+	assert.Nil(t, value)
+	assert.Equal(t, []any{}, decoded["blockers"], "blockers is an empty list rather than null")
+}
+
+func TestTaskRecord_Dates(t *testing.T) {
+	task := domain.NewTask("Task", "", "proj_1")
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local)
+	task.DueDate = &due
+
+	data, err := json.Marshal(NewTaskRecord(*task, nil))
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "2026-11-01", decoded["due_date"])
+	value, present := decoded["start_date"]
+	assert.True(t, present)
+	assert.Nil(t, value)
+
+	var record TaskRecord
+	require.NoError(t, json.Unmarshal(data, &record))
+	assert.Equal(t, "2026-11-01", domain.FormatDate(record.Task().DueDate))
+
+	invalid := "tomorrow"
+	record.DueDate = &invalid
+	_, _, err = record.Dates()
+	assert.Error(t, err)
+}
+
+func TestNewProjectListDocument_EmptyListIsArray(t *testing.T) {
+	data, err := json.Marshal(NewProjectListDocument(nil))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"schema_version":2,"projects":[]}`, string(data))
+}
+
+func TestNewTaskListDocument(t *testing.T) {
+	tasks := []domain.Task{*domain.NewTask("One", "", "proj_1"), *domain.NewTask("Two", "", "proj_1")}
+
+	doc := NewTaskListDocument(tasks, nil)
+	assert.Equal(t, SchemaVersion, doc.SchemaVersion)
+	require.Len(t, doc.Tasks, 2)
+	assert.Equal(t, "One", doc.Tasks[0].Name)
+}
+
+func TestReadArchive_UpgradesVersion1Statuses(t *testing.T) {
+	input := `{"format": "kahn-archive", "schema_version": 1,
+		"projects": [
+			{"id": "proj_plain", "name": "Plain"},
+			{"id": "proj_team", "name": "Team", "workflow": [
+				{"name": "Backlog"}, {"name": "Doing"}, {"name": "Review"}, {"name": "Shipped", "is_done": true}]}
+		],
+		"tasks": [
+			{"id": "t1", "project_id": "proj_plain", "name": "Old", "status": 1},
+			{"id": "t2", "project_id": "proj_team", "name": "Enum done", "status": 2},
+			{"id": "t3", "project_id": "proj_team", "name": "Enum started", "status": 1},
+			{"id": "t4", "project_id": "proj_team", "name": "Named", "status": 2, "status_name": "Review"}
+		]}`
+
+	archive, err := ReadArchive(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, SchemaVersion, archive.SchemaVersion)
+
+	statuses := make([]domain.Status, len(archive.Tasks))
+	for i, record := range archive.Tasks {
+		statuses[i] = record.Task().Status
+	}
+	assert.Equal(t, []domain.Status{domain.InProgress, 3, 1, 2}, statuses,
+		"Enum values map onto the workflow; a status name that matches a column wins")
+}
@@ -0,0 +1,246 @@
+package formats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"kahn/internal/domain"
+)
+
+const todoDateLayout = "2006-01-02"
+
+// Keys written by Kahn into todo.txt lines. Other key:value pairs and @contexts are
+// left in the task name so they survive a round trip. A word of a name that would
+// read back as a marker, such as +tag, @bug or due:x, is written with a leading
+// backslash, which the parser drops again.
+const (
+	todoIDKey       = "id"
+	todoPriorityKey = "pri"
+	todoStatusKey   = "status"
+	todoDueKey      = "due"
+)
+
+var todoPriorities = map[domain.Priority]string{
+	domain.High:   "A",
+	domain.Medium: "B",
+	domain.Low:    "C",
+}
+
+// TodoItem is one parsed todo.txt line
+type TodoItem struct {
+	Line     int
+	Done     bool
+	Priority string // "A"-"Z", or "" when the line has none
+	Name     string // description with Kahn's own markers removed
+	Projects []string
+	ID       string
+	Type     domain.TaskType
+	Status   domain.Status
+	Due      *time.Time // from due:YYYY-MM-DD
+}
+
+// TodoProjectTag converts a project name into a +Project tag, which cannot contain spaces
+func TodoProjectTag(projectName string) string {
+	return "+" + strings.Join(strings.Fields(projectName), "-")
+}
+
+// HasProject reports whether the line is tagged with the project, ignoring case
+func (item TodoItem) HasProject(projectName string) bool {
+	tag := strings.TrimPrefix(TodoProjectTag(projectName), "+")
+	for _, project := range item.Projects {
+		if strings.EqualFold(project, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// TaskPriority maps (A)/(B)/(C) onto High/Medium/Low; lower priorities and untagged lines are Low
+func (item TodoItem) TaskPriority() domain.Priority {
+	for priority, letter := range todoPriorities {
+		if item.Priority == letter {
+			return priority
+		}
+	}
+	return domain.Low
+}
+
+// WriteTodoTxt writes one todo.txt line per task of project in board order.
+// project.Tasks must already be loaded.
+func WriteTodoTxt(w io.Writer, project domain.Project) error {
+	bw := bufio.NewWriter(w)
+	for _, status := range project.Workflow.Statuses() {
+		for _, task := range project.GetTasksByStatus(status) {
+			fmt.Fprintln(bw, FormatTodoTxtLine(task, project.Name, project.Workflow))
+		}
+	}
+	return bw.Flush()
+}
+
+// FormatTodoTxtLine renders a task as a todo.txt line. Tasks in the workflow's done
+// column use the "x" prefix with UpdatedAt as the completion date and keep their
+// priority as pri:X, since todo.txt drops the (X) marker on completion. Columns
+// between the first and the done one are written as status:name, and due dates
+// use the common due:YYYY-MM-DD extension.
+func FormatTodoTxtLine(task domain.Task, projectName string, workflow domain.Workflow) string {
+	var parts []string
+	letter := todoPriorities[task.Priority]
+	done := workflow.IsDone(task.Status)
+
+	if done {
+		parts = append(parts, "x", task.UpdatedAt.Format(todoDateLayout))
+	} else {
+		parts = append(parts, "("+letter+")")
+	}
+	parts = append(parts, task.CreatedAt.Format(todoDateLayout))
+	for _, word := range strings.Fields(task.Name) {
+		parts = append(parts, escapeTodoWord(word))
+	}
+	parts = append(parts, TodoProjectTag(projectName))
+
+	switch task.Type {
+	case domain.Bug:
+		parts = append(parts, "@bug")
+	case domain.Feature:
+		parts = append(parts, "@feature")
+	}
+
+	if done {
+		parts = append(parts, todoPriorityKey+":"+letter)
+	} else if task.Status != domain.NotStarted {
+		statusName := strings.Join(strings.Fields(workflow.Name(task.Status)), "")
+		parts = append(parts, todoStatusKey+":"+strings.ToLower(statusName))
+	}
+	if task.DueDate != nil {
+		parts = append(parts, todoDueKey+":"+domain.FormatDate(task.DueDate))
+	}
+	parts = append(parts, todoIDKey+":"+task.ID)
+
+	return strings.Join(parts, " ")
+}
+
+// ParseTodoTxt reads todo.txt lines, skipping blank lines. status: values are
+// resolved against workflow and completed lines land in its done column.
+func ParseTodoTxt(r io.Reader, workflow domain.Workflow) ([]TodoItem, error) {
+	var items []TodoItem
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		item, err := parseTodoLine(line, workflow)
+		if err != nil {
+			return nil, domain.NewValidationError("todo.txt", fmt.Sprintf("line %d: %v", lineNumber, err))
+		}
+		item.Line = lineNumber
+		items = append(items, item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func parseTodoLine(line string, workflow domain.Workflow) (TodoItem, error) {
+	item := TodoItem{Status: domain.NotStarted, Type: domain.RegularTask}
+	tokens := strings.Fields(line)
+
+	if len(tokens) > 0 && tokens[0] == "x" {
+		item.Done = true
+		item.Status = workflow.DoneStatus()
+		tokens = tokens[1:]
+		// Completion date, then an optional creation date
+		for i := 0; i < 2 && len(tokens) > 0 && isTodoDate(tokens[0]); i++ {
+			tokens = tokens[1:]
+		}
+	} else {
+		if len(tokens) > 0 && isTodoPriority(tokens[0]) {
+			item.Priority = tokens[0][1:2]
+			tokens = tokens[1:]
+		}
+		if len(tokens) > 0 && isTodoDate(tokens[0]) {
+			tokens = tokens[1:]
+		}
+	}
+
+	var words []string
+	for _, token := range tokens {
+		switch {
+		case len(token) > 1 && token[0] == '\\':
+			words = append(words, token[1:])
+		case len(token) > 1 && token[0] == '+':
+			item.Projects = append(item.Projects, token[1:])
+		case strings.EqualFold(token, "@bug"):
+			item.Type = domain.Bug
+		case strings.EqualFold(token, "@feature"):
+			item.Type = domain.Feature
+		case strings.HasPrefix(token, todoIDKey+":") && len(token) > len(todoIDKey)+1:
+			item.ID = strings.TrimPrefix(token, todoIDKey+":")
+		case strings.HasPrefix(token, todoPriorityKey+":") && item.Done:
+			item.Priority = strings.ToUpper(strings.TrimPrefix(token, todoPriorityKey+":"))
+		case strings.HasPrefix(token, todoStatusKey+":"):
+			if item.Done {
+				continue
+			}
+			status, err := workflow.Parse(strings.TrimPrefix(token, todoStatusKey+":"))
+			if err != nil {
+				return item, err
+			}
+			item.Status = status
+		case strings.HasPrefix(token, todoDueKey+":"):
+			value := strings.TrimPrefix(token, todoDueKey+":")
+			due, err := time.ParseInLocation(todoDateLayout, value, time.Local)
+			if err != nil {
+				return item, fmt.Errorf("invalid due date %q, expected YYYY-MM-DD", value)
+			}
+			item.Due = &due
+		default:
+			words = append(words, token)
+		}
+	}
+
+	item.Name = strings.Join(words, " ")
+	return item, nil
+}
+
+// escapeTodoWord prefixes a word of a task name with a backslash when the parser
+// would take it for a marker. Words already starting with one are escaped too, so
+// the parser can always drop exactly one.
+func escapeTodoWord(word string) string {
+	if strings.HasPrefix(word, `\`) || isTodoMarker(word) {
+		return `\` + word
+	}
+	return word
+}
+
+// isTodoMarker reports whether parseTodoLine reads token as something other than
+// a word of the name
+func isTodoMarker(token string) bool {
+	if len(token) > 1 && token[0] == '+' {
+		return true
+	}
+	if strings.EqualFold(token, "@bug") || strings.EqualFold(token, "@feature") {
+		return true
+	}
+	for _, key := range []string{todoIDKey, todoPriorityKey, todoStatusKey, todoDueKey} {
+		if strings.HasPrefix(token, key+":") {
+			return true
+		}
+	}
+	return false
+}
+
+func isTodoDate(token string) bool {
+	_, err := time.Parse(todoDateLayout, token)
+	return err == nil
+}
+
+func isTodoPriority(token string) bool {
+	return len(token) == 3 && token[0] == '(' && token[2] == ')' && token[1] >= 'A' && token[1] <= 'Z'
+}
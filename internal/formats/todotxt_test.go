@@ -0,0 +1,151 @@
+package formats
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"kahn/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatTodoTxtLine(t *testing.T) {
+	created := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	updated := time.Date(2026, 9, 3, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		task     domain.Task
+		expected string
+	}{
+		{
+			name:     "not started task",
+			task:     domain.Task{ID: "task_1", Name: "Write docs", Status: domain.NotStarted, Type: domain.RegularTask, Priority: domain.Low, CreatedAt: created},
+			expected: "(C) 2026-09-01 Write docs +My-Site id:task_1",
+		},
+		{
+			name:     "in progress bug",
+			task:     domain.Task{ID: "task_2", Name: "Fix login", Status: domain.InProgress, Type: domain.Bug, Priority: domain.High, CreatedAt: created},
+			expected: "(A) 2026-09-01 Fix login +My-Site @bug status:inprogress id:task_2",
+		},
+		{
+			name:     "done feature",
+			task:     domain.Task{ID: "task_3", Name: "Dark mode", Status: domain.Done, Type: domain.Feature, Priority: domain.Medium, CreatedAt: created, UpdatedAt: updated},
+			expected: "x 2026-09-03 2026-09-01 Dark mode +My-Site @feature pri:B id:task_3",
+		},
+		{
+			name:     "task with due date",
+			task:     domain.Task{ID: "task_4", Name: "Renew domain", Status: domain.NotStarted, Type: domain.RegularTask, Priority: domain.Medium, CreatedAt: created, DueDate: &created},
+			expected: "(B) 2026-09-01 Renew domain +My-Site due:2026-09-01 id:task_4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatTodoTxtLine(tt.task, "My Site", nil))
+		})
+	}
+}
+
+func TestParseTodoTxt(t *testing.T) {
+	input := "(A) 2026-09-01 Fix login +My-Site @bug status:inprogress id:task_2\n" +
+		"\n" +
+		"x 2026-09-03 2026-09-01 Dark mode +my-site @feature pri:B id:task_3\n" +
+		"Call mom @phone due:2026-10-01 +Home\n"
+
+	items, err := ParseTodoTxt(strings.NewReader(input), nil)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+
+	assert.Equal(t, 1, items[0].Line)
+	assert.Equal(t, "Fix login", items[0].Name)
+	assert.Equal(t, domain.Bug, items[0].Type)
+	assert.Equal(t, domain.InProgress, items[0].Status)
+	assert.Equal(t, domain.High, items[0].TaskPriority())
+	assert.Equal(t, "task_2", items[0].ID)
+	assert.True(t, items[0].HasProject("My Site"))
+
+	assert.Equal(t, 3, items[1].Line, "Blank lines still count towards line numbers")
+	assert.True(t, items[1].Done)
+	assert.Equal(t, domain.Done, items[1].Status)
+	assert.Equal(t, domain.Medium, items[1].TaskPriority(), "pri: keeps the priority of completed tasks")
+	assert.True(t, items[1].HasProject("My Site"), "Project tags match case-insensitively")
+
+	assert.Equal(t, "Call mom @phone", items[2].Name, "Unknown contexts and tags stay in the name")
+	assert.Equal(t, "2026-10-01", domain.FormatDate(items[2].Due), "due: becomes the due date")
+	assert.Equal(t, domain.Low, items[2].TaskPriority())
+	assert.Empty(t, items[2].ID)
+	assert.False(t, items[2].HasProject("My Site"))
+}
+
+func TestParseTodoTxt_InvalidStatus(t *testing.T) {
+	_, err := ParseTodoTxt(strings.NewReader("Task status:someday\n"), nil)
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, err.Error(), "line 1")
+}
+
+func TestParseTodoTxt_InvalidDueDate(t *testing.T) {
+	_, err := ParseTodoTxt(strings.NewReader("Task due:someday\n"), nil)
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, err.Error(), "due date")
+}
+
+func TestTodoTxt_RoundTrip(t *testing.T) {
+	created := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	project := domain.Project{Name: "Site", Tasks: []domain.Task{
+		{ID: "task_1", Name: "One", Status: domain.Done, Type: domain.Bug, Priority: domain.High, CreatedAt: created, UpdatedAt: created},
+		{ID: "task_2", Name: "Two", Status: domain.InProgress, Type: domain.RegularTask, Priority: domain.Medium, CreatedAt: created, UpdatedAt: created},
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteTodoTxt(&buf, project))
+
+	items, err := ParseTodoTxt(&buf, nil)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	assert.Equal(t, "task_2", items[0].ID, "Board order puts In Progress before Done")
+	assert.Equal(t, domain.InProgress, items[0].Status)
+	assert.Equal(t, domain.Medium, items[0].TaskPriority())
+	assert.Equal(t, "task_1", items[1].ID)
+	assert.Equal(t, domain.Done, items[1].Status)
+	assert.Equal(t, domain.Bug, items[1].Type)
+	assert.Equal(t, domain.High, items[1].TaskPriority())
+}
+
+func TestTodoTxt_RoundTripEscapesMarkers(t *testing.T) {
+	created := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	names := []string{
+		"Tag +release and @bug",
+		"Set due:friday status:later id:x pri:A",
+		`Keep \n and @phone`,
+	}
+	project := domain.Project{Name: "Site"}
+	for i, name := range names {
+		project.Tasks = append(project.Tasks, domain.Task{ID: fmt.Sprintf("task_%d", i), Name: name, CreatedAt: created, UpdatedAt: created})
+	}
+	project.Tasks[0].Status = domain.Done
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteTodoTxt(&buf, project))
+	assert.Contains(t, buf.String(), `Tag \+release and \@bug +Site`)
+
+	items, err := ParseTodoTxt(&buf, nil)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, names[1], items[0].Name)
+	assert.Equal(t, domain.RegularTask, items[0].Type)
+	assert.Nil(t, items[0].Due)
+	assert.Equal(t, domain.NotStarted, items[0].Status)
+	assert.Equal(t, "task_1", items[0].ID)
+	assert.Equal(t, names[2], items[1].Name)
+	assert.Equal(t, names[0], items[2].Name)
+	assert.Equal(t, []string{"Site"}, items[2].Projects)
+	assert.Equal(t, domain.RegularTask, items[2].Type)
+}
@@ -0,0 +1,100 @@
+package formats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"kahn/internal/domain"
+)
+
+type trelloBoard struct {
+	Name  string       `json:"name"`
+	Desc  string       `json:"desc"`
+	Lists []trelloList `json:"lists"`
+	Cards []trelloCard `json:"cards"`
+}
+
+type trelloList struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Closed bool    `json:"closed"`
+	Pos    float64 `json:"pos"`
+}
+
+type trelloCard struct {
+	IDShort int           `json:"idShort"`
+	Name    string        `json:"name"`
+	Desc    string        `json:"desc"`
+	IDList  string        `json:"idList"`
+	Closed  bool          `json:"closed"`
+	Pos     float64       `json:"pos"`
+	Labels  []trelloLabel `json:"labels"`
+}
+
+type trelloLabel struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// ReadTrelloBoard reads a board exported with Trello's "Print and export > Export as
+// JSON". Archived cards and cards in archived lists are skipped and reported.
+func ReadTrelloBoard(r io.Reader) (ExternalBoard, error) {
+	var export trelloBoard
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return ExternalBoard{}, domain.NewValidationError("trello", fmt.Sprintf("invalid board JSON: %v", err))
+	}
+	if export.Name == "" || export.Lists == nil {
+		return ExternalBoard{}, domain.NewValidationError("trello", "file is not a Trello board export")
+	}
+
+	lists := make(map[string]trelloList, len(export.Lists))
+	for _, list := range export.Lists {
+		lists[list.ID] = list
+	}
+
+	// Cards keep the order they have on the board: by list position, then card position
+	sort.SliceStable(export.Cards, func(i, j int) bool {
+		a, b := lists[export.Cards[i].IDList], lists[export.Cards[j].IDList]
+		if a.Pos != b.Pos {
+			return a.Pos < b.Pos
+		}
+		return export.Cards[i].Pos < export.Cards[j].Pos
+	})
+
+	board := ExternalBoard{Source: "Trello", Name: export.Name, Description: export.Desc}
+	for _, card := range export.Cards {
+		ref := fmt.Sprintf("#%d", card.IDShort)
+		list, ok := lists[card.IDList]
+		switch {
+		case card.Closed:
+			board.Skipped = append(board.Skipped, fmt.Sprintf("card %s %q is archived and was skipped", ref, card.Name))
+			continue
+		case !ok:
+			board.Skipped = append(board.Skipped, fmt.Sprintf("card %s %q belongs to an unknown list and was skipped", ref, card.Name))
+			continue
+		case list.Closed:
+			board.Skipped = append(board.Skipped, fmt.Sprintf("card %s %q is in archived list %q and was skipped", ref, card.Name, list.Name))
+			continue
+		}
+
+		labels := make([]string, 0, len(card.Labels))
+		for _, label := range card.Labels {
+			name := label.Name
+			if name == "" {
+				name = "(" + label.Color + ")"
+			}
+			labels = append(labels, name)
+		}
+
+		board.Cards = append(board.Cards, ExternalCard{
+			Ref:    ref,
+			Name:   card.Name,
+			Desc:   card.Desc,
+			Column: list.Name,
+			Labels: labels,
+		})
+	}
+	return board, nil
+}
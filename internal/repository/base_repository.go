@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"fmt"
 	"kahn/internal/domain"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -50,14 +52,24 @@ func (b *BaseRepository) ScanTaskRows(rows *sql.Rows) ([]domain.Task, error) {
 	var tasks []domain.Task
 	for rows.Next() {
 		var task domain.Task
+		var blockers, startDate, dueDate sql.NullString
+		var archivedAt, deletedAt sql.NullTime
 		err := rows.Scan(
 			&task.IntID, &task.ID, &task.ProjectID, &task.Name, &task.Desc,
-			&task.Status, &task.Type, &task.Priority, &task.BlockedBy,
-			&task.CreatedAt, &task.UpdatedAt,
+			&task.Status, &task.Type, &task.Priority, &blockers,
+			&task.CreatedAt, &task.UpdatedAt, &startDate, &dueDate,
+			&task.ChecklistTotal, &task.ChecklistDone, &archivedAt, &deletedAt,
 		)
 		if err != nil {
 			return nil, b.WrapDBError("scan", "task", "", err)
 		}
+		if task.BlockedBy, err = parseBlockersColumn(blockers); err != nil {
+			return nil, b.WrapDBError("scan", "task", task.ID, err)
+		}
+		if err := scanTaskDates(&task, startDate, dueDate); err != nil {
+			return nil, b.WrapDBError("scan", "task", task.ID, err)
+		}
+		task.ArchivedAt, task.DeletedAt = timestampValue(archivedAt), timestampValue(deletedAt)
 		tasks = append(tasks, task)
 	}
 
@@ -72,13 +84,15 @@ func (b *BaseRepository) ScanProjectRows(rows *sql.Rows) ([]domain.Project, erro
 	var projects []domain.Project
 	for rows.Next() {
 		var project domain.Project
+		var deletedAt sql.NullTime
 		err := rows.Scan(
 			&project.ID, &project.Name, &project.Description, &project.Color,
-			&project.CreatedAt, &project.UpdatedAt,
+			&project.CreatedAt, &project.UpdatedAt, &deletedAt,
 		)
 		if err != nil {
 			return nil, b.WrapDBError("scan", "project", "", err)
 		}
+		project.DeletedAt = timestampValue(deletedAt)
 		projects = append(projects, project)
 	}
 
@@ -91,10 +105,13 @@ func (b *BaseRepository) ScanProjectRows(rows *sql.Rows) ([]domain.Project, erro
 
 func (b *BaseRepository) ScanSingleTask(row *sql.Row) (*domain.Task, error) {
 	var task domain.Task
+	var blockers, startDate, dueDate sql.NullString
+	var archivedAt, deletedAt sql.NullTime
 	err := row.Scan(
 		&task.IntID, &task.ID, &task.ProjectID, &task.Name, &task.Desc,
-		&task.Status, &task.Type, &task.Priority, &task.BlockedBy,
-		&task.CreatedAt, &task.UpdatedAt,
+		&task.Status, &task.Type, &task.Priority, &blockers,
+		&task.CreatedAt, &task.UpdatedAt, &startDate, &dueDate,
+		&task.ChecklistTotal, &task.ChecklistDone, &archivedAt, &deletedAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -102,14 +119,76 @@ func (b *BaseRepository) ScanSingleTask(row *sql.Row) (*domain.Task, error) {
 		}
 		return nil, b.WrapDBError("get", "task", "", err)
 	}
+	if task.BlockedBy, err = parseBlockersColumn(blockers); err != nil {
+		return nil, b.WrapDBError("scan", "task", task.ID, err)
+	}
+	if err := scanTaskDates(&task, startDate, dueDate); err != nil {
+		return nil, b.WrapDBError("scan", "task", task.ID, err)
+	}
+	task.ArchivedAt, task.DeletedAt = timestampValue(archivedAt), timestampValue(deletedAt)
 	return &task, nil
 }
 
+// parseBlockersColumn reads the comma separated int_ids selected from
+// task_dependencies into a sorted slice
+func parseBlockersColumn(value sql.NullString) ([]int, error) {
+	if !value.Valid || value.String == "" {
+		return nil, nil
+	}
+	var blockers []int
+	for _, field := range strings.Split(value.String, ",") {
+		blocker, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, err
+		}
+		blockers = append(blockers, blocker)
+	}
+	return domain.NormalizeBlockers(blockers), nil
+}
+
+// scanTaskDates parses the YYYY-MM-DD start and due date columns as local dates
+func scanTaskDates(task *domain.Task, startDate, dueDate sql.NullString) error {
+	var err error
+	if task.StartDate, err = parseDateColumn(startDate); err != nil {
+		return err
+	}
+	task.DueDate, err = parseDateColumn(dueDate)
+	return err
+}
+
+func parseDateColumn(value sql.NullString) (*time.Time, error) {
+	if !value.Valid || value.String == "" {
+		return nil, nil
+	}
+	date, err := time.ParseInLocation(domain.DateLayout, value.String, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+// timestampValue converts a nullable timestamp column into an optional time
+func timestampValue(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
+
+// DateValue converts an optional date into the value stored in a date column
+func DateValue(date *time.Time) interface{} {
+	if date == nil {
+		return nil
+	}
+	return domain.FormatDate(date)
+}
+
 func (b *BaseRepository) ScanSingleProject(row *sql.Row) (*domain.Project, error) {
 	var project domain.Project
+	var deletedAt sql.NullTime
 	err := row.Scan(
 		&project.ID, &project.Name, &project.Description, &project.Color,
-		&project.CreatedAt, &project.UpdatedAt,
+		&project.CreatedAt, &project.UpdatedAt, &deletedAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -117,6 +196,7 @@ func (b *BaseRepository) ScanSingleProject(row *sql.Row) (*domain.Project, error
 		}
 		return nil, b.WrapDBError("get", "project", "", err)
 	}
+	project.DeletedAt = timestampValue(deletedAt)
 	return &project, nil
 }
 
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"kahn/internal/database"
+	"kahn/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestEventRepository(t *testing.T) *SQLiteEventRepository {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	dbWrapper := &database.Database{Db: db}
+	require.NoError(t, dbWrapper.RunMigrations())
+
+	return NewSQLiteEventRepository(db)
+}
+
+func TestEventRepository_AppendAndList(t *testing.T) {
+	repo := setupTestEventRepository(t)
+
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	events := []domain.Event{
+		{ProjectID: "p1", Subject: "Website", Kind: domain.EventCreated},
+		{ProjectID: "p1", TaskID: "t1", TaskIntID: 1, Subject: "Login", Kind: domain.EventCreated, NewValue: "Not Started"},
+		{ProjectID: "p1", TaskID: "t1", TaskIntID: 1, Subject: "Login", Kind: domain.EventChanged, Field: "priority", OldValue: "Low", NewValue: "High"},
+		{ProjectID: "p2", TaskID: "t2", TaskIntID: 2, Subject: "Other", Kind: domain.EventCreated},
+		{ProjectID: "p1", TaskID: "t1", TaskIntID: 1, Subject: "Login", Kind: domain.EventMoved, OldValue: "Not Started", NewValue: "Done"},
+	}
+	for i := range events {
+		events[i].Actor = "alice"
+		// Mixed time zones still sort by instant
+		events[i].CreatedAt = base.Add(time.Duration(i) * time.Hour).In(time.FixedZone("UTC+5", 5*3600))
+		require.NoError(t, repo.Append(&events[i]))
+		assert.NotZero(t, events[i].ID)
+	}
+
+	all, err := repo.List(domain.EventFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 5)
+	assert.Equal(t, domain.EventCreated, all[0].Kind, "Events are listed oldest first")
+	assert.Equal(t, "High", all[2].NewValue)
+	assert.Equal(t, "alice", all[2].Actor)
+	assert.True(t, all[4].CreatedAt.Equal(base.Add(4*time.Hour)))
+
+	byTask, err := repo.List(domain.EventFilter{TaskIntID: 1})
+	require.NoError(t, err)
+	assert.Len(t, byTask, 3)
+
+	byProject, err := repo.List(domain.EventFilter{ProjectID: "p2"})
+	require.NoError(t, err)
+	require.Len(t, byProject, 1)
+	assert.Equal(t, "Other", byProject[0].Subject)
+
+	inRange, err := repo.List(domain.EventFilter{Since: base.Add(time.Hour), Until: base.Add(3 * time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, inRange, 2)
+	assert.Equal(t, events[1].ID, inRange[0].ID)
+
+	latest, err := repo.List(domain.EventFilter{ProjectID: "p1", Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, latest, 2)
+	assert.Equal(t, domain.EventChanged, latest[0].Kind, "A limit keeps the newest events")
+	assert.Equal(t, domain.EventMoved, latest[1].Kind)
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+
+	"kahn/internal/database"
+	"kahn/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func setupTestLabelRepositories(t *testing.T) (*SQLiteLabelRepository, *SQLiteTaskRepository, *domain.Project) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	dbWrapper := &database.Database{Db: db}
+	require.NoError(t, dbWrapper.RunMigrations())
+
+	project := domain.NewProject("Test", "", "blue")
+	require.NoError(t, NewSQLiteProjectRepository(db).Create(project))
+
+	return NewSQLiteLabelRepository(db), NewSQLiteTaskRepository(db), project
+}
+
+func TestLabelRepository_UniqueNamePerProject(t *testing.T) {
+	labelRepo, _, project := setupTestLabelRepositories(t)
+
+	backend := domain.NewLabel(project.ID, "backend", "#89b4fa")
+	backend.ID = "label_backend"
+	require.NoError(t, labelRepo.Create(backend))
+
+	duplicate := domain.NewLabel(project.ID, "Backend", "#a6e3a1")
+	duplicate.ID = "label_duplicate"
+	assert.Error(t, labelRepo.Create(duplicate), "names are unique ignoring case")
+
+	backend.Name = "api"
+	backend.Color = "#f38ba8"
+	require.NoError(t, labelRepo.Update(backend))
+
+	loaded, err := labelRepo.GetByID(backend.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "api", loaded.Name)
+	assert.Equal(t, "#f38ba8", loaded.Color)
+
+	missing, err := labelRepo.GetByID("label_missing")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestLabelRepository_TaskLabels(t *testing.T) {
+	labelRepo, taskRepo, project := setupTestLabelRepositories(t)
+
+	var labelIDs []string
+	for _, name := range []string{"tech-debt", "backend"} {
+		label := domain.NewLabel(project.ID, name, "#89b4fa")
+		label.ID = "label_" + name
+		require.NoError(t, labelRepo.Create(label))
+		labelIDs = append(labelIDs, label.ID)
+	}
+
+	task := domain.NewTask("Labelled", "", project.ID)
+	task.ID = "task_labelled"
+	require.NoError(t, taskRepo.Create(task))
+	plain := domain.NewTask("Plain", "", project.ID)
+	plain.ID = "task_plain"
+	require.NoError(t, taskRepo.Create(plain))
+
+	require.NoError(t, labelRepo.SetTaskLabels(task.ID, labelIDs))
+
+	loaded, err := taskRepo.GetByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backend", "tech-debt"}, loaded.LabelNames())
+
+	tasks, err := taskRepo.GetByProjectID(project.ID)
+	require.NoError(t, err)
+	for _, tk := range tasks {
+		if tk.ID == plain.ID {
+			assert.Empty(t, tk.Labels)
+		} else {
+			assert.Equal(t, []string{"backend", "tech-debt"}, tk.LabelNames())
+		}
+	}
+
+	// Deleting a label removes it from the tasks carrying it
+	require.NoError(t, labelRepo.Delete("label_backend"))
+	loaded, err = taskRepo.GetByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tech-debt"}, loaded.LabelNames())
+
+	require.NoError(t, labelRepo.SetTaskLabels(task.ID, nil))
+	loaded, err = taskRepo.GetByID(task.ID)
+	require.NoError(t, err)
+	assert.Empty(t, loaded.Labels)
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+
+	"kahn/internal/database"
+	"kahn/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func setupTestProjectRepositories(t *testing.T) (*SQLiteProjectRepository, *SQLiteTaskRepository) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	dbWrapper := &database.Database{Db: db}
+	require.NoError(t, dbWrapper.RunMigrations())
+
+	return NewSQLiteProjectRepository(db), NewSQLiteTaskRepository(db)
+}
+
+func TestProjectRepository_CreateStoresDefaultWorkflow(t *testing.T) {
+	projectRepo, _ := setupTestProjectRepositories(t)
+
+	project := domain.NewProject("Test", "", "blue")
+	project.Workflow = nil
+	require.NoError(t, projectRepo.Create(project))
+
+	loaded, err := projectRepo.GetByID(project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultWorkflow(), loaded.Workflow)
+}
+
+func TestProjectRepository_SaveWorkflow(t *testing.T) {
+	projectRepo, taskRepo := setupTestProjectRepositories(t)
+
+	project := domain.NewProject("Test", "", "blue")
+	require.NoError(t, projectRepo.Create(project))
+	other := domain.NewProject("Other", "", "blue")
+	require.NoError(t, projectRepo.Create(other))
+
+	inProgress := domain.NewTask("In progress", "", project.ID)
+	inProgress.ID = "task_in_progress"
+	inProgress.Status = domain.InProgress
+	require.NoError(t, taskRepo.Create(inProgress))
+	done := domain.NewTask("Done", "", project.ID)
+	done.ID = "task_done"
+	done.Status = domain.Done
+	require.NoError(t, taskRepo.Create(done))
+	otherDone := domain.NewTask("Other done", "", other.ID)
+	otherDone.ID = "task_other_done"
+	otherDone.Status = domain.Done
+	require.NoError(t, taskRepo.Create(otherDone))
+
+	workflow, err := domain.NewWorkflow([]string{"Backlog", "Ready", "In Progress", "Done"}, "")
+	require.NoError(t, err)
+
+	// Swapping positions in one statement must not move a task twice
+	remap := map[domain.Status]domain.Status{domain.InProgress: domain.Done, domain.Done: domain.Status(3)}
+	require.NoError(t, projectRepo.SaveWorkflow(project.ID, workflow, remap))
+
+	loaded, err := projectRepo.GetByID(project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, workflow, loaded.Workflow)
+
+	task, err := taskRepo.GetByID(inProgress.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Status(2), task.Status)
+	task, err = taskRepo.GetByID(done.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Status(3), task.Status)
+	task, err = taskRepo.GetByID(otherDone.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Done, task.Status, "Tasks of other projects are not remapped")
+
+	projects, err := projectRepo.GetAll()
+	require.NoError(t, err)
+	for _, p := range projects {
+		if p.ID == other.ID {
+			assert.Equal(t, domain.DefaultWorkflow(), p.Workflow)
+		} else {
+			assert.Equal(t, workflow, p.Workflow)
+		}
+	}
+}
+
+func TestProjectRepository_SaveWorkflowKeepsWIPLimits(t *testing.T) {
+	projectRepo, _ := setupTestProjectRepositories(t)
+
+	project := domain.NewProject("Test", "", "blue")
+	require.NoError(t, projectRepo.Create(project))
+
+	workflow := domain.DefaultWorkflow().WithWIPLimit(domain.InProgress, 3)
+	require.NoError(t, projectRepo.SaveWorkflow(project.ID, workflow, nil))
+
+	loaded, err := projectRepo.GetByID(project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, loaded.Workflow.WIPLimit(domain.InProgress))
+	assert.Zero(t, loaded.Workflow.WIPLimit(domain.NotStarted))
+}
+
+func TestProjectRepository_TrashAndPurge(t *testing.T) {
+	projectRepo, taskRepo := setupTestProjectRepositories(t)
+
+	project := domain.NewProject("Test", "", "blue")
+	require.NoError(t, projectRepo.Create(project))
+	task := domain.NewTask("Story", "", project.ID)
+	require.NoError(t, taskRepo.Create(task))
+	trashedTask := domain.NewTask("Dropped", "", project.ID)
+	require.NoError(t, taskRepo.Create(trashedTask))
+	require.NoError(t, taskRepo.Delete(trashedTask.ID))
+
+	require.NoError(t, projectRepo.Delete(project.ID))
+	loaded, err := projectRepo.GetByID(project.ID)
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+	all, err := projectRepo.GetAll()
+	require.NoError(t, err)
+	assert.Empty(t, all)
+	trashed, err := projectRepo.GetTrashed()
+	require.NoError(t, err)
+	require.Len(t, trashed, 1)
+	assert.NotNil(t, trashed[0].DeletedAt)
+	assert.Equal(t, domain.DefaultWorkflow(), trashed[0].Workflow)
+	tasks, err := taskRepo.GetTrashed("")
+	require.NoError(t, err)
+	assert.Empty(t, tasks, "The trashed tasks of a trashed project are listed with it")
+
+	require.NoError(t, projectRepo.Restore(project.ID))
+	tasks, err = taskRepo.GetByProjectID(project.ID)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 1, "Restoring the project brings back its tasks but not those trashed on their own")
+
+	require.NoError(t, projectRepo.Delete(project.ID))
+	require.NoError(t, projectRepo.Purge(project.ID))
+	trashed, err = projectRepo.GetTrashed()
+	require.NoError(t, err)
+	assert.Empty(t, trashed)
+	require.NoError(t, projectRepo.Create(domain.NewProject("Other", "", "blue")))
+	tasks, err = taskRepo.GetTrashed("")
+	require.NoError(t, err)
+	assert.Empty(t, tasks, "Purging the project purges its tasks")
+}
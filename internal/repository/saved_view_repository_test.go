@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+
+	"kahn/internal/database"
+	"kahn/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func TestSavedViewRepository(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, (&database.Database{Db: db}).RunMigrations())
+
+	projects := NewSQLiteProjectRepository(db)
+	web := domain.NewProject("Website", "", "blue")
+	require.NoError(t, projects.Create(web))
+	api := domain.NewProject("API", "", "green")
+	require.NoError(t, projects.Create(api))
+	views := NewSQLiteSavedViewRepository(db)
+
+	create := func(id, projectID, name, query string) *domain.SavedView {
+		view := domain.NewSavedView(projectID, name, query)
+		view.ID = id
+		require.NoError(t, views.Create(view))
+		return view
+	}
+	create("view_1", "", "stale", "updated:<-30d")
+	bugs := create("view_2", web.ID, "bugs", "type:bug")
+	create("view_3", api.ID, "blocked", "blocked:yes")
+
+	names := func(list []domain.SavedView) []string {
+		var names []string
+		for _, view := range list {
+			names = append(names, view.Name)
+		}
+		return names
+	}
+
+	listed, err := views.GetByProjectID(web.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bugs", "stale"}, names(listed), "A project's views come before the global ones")
+	assert.True(t, listed[1].IsGlobal())
+	listed, err = views.GetByProjectID("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"stale"}, names(listed))
+
+	bugs.Name, bugs.Query = "my bugs", "type:bug priority:high"
+	require.NoError(t, views.Update(bugs))
+	found, err := views.GetByID(bugs.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "type:bug priority:high", found.Query)
+	assert.Equal(t, web.ID, found.ProjectID)
+
+	// Trashed projects hide their views, and purging deletes them
+	require.NoError(t, projects.Delete(api.ID))
+	listed, err = views.GetAll()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"my bugs", "stale"}, names(listed))
+	require.NoError(t, projects.Purge(api.ID))
+	missing, err := views.GetByID("view_3")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	require.NoError(t, views.Delete(bugs.ID))
+	assert.Error(t, views.Delete(bugs.ID), "A view is deleted once")
+}
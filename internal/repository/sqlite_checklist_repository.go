@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"database/sql"
+	"kahn/internal/domain"
+	"time"
+)
+
+const checklistColumns = `id, task_id, text, done, position, created_at, updated_at`
+
+func (r *SQLiteTaskRepository) GetChecklist(taskID string) ([]domain.ChecklistItem, error) {
+	query := `
+		SELECT ` + checklistColumns + `
+		FROM checklist_items WHERE task_id = ? ORDER BY position
+	`
+
+	rows, err := r.base.db.Query(query, taskID)
+	if err != nil {
+		return nil, r.base.WrapDBError("get", "checklist for task", taskID, err)
+	}
+	defer rows.Close()
+
+	var items []domain.ChecklistItem
+	for rows.Next() {
+		var item domain.ChecklistItem
+		if err := scanChecklistItem(rows, &item); err != nil {
+			return nil, r.base.WrapDBError("scan", "checklist item", "", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.base.WrapDBError("iterate", "checklist items", taskID, err)
+	}
+	return items, nil
+}
+
+func (r *SQLiteTaskRepository) GetChecklistItem(id string) (*domain.ChecklistItem, error) {
+	query := `SELECT ` + checklistColumns + ` FROM checklist_items WHERE id = ?`
+
+	var item domain.ChecklistItem
+	if err := scanChecklistItem(r.base.db.QueryRow(query, id), &item); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, r.base.WrapDBError("get", "checklist item", id, err)
+	}
+	return &item, nil
+}
+
+// CreateChecklistItem inserts the item at its position, moving the items at or
+// after it one place down
+func (r *SQLiteTaskRepository) CreateChecklistItem(item *domain.ChecklistItem) error {
+	tx, err := r.base.db.Begin()
+	if err != nil {
+		return r.base.WrapDBError("begin", "checklist item", item.ID, err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`UPDATE checklist_items SET position = position + 1 WHERE task_id = ? AND position >= ?`,
+		item.TaskID, item.Position)
+	if err != nil {
+		return r.base.WrapDBError("create", "checklist item", item.ID, err)
+	}
+
+	query := `
+		INSERT INTO checklist_items (id, task_id, text, done, position, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = tx.Exec(query, item.ID, item.TaskID, item.Text, item.Done, item.Position, item.CreatedAt, item.UpdatedAt)
+	if err != nil {
+		return r.base.WrapDBError("create", "checklist item", item.ID, err)
+	}
+
+	if err := touchTask(tx, item.TaskID); err != nil {
+		return r.base.WrapDBError("create", "checklist item", item.ID, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return r.base.WrapDBError("commit", "checklist item", item.ID, err)
+	}
+	return nil
+}
+
+// UpdateChecklistItem saves the item's text and done flag. Positions change
+// through ReorderChecklist.
+func (r *SQLiteTaskRepository) UpdateChecklistItem(item *domain.ChecklistItem) error {
+	tx, err := r.base.db.Begin()
+	if err != nil {
+		return r.base.WrapDBError("begin", "checklist item", item.ID, err)
+	}
+	defer tx.Rollback()
+
+	item.UpdatedAt = time.Now()
+	result, err := tx.Exec(`UPDATE checklist_items SET text = ?, done = ?, updated_at = ? WHERE id = ?`,
+		item.Text, item.Done, item.UpdatedAt, item.ID)
+	if err != nil {
+		return r.base.WrapDBError("update", "checklist item", item.ID, err)
+	}
+	if err := r.base.HandleRowsAffected(result, "update", "checklist item"); err != nil {
+		return err
+	}
+
+	if err := touchTask(tx, item.TaskID); err != nil {
+		return r.base.WrapDBError("update", "checklist item", item.ID, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return r.base.WrapDBError("commit", "checklist item", item.ID, err)
+	}
+	return nil
+}
+
+func (r *SQLiteTaskRepository) DeleteChecklistItem(id string) error {
+	tx, err := r.base.db.Begin()
+	if err != nil {
+		return r.base.WrapDBError("begin", "checklist item", id, err)
+	}
+	defer tx.Rollback()
+
+	var taskID string
+	var position int
+	err = tx.QueryRow(`SELECT task_id, position FROM checklist_items WHERE id = ?`, id).Scan(&taskID, &position)
+	if err == sql.ErrNoRows {
+		return domain.NewNotFoundError("checklist item", id, err)
+	}
+	if err != nil {
+		return r.base.WrapDBError("delete", "checklist item", id, err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM checklist_items WHERE id = ?`, id); err != nil {
+		return r.base.WrapDBError("delete", "checklist item", id, err)
+	}
+	_, err = tx.Exec(`UPDATE checklist_items SET position = position - 1 WHERE task_id = ? AND position > ?`,
+		taskID, position)
+	if err != nil {
+		return r.base.WrapDBError("delete", "checklist item", id, err)
+	}
+
+	if err := touchTask(tx, taskID); err != nil {
+		return r.base.WrapDBError("delete", "checklist item", id, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return r.base.WrapDBError("commit", "checklist item", id, err)
+	}
+	return nil
+}
+
+func (r *SQLiteTaskRepository) ReorderChecklist(taskID string, itemIDs []string) error {
+	tx, err := r.base.db.Begin()
+	if err != nil {
+		return r.base.WrapDBError("begin", "checklist for task", taskID, err)
+	}
+	defer tx.Rollback()
+
+	for i, id := range itemIDs {
+		_, err := tx.Exec(`UPDATE checklist_items SET position = ? WHERE id = ? AND task_id = ?`, i+1, id, taskID)
+		if err != nil {
+			return r.base.WrapDBError("reorder", "checklist for task", taskID, err)
+		}
+	}
+
+	if err := touchTask(tx, taskID); err != nil {
+		return r.base.WrapDBError("reorder", "checklist for task", taskID, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return r.base.WrapDBError("commit", "checklist for task", taskID, err)
+	}
+	return nil
+}
+
+// touchTask bumps the updated_at of the task owning a changed checklist
+func touchTask(tx *sql.Tx, taskID string) error {
+	_, err := tx.Exec(`UPDATE tasks SET updated_at = ? WHERE id = ?`, time.Now(), taskID)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanChecklistItem(row rowScanner, item *domain.ChecklistItem) error {
+	return row.Scan(&item.ID, &item.TaskID, &item.Text, &item.Done, &item.Position, &item.CreatedAt, &item.UpdatedAt)
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"kahn/internal/domain"
+)
+
+func (r *SQLiteTaskRepository) GetComments(taskID string) ([]domain.Comment, error) {
+	comments, err := loadTaskComments(r.base, `WHERE c.task_id = ?`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return comments[taskID], nil
+}
+
+// CreateComment appends the comment and bumps the task's updated_at
+func (r *SQLiteTaskRepository) CreateComment(comment *domain.Comment) error {
+	tx, err := r.base.db.Begin()
+	if err != nil {
+		return r.base.WrapDBError("begin", "comment", comment.ID, err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO task_comments (id, task_id, author, body, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	_, err = tx.Exec(query, comment.ID, comment.TaskID, comment.Author, comment.Body, comment.CreatedAt)
+	if err != nil {
+		return r.base.WrapDBError("create", "comment", comment.ID, err)
+	}
+
+	if err := touchTask(tx, comment.TaskID); err != nil {
+		return r.base.WrapDBError("create", "comment", comment.ID, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return r.base.WrapDBError("commit", "comment", comment.ID, err)
+	}
+	return nil
+}
+
+// loadTaskComments reads the comments matching the WHERE clause on task_comments
+// (aliased c) joined to tasks (aliased t), keyed by task ID and oldest first
+func loadTaskComments(base *BaseRepository, where string, args ...interface{}) (map[string][]domain.Comment, error) {
+	query := `
+		SELECT c.id, c.task_id, c.author, c.body, c.created_at
+		FROM task_comments c JOIN tasks t ON t.id = c.task_id
+		` + where + `
+		ORDER BY c.created_at, c.id
+	`
+
+	rows, err := base.db.Query(query, args...)
+	if err != nil {
+		return nil, base.WrapDBError("get", "task comments", "", err)
+	}
+	defer rows.Close()
+
+	comments := make(map[string][]domain.Comment)
+	for rows.Next() {
+		var comment domain.Comment
+		if err := rows.Scan(&comment.ID, &comment.TaskID, &comment.Author, &comment.Body, &comment.CreatedAt); err != nil {
+			return nil, base.WrapDBError("scan", "task comment", "", err)
+		}
+		comments[comment.TaskID] = append(comments[comment.TaskID], comment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, base.WrapDBError("iterate", "task comments", "", err)
+	}
+	return comments, nil
+}
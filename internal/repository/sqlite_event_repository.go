@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"database/sql"
+	"kahn/internal/domain"
+	"slices"
+	"strings"
+	"time"
+)
+
+// eventTimeLayout stores event times in UTC with a fixed width so that time
+// ranges can be compared as text
+const eventTimeLayout = "2006-01-02T15:04:05.000000000Z"
+
+type SQLiteEventRepository struct {
+	base *BaseRepository
+}
+
+func NewSQLiteEventRepository(db *sql.DB) *SQLiteEventRepository {
+	return &SQLiteEventRepository{
+		base: NewBaseRepository(db),
+	}
+}
+
+func (r *SQLiteEventRepository) Append(event *domain.Event) error {
+	query := `
+		INSERT INTO events (project_id, task_id, task_int_id, subject, kind, field, old_value, new_value, actor, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.base.db.Exec(query, event.ProjectID, nullString(event.TaskID), nullInt(event.TaskIntID),
+		event.Subject, event.Kind, nullString(event.Field), nullString(event.OldValue), nullString(event.NewValue),
+		event.Actor, eventTime(event.CreatedAt))
+	if err != nil {
+		return r.base.WrapDBError("create", "event", event.Subject, err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return r.base.WrapDBError("read id", "event", event.Subject, err)
+	}
+	event.ID = id
+	return nil
+}
+
+func (r *SQLiteEventRepository) List(filter domain.EventFilter) ([]domain.Event, error) {
+	var where []string
+	var args []interface{}
+	if filter.ProjectID != "" {
+		where = append(where, "project_id = ?")
+		args = append(args, filter.ProjectID)
+	}
+	if filter.TaskIntID != 0 {
+		where = append(where, "task_int_id = ?")
+		args = append(args, filter.TaskIntID)
+	}
+	if !filter.Since.IsZero() {
+		where = append(where, "created_at >= ?")
+		args = append(args, eventTime(filter.Since))
+	}
+	if !filter.Until.IsZero() {
+		where = append(where, "created_at < ?")
+		args = append(args, eventTime(filter.Until))
+	}
+
+	query := `
+		SELECT id, project_id, COALESCE(task_id, ''), COALESCE(task_int_id, 0), subject, kind,
+			COALESCE(field, ''), COALESCE(old_value, ''), COALESCE(new_value, ''), actor, created_at
+		FROM events`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	// Newest first so a limit keeps the latest events; reversed below
+	query += " ORDER BY created_at DESC, id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := r.base.db.Query(query, args...)
+	if err != nil {
+		return nil, r.base.WrapDBError("get", "events", "", err)
+	}
+	defer rows.Close()
+
+	var events []domain.Event
+	for rows.Next() {
+		var event domain.Event
+		err := rows.Scan(&event.ID, &event.ProjectID, &event.TaskID, &event.TaskIntID, &event.Subject, &event.Kind,
+			&event.Field, &event.OldValue, &event.NewValue, &event.Actor, &event.CreatedAt)
+		if err != nil {
+			return nil, r.base.WrapDBError("scan", "event", "", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.base.WrapDBError("iterate", "events", "", err)
+	}
+
+	slices.Reverse(events)
+	return events, nil
+}
+
+func eventTime(t time.Time) string {
+	return t.UTC().Format(eventTimeLayout)
+}
+
+func nullString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+func nullInt(value int) interface{} {
+	if value == 0 {
+		return nil
+	}
+	return value
+}
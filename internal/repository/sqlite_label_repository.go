@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"database/sql"
+	"kahn/internal/domain"
+)
+
+type SQLiteLabelRepository struct {
+	base *BaseRepository
+}
+
+func NewSQLiteLabelRepository(db *sql.DB) *SQLiteLabelRepository {
+	return &SQLiteLabelRepository{
+		base: NewBaseRepository(db),
+	}
+}
+
+func (r *SQLiteLabelRepository) Create(label *domain.Label) error {
+	query := `
+		INSERT INTO labels (id, project_id, name, color, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	_, err := r.base.db.Exec(query, label.ID, label.ProjectID, label.Name, label.Color, label.CreatedAt)
+	if err != nil {
+		return r.base.WrapDBError("create", "label", label.ID, err)
+	}
+	return nil
+}
+
+func (r *SQLiteLabelRepository) GetByID(id string) (*domain.Label, error) {
+	query := `SELECT id, project_id, name, color, created_at FROM labels WHERE id = ?`
+
+	var label domain.Label
+	err := r.base.db.QueryRow(query, id).Scan(&label.ID, &label.ProjectID, &label.Name, &label.Color, &label.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, r.base.WrapDBError("get", "label", id, err)
+	}
+	return &label, nil
+}
+
+func (r *SQLiteLabelRepository) GetByProjectID(projectID string) ([]domain.Label, error) {
+	query := `
+		SELECT id, project_id, name, color, created_at
+		FROM labels WHERE project_id = ? ORDER BY name
+	`
+
+	rows, err := r.base.db.Query(query, projectID)
+	if err != nil {
+		return nil, r.base.WrapDBError("get", "labels for project", projectID, err)
+	}
+	defer rows.Close()
+
+	var labels []domain.Label
+	for rows.Next() {
+		var label domain.Label
+		if err := rows.Scan(&label.ID, &label.ProjectID, &label.Name, &label.Color, &label.CreatedAt); err != nil {
+			return nil, r.base.WrapDBError("scan", "label", "", err)
+		}
+		labels = append(labels, label)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.base.WrapDBError("iterate", "labels", projectID, err)
+	}
+	return labels, nil
+}
+
+func (r *SQLiteLabelRepository) Update(label *domain.Label) error {
+	query := `UPDATE labels SET name = ?, color = ? WHERE id = ?`
+
+	result, err := r.base.db.Exec(query, label.Name, label.Color, label.ID)
+	if err != nil {
+		return r.base.WrapDBError("update", "label", label.ID, err)
+	}
+	return r.base.HandleRowsAffected(result, "update", "label")
+}
+
+func (r *SQLiteLabelRepository) Delete(id string) error {
+	tx, err := r.base.db.Begin()
+	if err != nil {
+		return r.base.WrapDBError("delete", "label", id, err)
+	}
+	defer tx.Rollback()
+
+	// Remove the links explicitly so they go even when foreign keys are off
+	if _, err := tx.Exec(`DELETE FROM task_labels WHERE label_id = ?`, id); err != nil {
+		return r.base.WrapDBError("delete", "label", id, err)
+	}
+	result, err := tx.Exec(`DELETE FROM labels WHERE id = ?`, id)
+	if err != nil {
+		return r.base.WrapDBError("delete", "label", id, err)
+	}
+	if err := r.base.HandleRowsAffected(result, "delete", "label"); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return r.base.WrapDBError("delete", "label", id, err)
+	}
+	return nil
+}
+
+func (r *SQLiteLabelRepository) SetTaskLabels(taskID string, labelIDs []string) error {
+	tx, err := r.base.db.Begin()
+	if err != nil {
+		return r.base.WrapDBError("set labels of", "task", taskID, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM task_labels WHERE task_id = ?`, taskID); err != nil {
+		return r.base.WrapDBError("set labels of", "task", taskID, err)
+	}
+	for _, labelID := range labelIDs {
+		if _, err := tx.Exec(`INSERT INTO task_labels (task_id, label_id) VALUES (?, ?)`, taskID, labelID); err != nil {
+			return r.base.WrapDBError("set labels of", "task", taskID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return r.base.WrapDBError("set labels of", "task", taskID, err)
+	}
+	return nil
+}
+
+// loadTaskLabels reads the labels of the tasks matching the WHERE clause on
+// task_labels (aliased tl), keyed by task ID and sorted by name
+func loadTaskLabels(base *BaseRepository, where string, args ...interface{}) (map[string][]domain.Label, error) {
+	query := `
+		SELECT tl.task_id, l.id, l.project_id, l.name, l.color, l.created_at
+		FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+		` + where + `
+		ORDER BY l.name
+	`
+
+	rows, err := base.db.Query(query, args...)
+	if err != nil {
+		return nil, base.WrapDBError("get", "task labels", "", err)
+	}
+	defer rows.Close()
+
+	labels := make(map[string][]domain.Label)
+	for rows.Next() {
+		var taskID string
+		var label domain.Label
+		if err := rows.Scan(&taskID, &label.ID, &label.ProjectID, &label.Name, &label.Color, &label.CreatedAt); err != nil {
+			return nil, base.WrapDBError("scan", "task label", taskID, err)
+		}
+		labels[taskID] = append(labels[taskID], label)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, base.WrapDBError("iterate", "task labels", "", err)
+	}
+	return labels, nil
+}
+
+// attachLabels fills in the Labels of each task from the given label map
+func attachLabels(tasks []domain.Task, labels map[string][]domain.Label) {
+	for i := range tasks {
+		tasks[i].Labels = labels[tasks[i].ID]
+	}
+}
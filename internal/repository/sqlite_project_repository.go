@@ -2,10 +2,15 @@ package repository
 
 import (
 	"database/sql"
+	"fmt"
 	"kahn/internal/domain"
+	"strings"
 	"time"
 )
 
+// projectColumns is the select list read by ScanProjectRows and ScanSingleProject
+const projectColumns = `id, name, description, color, created_at, updated_at, deleted_at`
+
 type SQLiteProjectRepository struct {
 	base *BaseRepository // Composition, not embedding
 }
@@ -16,39 +21,88 @@ func NewSQLiteProjectRepository(db *sql.DB) *SQLiteProjectRepository {
 	}
 }
 
+// Create inserts the project together with its workflow; an empty workflow is
+// stored as the default one
 func (r *SQLiteProjectRepository) Create(project *domain.Project) error {
 	query := `
 		INSERT INTO projects (id, name, description, color, created_at, updated_at)
 		VALUES (?, ?, ?, ?, ?, ?)
 	`
 
-	return r.base.CreateGeneric(query, project.ID, project.Name, project.Description,
-		project.Color, project.CreatedAt, project.UpdatedAt)
+	if len(project.Workflow) == 0 {
+		project.Workflow = domain.DefaultWorkflow()
+	}
+
+	tx, err := r.base.db.Begin()
+	if err != nil {
+		return r.base.WrapDBError("create", "project", project.ID, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(query, project.ID, project.Name, project.Description,
+		project.Color, project.CreatedAt, project.UpdatedAt); err != nil {
+		return r.base.WrapDBError("create", "project", project.ID, err)
+	}
+	if err := insertWorkflow(tx, project.ID, project.Workflow); err != nil {
+		return r.base.WrapDBError("create", "workflow", project.ID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return r.base.WrapDBError("create", "project", project.ID, err)
+	}
+	return nil
 }
 
 func (r *SQLiteProjectRepository) GetByID(id string) (*domain.Project, error) {
 	query := `
-		SELECT id, name, description, color, created_at, updated_at
-		FROM projects WHERE id = ?
+		SELECT ` + projectColumns + `
+		FROM projects WHERE id = ? AND deleted_at IS NULL
 	`
 
 	row := r.base.db.QueryRow(query, id)
-	return r.base.ScanSingleProject(row)
+	project, err := r.base.ScanSingleProject(row)
+	if err != nil || project == nil {
+		return project, err
+	}
+
+	workflows, err := r.loadWorkflows(`WHERE project_id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	project.Workflow = workflows[id]
+	return project, nil
 }
 
 func (r *SQLiteProjectRepository) GetAll() ([]domain.Project, error) {
-	query := `
-		SELECT id, name, description, color, created_at, updated_at
-		FROM projects ORDER BY created_at DESC
-	`
+	return r.getProjects(`WHERE deleted_at IS NULL ORDER BY created_at DESC`)
+}
 
-	rows, err := r.base.db.Query(query)
+// GetTrashed returns the projects in the trash, most recently deleted first
+func (r *SQLiteProjectRepository) GetTrashed() ([]domain.Project, error) {
+	return r.getProjects(`WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC`)
+}
+
+// getProjects reads the projects selected by the clause with their workflows
+func (r *SQLiteProjectRepository) getProjects(clause string) ([]domain.Project, error) {
+	rows, err := r.base.db.Query(`SELECT ` + projectColumns + ` FROM projects ` + clause)
 	if err != nil {
 		return nil, r.base.WrapDBError("get", "projects", "", err)
 	}
 	defer rows.Close()
 
-	return r.base.ScanProjectRows(rows)
+	projects, err := r.base.ScanProjectRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	workflows, err := r.loadWorkflows("")
+	if err != nil {
+		return nil, err
+	}
+	for i := range projects {
+		projects[i].Workflow = workflows[projects[i].ID]
+	}
+	return projects, nil
 }
 
 func (r *SQLiteProjectRepository) Update(project *domain.Project) error {
@@ -67,7 +121,160 @@ func (r *SQLiteProjectRepository) Update(project *domain.Project) error {
 	return nil
 }
 
+// Delete moves the project to the trash, hiding its tasks with it
 func (r *SQLiteProjectRepository) Delete(id string) error {
-	query := `DELETE FROM projects WHERE id = ?`
-	return r.base.DeleteGeneric(query, id)
+	return r.setDeletedAt("delete", id, `deleted_at IS NULL`, time.Now())
+}
+
+// Restore takes the project out of the trash
+func (r *SQLiteProjectRepository) Restore(id string) error {
+	return r.setDeletedAt("restore", id, `deleted_at IS NOT NULL`, nil)
+}
+
+func (r *SQLiteProjectRepository) setDeletedAt(operation, id, where string, value interface{}) error {
+	result, err := r.base.db.Exec(`UPDATE projects SET deleted_at = ? WHERE id = ? AND `+where, value, id)
+	if err != nil {
+		return r.base.WrapDBError(operation, "project", id, err)
+	}
+	return r.base.HandleRowsAffected(result, operation, "project")
+}
+
+// Purge removes the project for good with its workflow, labels and tasks
+func (r *SQLiteProjectRepository) Purge(id string) error {
+	tx, err := r.base.db.Begin()
+	if err != nil {
+		return r.base.WrapDBError("begin", "project", id, err)
+	}
+	defer tx.Rollback()
+
+	result, err := deleteProject(r.base, tx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.base.HandleRowsAffected(result, "purge", "project"); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return r.base.WrapDBError("commit", "project", id, err)
+	}
+	return nil
+}
+
+// deleteProject removes everything the project's ON DELETE CASCADE clauses
+// cover, explicitly so it goes even when foreign keys are off, then the project
+// itself, returning the result of the last statement
+func deleteProject(base *BaseRepository, tx *sql.Tx, id string) (sql.Result, error) {
+	rows, err := tx.Query(`SELECT id FROM tasks WHERE project_id = ?`, id)
+	if err != nil {
+		return nil, base.WrapDBError("get tasks for", "project", id, err)
+	}
+	var taskIDs []string
+	for rows.Next() {
+		var taskID string
+		if err := rows.Scan(&taskID); err != nil {
+			rows.Close()
+			return nil, base.WrapDBError("scan", "task", "", err)
+		}
+		taskIDs = append(taskIDs, taskID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, base.WrapDBError("get tasks for", "project", id, err)
+	}
+
+	for _, taskID := range taskIDs {
+		if _, err := deleteTask(base, tx, taskID); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := tx.Exec(`DELETE FROM labels WHERE project_id = ?`, id); err != nil {
+		return nil, base.WrapDBError("delete", "labels", id, err)
+	}
+	if _, err := tx.Exec(`DELETE FROM workflow_statuses WHERE project_id = ?`, id); err != nil {
+		return nil, base.WrapDBError("delete", "workflow", id, err)
+	}
+	if _, err := tx.Exec(`DELETE FROM saved_views WHERE project_id = ?`, id); err != nil {
+		return nil, base.WrapDBError("delete", "saved views", id, err)
+	}
+
+	result, err := tx.Exec(`DELETE FROM projects WHERE id = ?`, id)
+	if err != nil {
+		return nil, base.WrapDBError("delete", "project", id, err)
+	}
+	return result, nil
+}
+
+func (r *SQLiteProjectRepository) SaveWorkflow(projectID string, workflow domain.Workflow, remap map[domain.Status]domain.Status) error {
+	tx, err := r.base.db.Begin()
+	if err != nil {
+		return r.base.WrapDBError("save", "workflow", projectID, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM workflow_statuses WHERE project_id = ?`, projectID); err != nil {
+		return r.base.WrapDBError("save", "workflow", projectID, err)
+	}
+	if err := insertWorkflow(tx, projectID, workflow); err != nil {
+		return r.base.WrapDBError("save", "workflow", projectID, err)
+	}
+
+	// A single CASE statement moves every task at once, so a task moved into a
+	// position is not moved again by a later mapping
+	if len(remap) > 0 {
+		var cases strings.Builder
+		args := make([]interface{}, 0, len(remap)*2+1)
+		for from, to := range remap {
+			cases.WriteString(" WHEN ? THEN ?")
+			args = append(args, from, to)
+		}
+		args = append(args, projectID)
+
+		query := fmt.Sprintf(`UPDATE tasks SET status = CASE status%s ELSE status END WHERE project_id = ?`, cases.String())
+		if _, err := tx.Exec(query, args...); err != nil {
+			return r.base.WrapDBError("save", "workflow", projectID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return r.base.WrapDBError("save", "workflow", projectID, err)
+	}
+	return nil
+}
+
+// loadWorkflows reads workflow rows matching the optional WHERE clause, keyed by project ID
+func (r *SQLiteProjectRepository) loadWorkflows(where string, args ...interface{}) (map[string]domain.Workflow, error) {
+	query := `SELECT project_id, name, is_done, wip_limit FROM workflow_statuses ` + where + ` ORDER BY project_id, position`
+
+	rows, err := r.base.db.Query(query, args...)
+	if err != nil {
+		return nil, r.base.WrapDBError("get", "workflows", "", err)
+	}
+	defer rows.Close()
+
+	workflows := make(map[string]domain.Workflow)
+	for rows.Next() {
+		var projectID string
+		var status domain.WorkflowStatus
+		if err := rows.Scan(&projectID, &status.Name, &status.IsDone, &status.WIPLimit); err != nil {
+			return nil, r.base.WrapDBError("scan", "workflow", projectID, err)
+		}
+		workflows[projectID] = append(workflows[projectID], status)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.base.WrapDBError("iterate", "workflows", "", err)
+	}
+	return workflows, nil
+}
+
+func insertWorkflow(tx *sql.Tx, projectID string, workflow domain.Workflow) error {
+	for position, status := range workflow {
+		if _, err := tx.Exec(`
+			INSERT INTO workflow_statuses (project_id, position, name, is_done, wip_limit)
+			VALUES (?, ?, ?, ?, ?)
+		`, projectID, position, status.Name, status.IsDone, status.WIPLimit); err != nil {
+			return err
+		}
+	}
+	return nil
 }
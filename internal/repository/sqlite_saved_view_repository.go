@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"database/sql"
+	"kahn/internal/domain"
+)
+
+type SQLiteSavedViewRepository struct {
+	base *BaseRepository
+}
+
+func NewSQLiteSavedViewRepository(db *sql.DB) *SQLiteSavedViewRepository {
+	return &SQLiteSavedViewRepository{
+		base: NewBaseRepository(db),
+	}
+}
+
+// savedViewColumns is the select list read by scanSavedViews
+const savedViewColumns = `id, COALESCE(project_id, ''), name, query, created_at`
+
+func (r *SQLiteSavedViewRepository) Create(view *domain.SavedView) error {
+	query := `
+		INSERT INTO saved_views (id, project_id, name, query, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	_, err := r.base.db.Exec(query, view.ID, projectIDValue(view.ProjectID), view.Name, view.Query, view.CreatedAt)
+	if err != nil {
+		return r.base.WrapDBError("create", "saved view", view.ID, err)
+	}
+	return nil
+}
+
+func (r *SQLiteSavedViewRepository) GetByID(id string) (*domain.SavedView, error) {
+	views, err := r.query(`WHERE id = ?`, id)
+	if err != nil || len(views) == 0 {
+		return nil, err
+	}
+	return &views[0], nil
+}
+
+func (r *SQLiteSavedViewRepository) GetByProjectID(projectID string) ([]domain.SavedView, error) {
+	return r.query(`WHERE project_id IS NULL OR project_id = ?`, projectID)
+}
+
+func (r *SQLiteSavedViewRepository) GetAll() ([]domain.SavedView, error) {
+	return r.query(`WHERE project_id IS NULL OR project_id IN (SELECT id FROM projects WHERE deleted_at IS NULL)`)
+}
+
+func (r *SQLiteSavedViewRepository) Update(view *domain.SavedView) error {
+	query := `UPDATE saved_views SET name = ?, query = ? WHERE id = ?`
+
+	result, err := r.base.db.Exec(query, view.Name, view.Query, view.ID)
+	if err != nil {
+		return r.base.WrapDBError("update", "saved view", view.ID, err)
+	}
+	return r.base.HandleRowsAffected(result, "update", "saved view")
+}
+
+func (r *SQLiteSavedViewRepository) Delete(id string) error {
+	result, err := r.base.db.Exec(`DELETE FROM saved_views WHERE id = ?`, id)
+	if err != nil {
+		return r.base.WrapDBError("delete", "saved view", id, err)
+	}
+	return r.base.HandleRowsAffected(result, "delete", "saved view")
+}
+
+// query reads the views matching the WHERE clause, in the order of
+// domain.SortSavedViews
+func (r *SQLiteSavedViewRepository) query(where string, args ...interface{}) ([]domain.SavedView, error) {
+	rows, err := r.base.db.Query(`SELECT `+savedViewColumns+` FROM saved_views `+where, args...)
+	if err != nil {
+		return nil, r.base.WrapDBError("get", "saved views", "", err)
+	}
+	defer rows.Close()
+
+	var views []domain.SavedView
+	for rows.Next() {
+		var view domain.SavedView
+		if err := rows.Scan(&view.ID, &view.ProjectID, &view.Name, &view.Query, &view.CreatedAt); err != nil {
+			return nil, r.base.WrapDBError("scan", "saved view", "", err)
+		}
+		views = append(views, view)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.base.WrapDBError("iterate", "saved views", "", err)
+	}
+	domain.SortSavedViews(views)
+	return views, nil
+}
+
+// projectIDValue stores the empty project ID of a global view as NULL, which the
+// foreign key on projects allows
+func projectIDValue(projectID string) interface{} {
+	if projectID == "" {
+		return nil
+	}
+	return projectID
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+	"unicode"
+
+	"kahn/internal/domain"
+)
+
+// searchWeights rank matches in the name above the description, and those
+// above the comments
+const searchWeights = `10.0, 4.0, 1.0`
+
+func (r *SQLiteTaskRepository) Search(query string, limit int) ([]domain.SearchResult, error) {
+	match := matchExpression(query)
+	if match == "" {
+		return nil, nil
+	}
+
+	rows, err := r.base.db.Query(`
+		SELECT task_search.task_id,
+			(SELECT p.name FROM projects p WHERE p.id = tasks.project_id),
+			highlight(task_search, 0, ?, ?),
+			snippet(task_search, -1, ?, ?, '…', 12)
+		FROM task_search JOIN tasks ON tasks.id = task_search.task_id
+		WHERE task_search MATCH ? AND `+onBoard+`
+		ORDER BY bm25(task_search, `+searchWeights+`), tasks.updated_at DESC
+		LIMIT ?
+	`, domain.HighlightStart, domain.HighlightEnd, domain.HighlightStart, domain.HighlightEnd, match, limit)
+	if err != nil {
+		return nil, r.base.WrapDBError("search", "tasks", "", err)
+	}
+	defer rows.Close()
+
+	var results []domain.SearchResult
+	var ids []interface{}
+	for rows.Next() {
+		var result domain.SearchResult
+		var projectName sql.NullString
+		if err := rows.Scan(&result.Task.ID, &projectName, &result.Name, &result.Snippet); err != nil {
+			return nil, r.base.WrapDBError("scan", "search result", "", err)
+		}
+		result.ProjectName = projectName.String
+		results = append(results, result)
+		ids = append(ids, result.Task.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.base.WrapDBError("iterate", "search results", "", err)
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	tasks, err := r.getTasksByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		results[i].Task = tasks[results[i].Task.ID]
+	}
+	return results, nil
+}
+
+// getTasksByIDs loads the tasks with the given IDs with their labels and
+// comments, keyed by ID
+func (r *SQLiteTaskRepository) getTasksByIDs(ids []interface{}) (map[string]domain.Task, error) {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	rows, err := r.base.db.Query(`SELECT `+taskColumns+` FROM tasks WHERE id IN (`+placeholders+`)`, ids...)
+	if err != nil {
+		return nil, r.base.WrapDBError("get", "tasks", "", err)
+	}
+	defer rows.Close()
+
+	tasks, err := r.base.ScanTaskRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := loadTaskLabels(r.base, `WHERE tl.task_id IN (`+placeholders+`)`, ids...)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := loadTaskComments(r.base, `WHERE c.task_id IN (`+placeholders+`)`, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]domain.Task, len(tasks))
+	for _, task := range tasks {
+		task.Labels = labels[task.ID]
+		task.Comments = comments[task.ID]
+		byID[task.ID] = task
+	}
+	return byID, nil
+}
+
+// matchExpression turns what the user typed into an FTS5 query, so that
+// punctuation and FTS5 operators in it are searched for rather than parsed.
+// Each word becomes a quoted prefix term, text in double quotes a phrase, and
+// all of them must match. It returns "" when there is nothing to search for.
+func matchExpression(query string) string {
+	var terms []string
+	add := func(text string, prefix bool) {
+		if !strings.ContainsFunc(text, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) {
+			return
+		}
+		term := `"` + text + `"`
+		if prefix {
+			term += "*"
+		}
+		terms = append(terms, term)
+	}
+
+	quoted := false
+	for _, part := range strings.Split(query, `"`) {
+		if quoted {
+			add(part, false)
+		} else {
+			for _, word := range strings.Fields(part) {
+				add(word, true)
+			}
+		}
+		quoted = !quoted
+	}
+	return strings.Join(terms, " ")
+}
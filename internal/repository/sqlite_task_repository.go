@@ -7,6 +7,25 @@ import (
 	"time"
 )
 
+// taskColumns is the select list read by ScanTaskRows and ScanSingleTask. Blockers
+// come from task_dependencies as a comma separated list of int_ids, leaving out
+// those in the trash; two columns count all and done checklist items.
+const taskColumns = `int_id, id, project_id, name, desc, status, type, priority,
+	(SELECT group_concat(d.blocker_id) FROM task_dependencies d
+		JOIN tasks b ON b.int_id = d.blocker_id WHERE d.task_id = tasks.int_id AND b.deleted_at IS NULL),
+	created_at, updated_at, start_date, due_date,
+	(SELECT COUNT(*) FROM checklist_items c WHERE c.task_id = tasks.id),
+	(SELECT COUNT(*) FROM checklist_items c WHERE c.task_id = tasks.id AND c.done),
+	archived_at, deleted_at`
+
+// notTrashed matches the tasks that are neither in the trash nor in a trashed project
+const notTrashed = `deleted_at IS NULL AND ` + projectNotTrashed
+
+const projectNotTrashed = `project_id NOT IN (SELECT id FROM projects WHERE deleted_at IS NOT NULL)`
+
+// onBoard matches the tasks shown on the board: not trashed and not archived
+const onBoard = notTrashed + ` AND archived_at IS NULL`
+
 type SQLiteTaskRepository struct {
 	base *BaseRepository // Composition, not embedding
 }
@@ -18,29 +37,64 @@ func NewSQLiteTaskRepository(db *sql.DB) *SQLiteTaskRepository {
 }
 
 func (r *SQLiteTaskRepository) Create(task *domain.Task) error {
+	tx, err := r.base.db.Begin()
+	if err != nil {
+		return r.base.WrapDBError("begin", "task", task.ID, err)
+	}
+	defer tx.Rollback()
+
 	query := `
-		INSERT INTO tasks (id, project_id, name, desc, status, type, priority, blocked_by, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO tasks (id, project_id, name, desc, status, type, priority, created_at, updated_at, start_date, due_date)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	return r.base.CreateGeneric(query, task.ID, task.ProjectID, task.Name, task.Desc,
-		task.Status, task.Type, task.Priority, task.BlockedBy, task.CreatedAt, task.UpdatedAt)
+	result, err := tx.Exec(query, task.ID, task.ProjectID, task.Name, task.Desc,
+		task.Status, task.Type, task.Priority, task.CreatedAt, task.UpdatedAt,
+		DateValue(task.StartDate), DateValue(task.DueDate))
+	if err != nil {
+		return r.base.WrapDBError("create", "task", task.ID, err)
+	}
+
+	// Populate the autoincrement key so callers can reference the task by number immediately
+	intID, err := result.LastInsertId()
+	if err != nil {
+		return r.base.WrapDBError("read int_id", "task", task.ID, err)
+	}
+
+	if err := insertBlockers(tx, int(intID), task.BlockedBy); err != nil {
+		return r.base.WrapDBError("create", "task dependencies", task.ID, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return r.base.WrapDBError("commit", "task", task.ID, err)
+	}
+	task.IntID = int(intID)
+	return nil
 }
 
 func (r *SQLiteTaskRepository) GetByID(id string) (*domain.Task, error) {
 	query := `
-		SELECT int_id, id, project_id, name, desc, status, type, priority, blocked_by, created_at, updated_at
-		FROM tasks WHERE id = ?
+		SELECT ` + taskColumns + `
+		FROM tasks WHERE id = ? AND ` + notTrashed + `
 	`
 
 	row := r.base.db.QueryRow(query, id)
-	return r.base.ScanSingleTask(row)
+	return r.withDetails(r.base.ScanSingleTask(row))
+}
+
+func (r *SQLiteTaskRepository) GetByIntID(intID int) (*domain.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks WHERE int_id = ? AND ` + notTrashed + `
+	`
+
+	row := r.base.db.QueryRow(query, intID)
+	return r.withDetails(r.base.ScanSingleTask(row))
 }
 
 func (r *SQLiteTaskRepository) GetByProjectID(projectID string) ([]domain.Task, error) {
 	query := `
-		SELECT int_id, id, project_id, name, desc, status, type, priority, blocked_by, created_at, updated_at
-		FROM tasks WHERE project_id = ? ORDER BY created_at DESC
+		SELECT ` + taskColumns + `
+		FROM tasks WHERE project_id = ? AND ` + onBoard + ` ORDER BY created_at DESC
 	`
 
 	rows, err := r.base.db.Query(query, projectID)
@@ -49,25 +103,28 @@ func (r *SQLiteTaskRepository) GetByProjectID(projectID string) ([]domain.Task,
 	}
 	defer rows.Close()
 
-	return r.base.ScanTaskRows(rows)
+	return r.withProjectDetails(projectID)(r.base.ScanTaskRows(rows))
 }
 
 func (r *SQLiteTaskRepository) GetByStatus(projectID string, status domain.Status) ([]domain.Task, error) {
 	var query string
 
-	// Different ordering based on status
+	// Different ordering based on the column's position in the workflow
 	if status == domain.NotStarted {
-		// Not Started: priority DESC, then created_at ASC (oldest highest priority first)
+		// First column: overdue tasks first (most overdue first), then priority DESC,
+		// then created_at ASC (oldest highest priority first), as domain.SortTasks does
 		query = `
-			SELECT int_id, id, project_id, name, desc, status, type, priority, blocked_by, created_at, updated_at
-			FROM tasks WHERE project_id = ? AND status = ? 
-			ORDER BY priority DESC, created_at ASC
+			SELECT ` + taskColumns + `
+			FROM tasks WHERE project_id = ? AND status = ? AND ` + onBoard + `
+			ORDER BY CASE WHEN due_date < date('now', 'localtime') THEN 0 ELSE 1 END,
+				CASE WHEN due_date < date('now', 'localtime') THEN due_date END,
+				priority DESC, created_at ASC
 		`
 	} else {
-		// In Progress and Done: updated_at DESC (newest changes first)
+		// Later columns: updated_at DESC (newest changes first)
 		query = `
-			SELECT int_id, id, project_id, name, desc, status, type, priority, blocked_by, created_at, updated_at
-			FROM tasks WHERE project_id = ? AND status = ? 
+			SELECT ` + taskColumns + `
+			FROM tasks WHERE project_id = ? AND status = ? AND ` + onBoard + `
 			ORDER BY updated_at DESC
 		`
 	}
@@ -78,22 +135,42 @@ func (r *SQLiteTaskRepository) GetByStatus(projectID string, status domain.Statu
 	}
 	defer rows.Close()
 
-	return r.base.ScanTaskRows(rows)
+	return r.withProjectDetails(projectID)(r.base.ScanTaskRows(rows))
 }
 
+// Update saves every field of the task, replacing its blockers
 func (r *SQLiteTaskRepository) Update(task *domain.Task) error {
+	tx, err := r.base.db.Begin()
+	if err != nil {
+		return r.base.WrapDBError("begin", "task", task.ID, err)
+	}
+	defer tx.Rollback()
+
 	query := `
 		UPDATE tasks 
-		SET name = ?, desc = ?, status = ?, type = ?, priority = ?, blocked_by = ?, updated_at = ?
+		SET name = ?, desc = ?, status = ?, type = ?, priority = ?, updated_at = ?,
+			start_date = ?, due_date = ?
 		WHERE id = ?
 	`
 
 	task.UpdatedAt = time.Now()
-	_, err := r.base.db.Exec(query, task.Name, task.Desc, task.Status,
-		task.Type, task.Priority, task.BlockedBy, task.UpdatedAt, task.ID)
+	_, err = tx.Exec(query, task.Name, task.Desc, task.Status,
+		task.Type, task.Priority, task.UpdatedAt,
+		DateValue(task.StartDate), DateValue(task.DueDate), task.ID)
 	if err != nil {
 		return r.base.WrapDBError("update", "task", task.ID, err)
 	}
+
+	if err := deleteBlockers(tx, task.IntID); err != nil {
+		return r.base.WrapDBError("update", "task dependencies", task.ID, err)
+	}
+	if err := insertBlockers(tx, task.IntID, task.BlockedBy); err != nil {
+		return r.base.WrapDBError("update", "task dependencies", task.ID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return r.base.WrapDBError("commit", "task", task.ID, err)
+	}
 	return nil
 }
 
@@ -111,21 +188,243 @@ func (r *SQLiteTaskRepository) UpdateStatus(taskID string, status domain.Status)
 	return nil
 }
 
+// ClearBlockersForIntID removes the task numbered intID from the blockers of every
+// task waiting on it
 func (r *SQLiteTaskRepository) ClearBlockersForIntID(intID int) error {
-	query := `
-		UPDATE tasks 
-		SET blocked_by = NULL, updated_at = ?
-		WHERE blocked_by = ?
-	`
+	tx, err := r.base.db.Begin()
+	if err != nil {
+		return r.base.WrapDBError("begin", "tasks", fmt.Sprintf("int_id=%d", intID), err)
+	}
+	defer tx.Rollback()
 
-	_, err := r.base.db.Exec(query, time.Now(), intID)
+	_, err = tx.Exec(`
+		UPDATE tasks 
+		SET updated_at = ?
+		WHERE int_id IN (SELECT task_id FROM task_dependencies WHERE blocker_id = ?)
+	`, time.Now(), intID)
 	if err != nil {
 		return r.base.WrapDBError("clear blockers", "tasks", fmt.Sprintf("int_id=%d", intID), err)
 	}
+	if _, err := tx.Exec(`DELETE FROM task_dependencies WHERE blocker_id = ?`, intID); err != nil {
+		return r.base.WrapDBError("clear blockers", "tasks", fmt.Sprintf("int_id=%d", intID), err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return r.base.WrapDBError("commit", "tasks", fmt.Sprintf("int_id=%d", intID), err)
+	}
 	return nil
 }
 
+// GetDependencyGraph returns every stored link between the project's tasks,
+// including the links kept for archived and trashed tasks
+func (r *SQLiteTaskRepository) GetDependencyGraph(projectID string) (domain.DependencyGraph, error) {
+	rows, err := r.base.db.Query(`
+		SELECT d.task_id, d.blocker_id FROM task_dependencies d
+		JOIN tasks t ON t.int_id = d.task_id
+		WHERE t.project_id = ?
+		ORDER BY d.task_id, d.blocker_id
+	`, projectID)
+	if err != nil {
+		return nil, r.base.WrapDBError("get", "dependency graph for project", projectID, err)
+	}
+	defer rows.Close()
+
+	graph := make(domain.DependencyGraph)
+	for rows.Next() {
+		var intID, blocker int
+		if err := rows.Scan(&intID, &blocker); err != nil {
+			return nil, r.base.WrapDBError("scan", "task dependency", projectID, err)
+		}
+		graph[intID] = append(graph[intID], blocker)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.base.WrapDBError("read", "task dependencies", projectID, err)
+	}
+	return graph, nil
+}
+
+// Delete moves the task to the trash. Its dependency links are kept so that
+// restoring it makes its dependents wait on it again.
 func (r *SQLiteTaskRepository) Delete(id string) error {
-	query := `DELETE FROM tasks WHERE id = ?`
-	return r.base.DeleteGeneric(query, id)
+	return r.setTimestamp("delete", id, `deleted_at = ?`, `deleted_at IS NULL`, time.Now())
+}
+
+// Restore takes the task out of the trash
+func (r *SQLiteTaskRepository) Restore(id string) error {
+	return r.setTimestamp("restore", id, `deleted_at = ?`, `deleted_at IS NOT NULL`, nil)
+}
+
+// Archive hides the task from the board
+func (r *SQLiteTaskRepository) Archive(id string) error {
+	return r.setTimestamp("archive", id, `archived_at = ?`, `archived_at IS NULL AND `+notTrashed, time.Now())
+}
+
+// Unarchive puts the task back on the board
+func (r *SQLiteTaskRepository) Unarchive(id string) error {
+	return r.setTimestamp("unarchive", id, `archived_at = ?`, `archived_at IS NOT NULL AND `+notTrashed, nil)
+}
+
+// setTimestamp sets one of the trash and archive columns of the task, provided
+// it is in the state described by the where condition
+func (r *SQLiteTaskRepository) setTimestamp(operation, id, set, where string, value interface{}) error {
+	result, err := r.base.db.Exec(`UPDATE tasks SET `+set+` WHERE id = ? AND `+where, value, id)
+	if err != nil {
+		return r.base.WrapDBError(operation, "task", id, err)
+	}
+	return r.base.HandleRowsAffected(result, operation, "task")
+}
+
+// GetArchived returns the project's archived tasks, most recently archived first
+func (r *SQLiteTaskRepository) GetArchived(projectID string) ([]domain.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks WHERE project_id = ? AND archived_at IS NOT NULL AND ` + notTrashed + `
+		ORDER BY archived_at DESC
+	`
+
+	rows, err := r.base.db.Query(query, projectID)
+	if err != nil {
+		return nil, r.base.WrapDBError("get", "archived tasks for project", projectID, err)
+	}
+	defer rows.Close()
+
+	return r.withProjectDetails(projectID)(r.base.ScanTaskRows(rows))
+}
+
+// GetTrashed returns the tasks in the trash, most recently deleted first. An
+// empty projectID lists those of every project that is not in the trash itself.
+func (r *SQLiteTaskRepository) GetTrashed(projectID string) ([]domain.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks WHERE deleted_at IS NOT NULL AND ` + projectNotTrashed + ` AND (? = '' OR project_id = ?)
+		ORDER BY deleted_at DESC
+	`
+
+	rows, err := r.base.db.Query(query, projectID, projectID)
+	if err != nil {
+		return nil, r.base.WrapDBError("get", "trashed tasks for project", projectID, err)
+	}
+	defer rows.Close()
+
+	return r.withProjectDetails(projectID)(r.base.ScanTaskRows(rows))
+}
+
+// Purge removes the task for good with its labels, checklist and comments, and
+// its dependency links in both directions
+func (r *SQLiteTaskRepository) Purge(id string) error {
+	tx, err := r.base.db.Begin()
+	if err != nil {
+		return r.base.WrapDBError("begin", "task", id, err)
+	}
+	defer tx.Rollback()
+
+	result, err := deleteTask(r.base, tx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.base.HandleRowsAffected(result, "purge", "task"); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return r.base.WrapDBError("commit", "task", id, err)
+	}
+	return nil
+}
+
+// deleteTask removes the task's dependency links, labels, checklist and
+// comments, then the task itself, returning the result of the last statement
+func deleteTask(base *BaseRepository, tx *sql.Tx, id string) (sql.Result, error) {
+	_, err := tx.Exec(`
+		DELETE FROM task_dependencies
+		WHERE task_id IN (SELECT int_id FROM tasks WHERE id = ?)
+			OR blocker_id IN (SELECT int_id FROM tasks WHERE id = ?)
+	`, id, id)
+	if err != nil {
+		return nil, base.WrapDBError("delete", "task dependencies", id, err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM task_labels WHERE task_id = ?`, id); err != nil {
+		return nil, base.WrapDBError("delete", "task labels", id, err)
+	}
+	if _, err := tx.Exec(`DELETE FROM checklist_items WHERE task_id = ?`, id); err != nil {
+		return nil, base.WrapDBError("delete", "checklist items", id, err)
+	}
+	if _, err := tx.Exec(`DELETE FROM task_comments WHERE task_id = ?`, id); err != nil {
+		return nil, base.WrapDBError("delete", "task comments", id, err)
+	}
+
+	result, err := tx.Exec(`DELETE FROM tasks WHERE id = ?`, id)
+	if err != nil {
+		return nil, base.WrapDBError("delete", "task", id, err)
+	}
+	return result, nil
+}
+
+// deleteBlockers unlinks intID from its blockers, except those in the trash
+// whose links wait for them to be restored
+func deleteBlockers(tx *sql.Tx, intID int) error {
+	_, err := tx.Exec(`
+		DELETE FROM task_dependencies
+		WHERE task_id = ? AND blocker_id NOT IN (SELECT int_id FROM tasks WHERE deleted_at IS NOT NULL)
+	`, intID)
+	return err
+}
+
+// insertBlockers links intID to each of its blockers
+func insertBlockers(tx *sql.Tx, intID int, blockers []int) error {
+	for _, blocker := range blockers {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO task_dependencies (task_id, blocker_id) VALUES (?, ?)`, intID, blocker); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withDetails loads the labels and comments of a single scanned task
+func (r *SQLiteTaskRepository) withDetails(task *domain.Task, err error) (*domain.Task, error) {
+	if err != nil || task == nil {
+		return task, err
+	}
+
+	labels, err := loadTaskLabels(r.base, `WHERE tl.task_id = ?`, task.ID)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := loadTaskComments(r.base, `WHERE c.task_id = ?`, task.ID)
+	if err != nil {
+		return nil, err
+	}
+	task.Labels = labels[task.ID]
+	task.Comments = comments[task.ID]
+	return task, nil
+}
+
+// withProjectDetails returns a function that loads the labels and comments of
+// scanned tasks with one query each for the whole project, or for every
+// project when projectID is empty
+func (r *SQLiteTaskRepository) withProjectDetails(projectID string) func([]domain.Task, error) ([]domain.Task, error) {
+	return func(tasks []domain.Task, err error) ([]domain.Task, error) {
+		if err != nil || len(tasks) == 0 {
+			return tasks, err
+		}
+
+		labelWhere, commentWhere, args := `WHERE l.project_id = ?`, `WHERE t.project_id = ?`, []interface{}{projectID}
+		if projectID == "" {
+			labelWhere, commentWhere, args = "", "", nil
+		}
+		labels, err := loadTaskLabels(r.base, labelWhere, args...)
+		if err != nil {
+			return nil, err
+		}
+		comments, err := loadTaskComments(r.base, commentWhere, args...)
+		if err != nil {
+			return nil, err
+		}
+		attachLabels(tasks, labels)
+		for i := range tasks {
+			tasks[i].Comments = comments[tasks[i].ID]
+		}
+		return tasks, nil
+	}
 }
@@ -0,0 +1,348 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"kahn/internal/domain"
+	"time"
+)
+
+const undoColumns = `id, project_id, COALESCE(task_id, ''), COALESCE(task_int_id, 0), subject, description,
+	undo_actions, redo_actions, undone, created_at`
+
+type SQLiteUndoRepository struct {
+	base *BaseRepository
+}
+
+func NewSQLiteUndoRepository(db *sql.DB) *SQLiteUndoRepository {
+	return &SQLiteUndoRepository{
+		base: NewBaseRepository(db),
+	}
+}
+
+func (r *SQLiteUndoRepository) Push(op *domain.UndoOperation) error {
+	undoActions, redoActions, err := encodeUndoActions(op)
+	if err != nil {
+		return r.base.WrapDBError("encode", "undo operation", op.Description, err)
+	}
+
+	tx, err := r.base.db.Begin()
+	if err != nil {
+		return r.base.WrapDBError("begin", "undo operation", op.Description, err)
+	}
+	defer tx.Rollback()
+
+	// A new change makes the undone operations impossible to redo
+	if _, err := tx.Exec(`DELETE FROM undo_operations WHERE undone`); err != nil {
+		return r.base.WrapDBError("delete", "redo operations", "", err)
+	}
+
+	op.CreatedAt = time.Now()
+	op.Undone = false
+	result, err := tx.Exec(`
+		INSERT INTO undo_operations (project_id, task_id, task_int_id, subject, description, undo_actions, redo_actions, undone, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, 0, ?)
+	`, op.ProjectID, nullString(op.TaskID), nullInt(op.TaskIntID), op.Subject, op.Description,
+		undoActions, redoActions, op.CreatedAt)
+	if err != nil {
+		return r.base.WrapDBError("create", "undo operation", op.Description, err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return r.base.WrapDBError("read id", "undo operation", op.Description, err)
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM undo_operations
+		WHERE id NOT IN (SELECT id FROM undo_operations ORDER BY id DESC LIMIT ?)
+	`, domain.MaxUndoOperations)
+	if err != nil {
+		return r.base.WrapDBError("trim", "undo operations", "", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return r.base.WrapDBError("commit", "undo operation", op.Description, err)
+	}
+	op.ID = id
+	return nil
+}
+
+func (r *SQLiteUndoRepository) LastDone() (*domain.UndoOperation, error) {
+	return r.scanOperation(r.base.db.QueryRow(`SELECT ` + undoColumns + ` FROM undo_operations WHERE NOT undone ORDER BY id DESC LIMIT 1`))
+}
+
+func (r *SQLiteUndoRepository) FirstUndone() (*domain.UndoOperation, error) {
+	return r.scanOperation(r.base.db.QueryRow(`SELECT ` + undoColumns + ` FROM undo_operations WHERE undone ORDER BY id ASC LIMIT 1`))
+}
+
+func (r *SQLiteUndoRepository) Apply(op *domain.UndoOperation, undo bool) error {
+	id := fmt.Sprintf("%d", op.ID)
+	undoActions, redoActions, err := encodeUndoActions(op)
+	if err != nil {
+		return r.base.WrapDBError("encode", "undo operation", id, err)
+	}
+
+	tx, err := r.base.db.Begin()
+	if err != nil {
+		return r.base.WrapDBError("begin", "undo operation", id, err)
+	}
+	defer tx.Rollback()
+
+	actions := op.Redo
+	if undo {
+		actions = op.Undo
+	}
+	for _, action := range actions {
+		if err := r.applyAction(tx, action); err != nil {
+			return err
+		}
+	}
+
+	// The actions are stored again because the caller may have refreshed snapshots
+	_, err = tx.Exec(`UPDATE undo_operations SET undone = ?, undo_actions = ?, redo_actions = ? WHERE id = ?`,
+		undo, undoActions, redoActions, op.ID)
+	if err != nil {
+		return r.base.WrapDBError("update", "undo operation", id, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return r.base.WrapDBError("commit", "undo operation", id, err)
+	}
+	op.Undone = undo
+	return nil
+}
+
+func (r *SQLiteUndoRepository) Drop(id int64) error {
+	if _, err := r.base.db.Exec(`DELETE FROM undo_operations WHERE id = ?`, id); err != nil {
+		return r.base.WrapDBError("delete", "undo operation", fmt.Sprintf("%d", id), err)
+	}
+	return nil
+}
+
+func (r *SQLiteUndoRepository) applyAction(tx *sql.Tx, action domain.UndoAction) error {
+	switch action.Kind {
+	case domain.UndoRestoreTask:
+		if action.Task == nil {
+			break
+		}
+		if err := r.restoreTask(tx, *action.Task); err != nil {
+			return err
+		}
+		return r.linkBlockers(tx, action.Task.Task.IntID, action.Task.Task.BlockedBy)
+	case domain.UndoDeleteTask:
+		result, err := deleteTask(r.base, tx, action.ID)
+		if err != nil {
+			return err
+		}
+		return r.requireRow(result, "task", action.ID)
+	case domain.UndoUpdateTask:
+		if action.Task == nil {
+			break
+		}
+		return r.updateTask(tx, action.Task.Task)
+	case domain.UndoLinkBlockers:
+		for _, link := range action.Links {
+			if err := r.linkBlockers(tx, link.TaskIntID, []int{link.BlockerIntID}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case domain.UndoUnlinkBlockers:
+		for _, link := range action.Links {
+			_, err := tx.Exec(`DELETE FROM task_dependencies WHERE task_id = ? AND blocker_id = ?`, link.TaskIntID, link.BlockerIntID)
+			if err != nil {
+				return r.base.WrapDBError("delete", "task dependency", fmt.Sprintf("int_id=%d", link.TaskIntID), err)
+			}
+		}
+		return nil
+	case domain.UndoTrashTask, domain.UndoUntrashTask, domain.UndoArchiveTask, domain.UndoUnarchiveTask,
+		domain.UndoTrashProject, domain.UndoUntrashProject:
+		return r.setTimestamp(tx, action)
+	default:
+		return domain.NewValidationError("undo", fmt.Sprintf("unknown undo action %q", action.Kind))
+	}
+	return domain.NewValidationError("undo", fmt.Sprintf("undo action %q has nothing to apply", action.Kind))
+}
+
+// restoreTask inserts the task under its old ID and number with its labels,
+// comments and checklist. Blockers are linked separately so that a project's
+// tasks can all be inserted before any of them waits on another.
+func (r *SQLiteUndoRepository) restoreTask(tx *sql.Tx, snapshot domain.TaskSnapshot) error {
+	task := snapshot.Task
+	var exists int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM tasks WHERE id = ? OR int_id = ?`, task.ID, task.IntID).Scan(&exists); err != nil {
+		return r.base.WrapDBError("get", "task", task.ID, err)
+	}
+	if exists > 0 {
+		return domain.NewValidationError("undo", fmt.Sprintf("task #%d already exists", task.IntID))
+	}
+
+	_, err := tx.Exec(`
+		INSERT INTO tasks (int_id, id, project_id, name, desc, status, type, priority, created_at, updated_at, start_date, due_date)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, task.IntID, task.ID, task.ProjectID, task.Name, task.Desc, task.Status, task.Type, task.Priority,
+		task.CreatedAt, task.UpdatedAt, DateValue(task.StartDate), DateValue(task.DueDate))
+	if err != nil {
+		return r.base.WrapDBError("restore", "task", task.ID, err)
+	}
+
+	if err := r.setTaskLabels(tx, task); err != nil {
+		return err
+	}
+	for _, comment := range task.Comments {
+		_, err := tx.Exec(`
+			INSERT INTO task_comments (id, task_id, author, body, created_at)
+			VALUES (?, ?, ?, ?, ?)
+		`, comment.ID, task.ID, comment.Author, comment.Body, comment.CreatedAt)
+		if err != nil {
+			return r.base.WrapDBError("restore", "comment", comment.ID, err)
+		}
+	}
+	for _, item := range snapshot.Checklist {
+		_, err := tx.Exec(`
+			INSERT INTO checklist_items (id, task_id, text, done, position, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, item.ID, task.ID, item.Text, item.Done, item.Position, item.CreatedAt, item.UpdatedAt)
+		if err != nil {
+			return r.base.WrapDBError("restore", "checklist item", item.ID, err)
+		}
+	}
+	return nil
+}
+
+// updateTask writes the fields, labels and blockers of task over the stored
+// task, keeping its checklist and comments. Tasks in the trash are left alone.
+func (r *SQLiteUndoRepository) updateTask(tx *sql.Tx, task domain.Task) error {
+	result, err := tx.Exec(`
+		UPDATE tasks
+		SET name = ?, desc = ?, status = ?, type = ?, priority = ?, updated_at = ?, start_date = ?, due_date = ?
+		WHERE id = ? AND deleted_at IS NULL
+	`, task.Name, task.Desc, task.Status, task.Type, task.Priority, task.UpdatedAt,
+		DateValue(task.StartDate), DateValue(task.DueDate), task.ID)
+	if err != nil {
+		return r.base.WrapDBError("update", "task", task.ID, err)
+	}
+	if err := r.requireRow(result, "task", task.ID); err != nil {
+		return err
+	}
+
+	if err := r.setTaskLabels(tx, task); err != nil {
+		return err
+	}
+	if err := deleteBlockers(tx, task.IntID); err != nil {
+		return r.base.WrapDBError("update", "task dependencies", task.ID, err)
+	}
+	return r.linkBlockers(tx, task.IntID, task.BlockedBy)
+}
+
+// setTaskLabels replaces the task's labels with those of task.Labels that still exist
+func (r *SQLiteUndoRepository) setTaskLabels(tx *sql.Tx, task domain.Task) error {
+	if _, err := tx.Exec(`DELETE FROM task_labels WHERE task_id = ?`, task.ID); err != nil {
+		return r.base.WrapDBError("update", "task labels", task.ID, err)
+	}
+	for _, label := range task.Labels {
+		_, err := tx.Exec(`INSERT OR IGNORE INTO task_labels (task_id, label_id) SELECT ?, id FROM labels WHERE id = ?`, task.ID, label.ID)
+		if err != nil {
+			return r.base.WrapDBError("update", "task labels", task.ID, err)
+		}
+	}
+	return nil
+}
+
+// linkBlockers makes the task numbered intID wait on the blockers that still exist
+func (r *SQLiteUndoRepository) linkBlockers(tx *sql.Tx, intID int, blockers []int) error {
+	for _, blocker := range blockers {
+		_, err := tx.Exec(`
+			INSERT OR IGNORE INTO task_dependencies (task_id, blocker_id)
+			SELECT t.int_id, b.int_id FROM tasks t, tasks b WHERE t.int_id = ? AND b.int_id = ? AND t.int_id <> b.int_id
+		`, intID, blocker)
+		if err != nil {
+			return r.base.WrapDBError("create", "task dependency", fmt.Sprintf("int_id=%d", intID), err)
+		}
+	}
+	return nil
+}
+
+// timestampActions are the actions moving a task or project in and out of the
+// trash or the archive by setting (set) or clearing one of its timestamps
+var timestampActions = map[domain.UndoActionKind]struct {
+	entity, column string
+	set            bool
+	requires       string // the state the action needs, for its error
+}{
+	domain.UndoTrashTask:      {"task", "deleted_at", true, "outside the trash"},
+	domain.UndoUntrashTask:    {"task", "deleted_at", false, "in the trash"},
+	domain.UndoArchiveTask:    {"task", "archived_at", true, "on the board"},
+	domain.UndoUnarchiveTask:  {"task", "archived_at", false, "archived"},
+	domain.UndoTrashProject:   {"project", "deleted_at", true, "outside the trash"},
+	domain.UndoUntrashProject: {"project", "deleted_at", false, "in the trash"},
+}
+
+func (r *SQLiteUndoRepository) setTimestamp(tx *sql.Tx, action domain.UndoAction) error {
+	update := timestampActions[action.Kind]
+	var value interface{}
+	condition := update.column + " IS NOT NULL"
+	if update.set {
+		value, condition = time.Now(), update.column+" IS NULL"
+	}
+
+	query := fmt.Sprintf(`UPDATE %ss SET %s = ? WHERE id = ? AND %s`, update.entity, update.column, condition)
+	result, err := tx.Exec(query, value, action.ID)
+	if err != nil {
+		return r.base.WrapDBError("update", update.entity, action.ID, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return r.base.WrapDBError("check rows affected", update.entity, action.ID, err)
+	}
+	if rows == 0 {
+		return domain.NewValidationError("undo", fmt.Sprintf("%s %q is no longer %s", update.entity, action.ID, update.requires))
+	}
+	return nil
+}
+
+// requireRow reports an undo action whose task or project no longer exists
+func (r *SQLiteUndoRepository) requireRow(result sql.Result, entity, id string) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return r.base.WrapDBError("check rows affected", entity, id, err)
+	}
+	if rows == 0 {
+		return domain.NewValidationError("undo", fmt.Sprintf("%s %q no longer exists", entity, id))
+	}
+	return nil
+}
+
+func (r *SQLiteUndoRepository) scanOperation(row *sql.Row) (*domain.UndoOperation, error) {
+	var op domain.UndoOperation
+	var undoActions, redoActions string
+	err := row.Scan(&op.ID, &op.ProjectID, &op.TaskID, &op.TaskIntID, &op.Subject, &op.Description,
+		&undoActions, &redoActions, &op.Undone, &op.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, r.base.WrapDBError("scan", "undo operation", "", err)
+	}
+
+	if err := json.Unmarshal([]byte(undoActions), &op.Undo); err != nil {
+		return nil, r.base.WrapDBError("decode", "undo operation", fmt.Sprintf("%d", op.ID), err)
+	}
+	if err := json.Unmarshal([]byte(redoActions), &op.Redo); err != nil {
+		return nil, r.base.WrapDBError("decode", "undo operation", fmt.Sprintf("%d", op.ID), err)
+	}
+	return &op, nil
+}
+
+func encodeUndoActions(op *domain.UndoOperation) (string, string, error) {
+	undoActions, err := json.Marshal(op.Undo)
+	if err != nil {
+		return "", "", err
+	}
+	redoActions, err := json.Marshal(op.Redo)
+	if err != nil {
+		return "", "", err
+	}
+	return string(undoActions), string(redoActions), nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"fmt"
 	"testing"
 	"time"
 
@@ -430,3 +431,421 @@ func TestTaskRepository_Migration_DefaultType(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, domain.RegularTask, retrievedTask.Type, "Default task type should be RegularTask")
 }
+
+func TestTaskRepository_GetByIntID(t *testing.T) {
+	repo := setupTestRepository(t)
+
+	task := &domain.Task{
+		ID:        "task_lookup",
+		ProjectID: "test_project",
+		Name:      "Lookup Task",
+		Status:    domain.InProgress,
+		Type:      domain.Bug,
+		Priority:  domain.High,
+	}
+	require.NoError(t, repo.Create(task))
+	require.NotZero(t, task.IntID, "Create should populate the autoincrement int_id")
+
+	created, err := repo.GetByID("task_lookup")
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.Equal(t, task.IntID, created.IntID)
+
+	found, err := repo.GetByIntID(created.IntID)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "task_lookup", found.ID)
+	assert.Equal(t, domain.Bug, found.Type)
+
+	missing, err := repo.GetByIntID(created.IntID + 100)
+	require.NoError(t, err)
+	assert.Nil(t, missing, "Unknown int_id should return nil without error")
+}
+
+func TestTaskRepository_Dates(t *testing.T) {
+	repo := setupTestRepository(t)
+
+	now := time.Now()
+	start, err := domain.ParseDate("today", now)
+	require.NoError(t, err)
+	due, err := domain.ParseDate("+3d", now)
+	require.NoError(t, err)
+
+	task := domain.NewTask("Dated", "", "test_project")
+	task.StartDate = start
+	task.DueDate = due
+	require.NoError(t, repo.Create(task))
+
+	loaded, err := repo.GetByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.FormatDate(start), domain.FormatDate(loaded.StartDate))
+	assert.Equal(t, domain.FormatDate(due), domain.FormatDate(loaded.DueDate))
+
+	loaded.StartDate = nil
+	loaded.DueDate = nil
+	require.NoError(t, repo.Update(loaded))
+
+	cleared, err := repo.GetByID(task.ID)
+	require.NoError(t, err)
+	assert.Nil(t, cleared.StartDate)
+	assert.Nil(t, cleared.DueDate)
+}
+
+func TestTaskRepository_GetByStatus_OverdueFirst(t *testing.T) {
+	repo := setupTestRepository(t)
+	baseTime := time.Now()
+
+	dates := map[string]string{
+		"Most Overdue": "-10d",
+		"Overdue Low":  "-1d",
+		"Due Today":    "today",
+		"Due Later":    "+7d",
+	}
+	for i, data := range []TaskTestData{
+		{"High Priority", domain.NotStarted, domain.RegularTask, domain.High, baseTime, baseTime},
+		{"Overdue Low", domain.NotStarted, domain.RegularTask, domain.Low, baseTime, baseTime},
+		{"Due Today", domain.NotStarted, domain.RegularTask, domain.Medium, baseTime, baseTime},
+		{"Due Later", domain.NotStarted, domain.RegularTask, domain.Medium, baseTime.Add(time.Hour), baseTime},
+		{"Most Overdue", domain.NotStarted, domain.RegularTask, domain.Low, baseTime.Add(time.Hour), baseTime},
+	} {
+		task := domain.NewTask(data.Name, "", "test_project")
+		task.ID = fmt.Sprintf("task_due_%d", i)
+		task.Priority = data.Priority
+		task.CreatedAt = data.CreatedAt
+		if value, ok := dates[data.Name]; ok {
+			task.DueDate, _ = domain.ParseDate(value, baseTime)
+		}
+		require.NoError(t, repo.Create(task))
+	}
+
+	tasks, err := repo.GetByStatus("test_project", domain.NotStarted)
+	require.NoError(t, err)
+
+	expected := []string{"Most Overdue", "Overdue Low", "High Priority", "Due Today", "Due Later"}
+	assert.Equal(t, expected, taskNames(tasks))
+	assert.Equal(t, expected, taskNames(domain.SortTasks(tasks, domain.NotStarted)), "SQL ordering matches SortTasks")
+}
+
+func taskNames(tasks []domain.Task) []string {
+	names := make([]string, len(tasks))
+	for i, task := range tasks {
+		names[i] = task.Name
+	}
+	return names
+}
+
+func TestTaskRepository_Blockers(t *testing.T) {
+	repo := setupTestRepository(t)
+
+	var tasks []*domain.Task
+	for i := 0; i < 3; i++ {
+		task := domain.NewTask(fmt.Sprintf("Blocker %d", i+1), "", "test_project")
+		require.NoError(t, repo.Create(task))
+		tasks = append(tasks, task)
+	}
+
+	blocked := domain.NewTask("Blocked", "", "test_project")
+	blocked.BlockedBy = []int{tasks[2].IntID, tasks[0].IntID}
+	require.NoError(t, repo.Create(blocked))
+
+	loaded, err := repo.GetByID(blocked.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{tasks[0].IntID, tasks[2].IntID}, loaded.BlockedBy, "Blockers load sorted")
+
+	// Update replaces the blockers
+	loaded.BlockedBy = []int{tasks[1].IntID, tasks[2].IntID}
+	require.NoError(t, repo.Update(loaded))
+
+	// Clearing one blocker leaves the others
+	require.NoError(t, repo.ClearBlockersForIntID(tasks[1].IntID))
+	all, err := repo.GetByProjectID("test_project")
+	require.NoError(t, err)
+	for _, task := range all {
+		if task.ID == blocked.ID {
+			assert.Equal(t, []int{tasks[2].IntID}, task.BlockedBy)
+		} else {
+			assert.Empty(t, task.BlockedBy)
+		}
+	}
+
+	// A blocker in the trash does not count until it is restored
+	require.NoError(t, repo.Delete(tasks[2].ID))
+	loaded, err = repo.GetByID(blocked.ID)
+	require.NoError(t, err)
+	assert.Empty(t, loaded.BlockedBy)
+	require.NoError(t, repo.Update(loaded))
+	require.NoError(t, repo.Restore(tasks[2].ID))
+	loaded, err = repo.GetByID(blocked.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{tasks[2].IntID}, loaded.BlockedBy, "Updating the task kept the trashed link")
+
+	// Purging a blocker removes its links
+	require.NoError(t, repo.Purge(tasks[2].ID))
+	loaded, err = repo.GetByID(blocked.ID)
+	require.NoError(t, err)
+	assert.Empty(t, loaded.BlockedBy)
+}
+
+func TestTaskRepository_Checklist(t *testing.T) {
+	repo := setupTestRepository(t)
+
+	task := domain.NewTask("Story", "", "test_project")
+	require.NoError(t, repo.Create(task))
+
+	var items []*domain.ChecklistItem
+	for i, text := range []string{"First", "Second", "Third"} {
+		item := domain.NewChecklistItem(task.ID, text, i+1)
+		item.ID = fmt.Sprintf("check_%d", i)
+		require.NoError(t, repo.CreateChecklistItem(item))
+		items = append(items, item)
+	}
+
+	checklistTexts := func() []string {
+		checklist, err := repo.GetChecklist(task.ID)
+		require.NoError(t, err)
+		texts := make([]string, len(checklist))
+		for i, item := range checklist {
+			assert.Equal(t, i+1, item.Position)
+			texts[i] = item.Text
+		}
+		return texts
+	}
+	assert.Equal(t, []string{"First", "Second", "Third"}, checklistTexts())
+
+	// Inserting at a position pushes the later items down
+	inserted := domain.NewChecklistItem(task.ID, "Inserted", 2)
+	inserted.ID = "check_inserted"
+	require.NoError(t, repo.CreateChecklistItem(inserted))
+	assert.Equal(t, []string{"First", "Inserted", "Second", "Third"}, checklistTexts())
+
+	// Task reads carry the checklist counts
+	items[0].Done = true
+	require.NoError(t, repo.UpdateChecklistItem(items[0]))
+	loaded, err := repo.GetByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, loaded.ChecklistTotal)
+	assert.Equal(t, 1, loaded.ChecklistDone)
+
+	item, err := repo.GetChecklistItem(items[0].ID)
+	require.NoError(t, err)
+	assert.True(t, item.Done)
+
+	require.NoError(t, repo.ReorderChecklist(task.ID, []string{items[2].ID, items[1].ID, inserted.ID, items[0].ID}))
+	assert.Equal(t, []string{"Third", "Second", "Inserted", "First"}, checklistTexts())
+
+	// Deleting closes the gap in the positions
+	require.NoError(t, repo.DeleteChecklistItem(items[1].ID))
+	assert.Equal(t, []string{"Third", "Inserted", "First"}, checklistTexts())
+
+	missing, err := repo.GetChecklistItem(items[1].ID)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	// Purging the task deletes its checklist
+	require.NoError(t, repo.Purge(task.ID))
+	checklist, err := repo.GetChecklist(task.ID)
+	require.NoError(t, err)
+	assert.Empty(t, checklist)
+}
+
+func TestTaskRepository_Comments(t *testing.T) {
+	repo := setupTestRepository(t)
+
+	task := domain.NewTask("Story", "", "test_project")
+	require.NoError(t, repo.Create(task))
+	other := domain.NewTask("Other", "", "test_project")
+	require.NoError(t, repo.Create(other))
+
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	for i, body := range []string{"First", "Second\nwith detail"} {
+		comment := domain.NewComment(task.ID, "alice", body)
+		comment.ID = fmt.Sprintf("comment_%d", i)
+		comment.CreatedAt = base.Add(time.Duration(i) * time.Hour)
+		require.NoError(t, repo.CreateComment(comment))
+	}
+
+	comments, err := repo.GetComments(task.ID)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, "First", comments[0].Body)
+	assert.Equal(t, "Second\nwith detail", comments[1].Body)
+	assert.Equal(t, "alice", comments[1].Author)
+	assert.True(t, comments[0].CreatedAt.Equal(base))
+
+	// Task reads carry their comments, single and per project
+	loaded, err := repo.GetByID(task.ID)
+	require.NoError(t, err)
+	assert.Len(t, loaded.Comments, 2)
+
+	tasks, err := repo.GetByProjectID("test_project")
+	require.NoError(t, err)
+	for _, loaded := range tasks {
+		if loaded.ID == task.ID {
+			assert.Len(t, loaded.Comments, 2)
+		} else {
+			assert.Empty(t, loaded.Comments)
+		}
+	}
+
+	// Purging the task deletes its comments
+	require.NoError(t, repo.Purge(task.ID))
+	comments, err = repo.GetComments(task.ID)
+	require.NoError(t, err)
+	assert.Empty(t, comments)
+}
+
+func TestTaskRepository_TrashAndArchive(t *testing.T) {
+	repo := setupTestRepository(t)
+
+	var tasks []*domain.Task
+	for _, name := range []string{"Shipped", "Dropped", "Open"} {
+		task := domain.NewTask(name, "", "test_project")
+		task.Status = domain.Done
+		require.NoError(t, repo.Create(task))
+		tasks = append(tasks, task)
+	}
+	shipped, dropped := tasks[0], tasks[1]
+
+	require.NoError(t, repo.Archive(shipped.ID))
+	assert.Error(t, repo.Archive(shipped.ID), "A task is archived once")
+	require.NoError(t, repo.Delete(dropped.ID))
+	assert.Error(t, repo.Delete(dropped.ID), "A task is trashed once")
+
+	board, err := repo.GetByStatus("test_project", domain.Done)
+	require.NoError(t, err)
+	require.Len(t, board, 1)
+	assert.Equal(t, "Open", board[0].Name)
+	all, err := repo.GetByProjectID("test_project")
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+
+	archived, err := repo.GetArchived("test_project")
+	require.NoError(t, err)
+	require.Len(t, archived, 1)
+	assert.Equal(t, shipped.ID, archived[0].ID)
+	assert.NotNil(t, archived[0].ArchivedAt)
+	found, err := repo.GetByID(shipped.ID)
+	require.NoError(t, err)
+	require.NotNil(t, found, "Archived tasks can still be looked up")
+
+	trashed, err := repo.GetTrashed("")
+	require.NoError(t, err)
+	require.Len(t, trashed, 1)
+	assert.Equal(t, dropped.ID, trashed[0].ID)
+	assert.NotNil(t, trashed[0].DeletedAt)
+	missing, err := repo.GetByIntID(dropped.IntID)
+	require.NoError(t, err)
+	assert.Nil(t, missing, "Trashed tasks cannot be looked up")
+
+	require.NoError(t, repo.Unarchive(shipped.ID))
+	require.NoError(t, repo.Restore(dropped.ID))
+	assert.Error(t, repo.Restore(dropped.ID), "Only trashed tasks are restored")
+	all, err = repo.GetByProjectID("test_project")
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+
+	require.NoError(t, repo.Delete(dropped.ID))
+	require.NoError(t, repo.Purge(dropped.ID))
+	trashed, err = repo.GetTrashed("test_project")
+	require.NoError(t, err)
+	assert.Empty(t, trashed)
+}
+
+func TestTaskRepository_GetDependencyGraph(t *testing.T) {
+	repo := setupTestRepository(t)
+
+	design := domain.NewTask("Design", "", "test_project")
+	require.NoError(t, repo.Create(design))
+	build := domain.NewTask("Build", "", "test_project")
+	build.BlockedBy = []int{design.IntID}
+	require.NoError(t, repo.Create(build))
+	ship := domain.NewTask("Ship", "", "test_project")
+	ship.BlockedBy = []int{design.IntID, build.IntID}
+	require.NoError(t, repo.Create(ship))
+	require.NoError(t, repo.Delete(build.ID))
+
+	graph, err := repo.GetDependencyGraph("test_project")
+	require.NoError(t, err)
+	assert.Equal(t, domain.DependencyGraph{
+		build.IntID: {design.IntID},
+		ship.IntID:  {design.IntID, build.IntID},
+	}, graph, "The links of trashed tasks are kept")
+
+	other, err := repo.GetDependencyGraph("other_project")
+	require.NoError(t, err)
+	assert.Empty(t, other)
+}
+
+func TestTaskRepository_Search(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	require.NoError(t, (&database.Database{Db: db}).RunMigrations())
+	repo := NewSQLiteTaskRepository(db)
+	projects := NewSQLiteProjectRepository(db)
+
+	web := domain.NewProject("Website", "", "blue")
+	require.NoError(t, projects.Create(web))
+	api := domain.NewProject("API", "", "green")
+	require.NoError(t, projects.Create(api))
+
+	login := domain.NewTask("Fix login redirect", "", web.ID)
+	require.NoError(t, repo.Create(login))
+	session := domain.NewTask("Refresh sessions", "Users are sent back to the login page", api.ID)
+	require.NoError(t, repo.Create(session))
+	other := domain.NewTask("Write docs", "", api.ID)
+	require.NoError(t, repo.Create(other))
+	require.NoError(t, repo.CreateComment(domain.NewComment(other.ID, "ann", "Cover the logging setup")))
+	labels := NewSQLiteLabelRepository(db)
+	urgent := domain.NewLabel(web.ID, "urgent", "red")
+	require.NoError(t, labels.Create(urgent))
+	require.NoError(t, labels.SetTaskLabels(login.ID, []string{urgent.ID}))
+
+	names := func(results []domain.SearchResult) []string {
+		var names []string
+		for _, result := range results {
+			names = append(names, result.Task.Name)
+		}
+		return names
+	}
+
+	results, err := repo.Search("log", domain.MaxSearchResults)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Fix login redirect", "Refresh sessions", "Write docs"}, names(results),
+		"Words match as prefixes, names ranking above descriptions and comments")
+	assert.Equal(t, "Website", results[0].ProjectName)
+	assert.Equal(t, login.IntID, results[0].Task.IntID, "Results carry the whole task")
+	assert.Equal(t, "Fix "+domain.HighlightStart+"login"+domain.HighlightEnd+" redirect", results[0].Name)
+	assert.Contains(t, results[1].Snippet, domain.HighlightStart+"login"+domain.HighlightEnd+" page")
+	assert.Contains(t, results[2].Snippet, domain.HighlightStart+"logging"+domain.HighlightEnd)
+	assert.Equal(t, []string{"urgent"}, results[0].Task.LabelNames(), "Results carry their labels")
+	require.Len(t, results[2].Task.Comments, 1, "Results carry their comments")
+	assert.Equal(t, "ann", results[2].Task.Comments[0].Author)
+
+	results, err = repo.Search(`"login page"`, domain.MaxSearchResults)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Refresh sessions"}, names(results), "Quoted phrases match as written")
+
+	results, err = repo.Search(`login AND (NOT "`, domain.MaxSearchResults)
+	require.NoError(t, err, "FTS5 syntax in the query is searched for, not parsed")
+	assert.Empty(t, results)
+
+	results, err = repo.Search("  - ", domain.MaxSearchResults)
+	require.NoError(t, err)
+	assert.Empty(t, results, "Nothing to search for")
+
+	results, err = repo.Search("log", 1)
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	// The index follows edits, and leaves out archived and trashed tasks
+	login.Name = "Fix signup redirect"
+	require.NoError(t, repo.Update(login))
+	require.NoError(t, repo.Archive(session.ID))
+	require.NoError(t, projects.Delete(api.ID))
+	results, err = repo.Search("log", domain.MaxSearchResults)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	results, err = repo.Search("signup", domain.MaxSearchResults)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Fix signup redirect"}, names(results))
+}
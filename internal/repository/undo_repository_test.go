@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+
+	"kahn/internal/database"
+	"kahn/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+type undoTestRepositories struct {
+	undo     *SQLiteUndoRepository
+	projects *SQLiteProjectRepository
+	tasks    *SQLiteTaskRepository
+	labels   *SQLiteLabelRepository
+	project  *domain.Project
+}
+
+func setupTestUndoRepositories(t *testing.T) undoTestRepositories {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	dbWrapper := &database.Database{Db: db}
+	require.NoError(t, dbWrapper.RunMigrations())
+
+	repos := undoTestRepositories{
+		undo:     NewSQLiteUndoRepository(db),
+		projects: NewSQLiteProjectRepository(db),
+		tasks:    NewSQLiteTaskRepository(db),
+		labels:   NewSQLiteLabelRepository(db),
+		project:  domain.NewProject("Website", "", "blue"),
+	}
+	repos.project.ID = "project_website"
+	require.NoError(t, repos.projects.Create(repos.project))
+	return repos
+}
+
+// createTask stores a task with a label, a comment and a checklist item
+func (repos undoTestRepositories) createTask(t *testing.T, name string, blockers ...int) *domain.Task {
+	task := domain.NewTask(name, "", repos.project.ID)
+	task.ID = "task_" + name
+	task.BlockedBy = blockers
+	require.NoError(t, repos.tasks.Create(task))
+
+	label := domain.NewLabel(repos.project.ID, name, "#89b4fa")
+	label.ID = "label_" + name
+	require.NoError(t, repos.labels.Create(label))
+	require.NoError(t, repos.labels.SetTaskLabels(task.ID, []string{label.ID}))
+
+	comment := domain.NewComment(task.ID, "alice", "About "+name)
+	comment.ID = "comment_" + name
+	require.NoError(t, repos.tasks.CreateComment(comment))
+	item := domain.NewChecklistItem(task.ID, "Step of "+name, 0)
+	item.ID = "item_" + name
+	require.NoError(t, repos.tasks.CreateChecklistItem(item))
+
+	stored := repos.snapshot(t, task.ID).Task
+	return &stored
+}
+
+func (repos undoTestRepositories) snapshot(t *testing.T, taskID string) domain.TaskSnapshot {
+	task, err := repos.tasks.GetByID(taskID)
+	require.NoError(t, err)
+	require.NotNil(t, task)
+	checklist, err := repos.tasks.GetChecklist(taskID)
+	require.NoError(t, err)
+	return domain.TaskSnapshot{Task: *task, Checklist: checklist}
+}
+
+func TestUndoRepository_Stack(t *testing.T) {
+	repos := setupTestUndoRepositories(t)
+
+	last, err := repos.undo.LastDone()
+	require.NoError(t, err)
+	assert.Nil(t, last, "An empty stack has nothing to undo")
+
+	for _, description := range []string{"first", "second", "third"} {
+		op := domain.UndoOperation{ProjectID: repos.project.ID, Subject: "Website", Description: description}
+		require.NoError(t, repos.undo.Push(&op))
+		assert.NotZero(t, op.ID)
+	}
+
+	last, err = repos.undo.LastDone()
+	require.NoError(t, err)
+	require.NotNil(t, last)
+	assert.Equal(t, "third", last.Description)
+	require.NoError(t, repos.undo.Apply(last, true))
+	second, _ := repos.undo.LastDone()
+	require.NoError(t, repos.undo.Apply(second, true))
+
+	redo, err := repos.undo.FirstUndone()
+	require.NoError(t, err)
+	assert.Equal(t, "second", redo.Description, "The most recently undone operation is redone first")
+
+	op := domain.UndoOperation{ProjectID: repos.project.ID, Subject: "Website", Description: "fourth"}
+	require.NoError(t, repos.undo.Push(&op))
+	redo, err = repos.undo.FirstUndone()
+	require.NoError(t, err)
+	assert.Nil(t, redo, "A new change discards the redo stack")
+
+	require.NoError(t, repos.undo.Drop(op.ID))
+	last, _ = repos.undo.LastDone()
+	assert.Equal(t, "first", last.Description)
+
+	for i := 0; i < domain.MaxUndoOperations+5; i++ {
+		require.NoError(t, repos.undo.Push(&domain.UndoOperation{ProjectID: repos.project.ID, Subject: "Website", Description: "more"}))
+	}
+	var count int
+	require.NoError(t, repos.undo.base.db.QueryRow(`SELECT COUNT(*) FROM undo_operations`).Scan(&count))
+	assert.Equal(t, domain.MaxUndoOperations, count, "Only the newest operations are kept")
+}
+
+func TestUndoRepository_TaskCreateAndRestore(t *testing.T) {
+	repos := setupTestUndoRepositories(t)
+	task := repos.createTask(t, "schema")
+
+	snapshot := repos.snapshot(t, task.ID)
+	op := domain.NewTaskCreatedUndo(snapshot)
+	require.NoError(t, repos.undo.Push(&op))
+	require.NoError(t, repos.undo.Apply(&op, true))
+	gone, err := repos.tasks.GetByID(task.ID)
+	require.NoError(t, err)
+	assert.Nil(t, gone)
+	trashed, err := repos.tasks.GetTrashed("")
+	require.NoError(t, err)
+	assert.Empty(t, trashed, "Undoing a create deletes the task for good")
+
+	require.NoError(t, repos.undo.Apply(&op, false))
+	restored := repos.snapshot(t, task.ID)
+	assert.Equal(t, task.IntID, restored.Task.IntID, "The task keeps its number")
+	assert.Equal(t, []string{"schema"}, restored.Task.LabelNames())
+	require.Len(t, restored.Task.Comments, 1)
+	assert.Equal(t, "About schema", restored.Task.Comments[0].Body)
+	require.Len(t, restored.Checklist, 1)
+	assert.Equal(t, "Step of schema", restored.Checklist[0].Text)
+
+	err = repos.undo.Apply(&op, false)
+	assert.IsType(t, &domain.ValidationError{}, err, "Restoring a task that exists fails")
+}
+
+func TestUndoRepository_TaskTrashAndArchive(t *testing.T) {
+	repos := setupTestUndoRepositories(t)
+	blocker := repos.createTask(t, "schema")
+	dependent := repos.createTask(t, "migration", blocker.IntID)
+
+	op := domain.NewTaskDeletedUndo(*blocker)
+	require.NoError(t, repos.tasks.Delete(blocker.ID))
+	require.NoError(t, repos.undo.Push(&op))
+	assert.Empty(t, repos.snapshot(t, dependent.ID).Task.BlockedBy, "A trashed blocker does not count")
+
+	loaded, _ := repos.undo.LastDone()
+	require.NoError(t, repos.undo.Apply(loaded, true))
+	restored := repos.snapshot(t, blocker.ID)
+	assert.Nil(t, restored.Task.DeletedAt)
+	assert.Len(t, restored.Checklist, 1)
+	assert.Equal(t, []int{blocker.IntID}, repos.snapshot(t, dependent.ID).Task.BlockedBy, "Dependents wait on it again")
+
+	require.NoError(t, repos.undo.Apply(loaded, false))
+	gone, err := repos.tasks.GetByID(blocker.ID)
+	require.NoError(t, err)
+	assert.Nil(t, gone)
+	err = repos.undo.Apply(loaded, false)
+	assert.IsType(t, &domain.ValidationError{}, err, "Trashing a trashed task fails")
+
+	require.NoError(t, repos.tasks.Purge(blocker.ID))
+	err = repos.undo.Apply(loaded, true)
+	assert.IsType(t, &domain.ValidationError{}, err, "A purged task cannot be restored")
+
+	archived := domain.NewTaskArchivedUndo(*dependent)
+	require.NoError(t, repos.tasks.Archive(dependent.ID))
+	require.NoError(t, repos.undo.Apply(&archived, true))
+	onBoard, err := repos.tasks.GetByProjectID(repos.project.ID)
+	require.NoError(t, err)
+	assert.Len(t, onBoard, 1, "Undoing an archive puts the task back on the board")
+	require.NoError(t, repos.undo.Apply(&archived, false))
+	onBoard, _ = repos.tasks.GetByProjectID(repos.project.ID)
+	assert.Empty(t, onBoard)
+}
+
+func TestUndoRepository_TaskUpdate(t *testing.T) {
+	repos := setupTestUndoRepositories(t)
+	blocker := repos.createTask(t, "schema")
+	task := repos.createTask(t, "migration")
+
+	before := repos.snapshot(t, task.ID).Task
+	after := before
+	after.Name = "migrations"
+	after.Status = domain.Done
+	after.BlockedBy = []int{blocker.IntID}
+	after.Labels = nil
+	op := domain.NewTaskChangedUndo("edit", before, after, nil)
+
+	require.NoError(t, repos.undo.Push(&op))
+	require.NoError(t, repos.undo.Apply(&op, false))
+	stored := repos.snapshot(t, task.ID).Task
+	assert.Equal(t, "migrations", stored.Name)
+	assert.Equal(t, domain.Done, stored.Status)
+	assert.Equal(t, []int{blocker.IntID}, stored.BlockedBy)
+	assert.Empty(t, stored.Labels)
+
+	require.NoError(t, repos.undo.Apply(&op, true))
+	assert.True(t, domain.SameTaskFields(before, repos.snapshot(t, task.ID).Task))
+	assert.Len(t, repos.snapshot(t, task.ID).Checklist, 1, "The checklist is left alone")
+
+	require.NoError(t, repos.tasks.Purge(task.ID))
+	err := repos.undo.Apply(&op, false)
+	assert.IsType(t, &domain.ValidationError{}, err, "Updating a deleted task fails")
+}
+
+func TestUndoRepository_ProjectDeleteAndRestore(t *testing.T) {
+	repos := setupTestUndoRepositories(t)
+	blocker := repos.createTask(t, "schema")
+	dependent := repos.createTask(t, "migration", blocker.IntID)
+
+	op := domain.NewProjectDeletedUndo(*repos.project, 2)
+	assert.Equal(t, "delete (2 tasks)", op.Description)
+
+	require.NoError(t, repos.projects.Delete(repos.project.ID))
+	hidden, err := repos.tasks.GetByID(dependent.ID)
+	require.NoError(t, err)
+	assert.Nil(t, hidden, "The tasks of a trashed project are hidden")
+
+	require.NoError(t, repos.undo.Push(&op))
+	require.NoError(t, repos.undo.Apply(&op, true))
+
+	restored, err := repos.projects.GetByID(repos.project.ID)
+	require.NoError(t, err)
+	require.NotNil(t, restored)
+	assert.Equal(t, domain.DefaultWorkflow(), restored.Workflow)
+	tasks, err := repos.tasks.GetByProjectID(repos.project.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	restoredDependent := repos.snapshot(t, dependent.ID)
+	assert.Equal(t, []int{blocker.IntID}, restoredDependent.Task.BlockedBy)
+	assert.Equal(t, []string{"migration"}, restoredDependent.Task.LabelNames())
+
+	require.NoError(t, repos.undo.Apply(&op, false))
+	gone, err := repos.projects.GetByID(repos.project.ID)
+	require.NoError(t, err)
+	assert.Nil(t, gone)
+
+	require.NoError(t, repos.projects.Purge(repos.project.ID))
+	err = repos.undo.Apply(&op, true)
+	assert.IsType(t, &domain.ValidationError{}, err, "A purged project cannot be restored")
+}
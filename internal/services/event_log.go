@@ -0,0 +1,148 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"kahn/internal/domain"
+)
+
+// EventLog appends the changes made through the services to the audit log,
+// signed by the current actor. Services without an event log record nothing,
+// and so does a nil *EventLog.
+type EventLog struct {
+	repo  domain.EventRepository
+	actor string
+}
+
+func NewEventLog(repo domain.EventRepository, actor string) *EventLog {
+	return &EventLog{
+		repo:  repo,
+		actor: actor,
+	}
+}
+
+// SetActor chooses the name recorded with later events
+func (l *EventLog) SetActor(actor string) {
+	l.actor = actor
+}
+
+// List returns the events matching filter, oldest first
+func (l *EventLog) List(filter domain.EventFilter) ([]domain.Event, error) {
+	events, err := l.repo.List(filter)
+	if err != nil {
+		return nil, domain.NewRepositoryError("list", "events", filter.ProjectID, err)
+	}
+	return events, nil
+}
+
+// record appends an event stamped with the actor and the current time. The
+// change it describes is already saved, so a failed append is ignored rather
+// than reported as a failed change.
+func (l *EventLog) record(event domain.Event) {
+	if l == nil {
+		return
+	}
+	event.Actor = l.actor
+	event.CreatedAt = time.Now()
+	_ = l.repo.Append(&event)
+}
+
+// RecordUndo records that op was undone, or redone when undone is false. Undo
+// writes straight to the repositories, so this one event stands for the change.
+func (l *EventLog) RecordUndo(op domain.UndoOperation, undone bool) {
+	kind := domain.EventRedone
+	if undone {
+		kind = domain.EventUndone
+	}
+	l.record(domain.Event{
+		ProjectID: op.ProjectID,
+		TaskID:    op.TaskID,
+		TaskIntID: op.TaskIntID,
+		Subject:   op.Subject,
+		Kind:      kind,
+		NewValue:  op.Description,
+	})
+}
+
+func (l *EventLog) recordTask(task *domain.Task, kind domain.EventKind, oldValue, newValue string) {
+	l.record(domain.Event{
+		ProjectID: task.ProjectID,
+		TaskID:    task.ID,
+		TaskIntID: task.IntID,
+		Subject:   task.Name,
+		Kind:      kind,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+	})
+}
+
+// recordTaskChange records a change of one of the task's fields, if it changed
+func (l *EventLog) recordTaskChange(task *domain.Task, field, oldValue, newValue string) {
+	if oldValue == newValue {
+		return
+	}
+	l.record(domain.Event{
+		ProjectID: task.ProjectID,
+		TaskID:    task.ID,
+		TaskIntID: task.IntID,
+		Subject:   task.Name,
+		Kind:      domain.EventChanged,
+		Field:     field,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+	})
+}
+
+// recordBlockers records every blocker the task gained or lost
+func (l *EventLog) recordBlockers(task *domain.Task, oldBlockers, newBlockers []int) {
+	for _, blocker := range newBlockers {
+		if !slices.Contains(oldBlockers, blocker) {
+			l.recordTask(task, domain.EventBlocked, "", fmt.Sprintf("#%d", blocker))
+		}
+	}
+	for _, blocker := range oldBlockers {
+		if !slices.Contains(newBlockers, blocker) {
+			l.recordTask(task, domain.EventUnblocked, fmt.Sprintf("#%d", blocker), "")
+		}
+	}
+}
+
+func (l *EventLog) recordProject(project *domain.Project, kind domain.EventKind, field, oldValue, newValue string) {
+	l.record(domain.Event{
+		ProjectID: project.ID,
+		Subject:   project.Name,
+		Kind:      kind,
+		Field:     field,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+	})
+}
+
+// recordProjectChange records a change of one of the project's fields, if it changed
+func (l *EventLog) recordProjectChange(project *domain.Project, field, oldValue, newValue string) {
+	if oldValue == newValue {
+		return
+	}
+	l.recordProject(project, domain.EventChanged, field, oldValue, newValue)
+}
+
+// workflowColumns lists a workflow's column names for a changed event
+func workflowColumns(workflow domain.Workflow) string {
+	names := make([]string, 0, workflow.Len())
+	for _, status := range workflow.Statuses() {
+		names = append(names, workflow.Name(status))
+	}
+	return strings.Join(names, ", ")
+}
+
+// wipLimitValue renders a column's WIP limit for a changed event; no limit is empty
+func wipLimitValue(workflow domain.Workflow, status domain.Status) string {
+	if limit := workflow.WIPLimit(status); limit > 0 {
+		return strconv.Itoa(limit)
+	}
+	return ""
+}
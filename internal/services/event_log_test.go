@@ -0,0 +1,94 @@
+package services
+
+import (
+	"kahn/internal/domain"
+	"testing"
+)
+
+func TestEventLog_RecordsTaskChanges(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	testProject := domain.NewProject("Test Project", "Test Description", "blue")
+	projectRepo.Create(testProject)
+	service := NewTaskService(taskRepo, projectRepo)
+	eventRepo := NewMockEventRepository()
+	events := NewEventLog(eventRepo, "alice")
+	service.SetEventLog(events)
+
+	blocker, _ := service.CreateTask("Design schema", "", testProject.ID, domain.Feature, domain.Medium, nil)
+	task, _ := service.CreateTask("Write migration", "", testProject.ID, domain.Feature, domain.Low, []int{blocker.IntID})
+
+	events.SetActor("bob")
+	service.UpdateTask(task.ID, "Write migrations", "", domain.Feature, domain.High)
+	service.UpdateTaskStatus(blocker.ID, domain.Done)
+	service.DeleteTask(blocker.ID)
+
+	history, err := events.List(domain.EventFilter{TaskIntID: task.IntID})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []string{
+		"created in Not Started",
+		"now waits on #1",
+		"name: Write migration → Write migrations",
+		"priority: Low → High",
+		"no longer waits on #1",
+	}
+	if len(history) != len(expected) {
+		t.Fatalf("Expected %d events, got %+v", len(expected), history)
+	}
+	for i, event := range history {
+		if event.Describe() != expected[i] {
+			t.Errorf("Event %d: expected %q, got %q", i, expected[i], event.Describe())
+		}
+	}
+	if history[0].Actor != "alice" || history[2].Actor != "bob" {
+		t.Errorf("Expected actors alice then bob, got %q and %q", history[0].Actor, history[2].Actor)
+	}
+
+	blockerHistory, _ := events.List(domain.EventFilter{TaskIntID: blocker.IntID})
+	if last := blockerHistory[len(blockerHistory)-1]; last.Kind != domain.EventDeleted || last.Subject != "Design schema" {
+		t.Errorf("Expected the blocker's last event to be its deletion, got %+v", last)
+	}
+	if blockerHistory[1].Describe() != "moved Not Started → Done" {
+		t.Errorf("Expected the move to be recorded, got %q", blockerHistory[1].Describe())
+	}
+}
+
+func TestEventLog_RecordsProjectChanges(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	service := NewProjectService(projectRepo, taskRepo)
+	events := NewEventLog(NewMockEventRepository(), "alice")
+	service.SetEventLog(events)
+
+	project, _ := service.CreateProject("Website", "")
+	service.UpdateProject(project.ID, "Website", "Marketing site")
+	service.SetWIPLimit(project.ID, domain.InProgress, 3)
+	service.DeleteProject(project.ID)
+
+	history, _ := events.List(domain.EventFilter{ProjectID: project.ID})
+	expected := []string{
+		"created",
+		"description: (none) → Marketing site",
+		"wip_limit(In Progress): (none) → 3",
+		"deleted",
+	}
+	if len(history) != len(expected) {
+		t.Fatalf("Expected %d events, got %+v", len(expected), history)
+	}
+	for i, event := range history {
+		if event.IsTaskEvent() {
+			t.Errorf("Event %d: expected a project event", i)
+		}
+		if event.Describe() != expected[i] {
+			t.Errorf("Event %d: expected %q, got %q", i, expected[i], event.Describe())
+		}
+	}
+}
+
+func TestEventLog_Nil(t *testing.T) {
+	var events *EventLog
+	// A service without an event log records nothing and must not panic
+	events.recordTask(&domain.Task{}, domain.EventCreated, "", "")
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"kahn/internal/domain"
+)
+
+type LabelService struct {
+	labelRepo   domain.LabelRepository
+	projectRepo domain.ProjectRepository
+	taskRepo    domain.TaskRepository
+	validator   *ServiceValidator
+	events      *EventLog
+}
+
+func NewLabelService(labelRepo domain.LabelRepository, projectRepo domain.ProjectRepository, taskRepo domain.TaskRepository) *LabelService {
+	return &LabelService{
+		labelRepo:   labelRepo,
+		projectRepo: projectRepo,
+		taskRepo:    taskRepo,
+		validator:   NewServiceValidator(),
+	}
+}
+
+// SetEventLog chooses where changes to task labels are recorded
+func (ls *LabelService) SetEventLog(events *EventLog) {
+	ls.events = events
+}
+
+// CreateLabel adds a label to a project. An empty color picks the next one from
+// domain.LabelColors.
+func (ls *LabelService) CreateLabel(projectID, name, color string) (*domain.Label, error) {
+	if _, err := ls.validator.ValidateProjectExists(ls.projectRepo, projectID); err != nil {
+		return nil, err
+	}
+
+	labels, err := ls.GetLabels(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if findLabel(labels, name) != nil {
+		return nil, domain.NewValidationError("name", fmt.Sprintf("label %q already exists", strings.TrimSpace(name)))
+	}
+
+	if color == "" {
+		color = domain.LabelColors[len(labels)%len(domain.LabelColors)]
+	}
+	label := domain.NewLabel(projectID, name, color)
+	if err := label.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := ls.labelRepo.Create(label); err != nil {
+		return nil, domain.NewRepositoryError("create", "label", label.ID, err)
+	}
+	return label, nil
+}
+
+// GetLabels returns a project's labels sorted by name
+func (ls *LabelService) GetLabels(projectID string) ([]domain.Label, error) {
+	if err := ls.validator.ValidateEntityID(projectID, "project"); err != nil {
+		return nil, err
+	}
+
+	labels, err := ls.labelRepo.GetByProjectID(projectID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("get by project", "labels", projectID, err)
+	}
+	return labels, nil
+}
+
+// GetLabelByName finds a project's label by name, ignoring case
+func (ls *LabelService) GetLabelByName(projectID, name string) (*domain.Label, error) {
+	labels, err := ls.GetLabels(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	label := findLabel(labels, name)
+	if label == nil {
+		return nil, domain.NewValidationError("label", fmt.Sprintf("label %q not found", name))
+	}
+	return label, nil
+}
+
+// UpdateLabel renames or recolors a label; the tasks carrying it keep it
+func (ls *LabelService) UpdateLabel(id, name, color string) (*domain.Label, error) {
+	label, err := ls.labelRepo.GetByID(id)
+	if err != nil {
+		return nil, domain.NewRepositoryError("get", "label", id, err)
+	}
+	if label == nil {
+		return nil, domain.NewValidationError("id", "label not found")
+	}
+
+	if !strings.EqualFold(strings.TrimSpace(name), label.Name) {
+		labels, err := ls.GetLabels(label.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if findLabel(labels, name) != nil {
+			return nil, domain.NewValidationError("name", fmt.Sprintf("label %q already exists", strings.TrimSpace(name)))
+		}
+	}
+
+	label.Name = strings.TrimSpace(name)
+	label.Color = color
+	if err := label.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := ls.labelRepo.Update(label); err != nil {
+		return nil, domain.NewRepositoryError("update", "label", id, err)
+	}
+	return label, nil
+}
+
+// DeleteLabel removes a label from its project and from every task carrying it
+func (ls *LabelService) DeleteLabel(id string) error {
+	if err := ls.validator.ValidateEntityID(id, "label"); err != nil {
+		return err
+	}
+
+	if err := ls.labelRepo.Delete(id); err != nil {
+		return domain.NewRepositoryError("delete", "label", id, err)
+	}
+	return nil
+}
+
+// SetTaskLabels replaces the labels of a task with the named labels of its
+// project, creating any that do not exist yet
+func (ls *LabelService) SetTaskLabels(taskID string, names []string) (*domain.Task, error) {
+	task, err := ls.validator.ValidateTaskExists(ls.taskRepo, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) > domain.MaxLabelsPerTask {
+		return nil, domain.NewValidationError("labels", fmt.Sprintf("too many labels (max %d)", domain.MaxLabelsPerTask))
+	}
+	for _, name := range names {
+		if err := domain.ValidateLabelName(name); err != nil {
+			return nil, err
+		}
+	}
+
+	labels, err := ls.GetLabels(task.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := strings.Join(task.LabelNames(), ", ")
+	task.Labels = make([]domain.Label, 0, len(names))
+	labelIDs := make([]string, 0, len(names))
+	for _, name := range names {
+		label := findLabel(labels, name)
+		if label == nil {
+			label, err = ls.CreateLabel(task.ProjectID, name, "")
+			if err != nil {
+				return nil, err
+			}
+			labels = append(labels, *label)
+		}
+		if task.HasLabel(label.Name) {
+			continue
+		}
+		task.Labels = append(task.Labels, *label)
+		labelIDs = append(labelIDs, label.ID)
+	}
+
+	if err := ls.labelRepo.SetTaskLabels(task.ID, labelIDs); err != nil {
+		return nil, domain.NewRepositoryError("set labels of", "task", task.ID, err)
+	}
+
+	domain.SortLabels(task.Labels)
+	ls.events.recordTaskChange(task, "labels", previous, strings.Join(task.LabelNames(), ", "))
+	return task, nil
+}
+
+func findLabel(labels []domain.Label, name string) *domain.Label {
+	name = strings.TrimSpace(name)
+	for i := range labels {
+		if strings.EqualFold(labels[i].Name, name) {
+			return &labels[i]
+		}
+	}
+	return nil
+}
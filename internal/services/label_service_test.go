@@ -0,0 +1,134 @@
+package services
+
+import (
+	"testing"
+
+	"kahn/internal/domain"
+)
+
+func setupLabelService(t *testing.T) (*LabelService, *MockLabelRepository, *domain.Project, *domain.Task) {
+	t.Helper()
+
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	labelRepo := NewMockLabelRepository()
+
+	project := domain.NewProject("Test Project", "Test Description", "#89b4fa")
+	projectRepo.Create(project)
+	task := domain.NewTask("Test Task", "", project.ID)
+	taskRepo.Create(task)
+
+	return NewLabelService(labelRepo, projectRepo, taskRepo), labelRepo, project, task
+}
+
+func TestLabelService_CreateLabel(t *testing.T) {
+	service, _, project, _ := setupLabelService(t)
+
+	label, err := service.CreateLabel(project.ID, "backend", "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if label.Color != domain.LabelColors[0] {
+		t.Errorf("Expected first palette color, got %s", label.Color)
+	}
+
+	second, _ := service.CreateLabel(project.ID, "frontend", "")
+	if second.Color != domain.LabelColors[1] {
+		t.Errorf("Expected second palette color, got %s", second.Color)
+	}
+
+	tests := []struct {
+		name      string
+		projectID string
+		label     string
+		color     string
+	}{
+		{"duplicate name ignoring case", project.ID, "Backend", ""},
+		{"invalid name", project.ID, "tech debt", ""},
+		{"invalid color", project.ID, "ops", "red"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreateLabel(tt.projectID, tt.label, tt.color)
+			if _, ok := err.(*domain.ValidationError); !ok {
+				t.Errorf("Expected ValidationError, got %v", err)
+			}
+		})
+	}
+
+	if _, err := service.CreateLabel("proj_missing", "ops", ""); err == nil {
+		t.Error("Expected an unknown project to be rejected")
+	}
+}
+
+func TestLabelService_UpdateLabel(t *testing.T) {
+	service, _, project, _ := setupLabelService(t)
+	backend, _ := service.CreateLabel(project.ID, "backend", "")
+	service.CreateLabel(project.ID, "frontend", "")
+
+	updated, err := service.UpdateLabel(backend.ID, "api", "#f38ba8")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.Name != "api" || updated.Color != "#f38ba8" {
+		t.Errorf("Unexpected label %+v", updated)
+	}
+
+	if _, err := service.UpdateLabel(backend.ID, "API", "#f38ba8"); err != nil {
+		t.Errorf("Expected changing only the case to succeed, got %v", err)
+	}
+	if _, err := service.UpdateLabel(backend.ID, "frontend", "#f38ba8"); err == nil {
+		t.Error("Expected renaming onto another label to fail")
+	}
+}
+
+func TestLabelService_SetTaskLabels(t *testing.T) {
+	service, labelRepo, project, task := setupLabelService(t)
+	existing, _ := service.CreateLabel(project.ID, "backend", "#f38ba8")
+
+	updated, err := service.SetTaskLabels(task.ID, []string{"tech-debt", "Backend"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	names := updated.LabelNames()
+	if len(names) != 2 || names[0] != "backend" || names[1] != "tech-debt" {
+		t.Errorf("Expected [backend tech-debt], got %v", names)
+	}
+	if updated.Labels[0].ID != existing.ID {
+		t.Error("Expected the existing label to be reused")
+	}
+
+	labels, _ := service.GetLabels(project.ID)
+	if len(labels) != 2 {
+		t.Errorf("Expected the missing label to be created, got %d labels", len(labels))
+	}
+	if len(labelRepo.taskLabels[task.ID]) != 2 {
+		t.Errorf("Expected 2 stored links, got %d", len(labelRepo.taskLabels[task.ID]))
+	}
+
+	if _, err := service.SetTaskLabels(task.ID, []string{"has space"}); err == nil {
+		t.Error("Expected an invalid label name to be rejected")
+	}
+
+	cleared, err := service.SetTaskLabels(task.ID, nil)
+	if err != nil || len(cleared.Labels) != 0 {
+		t.Errorf("Expected labels to be cleared, got %v (%v)", cleared, err)
+	}
+}
+
+func TestLabelService_DeleteLabel(t *testing.T) {
+	service, labelRepo, project, task := setupLabelService(t)
+	service.SetTaskLabels(task.ID, []string{"backend", "frontend"})
+	backend, _ := service.GetLabelByName(project.ID, "BACKEND")
+
+	if err := service.DeleteLabel(backend.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.GetLabelByName(project.ID, "backend"); err == nil {
+		t.Error("Expected the label to be gone")
+	}
+	if len(labelRepo.taskLabels[task.ID]) != 1 {
+		t.Errorf("Expected the task to keep only frontend, got %v", labelRepo.taskLabels[task.ID])
+	}
+}
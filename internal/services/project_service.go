@@ -1,6 +1,7 @@
 package services
 
 import (
+	"fmt"
 	"kahn/internal/domain"
 )
 
@@ -8,6 +9,7 @@ type ProjectService struct {
 	projectRepo domain.ProjectRepository
 	taskRepo    domain.TaskRepository
 	validator   *ServiceValidator
+	events      *EventLog
 }
 
 func NewProjectService(projectRepo domain.ProjectRepository, taskRepo domain.TaskRepository) *ProjectService {
@@ -18,6 +20,11 @@ func NewProjectService(projectRepo domain.ProjectRepository, taskRepo domain.Tas
 	}
 }
 
+// SetEventLog chooses where project changes are recorded
+func (ps *ProjectService) SetEventLog(events *EventLog) {
+	ps.events = events
+}
+
 func (ps *ProjectService) CreateProject(name, description string) (*domain.Project, error) {
 	validator := domain.NewFieldValidator()
 	if err := validator.ValidateNotEmpty("name", name, "project"); err != nil {
@@ -33,6 +40,7 @@ func (ps *ProjectService) CreateProject(name, description string) (*domain.Proje
 	if err := ps.projectRepo.Create(project); err != nil {
 		return nil, domain.NewRepositoryError("create", "project", project.ID, err)
 	}
+	ps.events.recordProject(project, domain.EventCreated, "", "", "")
 
 	return project, nil
 }
@@ -70,18 +78,75 @@ func (ps *ProjectService) UpdateProject(id, name, description string) (*domain.P
 		return nil, err
 	}
 
+	before := *project
 	project.Name = name
 	project.Description = description
 
+	if err := project.Validate(); err != nil {
+		return nil, err
+	}
+
 	if err := ps.projectRepo.Update(project); err != nil {
 		return nil, domain.NewRepositoryError("update", "project", id, err)
 	}
+	ps.events.recordProjectChange(project, "name", before.Name, project.Name)
+	ps.events.recordProjectChange(project, "description", before.Description, project.Description)
+
+	return project, nil
+}
+
+// SetWorkflow replaces a project's columns. Tasks follow their column by name and
+// are otherwise moved as described by Workflow.RemapStatuses.
+func (ps *ProjectService) SetWorkflow(id string, workflow domain.Workflow) (*domain.Project, error) {
+	project, err := ps.validator.ValidateProjectExists(ps.projectRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := workflow.Validate(); err != nil {
+		return nil, err
+	}
+
+	previous := workflowColumns(project.Workflow)
+	remap := project.Workflow.RemapStatuses(workflow)
+	if err := ps.projectRepo.SaveWorkflow(id, workflow, remap); err != nil {
+		return nil, domain.NewRepositoryError("save workflow for", "project", id, err)
+	}
 
+	ps.events.recordProjectChange(project, "workflow", previous, workflowColumns(workflow))
+	project.Workflow = workflow
 	return project, nil
 }
 
+// SetWIPLimit sets the maximum number of tasks in one column of a project's
+// workflow; a limit of 0 removes it. Tasks already in the column are not moved.
+func (ps *ProjectService) SetWIPLimit(id string, status domain.Status, limit int) (*domain.Project, error) {
+	project, err := ps.validator.ValidateProjectExists(ps.projectRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if !project.Workflow.Contains(status) {
+		return nil, domain.NewValidationError("status", fmt.Sprintf("status %d is not part of the project's workflow", status))
+	}
+
+	workflow := project.Workflow.WithWIPLimit(status, limit)
+	if err := workflow.Validate(); err != nil {
+		return nil, err
+	}
+
+	previous := wipLimitValue(project.Workflow, status)
+	if err := ps.projectRepo.SaveWorkflow(id, workflow, nil); err != nil {
+		return nil, domain.NewRepositoryError("save workflow for", "project", id, err)
+	}
+
+	ps.events.recordProjectChange(project, fmt.Sprintf("wip_limit(%s)", workflow.Name(status)), previous, wipLimitValue(workflow, status))
+	project.Workflow = workflow
+	return project, nil
+}
+
+// DeleteProject moves the project to the trash with its tasks
 func (ps *ProjectService) DeleteProject(id string) error {
-	_, err := ps.validator.ValidateProjectExists(ps.projectRepo, id)
+	project, err := ps.validator.ValidateProjectExists(ps.projectRepo, id)
 	if err != nil {
 		return err
 	}
@@ -89,10 +154,69 @@ func (ps *ProjectService) DeleteProject(id string) error {
 	if err := ps.projectRepo.Delete(id); err != nil {
 		return domain.NewRepositoryError("delete", "project", id, err)
 	}
+	ps.events.recordProject(project, domain.EventDeleted, "", "", "")
+
+	return nil
+}
+
+// GetTrashedProjects returns the projects in the trash, most recently deleted first
+func (ps *ProjectService) GetTrashedProjects() ([]domain.Project, error) {
+	projects, err := ps.projectRepo.GetTrashed()
+	if err != nil {
+		return nil, domain.NewRepositoryError("get trashed", "projects", "", err)
+	}
+	return projects, nil
+}
+
+// RestoreProject takes the project out of the trash with its tasks
+func (ps *ProjectService) RestoreProject(id string) (*domain.Project, error) {
+	project, err := ps.trashedProject(id)
+	if err != nil {
+		return nil, err
+	}
 
+	if err := ps.projectRepo.Restore(id); err != nil {
+		return nil, domain.NewRepositoryError("restore", "project", id, err)
+	}
+	ps.events.recordProject(project, domain.EventRestored, "", "", "")
+
+	project.DeletedAt = nil
+	return project, nil
+}
+
+// PurgeProject deletes a project in the trash for good with its workflow,
+// labels and tasks
+func (ps *ProjectService) PurgeProject(id string) error {
+	project, err := ps.trashedProject(id)
+	if err != nil {
+		return err
+	}
+
+	if err := ps.projectRepo.Purge(id); err != nil {
+		return domain.NewRepositoryError("purge", "project", id, err)
+	}
+	ps.events.recordProject(project, domain.EventPurged, "", "", "")
 	return nil
 }
 
+// trashedProject finds a project in the trash by ID
+func (ps *ProjectService) trashedProject(id string) (*domain.Project, error) {
+	if err := ps.validator.ValidateEntityID(id, "project"); err != nil {
+		return nil, err
+	}
+
+	projects, err := ps.GetTrashedProjects()
+	if err != nil {
+		return nil, err
+	}
+	for i := range projects {
+		if projects[i].ID == id {
+			return &projects[i], nil
+		}
+	}
+	return nil, domain.NewValidationError("id", "project is not in the trash")
+}
+
 func (ps *ProjectService) GetProjectWithTasks(id string) (*domain.Project, error) {
 	if err := ps.validator.ValidateEntityID(id, "project"); err != nil {
 		return nil, err
@@ -114,3 +238,22 @@ func (ps *ProjectService) GetProjectWithTasks(id string) (*domain.Project, error
 	project.Tasks = tasks
 	return project, nil
 }
+
+// GetMyWork gathers the My Work board from every project: the high-priority
+// tasks waiting to start and the tasks in progress, one slice per column
+func (ps *ProjectService) GetMyWork() ([][]domain.MyWorkTask, error) {
+	projects, err := ps.GetAllProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range projects {
+		tasks, err := ps.taskRepo.GetByProjectID(projects[i].ID)
+		if err != nil {
+			return nil, domain.NewRepositoryError("get tasks for", "project", projects[i].ID, err)
+		}
+		projects[i].Tasks = tasks
+	}
+
+	return domain.CollectMyWork(projects), nil
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"kahn/internal/domain"
+	"strings"
 	"testing"
 )
 
@@ -107,3 +108,176 @@ func TestProjectService_DeleteProject(t *testing.T) {
 		}
 	})
 }
+
+func TestProjectService_UpdateProject(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	service := NewProjectService(projectRepo, taskRepo)
+
+	testProject := domain.NewProject("Test Project", "Test Description", "#89b4fa")
+	projectRepo.projects = []domain.Project{*testProject}
+
+	t.Run("successful rename", func(t *testing.T) {
+		project, err := service.UpdateProject(testProject.ID, "Renamed", "Test Description")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if project.Name != "Renamed" {
+			t.Errorf("Expected project name 'Renamed', got '%s'", project.Name)
+		}
+	})
+
+	t.Run("name over max length is rejected", func(t *testing.T) {
+		longName := strings.Repeat("x", domain.MaxProjectNameLength+1)
+		_, err := service.UpdateProject(testProject.ID, longName, "")
+		if _, ok := err.(*domain.ValidationError); !ok {
+			t.Errorf("Expected ValidationError, got %v", err)
+		}
+	})
+}
+
+func TestProjectService_SetWorkflow(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	projectRepo.tasks = taskRepo
+	service := NewProjectService(projectRepo, taskRepo)
+
+	testProject := domain.NewProject("Test Project", "Test Description", "#89b4fa")
+	projectRepo.Create(testProject)
+
+	doneTask := domain.NewTask("Shipped", "", testProject.ID)
+	doneTask.Status = domain.Done
+	taskRepo.Create(doneTask)
+
+	workflow, err := domain.NewWorkflow([]string{"Backlog", "In Progress", "In Review", "QA", "Done"}, "")
+	if err != nil {
+		t.Fatalf("Expected valid workflow, got %v", err)
+	}
+
+	t.Run("tasks follow their column by name", func(t *testing.T) {
+		project, err := service.SetWorkflow(testProject.ID, workflow)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if project.Workflow.Len() != 5 {
+			t.Errorf("Expected 5 columns, got %d", project.Workflow.Len())
+		}
+
+		task, _ := taskRepo.GetByID(doneTask.ID)
+		if task.Status != domain.Status(4) {
+			t.Errorf("Expected task to move to Done at position 4, got %d", task.Status)
+		}
+	})
+
+	t.Run("invalid workflow is rejected", func(t *testing.T) {
+		_, err := service.SetWorkflow(testProject.ID, domain.Workflow{{Name: "Open"}})
+		if _, ok := err.(*domain.ValidationError); !ok {
+			t.Errorf("Expected ValidationError, got %v", err)
+		}
+	})
+}
+
+func TestProjectService_SetWIPLimit(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	service := NewProjectService(projectRepo, taskRepo)
+
+	testProject := domain.NewProject("Test Project", "Test Description", "#89b4fa")
+	projectRepo.Create(testProject)
+
+	project, err := service.SetWIPLimit(testProject.ID, domain.InProgress, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if project.Workflow.WIPLimit(domain.InProgress) != 3 {
+		t.Errorf("Expected limit 3, got %d", project.Workflow.WIPLimit(domain.InProgress))
+	}
+	stored, _ := projectRepo.GetByID(testProject.ID)
+	if stored.Workflow.WIPLimit(domain.InProgress) != 3 {
+		t.Error("Expected the limit to be saved")
+	}
+
+	tests := []struct {
+		name   string
+		status domain.Status
+		limit  int
+	}{
+		{"negative limit", domain.InProgress, -1},
+		{"limit too large", domain.InProgress, domain.MaxWIPLimit + 1},
+		{"status outside the workflow", domain.Status(5), 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.SetWIPLimit(testProject.ID, tt.status, tt.limit)
+			if _, ok := err.(*domain.ValidationError); !ok {
+				t.Errorf("Expected ValidationError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestProjectService_TrashAndRestore(t *testing.T) {
+	projectRepo := NewMockProjectRepository()
+	taskRepo := NewMockTaskRepository()
+	service := NewProjectService(projectRepo, taskRepo)
+
+	project, _ := service.CreateProject("Website", "")
+	if err := service.DeleteProject(project.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if projects, _ := service.GetAllProjects(); len(projects) != 0 {
+		t.Errorf("Expected a trashed project to be hidden, got %+v", projects)
+	}
+	trashed, _ := service.GetTrashedProjects()
+	if len(trashed) != 1 || trashed[0].DeletedAt == nil {
+		t.Fatalf("Expected the project in the trash, got %+v", trashed)
+	}
+
+	if _, err := service.RestoreProject(project.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.GetProjectWithTasks(project.ID); err != nil {
+		t.Errorf("Expected the restored project to be found, got %v", err)
+	}
+	if err := service.PurgeProject(project.ID); err == nil {
+		t.Error("Expected an error purging a project that is not in the trash")
+	}
+
+	service.DeleteProject(project.ID)
+	if err := service.PurgeProject(project.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if trashed, _ := service.GetTrashedProjects(); len(trashed) != 0 {
+		t.Errorf("Expected an empty trash, got %+v", trashed)
+	}
+}
+
+func TestProjectService_GetMyWork(t *testing.T) {
+	projectRepo := NewMockProjectRepository()
+	taskRepo := NewMockTaskRepository()
+	service := NewProjectService(projectRepo, taskRepo)
+
+	website, _ := service.CreateProject("Website", "")
+	api, _ := service.CreateProject("API", "")
+	addTask := func(projectID, name string, status domain.Status, priority domain.Priority) {
+		task := domain.NewTask(name, "", projectID)
+		task.Status, task.Priority = status, priority
+		taskRepo.Create(task)
+	}
+	addTask(website.ID, "Fix login", domain.NotStarted, domain.High)
+	addTask(website.ID, "Tweak footer", domain.NotStarted, domain.Low)
+	addTask(api.ID, "Rate limits", domain.InProgress, domain.Low)
+	addTask(api.ID, "Release", domain.Done, domain.High)
+
+	columns, err := service.GetMyWork()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	upNext, inProgress := columns[domain.MyWorkUpNext], columns[domain.MyWorkInProgress]
+	if len(upNext) != 1 || upNext[0].Task.Name != "Fix login" || upNext[0].Project.Name != "Website" {
+		t.Errorf("Expected Fix login from Website up next, got %+v", upNext)
+	}
+	if len(inProgress) != 1 || inProgress[0].Task.Name != "Rate limits" || inProgress[0].Project.Name != "API" {
+		t.Errorf("Expected Rate limits from API in progress, got %+v", inProgress)
+	}
+}
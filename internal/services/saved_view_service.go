@@ -0,0 +1,133 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"kahn/internal/domain"
+)
+
+type SavedViewService struct {
+	viewRepo    domain.SavedViewRepository
+	projectRepo domain.ProjectRepository
+	validator   *ServiceValidator
+}
+
+func NewSavedViewService(viewRepo domain.SavedViewRepository, projectRepo domain.ProjectRepository) *SavedViewService {
+	return &SavedViewService{
+		viewRepo:    viewRepo,
+		projectRepo: projectRepo,
+		validator:   NewServiceValidator(),
+	}
+}
+
+// SaveView stores query under name for the project, or for every project when
+// projectID is empty. Saving under the name of a view in the same scope
+// replaces its query.
+func (vs *SavedViewService) SaveView(projectID, name, query string) (*domain.SavedView, error) {
+	view := domain.NewSavedView(projectID, name, query)
+	if err := view.Validate(); err != nil {
+		return nil, err
+	}
+	if err := vs.validateQuery(projectID, view.Query); err != nil {
+		return nil, err
+	}
+
+	views, err := vs.viewRepo.GetByProjectID(projectID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("get by project", "saved views", projectID, err)
+	}
+	for _, existing := range views {
+		if existing.ProjectID != projectID || !strings.EqualFold(existing.Name, view.Name) {
+			continue
+		}
+		existing.Name, existing.Query = view.Name, view.Query
+		if err := vs.viewRepo.Update(&existing); err != nil {
+			return nil, domain.NewRepositoryError("update", "saved view", existing.ID, err)
+		}
+		return &existing, nil
+	}
+
+	if err := vs.viewRepo.Create(view); err != nil {
+		return nil, domain.NewRepositoryError("create", "saved view", view.ID, err)
+	}
+	return view, nil
+}
+
+// validateQuery checks that query parses with the project's workflow. A global
+// view only has to parse with the workflow of one project, since its status
+// names may come from a custom workflow.
+func (vs *SavedViewService) validateQuery(projectID, query string) error {
+	var workflows []domain.Workflow
+	if projectID != "" {
+		project, err := vs.validator.ValidateProjectExists(vs.projectRepo, projectID)
+		if err != nil {
+			return err
+		}
+		workflows = append(workflows, project.Workflow)
+	} else {
+		projects, err := vs.projectRepo.GetAll()
+		if err != nil {
+			return domain.NewRepositoryError("get all", "projects", "", err)
+		}
+		for _, project := range projects {
+			workflows = append(workflows, project.Workflow)
+		}
+		workflows = append(workflows, domain.DefaultWorkflow())
+	}
+
+	var firstErr error
+	for _, workflow := range workflows {
+		_, err := domain.ParseTaskQuery(query, workflow, time.Now())
+		if err == nil {
+			return nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// GetViews lists a project's views, then the global ones, each by name
+func (vs *SavedViewService) GetViews(projectID string) ([]domain.SavedView, error) {
+	views, err := vs.viewRepo.GetByProjectID(projectID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("get by project", "saved views", projectID, err)
+	}
+	return views, nil
+}
+
+// GetAllViews lists the views of every project outside the trash and the global ones
+func (vs *SavedViewService) GetAllViews() ([]domain.SavedView, error) {
+	views, err := vs.viewRepo.GetAll()
+	if err != nil {
+		return nil, domain.NewRepositoryError("get all", "saved views", "", err)
+	}
+	return views, nil
+}
+
+// GetViewByName finds a view of the project, or else a global one, by name
+// ignoring case
+func (vs *SavedViewService) GetViewByName(projectID, name string) (*domain.SavedView, error) {
+	views, err := vs.GetViews(projectID)
+	if err != nil {
+		return nil, err
+	}
+	view := domain.FindSavedView(views, name)
+	if view == nil {
+		return nil, domain.NewValidationError("view", fmt.Sprintf("view %q not found", name))
+	}
+	return view, nil
+}
+
+func (vs *SavedViewService) DeleteView(id string) error {
+	if err := vs.validator.ValidateEntityID(id, "view"); err != nil {
+		return err
+	}
+	if err := vs.viewRepo.Delete(id); err != nil {
+		return domain.NewRepositoryError("delete", "saved view", id, err)
+	}
+	return nil
+}
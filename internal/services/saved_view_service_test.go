@@ -0,0 +1,87 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"kahn/internal/domain"
+)
+
+func setupSavedViewService(t *testing.T) (*SavedViewService, *domain.Project) {
+	t.Helper()
+
+	projectRepo := NewMockProjectRepository()
+	project := domain.NewProject("Test Project", "Test Description", "#89b4fa")
+	projectRepo.Create(project)
+
+	return NewSavedViewService(NewMockSavedViewRepository(), projectRepo), project
+}
+
+func TestSavedViewService_SaveView(t *testing.T) {
+	service, project := setupSavedViewService(t)
+
+	bugs, err := service.SaveView(project.ID, " My bugs ", "type:bug")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if bugs.Name != "My bugs" {
+		t.Errorf("Expected trimmed name, got %q", bugs.Name)
+	}
+	if _, err := service.SaveView("", "Blocked", "blocked:yes"); err != nil {
+		t.Fatalf("Expected global view to be saved, got %v", err)
+	}
+
+	// Saving under a taken name replaces the query
+	replaced, err := service.SaveView(project.ID, "my BUGS", "type:bug priority:high")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if replaced.ID != bugs.ID {
+		t.Error("Expected the existing view to be updated")
+	}
+	views, _ := service.GetViews(project.ID)
+	if len(views) != 2 || views[0].Query != "type:bug priority:high" || !views[1].IsGlobal() {
+		t.Errorf("Expected the project view then the global one, got %+v", views)
+	}
+
+	tests := []struct {
+		name      string
+		projectID string
+		viewName  string
+		query     string
+		wantErr   string
+	}{
+		{"empty name", project.ID, "", "type:bug", "cannot be empty"},
+		{"empty query", project.ID, "All", " ", "cannot be empty"},
+		{"unparsable query", project.ID, "Broken", "(type:bug", `missing ")"`},
+		{"unknown status", project.ID, "Review", "status:review", "unknown status"},
+		{"unknown project", "missing", "Bugs", "type:bug", "missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.SaveView(tt.projectID, tt.viewName, tt.query)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSavedViewService_GetViewByNameAndDelete(t *testing.T) {
+	service, project := setupSavedViewService(t)
+	service.SaveView("", "Stale", "updated:<-30d")
+
+	view, err := service.GetViewByName(project.ID, "stale")
+	if err != nil {
+		t.Fatalf("Expected the global view to be found, got %v", err)
+	}
+	if err := service.DeleteView(view.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.GetViewByName(project.ID, "stale"); err == nil {
+		t.Error("Expected deleted view not to be found")
+	}
+	if err := service.DeleteView(view.ID); err == nil {
+		t.Error("Expected error deleting a view twice")
+	}
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"fmt"
+	"kahn/internal/domain"
+)
+
+// GetChecklist returns the task's checklist items in order
+func (ts *TaskService) GetChecklist(taskID string) ([]domain.ChecklistItem, error) {
+	if _, err := ts.validator.ValidateTaskExists(ts.taskRepo, taskID); err != nil {
+		return nil, err
+	}
+
+	items, err := ts.taskRepo.GetChecklist(taskID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("get", "checklist", taskID, err)
+	}
+	return items, nil
+}
+
+// AddChecklistItem appends an item to the end of the task's checklist
+func (ts *TaskService) AddChecklistItem(taskID, text string) (*domain.ChecklistItem, error) {
+	items, err := ts.GetChecklist(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) >= domain.MaxChecklistItems {
+		return nil, domain.NewValidationError("checklist", fmt.Sprintf("a task can have at most %d checklist items", domain.MaxChecklistItems))
+	}
+
+	item := domain.NewChecklistItem(taskID, text, len(items)+1)
+	if err := item.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := ts.taskRepo.CreateChecklistItem(item); err != nil {
+		return nil, domain.NewRepositoryError("create", "checklist item", item.ID, err)
+	}
+	return item, nil
+}
+
+// RenameChecklistItem replaces the text of a checklist item
+func (ts *TaskService) RenameChecklistItem(itemID, text string) (*domain.ChecklistItem, error) {
+	item, err := ts.checklistItemExists(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	renamed := domain.NewChecklistItem(item.TaskID, text, item.Position)
+	item.Text = renamed.Text
+	if err := item.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := ts.taskRepo.UpdateChecklistItem(item); err != nil {
+		return nil, domain.NewRepositoryError("update", "checklist item", itemID, err)
+	}
+	return item, nil
+}
+
+// SetChecklistItemDone ticks a checklist item off, or unticks it
+func (ts *TaskService) SetChecklistItemDone(itemID string, done bool) (*domain.ChecklistItem, error) {
+	item, err := ts.checklistItemExists(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	item.Done = done
+	if err := ts.taskRepo.UpdateChecklistItem(item); err != nil {
+		return nil, domain.NewRepositoryError("update", "checklist item", itemID, err)
+	}
+	return item, nil
+}
+
+// DeleteChecklistItem removes an item, moving the items after it up one place
+func (ts *TaskService) DeleteChecklistItem(itemID string) error {
+	if _, err := ts.checklistItemExists(itemID); err != nil {
+		return err
+	}
+
+	if err := ts.taskRepo.DeleteChecklistItem(itemID); err != nil {
+		return domain.NewRepositoryError("delete", "checklist item", itemID, err)
+	}
+	return nil
+}
+
+// MoveChecklistItem moves an item to position (1-based) in its checklist and
+// returns the reordered checklist
+func (ts *TaskService) MoveChecklistItem(itemID string, position int) ([]domain.ChecklistItem, error) {
+	item, err := ts.checklistItemExists(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := ts.taskRepo.GetChecklist(item.TaskID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("get", "checklist", item.TaskID, err)
+	}
+	if position < 1 || position > len(items) {
+		return nil, domain.NewValidationError("position", fmt.Sprintf("position must be between 1 and %d", len(items)))
+	}
+
+	reordered := make([]domain.ChecklistItem, 0, len(items))
+	for _, other := range items {
+		if other.ID != itemID {
+			reordered = append(reordered, other)
+		}
+	}
+	reordered = append(reordered[:position-1], append([]domain.ChecklistItem{*item}, reordered[position-1:]...)...)
+
+	itemIDs := make([]string, len(reordered))
+	for i := range reordered {
+		reordered[i].Position = i + 1
+		itemIDs[i] = reordered[i].ID
+	}
+	if err := ts.taskRepo.ReorderChecklist(item.TaskID, itemIDs); err != nil {
+		return nil, domain.NewRepositoryError("reorder", "checklist", item.TaskID, err)
+	}
+	return reordered, nil
+}
+
+// PromoteChecklistItem turns an unfinished checklist item into a task of its own
+// with the parent's priority. The parent is made to wait on the new task and the
+// item leaves the checklist.
+func (ts *TaskService) PromoteChecklistItem(itemID string) (*domain.Task, error) {
+	item, err := ts.checklistItemExists(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.Done {
+		return nil, domain.NewValidationError("checklist", "only unfinished checklist items can be promoted")
+	}
+
+	parent, err := ts.validator.ValidateTaskExists(ts.taskRepo, item.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(parent.BlockedBy) >= domain.MaxBlockersPerTask {
+		return nil, domain.NewValidationError("blocked_by", fmt.Sprintf("a task can have at most %d blockers", domain.MaxBlockersPerTask))
+	}
+
+	task, err := ts.CreateTask(item.Text, "", parent.ProjectID, domain.RegularTask, parent.Priority, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ts.AddTaskBlockers(parent.ID, task.IntID); err != nil {
+		// Leave things as they were rather than keep a task nothing points to
+		_ = ts.taskRepo.Delete(task.ID)
+		return nil, err
+	}
+
+	if err := ts.taskRepo.DeleteChecklistItem(itemID); err != nil {
+		return nil, domain.NewRepositoryError("delete", "checklist item", itemID, err)
+	}
+	return task, nil
+}
+
+// checklistItemExists loads a checklist item or reports that it does not exist
+func (ts *TaskService) checklistItemExists(itemID string) (*domain.ChecklistItem, error) {
+	if err := ts.validator.ValidateEntityID(itemID, "checklist item"); err != nil {
+		return nil, err
+	}
+
+	item, err := ts.taskRepo.GetChecklistItem(itemID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("get", "checklist item", itemID, err)
+	}
+	if item == nil {
+		return nil, domain.NewValidationError("id", "checklist item not found")
+	}
+	return item, nil
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"kahn/internal/domain"
+	"strings"
+	"testing"
+)
+
+func TestTaskService_Checklist(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	testProject := domain.NewProject("Test Project", "Test Description", "blue")
+	projectRepo.Create(testProject)
+	service := NewTaskService(taskRepo, projectRepo)
+
+	story, _ := service.CreateTask("Story", "", testProject.ID, domain.Feature, domain.High, nil)
+
+	var items []*domain.ChecklistItem
+	for _, text := range []string{"Write migration", "  Add endpoint  ", "Update docs"} {
+		item, err := service.AddChecklistItem(story.ID, text)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		items = append(items, item)
+	}
+
+	texts := func() []string {
+		checklist, err := service.GetChecklist(story.ID)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		var result []string
+		for i, item := range checklist {
+			if item.Position != i+1 {
+				t.Errorf("Expected %q at position %d, got %d", item.Text, i+1, item.Position)
+			}
+			result = append(result, item.Text)
+		}
+		return result
+	}
+
+	t.Run("items are appended in order and trimmed", func(t *testing.T) {
+		got := strings.Join(texts(), "|")
+		if got != "Write migration|Add endpoint|Update docs" {
+			t.Errorf("Unexpected checklist %q", got)
+		}
+	})
+
+	t.Run("ticking items updates the task progress", func(t *testing.T) {
+		if _, err := service.SetChecklistItemDone(items[0].ID, true); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		task, _ := service.GetTask(story.ID)
+		if task.ChecklistProgress() != "1/3" {
+			t.Errorf("Expected progress 1/3, got %q", task.ChecklistProgress())
+		}
+	})
+
+	t.Run("invalid text is rejected", func(t *testing.T) {
+		for _, text := range []string{"", "   ", "two\nlines", strings.Repeat("x", domain.MaxChecklistItemLength+1)} {
+			if _, err := service.AddChecklistItem(story.ID, text); err == nil {
+				t.Errorf("Expected validation error for %q", text)
+			}
+		}
+		if _, err := service.RenameChecklistItem(items[1].ID, ""); err == nil {
+			t.Error("Expected validation error for an empty rename")
+		}
+	})
+
+	t.Run("items can be renamed and moved", func(t *testing.T) {
+		if _, err := service.RenameChecklistItem(items[1].ID, "Add API endpoint"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := service.MoveChecklistItem(items[2].ID, 1); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		got := strings.Join(texts(), "|")
+		if got != "Update docs|Write migration|Add API endpoint" {
+			t.Errorf("Unexpected checklist %q", got)
+		}
+		if _, err := service.MoveChecklistItem(items[2].ID, 4); err == nil {
+			t.Error("Expected validation error for a position past the end")
+		}
+	})
+
+	t.Run("deleting closes the gap", func(t *testing.T) {
+		if err := service.DeleteChecklistItem(items[0].ID); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		got := strings.Join(texts(), "|")
+		if got != "Update docs|Add API endpoint" {
+			t.Errorf("Unexpected checklist %q", got)
+		}
+		task, _ := service.GetTask(story.ID)
+		if task.ChecklistProgress() != "0/2" {
+			t.Errorf("Expected progress 0/2, got %q", task.ChecklistProgress())
+		}
+	})
+
+	t.Run("promoting makes the parent wait on a new task", func(t *testing.T) {
+		promoted, err := service.PromoteChecklistItem(items[1].ID)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if promoted.Name != "Add API endpoint" || promoted.Priority != domain.High || promoted.ProjectID != testProject.ID {
+			t.Errorf("Unexpected promoted task %+v", promoted)
+		}
+		parent, _ := service.GetTask(story.ID)
+		if !parent.IsBlockedBy(promoted.IntID) {
+			t.Errorf("Expected the story to be blocked by #%d, got %v", promoted.IntID, parent.BlockedBy)
+		}
+		if got := strings.Join(texts(), "|"); got != "Update docs" {
+			t.Errorf("Expected the promoted item to leave the checklist, got %q", got)
+		}
+	})
+
+	t.Run("done items cannot be promoted", func(t *testing.T) {
+		item, _ := service.AddChecklistItem(story.ID, "Celebrate")
+		service.SetChecklistItemDone(item.ID, true)
+		if _, err := service.PromoteChecklistItem(item.ID); err == nil {
+			t.Error("Expected validation error for a done item")
+		}
+	})
+
+	t.Run("checklist size is capped", func(t *testing.T) {
+		other, _ := service.CreateTask("Big", "", testProject.ID, domain.RegularTask, domain.Low, nil)
+		for i := 0; i < domain.MaxChecklistItems; i++ {
+			if _, err := service.AddChecklistItem(other.ID, "step"); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+		if _, err := service.AddChecklistItem(other.ID, "one too many"); err == nil {
+			t.Error("Expected validation error past the cap")
+		}
+	})
+
+	t.Run("unknown item is rejected", func(t *testing.T) {
+		if _, err := service.SetChecklistItemDone("missing", true); err == nil {
+			t.Error("Expected error for an unknown item")
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"kahn/internal/domain"
+)
+
+// GetComments returns the task's comments, oldest first
+func (ts *TaskService) GetComments(taskID string) ([]domain.Comment, error) {
+	if _, err := ts.validator.ValidateTaskExists(ts.taskRepo, taskID); err != nil {
+		return nil, err
+	}
+
+	comments, err := ts.taskRepo.GetComments(taskID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("get", "comments", taskID, err)
+	}
+	return comments, nil
+}
+
+// AddComment appends a comment by author to the task
+func (ts *TaskService) AddComment(taskID, author, body string) (*domain.Comment, error) {
+	if _, err := ts.validator.ValidateTaskExists(ts.taskRepo, taskID); err != nil {
+		return nil, err
+	}
+
+	comment := domain.NewComment(taskID, author, body)
+	if err := comment.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := ts.taskRepo.CreateComment(comment); err != nil {
+		return nil, domain.NewRepositoryError("create", "comment", comment.ID, err)
+	}
+	return comment, nil
+}
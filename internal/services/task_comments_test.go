@@ -0,0 +1,54 @@
+package services
+
+import (
+	"kahn/internal/domain"
+	"testing"
+)
+
+func TestTaskService_Comments(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	testProject := domain.NewProject("Test Project", "Test Description", "blue")
+	projectRepo.Create(testProject)
+	service := NewTaskService(taskRepo, projectRepo)
+
+	task, _ := service.CreateTask("Flaky test", "", testProject.ID, domain.Bug, domain.High, nil)
+
+	t.Run("comments are appended oldest first", func(t *testing.T) {
+		for _, body := range []string{"Reproduced on CI", "  Fixed the timeout\nwaiting on review  "} {
+			if _, err := service.AddComment(task.ID, "alice", body); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+		comments, err := service.GetComments(task.ID)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(comments) != 2 {
+			t.Fatalf("Expected 2 comments, got %d", len(comments))
+		}
+		if comments[0].Body != "Reproduced on CI" || comments[1].Body != "Fixed the timeout\nwaiting on review" {
+			t.Errorf("Unexpected comments %+v", comments)
+		}
+		if comments[1].Author != "alice" {
+			t.Errorf("Expected author alice, got %q", comments[1].Author)
+		}
+
+		loaded, _ := service.GetTask(task.ID)
+		if len(loaded.Comments) != 2 {
+			t.Errorf("Expected the task to carry 2 comments, got %d", len(loaded.Comments))
+		}
+	})
+
+	t.Run("invalid comments are rejected", func(t *testing.T) {
+		if _, err := service.AddComment(task.ID, "alice", "   "); err == nil {
+			t.Error("Expected validation error for an empty comment")
+		}
+		if _, err := service.AddComment(task.ID, "", "note"); err == nil {
+			t.Error("Expected validation error for a missing author")
+		}
+		if _, err := service.AddComment("missing", "alice", "note"); err == nil {
+			t.Error("Expected error for an unknown task")
+		}
+	})
+}
@@ -3,12 +3,16 @@ package services
 import (
 	"fmt"
 	"kahn/internal/domain"
+	"slices"
+	"time"
 )
 
 type TaskService struct {
-	taskRepo    domain.TaskRepository
-	projectRepo domain.ProjectRepository
-	validator   *ServiceValidator
+	taskRepo       domain.TaskRepository
+	projectRepo    domain.ProjectRepository
+	validator      *ServiceValidator
+	wipEnforcement domain.WIPEnforcement
+	events         *EventLog
 }
 
 func NewTaskService(taskRepo domain.TaskRepository, projectRepo domain.ProjectRepository) *TaskService {
@@ -19,9 +23,22 @@ func NewTaskService(taskRepo domain.TaskRepository, projectRepo domain.ProjectRe
 	}
 }
 
-func (ts *TaskService) CreateTask(name, description, projectID string, taskType domain.TaskType, priority domain.Priority, blockedByIntID *int) (*domain.Task, error) {
+// SetWIPEnforcement chooses whether moves over a column's WIP limit are rejected
+// (the default) or saved with a warning
+func (ts *TaskService) SetWIPEnforcement(enforcement domain.WIPEnforcement) {
+	ts.wipEnforcement = enforcement
+}
+
+// SetEventLog chooses where task changes are recorded
+func (ts *TaskService) SetEventLog(events *EventLog) {
+	ts.events = events
+}
 
-	_, err := ts.validator.ValidateProjectExists(ts.projectRepo, projectID)
+// CreateTask creates a task waiting on the tasks numbered blockedBy, which must
+// belong to the same project
+func (ts *TaskService) CreateTask(name, description, projectID string, taskType domain.TaskType, priority domain.Priority, blockedBy []int) (*domain.Task, error) {
+
+	project, err := ts.validator.ValidateProjectExists(ts.projectRepo, projectID)
 	if err != nil {
 		return nil, err
 	}
@@ -29,16 +46,21 @@ func (ts *TaskService) CreateTask(name, description, projectID string, taskType
 	task := domain.NewTask(name, description, projectID)
 	task.Type = taskType
 	task.Priority = priority
-	task.BlockedBy = blockedByIntID
+	task.BlockedBy = domain.NormalizeBlockers(blockedBy)
 
 	if err := task.Validate(); err != nil {
 		return nil, err
 	}
+	if err := ts.validateBlockers(task, task.BlockedBy); err != nil {
+		return nil, err
+	}
 
 	if err := ts.taskRepo.Create(task); err != nil {
 		return nil, domain.NewRepositoryError("create", "task", task.ID, err)
 	}
 
+	ts.events.recordTask(task, domain.EventCreated, "", project.Workflow.Name(task.Status))
+	ts.events.recordBlockers(task, nil, task.BlockedBy)
 	return task, nil
 }
 
@@ -48,6 +70,8 @@ func (ts *TaskService) UpdateTask(id, name, description string, taskType domain.
 		return nil, err
 	}
 
+	before := *task
+
 	// Update task fields
 	task.Name = name
 	task.Desc = description
@@ -62,91 +86,220 @@ func (ts *TaskService) UpdateTask(id, name, description string, taskType domain.
 		return nil, domain.NewRepositoryError("update", "task", id, err)
 	}
 
+	ts.events.recordTaskChange(task, "name", before.Name, task.Name)
+	ts.events.recordTaskChange(task, "description", before.Desc, task.Desc)
+	ts.events.recordTaskChange(task, "type", before.Type.String(), task.Type.String())
+	ts.events.recordTaskChange(task, "priority", before.Priority.String(), task.Priority.String())
+	return task, nil
+}
+
+// EditTask applies edit to the task and saves its fields, dates and blockers in
+// a single write. The edited task is validated as a whole, and changed blockers
+// as SetTaskBlockers does, before anything is saved. Edit must not change the
+// task's status; moves go through the workflow.
+func (ts *TaskService) EditTask(id string, edit func(task *domain.Task)) (*domain.Task, error) {
+	task, err := ts.validator.ValidateTaskExists(ts.taskRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *task
+	edit(task)
+	task.Status = before.Status
+	task.BlockedBy = domain.NormalizeBlockers(task.BlockedBy)
+	if !slices.Equal(before.BlockedBy, task.BlockedBy) {
+		if err := ts.validateBlockers(task, task.BlockedBy); err != nil {
+			return nil, err
+		}
+	}
+	if err := task.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := ts.taskRepo.Update(task); err != nil {
+		return nil, domain.NewRepositoryError("update", "task", id, err)
+	}
+
+	ts.events.recordTaskChange(task, "name", before.Name, task.Name)
+	ts.events.recordTaskChange(task, "description", before.Desc, task.Desc)
+	ts.events.recordTaskChange(task, "type", before.Type.String(), task.Type.String())
+	ts.events.recordTaskChange(task, "priority", before.Priority.String(), task.Priority.String())
+	ts.events.recordTaskChange(task, "start_date", domain.FormatDate(before.StartDate), domain.FormatDate(task.StartDate))
+	ts.events.recordTaskChange(task, "due_date", domain.FormatDate(before.DueDate), domain.FormatDate(task.DueDate))
+	ts.events.recordBlockers(task, before.BlockedBy, task.BlockedBy)
 	return task, nil
 }
 
+// DeleteTask moves the task to the trash. Its dependents stop waiting on it
+// until it is restored.
 func (ts *TaskService) DeleteTask(id string) error {
 	task, err := ts.validator.ValidateTaskExists(ts.taskRepo, id)
 	if err != nil {
 		return err
 	}
 
+	dependents := ts.dependentsOf(task)
 	if err := ts.taskRepo.Delete(id); err != nil {
 		return domain.NewRepositoryError("delete", "task", id, err)
 	}
+	ts.events.recordTask(task, domain.EventDeleted, "", "")
+	ts.recordUnblocked(task, dependents)
+	return nil
+}
 
-	// Unblock dependent tasks to trigger UI refresh.
-	// Database constraint also handles this, but explicit call ensures UI state updates.
-	if task.IntID != 0 {
-		if err := ts.UnblockDependents(task.IntID); err != nil {
-			// Ignore error; task was deleted successfully
-			return nil
-		}
+func (ts *TaskService) MoveTaskToNextStatus(id string) (*domain.Task, error) {
+	task, err := ts.validator.ValidateTaskExists(ts.taskRepo, id)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	workflow, err := ts.workflowFor(task.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	return ts.moveTask(task, workflow, workflow.Next(task.Status))
 }
 
-func (ts *TaskService) MoveTaskToNextStatus(id string) (*domain.Task, error) {
+func (ts *TaskService) MoveTaskToPreviousStatus(id string) (*domain.Task, error) {
 	task, err := ts.validator.ValidateTaskExists(ts.taskRepo, id)
 	if err != nil {
 		return nil, err
 	}
 
-	var nextStatus domain.Status
-	switch task.Status {
-	case domain.NotStarted:
-		nextStatus = domain.InProgress
-	case domain.InProgress:
-		nextStatus = domain.Done
-	case domain.Done:
-		nextStatus = domain.NotStarted
+	workflow, err := ts.workflowFor(task.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	return ts.moveTask(task, workflow, workflow.Previous(task.Status))
+}
+
+// moveTask stores the new status and unblocks dependents when the task reaches the
+// workflow's done column. A move over the column's WIP limit returns a
+// *domain.WIPLimitError: with WIPReject nothing is saved, with WIPWarn the moved
+// task is returned alongside it.
+func (ts *TaskService) moveTask(task *domain.Task, workflow domain.Workflow, status domain.Status) (*domain.Task, error) {
+	if task.ArchivedAt != nil {
+		return nil, domain.NewValidationError("status", "archived tasks cannot be moved; unarchive the task first")
 	}
 
-	if err := ts.taskRepo.UpdateStatus(id, nextStatus); err != nil {
-		return nil, domain.NewRepositoryError("update status", "task", id, err)
+	wipErr, err := ts.checkWIPLimit(task, workflow, status)
+	if err != nil {
+		return nil, err
+	}
+	if wipErr != nil && wipErr.Enforced {
+		return nil, wipErr
 	}
 
-	if nextStatus == domain.Done {
-		if err := ts.UnblockDependents(task.IntID); err != nil {
-			// Ignore error; status was updated successfully
-			return task, nil
+	var dependents []domain.Task
+	if workflow.IsDone(status) {
+		dependents = ts.dependentsOf(task)
+	}
+	if err := ts.taskRepo.UpdateStatus(task.ID, status); err != nil {
+		return nil, domain.NewRepositoryError("update status", "task", task.ID, err)
+	}
+
+	if task.Status != status {
+		ts.events.recordTask(task, domain.EventMoved, workflow.Name(task.Status), workflow.Name(status))
+	}
+	task.Status = status
+	if workflow.IsDone(status) {
+		// Ignore error; status was updated successfully
+		if err := ts.UnblockDependents(task.IntID); err == nil {
+			ts.recordUnblocked(task, dependents)
 		}
 	}
 
-	task.Status = nextStatus
+	if wipErr != nil {
+		return task, wipErr
+	}
 	return task, nil
 }
 
-func (ts *TaskService) MoveTaskToPreviousStatus(id string) (*domain.Task, error) {
-	task, err := ts.validator.ValidateTaskExists(ts.taskRepo, id)
+// checkWIPLimit returns a WIPLimitError when moving task into status would put more
+// tasks in the column than its limit allows
+func (ts *TaskService) checkWIPLimit(task *domain.Task, workflow domain.Workflow, status domain.Status) (*domain.WIPLimitError, error) {
+	if task.Status == status {
+		return nil, nil
+	}
+	return ts.checkColumnRoom(task.ProjectID, workflow, status)
+}
+
+// checkColumnRoom returns a WIPLimitError when one more task in the project's
+// status column would go over its limit
+func (ts *TaskService) checkColumnRoom(projectID string, workflow domain.Workflow, status domain.Status) (*domain.WIPLimitError, error) {
+	limit := workflow.WIPLimit(status)
+	if limit == 0 {
+		return nil, nil
+	}
+
+	tasks, err := ts.taskRepo.GetByStatus(projectID, status)
 	if err != nil {
-		return nil, err
+		return nil, domain.NewRepositoryError("get by status", "tasks", projectID, err)
 	}
 
-	var prevStatus domain.Status
-	switch task.Status {
-	case domain.NotStarted:
-		prevStatus = domain.Done
-	case domain.InProgress:
-		prevStatus = domain.NotStarted
-	case domain.Done:
-		prevStatus = domain.InProgress
+	count := len(tasks) + 1
+	if !workflow.OverWIPLimit(status, count) {
+		return nil, nil
 	}
+	return domain.NewWIPLimitError(workflow.Name(status), limit, count, ts.wipEnforcement == domain.WIPReject), nil
+}
 
-	if err := ts.taskRepo.UpdateStatus(id, prevStatus); err != nil {
-		return nil, domain.NewRepositoryError("update status", "task", id, err)
+// WIPPlan follows the number of tasks in each column of a project through a
+// batch of changes, so an import can meet every WIP limit before it writes
+// anything
+type WIPPlan struct {
+	workflow domain.Workflow
+	enforced bool
+	counts   map[domain.Status]int
+}
+
+// PlanWIP starts a WIPPlan from the project's columns as they stand
+func (ts *TaskService) PlanWIP(projectID string) (*WIPPlan, error) {
+	workflow, err := ts.workflowFor(projectID)
+	if err != nil {
+		return nil, err
 	}
 
-	if prevStatus == domain.Done {
-		if err := ts.UnblockDependents(task.IntID); err != nil {
-			// Ignore error; status was updated successfully
-			return task, nil
+	counts := make(map[domain.Status]int)
+	for _, status := range workflow.Statuses() {
+		if workflow.WIPLimit(status) == 0 {
+			continue
+		}
+		tasks, err := ts.taskRepo.GetByStatus(projectID, status)
+		if err != nil {
+			return nil, domain.NewRepositoryError("get by status", "tasks", projectID, err)
 		}
+		counts[status] = len(tasks)
 	}
+	return &WIPPlan{workflow: workflow, enforced: ts.wipEnforcement == domain.WIPReject, counts: counts}, nil
+}
 
-	task.Status = prevStatus
-	return task, nil
+// Create counts a new task, which starts in the first column without a WIP check
+func (p *WIPPlan) Create() {
+	p.counts[domain.NotStarted]++
+}
+
+// Move counts a task moving between columns and returns the WIP limit error
+// UpdateTaskStatus would give for it, if any
+func (p *WIPPlan) Move(from, to domain.Status) *domain.WIPLimitError {
+	if from == to {
+		return nil
+	}
+	p.counts[from]--
+	p.counts[to]++
+	if !p.workflow.OverWIPLimit(to, p.counts[to]) {
+		return nil
+	}
+	return domain.NewWIPLimitError(p.workflow.Name(to), p.workflow.WIPLimit(to), p.counts[to], p.enforced)
+}
+
+// workflowFor returns the workflow of the task's project
+func (ts *TaskService) workflowFor(projectID string) (domain.Workflow, error) {
+	project, err := ts.validator.ValidateProjectExists(ts.projectRepo, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return project.Workflow, nil
 }
 
 func (ts *TaskService) GetTask(id string) (*domain.Task, error) {
@@ -157,6 +310,18 @@ func (ts *TaskService) GetTask(id string) (*domain.Task, error) {
 	return task, nil
 }
 
+// GetTaskByIntID looks a task up by the short numeric ID shown to users (e.g. "#12")
+func (ts *TaskService) GetTaskByIntID(intID int) (*domain.Task, error) {
+	task, err := ts.taskRepo.GetByIntID(intID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("get", "task", fmt.Sprintf("int_id=%d", intID), err)
+	}
+	if task == nil {
+		return nil, domain.NewValidationError("id", fmt.Sprintf("task #%d not found", intID))
+	}
+	return task, nil
+}
+
 func (ts *TaskService) GetTasksByProject(projectID string) ([]domain.Task, error) {
 	if err := ts.validator.ValidateEntityID(projectID, "project"); err != nil {
 		return nil, err
@@ -170,6 +335,16 @@ func (ts *TaskService) GetTasksByProject(projectID string) ([]domain.Task, error
 	return tasks, nil
 }
 
+// SearchTasks runs a full-text search of every project's board, returning at
+// most domain.MaxSearchResults matches, best first
+func (ts *TaskService) SearchTasks(query string) ([]domain.SearchResult, error) {
+	results, err := ts.taskRepo.Search(query, domain.MaxSearchResults)
+	if err != nil {
+		return nil, domain.NewRepositoryError("search", "tasks", "", err)
+	}
+	return results, nil
+}
+
 func (ts *TaskService) GetTasksByStatus(projectID string, status domain.Status) ([]domain.Task, error) {
 	if err := ts.validator.ValidateEntityID(projectID, "project"); err != nil {
 		return nil, err
@@ -193,23 +368,19 @@ func (ts *TaskService) UpdateTaskStatus(id string, status domain.Status) (*domai
 		return nil, err
 	}
 
-	if err := ts.taskRepo.UpdateStatus(id, status); err != nil {
-		return nil, domain.NewRepositoryError("update status", "task", id, err)
+	workflow, err := ts.workflowFor(task.ProjectID)
+	if err != nil {
+		return nil, err
 	}
-
-	if status == domain.Done {
-		if err := ts.UnblockDependents(task.IntID); err != nil {
-			// Ignore error; status was updated successfully
-			return task, nil
-		}
+	if !workflow.Contains(status) {
+		return nil, domain.NewValidationError("status", fmt.Sprintf("status %d is not part of the project's workflow", status))
 	}
 
-	task.Status = status
-	return task, nil
+	return ts.moveTask(task, workflow, status)
 }
 
-// UnblockDependents clears the BlockedBy field for all tasks blocked by the given intID.
-// Called when a task is moved to Done or deleted to ensure dependent tasks can proceed.
+// UnblockDependents removes the given intID from the blockers of every task waiting on it.
+// Called when a task is moved to its workflow's done column or deleted to ensure dependent tasks can proceed.
 func (ts *TaskService) UnblockDependents(intID int) error {
 	if intID == 0 {
 		return nil
@@ -222,49 +393,71 @@ func (ts *TaskService) UnblockDependents(intID int) error {
 	return nil
 }
 
-// SetTaskBlockedBy sets or clears the BlockedBy field for a task
-func (ts *TaskService) SetTaskBlockedBy(taskID string, blockedByIntID *int) (*domain.Task, error) {
-	// Validate the task to be updated exists
+// dependentsOf returns the tasks waiting on task, so that clearing their
+// blockers can be recorded. Without an event log nothing is looked up.
+func (ts *TaskService) dependentsOf(task *domain.Task) []domain.Task {
+	if ts.events == nil || task.IntID == 0 {
+		return nil
+	}
+
+	projectTasks, err := ts.taskRepo.GetByProjectID(task.ProjectID)
+	if err != nil {
+		return nil
+	}
+	var dependents []domain.Task
+	for _, projectTask := range projectTasks {
+		if projectTask.IsBlockedBy(task.IntID) {
+			dependents = append(dependents, projectTask)
+		}
+	}
+	return dependents
+}
+
+// recordUnblocked records that the dependents no longer wait on task
+func (ts *TaskService) recordUnblocked(task *domain.Task, dependents []domain.Task) {
+	for i := range dependents {
+		ts.events.recordTask(&dependents[i], domain.EventUnblocked, fmt.Sprintf("#%d", task.IntID), "")
+	}
+}
+
+// SetTaskDates sets or clears (nil) the start and due dates of a task
+func (ts *TaskService) SetTaskDates(taskID string, startDate, dueDate *time.Time) (*domain.Task, error) {
 	task, err := ts.validator.ValidateTaskExists(ts.taskRepo, taskID)
 	if err != nil {
 		return nil, err
 	}
 
-	// If setting a blocker, validate it exists and is in the same project
-	if blockedByIntID != nil {
-		// Get all tasks for the project to find the blocking task
-		allTasks, err := ts.taskRepo.GetByProjectID(task.ProjectID)
-		if err != nil {
-			return nil, domain.NewRepositoryError("get tasks", "project", task.ProjectID, err)
-		}
-
-		// Find the blocking task by IntID
-		var blockingTask *domain.Task
-		for i := range allTasks {
-			if allTasks[i].IntID == *blockedByIntID {
-				blockingTask = &allTasks[i]
-				break
-			}
-		}
+	before := *task
+	task.StartDate = startDate
+	task.DueDate = dueDate
+	if err := task.Validate(); err != nil {
+		return nil, err
+	}
 
-		if blockingTask == nil {
-			return nil, domain.NewValidationError("blocked_by", "blocking task not found")
-		}
+	if err := ts.taskRepo.Update(task); err != nil {
+		return nil, domain.NewRepositoryError("update", "task", taskID, err)
+	}
 
-		// Validate that the blocking task is in the same project
-		if blockingTask.ProjectID != task.ProjectID {
-			return nil, domain.NewValidationError("blocked_by", "blocking task must be in the same project")
-		}
+	ts.events.recordTaskChange(task, "start_date", domain.FormatDate(before.StartDate), domain.FormatDate(task.StartDate))
+	ts.events.recordTaskChange(task, "due_date", domain.FormatDate(before.DueDate), domain.FormatDate(task.DueDate))
+	return task, nil
+}
 
-		// Validate that task doesn't block itself
-		if blockingTask.IntID == task.IntID {
-			return nil, domain.NewValidationError("blocked_by", "task cannot block itself")
-		}
+// SetTaskBlockers replaces the tasks the task waits on; an empty list clears them.
+// Blockers must belong to the same project and may not lead back to the task.
+func (ts *TaskService) SetTaskBlockers(taskID string, blockers []int) (*domain.Task, error) {
+	task, err := ts.validator.ValidateTaskExists(ts.taskRepo, taskID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Update the BlockedBy field
-	task.BlockedBy = blockedByIntID
+	blockers = domain.NormalizeBlockers(blockers)
+	if err := ts.validateBlockers(task, blockers); err != nil {
+		return nil, err
+	}
 
+	previous := task.BlockedBy
+	task.BlockedBy = blockers
 	if err := task.Validate(); err != nil {
 		return nil, err
 	}
@@ -273,5 +466,77 @@ func (ts *TaskService) SetTaskBlockedBy(taskID string, blockedByIntID *int) (*do
 		return nil, domain.NewRepositoryError("update", "task", taskID, err)
 	}
 
+	ts.events.recordBlockers(task, previous, blockers)
 	return task, nil
 }
+
+// AddTaskBlockers makes the task wait on the given tasks as well
+func (ts *TaskService) AddTaskBlockers(taskID string, blockers ...int) (*domain.Task, error) {
+	task, err := ts.validator.ValidateTaskExists(ts.taskRepo, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return ts.SetTaskBlockers(taskID, append(task.BlockedBy, blockers...))
+}
+
+// RemoveTaskBlockers stops the task waiting on the given tasks
+func (ts *TaskService) RemoveTaskBlockers(taskID string, blockers ...int) (*domain.Task, error) {
+	task, err := ts.validator.ValidateTaskExists(ts.taskRepo, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := make(map[int]bool, len(blockers))
+	for _, blocker := range blockers {
+		removed[blocker] = true
+	}
+	var remaining []int
+	for _, blocker := range task.BlockedBy {
+		if !removed[blocker] {
+			remaining = append(remaining, blocker)
+		}
+	}
+	return ts.SetTaskBlockers(taskID, remaining)
+}
+
+// validateBlockers checks that every blocker is another task of the same project
+// and that waiting on them would not close a dependency cycle
+func (ts *TaskService) validateBlockers(task *domain.Task, blockers []int) error {
+	if len(blockers) == 0 {
+		return nil
+	}
+
+	projectTasks, err := ts.taskRepo.GetByProjectID(task.ProjectID)
+	if err != nil {
+		return domain.NewRepositoryError("get tasks", "project", task.ProjectID, err)
+	}
+	inProject := make(map[int]bool, len(projectTasks))
+	for _, projectTask := range projectTasks {
+		inProject[projectTask.IntID] = true
+	}
+
+	for _, blocker := range blockers {
+		if task.IntID != 0 && blocker == task.IntID {
+			return domain.NewValidationError("blocked_by", "task cannot block itself")
+		}
+		if !inProject[blocker] {
+			blockingTask, err := ts.taskRepo.GetByIntID(blocker)
+			if err != nil {
+				return domain.NewRepositoryError("get", "task", fmt.Sprintf("int_id=%d", blocker), err)
+			}
+			if blockingTask == nil {
+				return domain.NewValidationError("blocked_by", fmt.Sprintf("blocking task #%d not found", blocker))
+			}
+			return domain.NewValidationError("blocked_by", "blocking task must be in the same project")
+		}
+	}
+
+	// A task that is not stored yet has no dependents, so it cannot close a cycle
+	if task.IntID == 0 {
+		return nil
+	}
+	if cycle := domain.NewDependencyGraph(projectTasks).FindCycle(task.IntID, blockers); cycle != nil {
+		return domain.NewDependencyCycleError(cycle)
+	}
+	return nil
+}
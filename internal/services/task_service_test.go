@@ -2,7 +2,9 @@ package services
 
 import (
 	"kahn/internal/domain"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestTaskService_CreateTask(t *testing.T) {
@@ -71,8 +73,12 @@ func TestTaskService_MoveTaskToNextStatus(t *testing.T) {
 	projectRepo := NewMockProjectRepository()
 	service := NewTaskService(taskRepo, projectRepo)
 
+	// Moves look up the project's workflow, so the task needs an existing project
+	testProject := domain.NewProject("Test Project", "Test Description", "#89b4fa")
+	projectRepo.Create(testProject)
+
 	// Create test task
-	task := domain.NewTask("Test Task", "Test Description", "project-123")
+	task := domain.NewTask("Test Task", "Test Description", testProject.ID)
 	task.Status = domain.NotStarted
 	taskRepo.Create(task)
 
@@ -296,10 +302,10 @@ func TestTaskService_AutoUnblock(t *testing.T) {
 	t.Run("moving blocker to Done unblocks dependent task", func(t *testing.T) {
 		// Create Task A (blocker) and Task B (blocked by A)
 		taskA, _ := service.CreateTask("Task A", "Blocker task", testProject.ID, domain.RegularTask, domain.Medium, nil)
-		taskB, _ := service.CreateTask("Task B", "Blocked task", testProject.ID, domain.RegularTask, domain.Low, &taskA.IntID)
+		taskB, _ := service.CreateTask("Task B", "Blocked task", testProject.ID, domain.RegularTask, domain.Low, []int{taskA.IntID})
 
 		// Verify Task B is blocked
-		if taskB.BlockedBy == nil || *taskB.BlockedBy != taskA.IntID {
+		if !taskB.IsBlockedBy(taskA.IntID) {
 			t.Error("Task B should be blocked by Task A")
 		}
 
@@ -308,7 +314,7 @@ func TestTaskService_AutoUnblock(t *testing.T) {
 
 		// Verify Task B is still blocked (blocker not Done yet)
 		taskB, _ = service.GetTask(taskB.ID)
-		if taskB.BlockedBy == nil {
+		if !taskB.IsBlocked() {
 			t.Error("Task B should still be blocked (Task A is InProgress)")
 		}
 
@@ -317,7 +323,7 @@ func TestTaskService_AutoUnblock(t *testing.T) {
 
 		// Verify Task B is now unblocked
 		taskB, _ = service.GetTask(taskB.ID)
-		if taskB.BlockedBy != nil {
+		if taskB.IsBlocked() {
 			t.Error("Task B should be unblocked after Task A moved to Done")
 		}
 	})
@@ -325,8 +331,8 @@ func TestTaskService_AutoUnblock(t *testing.T) {
 	t.Run("multiple blocked tasks are unblocked", func(t *testing.T) {
 		// Create Task X (blocker) and Tasks Y, Z (both blocked by X)
 		taskX, _ := service.CreateTask("Task X", "Blocker", testProject.ID, domain.RegularTask, domain.High, nil)
-		taskY, _ := service.CreateTask("Task Y", "Blocked 1", testProject.ID, domain.RegularTask, domain.Low, &taskX.IntID)
-		taskZ, _ := service.CreateTask("Task Z", "Blocked 2", testProject.ID, domain.RegularTask, domain.Low, &taskX.IntID)
+		taskY, _ := service.CreateTask("Task Y", "Blocked 1", testProject.ID, domain.RegularTask, domain.Low, []int{taskX.IntID})
+		taskZ, _ := service.CreateTask("Task Z", "Blocked 2", testProject.ID, domain.RegularTask, domain.Low, []int{taskX.IntID})
 
 		// Move Task X to Done
 		service.MoveTaskToNextStatus(taskX.ID)
@@ -336,10 +342,10 @@ func TestTaskService_AutoUnblock(t *testing.T) {
 		taskY, _ = service.GetTask(taskY.ID)
 		taskZ, _ = service.GetTask(taskZ.ID)
 
-		if taskY.BlockedBy != nil {
+		if taskY.IsBlocked() {
 			t.Error("Task Y should be unblocked")
 		}
-		if taskZ.BlockedBy != nil {
+		if taskZ.IsBlocked() {
 			t.Error("Task Z should be unblocked")
 		}
 	})
@@ -347,7 +353,7 @@ func TestTaskService_AutoUnblock(t *testing.T) {
 	t.Run("moving blocker back from Done does not re-block", func(t *testing.T) {
 		// Create Task C (blocker) and Task D (blocked by C)
 		taskC, _ := service.CreateTask("Task C", "Blocker", testProject.ID, domain.RegularTask, domain.Medium, nil)
-		taskD, _ := service.CreateTask("Task D", "Blocked", testProject.ID, domain.RegularTask, domain.Low, &taskC.IntID)
+		taskD, _ := service.CreateTask("Task D", "Blocked", testProject.ID, domain.RegularTask, domain.Low, []int{taskC.IntID})
 
 		// Move Task C to Done (unblocks Task D)
 		service.MoveTaskToNextStatus(taskC.ID)
@@ -355,7 +361,7 @@ func TestTaskService_AutoUnblock(t *testing.T) {
 
 		// Verify Task D is unblocked
 		taskD, _ = service.GetTask(taskD.ID)
-		if taskD.BlockedBy != nil {
+		if taskD.IsBlocked() {
 			t.Error("Task D should be unblocked")
 		}
 
@@ -364,7 +370,7 @@ func TestTaskService_AutoUnblock(t *testing.T) {
 
 		// Verify Task D remains unblocked (no re-blocking)
 		taskD, _ = service.GetTask(taskD.ID)
-		if taskD.BlockedBy != nil {
+		if taskD.IsBlocked() {
 			t.Error("Task D should remain unblocked (no re-blocking)")
 		}
 	})
@@ -372,8 +378,8 @@ func TestTaskService_AutoUnblock(t *testing.T) {
 	t.Run("cascade scenario - only direct dependents unblocked", func(t *testing.T) {
 		// Create Task E, F, G where E blocks F, F blocks G
 		taskE, _ := service.CreateTask("Task E", "Root blocker", testProject.ID, domain.RegularTask, domain.High, nil)
-		taskF, _ := service.CreateTask("Task F", "Intermediate", testProject.ID, domain.RegularTask, domain.Medium, &taskE.IntID)
-		taskG, _ := service.CreateTask("Task G", "Final", testProject.ID, domain.RegularTask, domain.Low, &taskF.IntID)
+		taskF, _ := service.CreateTask("Task F", "Intermediate", testProject.ID, domain.RegularTask, domain.Medium, []int{taskE.IntID})
+		taskG, _ := service.CreateTask("Task G", "Final", testProject.ID, domain.RegularTask, domain.Low, []int{taskF.IntID})
 
 		// Move Task E to Done
 		service.MoveTaskToNextStatus(taskE.ID)
@@ -381,13 +387,13 @@ func TestTaskService_AutoUnblock(t *testing.T) {
 
 		// Verify Task F is unblocked (direct dependent)
 		taskF, _ = service.GetTask(taskF.ID)
-		if taskF.BlockedBy != nil {
+		if taskF.IsBlocked() {
 			t.Error("Task F should be unblocked (direct dependent of E)")
 		}
 
 		// Verify Task G is still blocked by F (no cascade)
 		taskG, _ = service.GetTask(taskG.ID)
-		if taskG.BlockedBy == nil || *taskG.BlockedBy != taskF.IntID {
+		if !taskG.IsBlockedBy(taskF.IntID) {
 			t.Error("Task G should still be blocked by F (no cascade unblocking)")
 		}
 	})
@@ -395,10 +401,10 @@ func TestTaskService_AutoUnblock(t *testing.T) {
 	t.Run("using backwards movement to Done also unblocks", func(t *testing.T) {
 		// Create Task H (blocker) and Task I (blocked by H)
 		taskH, _ := service.CreateTask("Task H", "Blocker", testProject.ID, domain.RegularTask, domain.Medium, nil)
-		taskI, _ := service.CreateTask("Task I", "Blocked", testProject.ID, domain.RegularTask, domain.Low, &taskH.IntID)
+		taskI, _ := service.CreateTask("Task I", "Blocked", testProject.ID, domain.RegularTask, domain.Low, []int{taskH.IntID})
 
 		// Verify Task I is blocked
-		if taskI.BlockedBy == nil || *taskI.BlockedBy != taskH.IntID {
+		if !taskI.IsBlockedBy(taskH.IntID) {
 			t.Error("Task I should be blocked by Task H")
 		}
 
@@ -407,7 +413,7 @@ func TestTaskService_AutoUnblock(t *testing.T) {
 
 		// Verify Task I is now unblocked
 		taskI, _ = service.GetTask(taskI.ID)
-		if taskI.BlockedBy != nil {
+		if taskI.IsBlocked() {
 			t.Error("Task I should be unblocked after Task H moved to Done via backwards movement")
 		}
 	})
@@ -415,10 +421,10 @@ func TestTaskService_AutoUnblock(t *testing.T) {
 	t.Run("deleting blocker unblocks dependent task", func(t *testing.T) {
 		// Create Task J (blocker) and Task K (blocked by J)
 		taskJ, _ := service.CreateTask("Task J", "Blocker to be deleted", testProject.ID, domain.RegularTask, domain.High, nil)
-		taskK, _ := service.CreateTask("Task K", "Blocked by J", testProject.ID, domain.RegularTask, domain.Low, &taskJ.IntID)
+		taskK, _ := service.CreateTask("Task K", "Blocked by J", testProject.ID, domain.RegularTask, domain.Low, []int{taskJ.IntID})
 
 		// Verify Task K is blocked
-		if taskK.BlockedBy == nil || *taskK.BlockedBy != taskJ.IntID {
+		if !taskK.IsBlockedBy(taskJ.IntID) {
 			t.Error("Task K should be blocked by Task J")
 		}
 
@@ -427,8 +433,342 @@ func TestTaskService_AutoUnblock(t *testing.T) {
 
 		// Verify Task K is now unblocked
 		taskK, _ = service.GetTask(taskK.ID)
-		if taskK.BlockedBy != nil {
+		if taskK.IsBlocked() {
 			t.Error("Task K should be unblocked after Task J was deleted")
 		}
 	})
 }
+
+func TestTaskService_SetTaskBlockers(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	testProject := domain.NewProject("Test Project", "Test Description", "blue")
+	projectRepo.Create(testProject)
+	otherProject := domain.NewProject("Other Project", "", "red")
+	projectRepo.Create(otherProject)
+	service := NewTaskService(taskRepo, projectRepo)
+
+	taskA, _ := service.CreateTask("Task A", "", testProject.ID, domain.RegularTask, domain.Low, nil)
+	taskB, _ := service.CreateTask("Task B", "", testProject.ID, domain.RegularTask, domain.Low, nil)
+	taskC, _ := service.CreateTask("Task C", "", testProject.ID, domain.RegularTask, domain.Low, []int{taskA.IntID, taskB.IntID})
+	outsider, _ := service.CreateTask("Outsider", "", otherProject.ID, domain.RegularTask, domain.Low, nil)
+
+	t.Run("task waits on several blockers", func(t *testing.T) {
+		task, _ := service.GetTask(taskC.ID)
+		if len(task.BlockedBy) != 2 || !task.IsBlockedBy(taskA.IntID) || !task.IsBlockedBy(taskB.IntID) {
+			t.Errorf("Expected Task C to be blocked by A and B, got %v", task.BlockedBy)
+		}
+	})
+
+	t.Run("finishing one blocker keeps the others", func(t *testing.T) {
+		if _, err := service.UpdateTaskStatus(taskA.ID, domain.Done); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		task, _ := service.GetTask(taskC.ID)
+		if task.IsBlockedBy(taskA.IntID) || !task.IsBlockedBy(taskB.IntID) {
+			t.Errorf("Expected Task C to be blocked by B only, got %v", task.BlockedBy)
+		}
+	})
+
+	t.Run("direct cycle is rejected", func(t *testing.T) {
+		_, err := service.SetTaskBlockers(taskB.ID, []int{taskC.IntID})
+		validationErr, ok := err.(*domain.ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T", err)
+		}
+		if !strings.Contains(validationErr.Message, "cycle") {
+			t.Errorf("Expected a cycle error, got %q", validationErr.Message)
+		}
+	})
+
+	t.Run("transitive cycle is rejected", func(t *testing.T) {
+		taskD, _ := service.CreateTask("Task D", "", testProject.ID, domain.RegularTask, domain.Low, []int{taskC.IntID})
+		_, err := service.AddTaskBlockers(taskB.ID, taskD.IntID)
+		if _, ok := err.(*domain.ValidationError); !ok {
+			t.Errorf("Expected ValidationError for B → D → C → B, got %T", err)
+		}
+		task, _ := service.GetTask(taskB.ID)
+		if task.IsBlocked() {
+			t.Error("A rejected change must not be saved")
+		}
+	})
+
+	t.Run("blocker from another project is rejected", func(t *testing.T) {
+		_, err := service.SetTaskBlockers(taskC.ID, []int{outsider.IntID})
+		if _, ok := err.(*domain.ValidationError); !ok {
+			t.Errorf("Expected ValidationError, got %T", err)
+		}
+	})
+
+	t.Run("missing blocker is rejected", func(t *testing.T) {
+		_, err := service.CreateTask("Orphan", "", testProject.ID, domain.RegularTask, domain.Low, []int{999})
+		if _, ok := err.(*domain.ValidationError); !ok {
+			t.Errorf("Expected ValidationError, got %T", err)
+		}
+	})
+
+	t.Run("blockers can be removed one at a time", func(t *testing.T) {
+		task, err := service.RemoveTaskBlockers(taskC.ID, taskB.IntID)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if task.IsBlocked() {
+			t.Errorf("Expected Task C to be unblocked, got %v", task.BlockedBy)
+		}
+	})
+}
+
+func TestTaskService_GetTaskByIntID(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	testProject := domain.NewProject("Test Project", "Test Description", "blue")
+	projectRepo.Create(testProject)
+	service := NewTaskService(taskRepo, projectRepo)
+
+	created, err := service.CreateTask("Find me", "", testProject.ID, domain.Bug, domain.High, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	t.Run("existing task is returned", func(t *testing.T) {
+		task, err := service.GetTaskByIntID(created.IntID)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if task.ID != created.ID {
+			t.Errorf("Expected task '%s', got '%s'", created.ID, task.ID)
+		}
+	})
+
+	t.Run("missing task is a validation error", func(t *testing.T) {
+		task, err := service.GetTaskByIntID(999)
+		if task != nil {
+			t.Error("Expected no task")
+		}
+		if _, ok := err.(*domain.ValidationError); !ok {
+			t.Errorf("Expected ValidationError, got %T", err)
+		}
+	})
+}
+
+func TestTaskService_CustomWorkflow(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	testProject := domain.NewProject("Test Project", "Test Description", "blue")
+	workflow, err := domain.NewWorkflow([]string{"Backlog", "Ready", "In Progress", "In Review", "Done"}, "")
+	if err != nil {
+		t.Fatalf("Expected valid workflow, got %v", err)
+	}
+	testProject.Workflow = workflow
+	projectRepo.Create(testProject)
+	service := NewTaskService(taskRepo, projectRepo)
+
+	blocker, _ := service.CreateTask("Blocker", "", testProject.ID, domain.RegularTask, domain.Low, nil)
+	blocked, _ := service.CreateTask("Blocked", "", testProject.ID, domain.RegularTask, domain.Low, []int{blocker.IntID})
+
+	t.Run("next walks every column", func(t *testing.T) {
+		for want := domain.Status(1); want < domain.Status(4); want++ {
+			task, err := service.MoveTaskToNextStatus(blocker.ID)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if task.Status != want {
+				t.Errorf("Expected status %d, got %d", want, task.Status)
+			}
+		}
+
+		dependent, _ := service.GetTask(blocked.ID)
+		if !dependent.IsBlocked() {
+			t.Error("Columns before Done must not unblock dependents")
+		}
+	})
+
+	t.Run("done column unblocks dependents", func(t *testing.T) {
+		task, _ := service.MoveTaskToNextStatus(blocker.ID)
+		if task.Status != domain.Status(4) {
+			t.Errorf("Expected Done at position 4, got %d", task.Status)
+		}
+
+		dependent, _ := service.GetTask(blocked.ID)
+		if dependent.IsBlocked() {
+			t.Error("Expected dependent to be unblocked")
+		}
+	})
+
+	t.Run("status outside the workflow is rejected", func(t *testing.T) {
+		_, err := service.UpdateTaskStatus(blocker.ID, domain.Status(5))
+		if _, ok := err.(*domain.ValidationError); !ok {
+			t.Errorf("Expected ValidationError, got %v", err)
+		}
+	})
+}
+
+func TestTaskService_WIPLimits(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	testProject := domain.NewProject("Test Project", "Test Description", "blue")
+	testProject.Workflow = domain.DefaultWorkflow().WithWIPLimit(domain.InProgress, 1)
+	projectRepo.Create(testProject)
+	service := NewTaskService(taskRepo, projectRepo)
+
+	first, _ := service.CreateTask("First", "", testProject.ID, domain.RegularTask, domain.Low, nil)
+	second, _ := service.CreateTask("Second", "", testProject.ID, domain.RegularTask, domain.Low, nil)
+	if _, err := service.MoveTaskToNextStatus(first.ID); err != nil {
+		t.Fatalf("Expected move within the limit to succeed, got %v", err)
+	}
+
+	t.Run("reject mode refuses the move", func(t *testing.T) {
+		task, err := service.MoveTaskToNextStatus(second.ID)
+		wipErr, ok := err.(*domain.WIPLimitError)
+		if !ok {
+			t.Fatalf("Expected WIPLimitError, got %v", err)
+		}
+		if !wipErr.Enforced || wipErr.Count != 2 || wipErr.Limit != 1 || wipErr.Status != "In Progress" {
+			t.Errorf("Unexpected error details: %+v", wipErr)
+		}
+		if task != nil {
+			t.Error("Expected no task for a rejected move")
+		}
+
+		stored, _ := service.GetTask(second.ID)
+		if stored.Status != domain.NotStarted {
+			t.Errorf("Expected task to stay in Not Started, got %d", stored.Status)
+		}
+
+		if _, err := service.UpdateTaskStatus(second.ID, domain.InProgress); err == nil {
+			t.Error("Expected UpdateTaskStatus to enforce the limit too")
+		}
+	})
+
+	t.Run("moves within a column or out of it are not limited", func(t *testing.T) {
+		if _, err := service.UpdateTaskStatus(first.ID, domain.InProgress); err != nil {
+			t.Errorf("Expected no error for an unchanged status, got %v", err)
+		}
+		if _, err := service.UpdateTaskStatus(second.ID, domain.Done); err != nil {
+			t.Errorf("Expected no error for an unlimited column, got %v", err)
+		}
+		service.UpdateTaskStatus(second.ID, domain.NotStarted)
+	})
+
+	t.Run("warn mode saves the move and returns a warning", func(t *testing.T) {
+		service.SetWIPEnforcement(domain.WIPWarn)
+		defer service.SetWIPEnforcement(domain.WIPReject)
+
+		task, err := service.MoveTaskToNextStatus(second.ID)
+		wipErr, ok := err.(*domain.WIPLimitError)
+		if !ok || wipErr.Enforced {
+			t.Fatalf("Expected an unenforced WIPLimitError, got %v", err)
+		}
+		if task == nil || task.Status != domain.InProgress {
+			t.Errorf("Expected the task to be moved to In Progress, got %+v", task)
+		}
+	})
+}
+
+func TestTaskService_SetTaskDates(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	testProject := domain.NewProject("Test Project", "Test Description", "blue")
+	projectRepo.Create(testProject)
+	service := NewTaskService(taskRepo, projectRepo)
+
+	created, err := service.CreateTask("Dated", "", testProject.ID, domain.RegularTask, domain.Low, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	now := time.Now()
+	start, _ := domain.ParseDate("today", now)
+	due, _ := domain.ParseDate("+3d", now)
+
+	task, err := service.SetTaskDates(created.ID, start, due)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if domain.FormatDate(task.DueDate) != domain.FormatDate(due) {
+		t.Errorf("Expected due date %s, got %s", domain.FormatDate(due), domain.FormatDate(task.DueDate))
+	}
+
+	if _, err := service.SetTaskDates(created.ID, due, start); err == nil {
+		t.Error("Expected a start date after the due date to be rejected")
+	}
+
+	task, err = service.SetTaskDates(created.ID, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if task.StartDate != nil || task.DueDate != nil {
+		t.Error("Expected both dates to be cleared")
+	}
+}
+
+func TestTaskService_EditTask(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	testProject := domain.NewProject("Test Project", "Test Description", "blue")
+	projectRepo.Create(testProject)
+	service := NewTaskService(taskRepo, projectRepo)
+
+	blocker, _ := service.CreateTask("Blocker", "", testProject.ID, domain.RegularTask, domain.Low, nil)
+	created, _ := service.CreateTask("Original", "", testProject.ID, domain.RegularTask, domain.Low, nil)
+	due, _ := domain.ParseDate("+3d", time.Now())
+
+	task, err := service.EditTask(created.ID, func(task *domain.Task) {
+		task.Name = "Renamed"
+		task.Priority = domain.High
+		task.BlockedBy = []int{blocker.IntID}
+		task.DueDate = due
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if task.Name != "Renamed" || task.Priority != domain.High || !task.IsBlockedBy(blocker.IntID) || task.DueDate == nil {
+		t.Errorf("Expected every change to be saved, got %+v", task)
+	}
+
+	_, err = service.EditTask(blocker.ID, func(task *domain.Task) {
+		task.Name = "Cyclic"
+		task.BlockedBy = []int{created.IntID}
+	})
+	if err == nil {
+		t.Fatal("Expected a dependency cycle to be rejected")
+	}
+	if stored, _ := service.GetTask(blocker.ID); stored.Name != "Blocker" || len(stored.BlockedBy) != 0 {
+		t.Errorf("Expected a refused edit to change nothing, got %+v", stored)
+	}
+}
+
+func TestTaskService_PlanWIP(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	testProject := domain.NewProject("Test Project", "Test Description", "blue")
+	testProject.Workflow = domain.DefaultWorkflow().WithWIPLimit(domain.InProgress, 2)
+	projectRepo.Create(testProject)
+	service := NewTaskService(taskRepo, projectRepo)
+
+	started, _ := service.CreateTask("Started", "", testProject.ID, domain.RegularTask, domain.Low, nil)
+	service.UpdateTaskStatus(started.ID, domain.InProgress)
+
+	plan, err := service.PlanWIP(testProject.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	plan.Create()
+	if wipErr := plan.Move(domain.NotStarted, domain.InProgress); wipErr != nil {
+		t.Errorf("Expected the second task to fit, got %v", wipErr)
+	}
+	plan.Create()
+	wipErr := plan.Move(domain.NotStarted, domain.InProgress)
+	if wipErr == nil || !wipErr.Enforced || wipErr.Count != 3 {
+		t.Fatalf("Expected the third task to go over the limit, got %+v", wipErr)
+	}
+	if wipErr := plan.Move(domain.InProgress, domain.Done); wipErr != nil {
+		t.Errorf("Expected moving out of a column to be free, got %v", wipErr)
+	}
+	if wipErr := plan.Move(domain.InProgress, domain.InProgress); wipErr != nil {
+		t.Errorf("Expected an unchanged status to be free, got %v", wipErr)
+	}
+	if stored, _ := service.GetTasksByStatus(testProject.ID, domain.InProgress); len(stored) != 1 {
+		t.Errorf("Expected the plan to write nothing, got %d tasks in progress", len(stored))
+	}
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"fmt"
+	"kahn/internal/domain"
+	"time"
+)
+
+// GetTrashedTasks returns the tasks in the trash of a project, or of every
+// project when projectID is empty, most recently deleted first
+func (ts *TaskService) GetTrashedTasks(projectID string) ([]domain.Task, error) {
+	tasks, err := ts.taskRepo.GetTrashed(projectID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("get trashed tasks for", "project", projectID, err)
+	}
+	return tasks, nil
+}
+
+// RestoreTask takes the task out of the trash. Tasks that waited on it before
+// it was deleted wait on it again, so the task stays in the trash when those
+// links would close a dependency cycle with links made since. Its column's WIP
+// limit is checked as for a move: with WIPWarn the restored task is returned
+// alongside a *domain.WIPLimitError.
+func (ts *TaskService) RestoreTask(id string) (*domain.Task, error) {
+	task, err := ts.trashedTask(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ts.checkRestoreCycle(task); err != nil {
+		return nil, err
+	}
+	wipErr, err := ts.checkRestoreWIPLimit(task)
+	if err != nil {
+		return nil, err
+	}
+	if wipErr != nil && wipErr.Enforced {
+		return nil, wipErr
+	}
+
+	if err := ts.taskRepo.Restore(id); err != nil {
+		return nil, domain.NewRepositoryError("restore", "task", id, err)
+	}
+	ts.events.recordTask(task, domain.EventRestored, "", "")
+	dependents := ts.dependentsOf(task)
+	for i := range dependents {
+		ts.events.recordTask(&dependents[i], domain.EventBlocked, "", fmt.Sprintf("#%d", task.IntID))
+	}
+
+	task.DeletedAt = nil
+	if wipErr != nil {
+		return task, wipErr
+	}
+	return task, nil
+}
+
+// checkRestoreWIPLimit returns a WIPLimitError when taking the trashed task out
+// of the trash puts its column over its limit. Archived tasks are not on the
+// board and never count.
+func (ts *TaskService) checkRestoreWIPLimit(task *domain.Task) (*domain.WIPLimitError, error) {
+	if task.ArchivedAt != nil {
+		return nil, nil
+	}
+	workflow, err := ts.workflowFor(task.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	return ts.checkColumnRoom(task.ProjectID, workflow, task.Status)
+}
+
+// checkRestoreCycle returns the dependency graph of the trashed task's project
+// with the task out of the trash, or an error when the links kept for it close
+// a cycle
+func (ts *TaskService) checkRestoreCycle(task *domain.Task) (domain.DependencyGraph, error) {
+	graph, err := ts.dependencyGraph(task.ProjectID, task.IntID)
+	if err != nil {
+		return nil, err
+	}
+	if cycle := graph.FindCycle(task.IntID, graph[task.IntID]); cycle != nil {
+		return nil, domain.NewValidationError("blocked_by", fmt.Sprintf(
+			"restoring #%d would close a %s; unblock one of these tasks first", task.IntID, domain.NewDependencyCycleError(cycle).Message))
+	}
+	return graph, nil
+}
+
+// PurgeTask deletes a task in the trash for good
+func (ts *TaskService) PurgeTask(id string) error {
+	task, err := ts.trashedTask(id)
+	if err != nil {
+		return err
+	}
+
+	if err := ts.taskRepo.Purge(id); err != nil {
+		return domain.NewRepositoryError("purge", "task", id, err)
+	}
+	ts.events.recordTask(task, domain.EventPurged, "", "")
+	return nil
+}
+
+// trashedTask finds a task in the trash by ID
+func (ts *TaskService) trashedTask(id string) (*domain.Task, error) {
+	if err := ts.validator.ValidateEntityID(id, "task"); err != nil {
+		return nil, err
+	}
+
+	tasks, err := ts.GetTrashedTasks("")
+	if err != nil {
+		return nil, err
+	}
+	for i := range tasks {
+		if tasks[i].ID == id {
+			return &tasks[i], nil
+		}
+	}
+	return nil, domain.NewValidationError("id", "task is not in the trash")
+}
+
+// GetArchivedTasks returns the project's archived tasks, most recently archived first
+func (ts *TaskService) GetArchivedTasks(projectID string) ([]domain.Task, error) {
+	tasks, err := ts.taskRepo.GetArchived(projectID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("get archived tasks for", "project", projectID, err)
+	}
+	return tasks, nil
+}
+
+// ArchiveTask hides a task in its workflow's done column from the board
+func (ts *TaskService) ArchiveTask(id string) (*domain.Task, error) {
+	task, err := ts.validator.ValidateTaskExists(ts.taskRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if task.ArchivedAt != nil {
+		return nil, domain.NewValidationError("id", "task is already archived")
+	}
+
+	workflow, err := ts.workflowFor(task.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if !workflow.IsDone(task.Status) {
+		return nil, domain.NewValidationError("status", fmt.Sprintf("only tasks in '%s' can be archived", workflow.Name(workflow.DoneStatus())))
+	}
+
+	if err := ts.archive(task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (ts *TaskService) archive(task *domain.Task) error {
+	if err := ts.taskRepo.Archive(task.ID); err != nil {
+		return domain.NewRepositoryError("archive", "task", task.ID, err)
+	}
+	ts.events.recordTask(task, domain.EventArchived, "", "")
+
+	now := time.Now()
+	task.ArchivedAt = &now
+	return nil
+}
+
+// UnarchiveTask puts an archived task back on the board, in the column it was
+// archived from
+func (ts *TaskService) UnarchiveTask(id string) (*domain.Task, error) {
+	task, err := ts.validator.ValidateTaskExists(ts.taskRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if task.ArchivedAt == nil {
+		return nil, domain.NewValidationError("id", "task is not archived")
+	}
+
+	if err := ts.taskRepo.Unarchive(id); err != nil {
+		return nil, domain.NewRepositoryError("unarchive", "task", id, err)
+	}
+	ts.events.recordTask(task, domain.EventUnarchived, "", "")
+
+	task.ArchivedAt = nil
+	return task, nil
+}
+
+// AutoArchive archives the tasks of every project that have sat in a done
+// column for more than days days, judged by their last update, and returns how
+// many it archived. Zero or fewer days archives nothing.
+func (ts *TaskService) AutoArchive(days int) (int, error) {
+	if days <= 0 {
+		return 0, nil
+	}
+
+	projects, err := ts.projectRepo.GetAll()
+	if err != nil {
+		return 0, domain.NewRepositoryError("get all", "projects", "", err)
+	}
+	cutoff := time.Now().AddDate(0, 0, -days)
+	archived := 0
+	for _, project := range projects {
+		tasks, err := ts.taskRepo.GetByProjectID(project.ID)
+		if err != nil {
+			return archived, domain.NewRepositoryError("get tasks for", "project", project.ID, err)
+		}
+		for i := range tasks {
+			if !project.Workflow.IsDone(tasks[i].Status) || !tasks[i].UpdatedAt.Before(cutoff) {
+				continue
+			}
+			if err := ts.archive(&tasks[i]); err != nil {
+				return archived, err
+			}
+			archived++
+		}
+	}
+	return archived, nil
+}
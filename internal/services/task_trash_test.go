@@ -0,0 +1,228 @@
+package services
+
+import (
+	"kahn/internal/domain"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTaskService_TrashAndRestore(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	testProject := domain.NewProject("Test Project", "Test Description", "blue")
+	projectRepo.Create(testProject)
+	service := NewTaskService(taskRepo, projectRepo)
+	events := NewEventLog(NewMockEventRepository(), "alice")
+	service.SetEventLog(events)
+
+	blocker, _ := service.CreateTask("Design schema", "", testProject.ID, domain.Feature, domain.Medium, nil)
+	task, _ := service.CreateTask("Write migration", "", testProject.ID, domain.Feature, domain.Low, []int{blocker.IntID})
+
+	if err := service.DeleteTask(blocker.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if found, _ := service.GetTask(blocker.ID); found != nil {
+		t.Error("Expected a trashed task to be hidden")
+	}
+	if waiting, _ := service.GetTask(task.ID); len(waiting.BlockedBy) != 0 {
+		t.Errorf("Expected the dependent to stop waiting, got %v", waiting.BlockedBy)
+	}
+	trashed, _ := service.GetTrashedTasks(testProject.ID)
+	if len(trashed) != 1 || trashed[0].ID != blocker.ID {
+		t.Fatalf("Expected the task in the trash, got %+v", trashed)
+	}
+
+	restored, err := service.RestoreTask(blocker.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if restored.DeletedAt != nil {
+		t.Error("Expected the restored task to be out of the trash")
+	}
+	if waiting, _ := service.GetTask(task.ID); len(waiting.BlockedBy) != 1 {
+		t.Errorf("Expected the dependent to wait on the restored task, got %v", waiting.BlockedBy)
+	}
+	history, _ := events.List(domain.EventFilter{TaskIntID: task.IntID})
+	if last := history[len(history)-1]; last.Describe() != "now waits on #1" {
+		t.Errorf("Expected the dependent to be blocked again, got %q", last.Describe())
+	}
+
+	if _, err := service.RestoreTask(blocker.ID); err == nil {
+		t.Error("Expected an error restoring a task that is not in the trash")
+	}
+	if err := service.PurgeTask(blocker.ID); err == nil {
+		t.Error("Expected an error purging a task that is not in the trash")
+	}
+
+	service.DeleteTask(blocker.ID)
+	if err := service.PurgeTask(blocker.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if trashed, _ := service.GetTrashedTasks(""); len(trashed) != 0 {
+		t.Errorf("Expected an empty trash, got %+v", trashed)
+	}
+	blockerHistory, _ := events.List(domain.EventFilter{TaskIntID: blocker.IntID})
+	if last := blockerHistory[len(blockerHistory)-1]; last.Kind != domain.EventPurged {
+		t.Errorf("Expected the purge to be recorded, got %+v", last)
+	}
+}
+
+func TestTaskService_RestoreChecksWIPLimit(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	testProject := domain.NewProject("Test Project", "Test Description", "blue")
+	testProject.Workflow = domain.DefaultWorkflow().WithWIPLimit(domain.InProgress, 1)
+	projectRepo.Create(testProject)
+	service := NewTaskService(taskRepo, projectRepo)
+
+	deleted, _ := service.CreateTask("Deleted", "", testProject.ID, domain.RegularTask, domain.Low, nil)
+	service.UpdateTaskStatus(deleted.ID, domain.InProgress)
+	service.DeleteTask(deleted.ID)
+	started, _ := service.CreateTask("Started", "", testProject.ID, domain.RegularTask, domain.Low, nil)
+	service.UpdateTaskStatus(started.ID, domain.InProgress)
+
+	task, err := service.RestoreTask(deleted.ID)
+	wipErr, ok := err.(*domain.WIPLimitError)
+	if !ok || !wipErr.Enforced || task != nil {
+		t.Fatalf("Expected an enforced WIPLimitError, got %v", err)
+	}
+	if trashed, _ := service.GetTrashedTasks(testProject.ID); len(trashed) != 1 {
+		t.Errorf("Expected the task to stay in the trash, got %+v", trashed)
+	}
+
+	untrash := []domain.UndoAction{{Kind: domain.UndoUntrashTask, ID: deleted.ID}}
+	if _, err := service.CheckUndo(untrash); err == nil {
+		t.Error("Expected undoing the deletion to be refused as well")
+	}
+
+	service.SetWIPEnforcement(domain.WIPWarn)
+	if warning, err := service.CheckUndo(untrash); err != nil || warning == nil {
+		t.Errorf("Expected undoing the deletion to warn, got %v and %v", warning, err)
+	}
+	task, err = service.RestoreTask(deleted.ID)
+	if wipErr, ok := err.(*domain.WIPLimitError); !ok || wipErr.Enforced || task == nil {
+		t.Errorf("Expected the task restored with a warning, got %+v and %v", task, err)
+	}
+}
+
+func TestTaskService_RestoreRefusesCycle(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	testProject := domain.NewProject("Test Project", "Test Description", "blue")
+	projectRepo.Create(testProject)
+	service := NewTaskService(taskRepo, projectRepo)
+
+	// #1 waits on #2, which waits on #3
+	third, _ := service.CreateTask("Third", "", testProject.ID, domain.Feature, domain.Medium, nil)
+	second, _ := service.CreateTask("Second", "", testProject.ID, domain.Feature, domain.Medium, nil)
+	first, _ := service.CreateTask("First", "", testProject.ID, domain.Feature, domain.Medium, nil)
+	service.SetTaskBlockers(first.ID, []int{second.IntID})
+	service.SetTaskBlockers(second.ID, []int{third.IntID})
+
+	service.DeleteTask(second.ID)
+	if _, err := service.SetTaskBlockers(third.ID, []int{first.IntID}); err != nil {
+		t.Fatalf("Expected no error while the middle task is in the trash, got %v", err)
+	}
+
+	_, err := service.RestoreTask(second.ID)
+	if err == nil {
+		t.Fatal("Expected an error restoring a task that closes a cycle")
+	}
+	if !strings.Contains(err.Error(), "dependency cycle") {
+		t.Errorf("Expected the cycle in the error, got %v", err)
+	}
+	if trashed, _ := service.GetTrashedTasks(testProject.ID); len(trashed) != 1 {
+		t.Errorf("Expected the task to stay in the trash, got %+v", trashed)
+	}
+
+	untrash := []domain.UndoAction{{Kind: domain.UndoUntrashTask, ID: second.ID}}
+	if _, err := service.CheckUndo(untrash); err == nil {
+		t.Error("Expected undoing the deletion to be refused as well")
+	}
+
+	service.SetTaskBlockers(third.ID, nil)
+	if _, err := service.RestoreTask(second.ID); err != nil {
+		t.Errorf("Expected the restore to succeed once the cycle is broken, got %v", err)
+	}
+}
+
+func TestTaskService_Archive(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	testProject := domain.NewProject("Test Project", "Test Description", "blue")
+	projectRepo.Create(testProject)
+	service := NewTaskService(taskRepo, projectRepo)
+
+	open, _ := service.CreateTask("Open", "", testProject.ID, domain.RegularTask, domain.Medium, nil)
+	done, _ := service.CreateTask("Shipped", "", testProject.ID, domain.RegularTask, domain.Medium, nil)
+	service.UpdateTaskStatus(done.ID, domain.Done)
+
+	if _, err := service.ArchiveTask(open.ID); err == nil {
+		t.Error("Expected an error archiving a task outside the done column")
+	}
+	archived, err := service.ArchiveTask(done.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if archived.ArchivedAt == nil {
+		t.Error("Expected the task to be archived")
+	}
+	if _, err := service.ArchiveTask(done.ID); err == nil {
+		t.Error("Expected an error archiving a task twice")
+	}
+	if _, err := service.MoveTaskToPreviousStatus(done.ID); err == nil {
+		t.Error("Expected an error moving an archived task")
+	}
+
+	board, _ := service.GetTasksByStatus(testProject.ID, domain.Done)
+	if len(board) != 0 {
+		t.Errorf("Expected archived tasks to leave the board, got %+v", board)
+	}
+	list, _ := service.GetArchivedTasks(testProject.ID)
+	if len(list) != 1 || list[0].ID != done.ID {
+		t.Fatalf("Expected the task in the archive, got %+v", list)
+	}
+
+	if _, err := service.UnarchiveTask(done.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.UnarchiveTask(done.ID); err == nil {
+		t.Error("Expected an error unarchiving a task that is not archived")
+	}
+	if board, _ := service.GetTasksByStatus(testProject.ID, domain.Done); len(board) != 1 {
+		t.Errorf("Expected the task back in the done column, got %+v", board)
+	}
+}
+
+func TestTaskService_AutoArchive(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	testProject := domain.NewProject("Test Project", "Test Description", "blue")
+	projectRepo.Create(testProject)
+	service := NewTaskService(taskRepo, projectRepo)
+
+	old := time.Now().AddDate(0, 0, -10)
+	for _, task := range []domain.Task{
+		{ID: "old_done", ProjectID: testProject.ID, Name: "Old done", Status: domain.Done, UpdatedAt: old},
+		{ID: "new_done", ProjectID: testProject.ID, Name: "New done", Status: domain.Done, UpdatedAt: time.Now()},
+		{ID: "old_open", ProjectID: testProject.ID, Name: "Old open", Status: domain.InProgress, UpdatedAt: old},
+	} {
+		taskRepo.Create(&task)
+	}
+
+	if count, _ := service.AutoArchive(0); count != 0 {
+		t.Errorf("Expected auto-archiving to be off for 0 days, archived %d", count)
+	}
+	count, err := service.AutoArchive(7)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("Expected 1 task archived, got %d", count)
+	}
+	archived, _ := service.GetArchivedTasks(testProject.ID)
+	if len(archived) != 1 || archived[0].ID != "old_done" {
+		t.Errorf("Expected only the old done task archived, got %+v", archived)
+	}
+}
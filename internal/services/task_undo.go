@@ -0,0 +1,188 @@
+package services
+
+import (
+	"fmt"
+	"kahn/internal/domain"
+)
+
+// CheckUndo checks the actions of an undo or redo step before they are written
+// straight to the database, as the service would check the same changes: a
+// task moved back or taken out of the trash fits in its column's WIP limit, a
+// moved task is on the board, a restored task belongs to a project outside the
+// trash, and no link, including those kept for a task taken out of the trash,
+// closes a dependency cycle.
+// Under WIPWarn a column going over its limit is returned as a warning instead
+// of an error.
+func (ts *TaskService) CheckUndo(actions []domain.UndoAction) (*domain.WIPLimitError, error) {
+	check := &undoCheck{ts: ts, graphs: make(map[string]domain.DependencyGraph)}
+	for _, action := range actions {
+		var err error
+		switch action.Kind {
+		case domain.UndoUpdateTask:
+			if action.Task != nil {
+				err = check.updateTask(action.Task.Task)
+			}
+		case domain.UndoRestoreTask:
+			if action.Task != nil {
+				err = check.restoreTask(action.Task.Task)
+			}
+		case domain.UndoLinkBlockers:
+			err = check.linkBlockers(action.Links)
+		case domain.UndoUntrashTask:
+			err = check.untrashTask(action.ID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return check.warning, nil
+}
+
+// undoCheck follows the actions of one undo or redo step on the dependency
+// graphs of the projects they touch, so each action is checked against the
+// links the earlier ones make
+type undoCheck struct {
+	ts      *TaskService
+	graphs  map[string]domain.DependencyGraph // by project ID
+	warning *domain.WIPLimitError
+}
+
+// updateTask checks writing task over the stored task with the same ID
+func (c *undoCheck) updateTask(task domain.Task) error {
+	current, err := c.ts.validator.ValidateTaskExists(c.ts.taskRepo, task.ID)
+	if err != nil {
+		return err
+	}
+
+	if current.Status != task.Status {
+		if current.ArchivedAt != nil {
+			return domain.NewValidationError("status", "archived tasks cannot be moved; unarchive the task first")
+		}
+		workflow, err := c.ts.workflowFor(current.ProjectID)
+		if err != nil {
+			return err
+		}
+		wipErr, err := c.ts.checkWIPLimit(current, workflow, task.Status)
+		if err != nil {
+			return err
+		}
+		if wipErr != nil && wipErr.Enforced {
+			return wipErr
+		}
+		if wipErr != nil {
+			c.warning = wipErr
+		}
+	}
+
+	graph, err := c.graph(current.ProjectID)
+	if err != nil {
+		return err
+	}
+	if cycle := graph.FindCycle(task.IntID, task.BlockedBy); cycle != nil {
+		return domain.NewDependencyCycleError(cycle)
+	}
+	graph[task.IntID] = task.BlockedBy
+	return nil
+}
+
+// restoreTask checks inserting a task deleted for good again
+func (c *undoCheck) restoreTask(task domain.Task) error {
+	if _, err := c.ts.validator.ValidateProjectExists(c.ts.projectRepo, task.ProjectID); err != nil {
+		return err
+	}
+	graph, err := c.graph(task.ProjectID)
+	if err != nil {
+		return err
+	}
+	if cycle := graph.FindCycle(task.IntID, task.BlockedBy); cycle != nil {
+		return domain.NewDependencyCycleError(cycle)
+	}
+	graph[task.IntID] = task.BlockedBy
+	return nil
+}
+
+// linkBlockers checks making each task wait on its blocker again. The links of
+// tasks in the trash are left for restoring them to check.
+func (c *undoCheck) linkBlockers(links []domain.BlockerLink) error {
+	for _, link := range links {
+		task, err := c.ts.taskRepo.GetByIntID(link.TaskIntID)
+		if err != nil {
+			return domain.NewRepositoryError("get", "task", fmt.Sprintf("int_id=%d", link.TaskIntID), err)
+		}
+		if task == nil {
+			continue
+		}
+		graph, err := c.graph(task.ProjectID)
+		if err != nil {
+			return err
+		}
+		if cycle := graph.FindCycle(link.TaskIntID, []int{link.BlockerIntID}); cycle != nil {
+			return domain.NewDependencyCycleError(cycle)
+		}
+		graph[link.TaskIntID] = append(graph[link.TaskIntID], link.BlockerIntID)
+	}
+	return nil
+}
+
+// untrashTask checks taking the task with id out of the trash. A task no longer
+// in the trash is left for the repository to report.
+func (c *undoCheck) untrashTask(id string) error {
+	task, err := c.ts.trashedTask(id)
+	if err != nil {
+		return nil
+	}
+	graph, err := c.ts.checkRestoreCycle(task)
+	if err != nil {
+		return err
+	}
+	wipErr, err := c.ts.checkRestoreWIPLimit(task)
+	if err != nil {
+		return err
+	}
+	if wipErr != nil && wipErr.Enforced {
+		return wipErr
+	}
+	if wipErr != nil {
+		c.warning = wipErr
+	}
+	c.graphs[task.ProjectID] = graph
+	return nil
+}
+
+// graph returns the dependency graph of the project as the step has left it so far
+func (c *undoCheck) graph(projectID string) (domain.DependencyGraph, error) {
+	if graph, ok := c.graphs[projectID]; ok {
+		return graph, nil
+	}
+	graph, err := c.ts.dependencyGraph(projectID)
+	if err != nil {
+		return nil, err
+	}
+	c.graphs[projectID] = graph
+	return graph, nil
+}
+
+// dependencyGraph returns the links between the project's tasks outside the
+// trash, archived ones included. The trashed tasks numbered restoring keep
+// their links, as if they were out of the trash.
+func (ts *TaskService) dependencyGraph(projectID string, restoring ...int) (domain.DependencyGraph, error) {
+	graph, err := ts.taskRepo.GetDependencyGraph(projectID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("get dependency graph for", "project", projectID, err)
+	}
+	trashed, err := ts.taskRepo.GetTrashed(projectID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("get trashed tasks for", "project", projectID, err)
+	}
+	kept := make(map[int]bool, len(restoring))
+	for _, intID := range restoring {
+		kept[intID] = true
+	}
+	var intIDs []int
+	for _, task := range trashed {
+		if !kept[task.IntID] {
+			intIDs = append(intIDs, task.IntID)
+		}
+	}
+	return graph.Without(intIDs...), nil
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"kahn/internal/domain"
+	"testing"
+)
+
+func TestTaskService_CheckUndo(t *testing.T) {
+	taskRepo := NewMockTaskRepository()
+	projectRepo := NewMockProjectRepository()
+	testProject := domain.NewProject("Test Project", "Test Description", "blue")
+	testProject.Workflow = domain.DefaultWorkflow().WithWIPLimit(domain.InProgress, 1)
+	projectRepo.Create(testProject)
+	service := NewTaskService(taskRepo, projectRepo)
+
+	design, _ := service.CreateTask("Design", "", testProject.ID, domain.Feature, domain.Medium, nil)
+	build, _ := service.CreateTask("Build", "", testProject.ID, domain.Feature, domain.Medium, []int{design.IntID})
+	service.UpdateTaskStatus(build.ID, domain.InProgress)
+
+	update := func(task domain.Task) []domain.UndoAction {
+		return []domain.UndoAction{{Kind: domain.UndoUpdateTask, Task: &domain.TaskSnapshot{Task: task}}}
+	}
+
+	t.Run("Cycle", func(t *testing.T) {
+		waiting := *design
+		waiting.BlockedBy = []int{build.IntID}
+		if _, err := service.CheckUndo(update(waiting)); err == nil {
+			t.Error("Expected an error for an update closing a cycle")
+		}
+
+		link := []domain.UndoAction{{Kind: domain.UndoLinkBlockers, Links: []domain.BlockerLink{{TaskIntID: design.IntID, BlockerIntID: build.IntID}}}}
+		if _, err := service.CheckUndo(link); err == nil {
+			t.Error("Expected an error for a link closing a cycle")
+		}
+	})
+
+	t.Run("WIP limit", func(t *testing.T) {
+		started := *design
+		started.Status = domain.InProgress
+		_, err := service.CheckUndo(update(started))
+		if wipErr, ok := err.(*domain.WIPLimitError); !ok || !wipErr.Enforced {
+			t.Fatalf("Expected an enforced WIPLimitError, got %v", err)
+		}
+
+		service.SetWIPEnforcement(domain.WIPWarn)
+		defer service.SetWIPEnforcement(domain.WIPReject)
+		warning, err := service.CheckUndo(update(started))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if warning == nil || warning.Enforced {
+			t.Errorf("Expected an unenforced warning, got %v", warning)
+		}
+	})
+
+	t.Run("Archived task", func(t *testing.T) {
+		shipped, _ := service.CreateTask("Ship", "", testProject.ID, domain.Feature, domain.Medium, nil)
+		service.UpdateTaskStatus(shipped.ID, domain.Done)
+		archived, err := service.ArchiveTask(shipped.ID)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		reopened := *archived
+		reopened.Status = domain.NotStarted
+		if _, err := service.CheckUndo(update(reopened)); err == nil {
+			t.Error("Expected an error moving an archived task")
+		}
+		renamed := *archived
+		renamed.Name = "Ship it"
+		if _, err := service.CheckUndo(update(renamed)); err != nil {
+			t.Errorf("Expected an archived task to be edited in place, got %v", err)
+		}
+	})
+
+	t.Run("Trashed project", func(t *testing.T) {
+		other := domain.NewProject("Other", "", "green")
+		projectRepo.Create(other)
+		projectRepo.Delete(other.ID)
+
+		task := domain.Task{ID: "task_gone", IntID: 99, ProjectID: other.ID, Name: "Gone"}
+		restore := []domain.UndoAction{{Kind: domain.UndoRestoreTask, Task: &domain.TaskSnapshot{Task: task}}}
+		if _, err := service.CheckUndo(restore); err == nil {
+			t.Error("Expected an error restoring a task into a trashed project")
+		}
+	})
+
+	if warning, err := service.CheckUndo(update(*design)); warning != nil || err != nil {
+		t.Errorf("Expected an unchanged task to pass, got %v, %v", warning, err)
+	}
+}
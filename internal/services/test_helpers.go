@@ -3,6 +3,7 @@ package services
 import (
 	"kahn/internal/domain"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -10,6 +11,7 @@ import (
 type MockTaskRepository struct {
 	tasks     []domain.Task
 	nextIntID int
+	checklist []domain.ChecklistItem
 }
 
 func NewMockTaskRepository() *MockTaskRepository {
@@ -26,21 +28,31 @@ func (r *MockTaskRepository) Create(task *domain.Task) error {
 }
 
 func (r *MockTaskRepository) GetByID(id string) (*domain.Task, error) {
-	for i, task := range r.tasks {
-		if task.ID == id {
+	for _, task := range r.tasks {
+		if task.ID == id && task.DeletedAt == nil {
 			// Return a copy to ensure we get current values
-			taskCopy := r.tasks[i]
+			taskCopy := r.visible(task)
 			return &taskCopy, nil
 		}
 	}
 	return &domain.Task{}, &domain.RepositoryError{Operation: "get", Entity: "task", ID: id}
 }
 
+func (r *MockTaskRepository) GetByIntID(intID int) (*domain.Task, error) {
+	for _, task := range r.tasks {
+		if task.IntID == intID && task.DeletedAt == nil {
+			taskCopy := r.visible(task)
+			return &taskCopy, nil
+		}
+	}
+	return nil, nil
+}
+
 func (r *MockTaskRepository) GetByProjectID(projectID string) ([]domain.Task, error) {
 	var result []domain.Task
 	for _, task := range r.tasks {
-		if task.ProjectID == projectID {
-			result = append(result, task)
+		if task.ProjectID == projectID && onBoard(task) {
+			result = append(result, r.visible(task))
 		}
 	}
 	return result, nil
@@ -49,14 +61,14 @@ func (r *MockTaskRepository) GetByProjectID(projectID string) ([]domain.Task, er
 func (r *MockTaskRepository) GetByStatus(projectID string, status domain.Status) ([]domain.Task, error) {
 	var result []domain.Task
 	for _, task := range r.tasks {
-		if task.ProjectID == projectID && task.Status == status {
-			result = append(result, task)
+		if task.ProjectID == projectID && task.Status == status && onBoard(task) {
+			result = append(result, r.visible(task))
 		}
 	}
 
 	// Apply same ordering logic as SQLite repository
 	if status == domain.NotStarted {
-		// Not Started: priority DESC, then created_at ASC (oldest highest priority first)
+		// First column: priority DESC, then created_at ASC (oldest highest priority first)
 		sort.Slice(result, func(i, j int) bool {
 			if result[i].Priority != result[j].Priority {
 				return result[i].Priority > result[j].Priority // Higher priority first
@@ -64,7 +76,7 @@ func (r *MockTaskRepository) GetByStatus(projectID string, status domain.Status)
 			return result[i].CreatedAt.Before(result[j].CreatedAt) // Older created first
 		})
 	} else {
-		// In Progress and Done: updated_at DESC (newest changes first)
+		// Later columns: updated_at DESC (newest changes first)
 		sort.Slice(result, func(i, j int) bool {
 			return result[i].UpdatedAt.After(result[j].UpdatedAt)
 		})
@@ -101,11 +113,16 @@ func (r *MockTaskRepository) UpdateStatus(taskID string, status domain.Status) e
 }
 
 func (r *MockTaskRepository) ClearBlockersForIntID(intID int) error {
-	// Find and clear BlockedBy for all tasks that are blocked by this intID
+	// Remove intID from the blockers of every task waiting on it
 	for i, task := range r.tasks {
-		if task.BlockedBy != nil && *task.BlockedBy == intID {
+		if task.IsBlockedBy(intID) {
 			updatedTask := task
 			updatedTask.BlockedBy = nil
+			for _, blocker := range task.BlockedBy {
+				if blocker != intID {
+					updatedTask.BlockedBy = append(updatedTask.BlockedBy, blocker)
+				}
+			}
 			updatedTask.UpdatedAt = time.Now()
 			r.tasks[i] = updatedTask
 		}
@@ -113,19 +130,244 @@ func (r *MockTaskRepository) ClearBlockersForIntID(intID int) error {
 	return nil
 }
 
+func (r *MockTaskRepository) GetDependencyGraph(projectID string) (domain.DependencyGraph, error) {
+	graph := make(domain.DependencyGraph)
+	for _, task := range r.tasks {
+		if task.ProjectID == projectID && len(task.BlockedBy) > 0 {
+			graph[task.IntID] = append([]int(nil), task.BlockedBy...)
+		}
+	}
+	return graph, nil
+}
+
 func (r *MockTaskRepository) Delete(id string) error {
+	return r.setTimestamp(id, "delete", func(task *domain.Task) **time.Time { return &task.DeletedAt }, true)
+}
+
+func (r *MockTaskRepository) Restore(id string) error {
+	return r.setTimestamp(id, "restore", func(task *domain.Task) **time.Time { return &task.DeletedAt }, false)
+}
+
+func (r *MockTaskRepository) Archive(id string) error {
+	return r.setTimestamp(id, "archive", func(task *domain.Task) **time.Time { return &task.ArchivedAt }, true)
+}
+
+func (r *MockTaskRepository) Unarchive(id string) error {
+	return r.setTimestamp(id, "unarchive", func(task *domain.Task) **time.Time { return &task.ArchivedAt }, false)
+}
+
+// setTimestamp sets or clears the trash or archive timestamp chosen by field,
+// failing like the SQLite repository when it is already in that state
+func (r *MockTaskRepository) setTimestamp(id, operation string, field func(*domain.Task) **time.Time, set bool) error {
+	for i := range r.tasks {
+		timestamp := field(&r.tasks[i])
+		if r.tasks[i].ID == id && (*timestamp == nil) == set {
+			if set {
+				now := time.Now()
+				*timestamp = &now
+			} else {
+				*timestamp = nil
+			}
+			return nil
+		}
+	}
+	return &domain.RepositoryError{Operation: operation, Entity: "task", ID: id}
+}
+
+func (r *MockTaskRepository) Purge(id string) error {
 	for i, task := range r.tasks {
 		if task.ID == id {
 			r.tasks = append(r.tasks[:i], r.tasks[i+1:]...)
+			return r.ClearBlockersForIntID(task.IntID)
+		}
+	}
+	return &domain.RepositoryError{Operation: "purge", Entity: "task", ID: id}
+}
+
+func (r *MockTaskRepository) GetArchived(projectID string) ([]domain.Task, error) {
+	var result []domain.Task
+	for _, task := range r.tasks {
+		if task.ProjectID == projectID && task.ArchivedAt != nil && task.DeletedAt == nil {
+			result = append(result, r.visible(task))
+		}
+	}
+	return result, nil
+}
+
+func (r *MockTaskRepository) GetTrashed(projectID string) ([]domain.Task, error) {
+	var result []domain.Task
+	for _, task := range r.tasks {
+		if (projectID == "" || task.ProjectID == projectID) && task.DeletedAt != nil {
+			result = append(result, r.visible(task))
+		}
+	}
+	return result, nil
+}
+
+func onBoard(task domain.Task) bool {
+	return task.DeletedAt == nil && task.ArchivedAt == nil
+}
+
+// visible leaves out the blockers in the trash, as the SQLite repository does
+func (r *MockTaskRepository) visible(task domain.Task) domain.Task {
+	trashed := make(map[int]bool)
+	for _, other := range r.tasks {
+		if other.DeletedAt != nil {
+			trashed[other.IntID] = true
+		}
+	}
+	var blockers []int
+	for _, blocker := range task.BlockedBy {
+		if !trashed[blocker] {
+			blockers = append(blockers, blocker)
+		}
+	}
+	task.BlockedBy = blockers
+	return task
+}
+
+func (r *MockTaskRepository) GetChecklist(taskID string) ([]domain.ChecklistItem, error) {
+	var result []domain.ChecklistItem
+	for _, item := range r.checklist {
+		if item.TaskID == taskID {
+			result = append(result, item)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Position < result[j].Position
+	})
+	return result, nil
+}
+
+func (r *MockTaskRepository) GetChecklistItem(id string) (*domain.ChecklistItem, error) {
+	for _, item := range r.checklist {
+		if item.ID == id {
+			itemCopy := item
+			return &itemCopy, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *MockTaskRepository) CreateChecklistItem(item *domain.ChecklistItem) error {
+	for i := range r.checklist {
+		if r.checklist[i].TaskID == item.TaskID && r.checklist[i].Position >= item.Position {
+			r.checklist[i].Position++
+		}
+	}
+	r.checklist = append(r.checklist, *item)
+	r.countChecklist(item.TaskID)
+	return nil
+}
+
+func (r *MockTaskRepository) UpdateChecklistItem(item *domain.ChecklistItem) error {
+	for i := range r.checklist {
+		if r.checklist[i].ID == item.ID {
+			r.checklist[i].Text = item.Text
+			r.checklist[i].Done = item.Done
+			r.checklist[i].UpdatedAt = time.Now()
+			r.countChecklist(item.TaskID)
+			return nil
+		}
+	}
+	return &domain.RepositoryError{Operation: "update", Entity: "checklist item", ID: item.ID}
+}
+
+func (r *MockTaskRepository) DeleteChecklistItem(id string) error {
+	for i, item := range r.checklist {
+		if item.ID == id {
+			r.checklist = append(r.checklist[:i], r.checklist[i+1:]...)
+			for j := range r.checklist {
+				if r.checklist[j].TaskID == item.TaskID && r.checklist[j].Position > item.Position {
+					r.checklist[j].Position--
+				}
+			}
+			r.countChecklist(item.TaskID)
+			return nil
+		}
+	}
+	return &domain.RepositoryError{Operation: "delete", Entity: "checklist item", ID: id}
+}
+
+func (r *MockTaskRepository) ReorderChecklist(taskID string, itemIDs []string) error {
+	for position, id := range itemIDs {
+		for i := range r.checklist {
+			if r.checklist[i].ID == id && r.checklist[i].TaskID == taskID {
+				r.checklist[i].Position = position + 1
+			}
+		}
+	}
+	return nil
+}
+
+// Comments live on the mock tasks themselves, as the SQLite repository loads them
+// with every task
+func (r *MockTaskRepository) GetComments(taskID string) ([]domain.Comment, error) {
+	for _, task := range r.tasks {
+		if task.ID == taskID {
+			return task.Comments, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *MockTaskRepository) CreateComment(comment *domain.Comment) error {
+	for i := range r.tasks {
+		if r.tasks[i].ID == comment.TaskID {
+			r.tasks[i].Comments = append(r.tasks[i].Comments, *comment)
+			r.tasks[i].UpdatedAt = time.Now()
 			return nil
 		}
 	}
-	return &domain.RepositoryError{Operation: "delete", Entity: "task", ID: id}
+	return &domain.RepositoryError{Operation: "create", Entity: "comment", ID: comment.ID}
+}
+
+// Search matches the query as one case-insensitive substring of the name,
+// description or comments, with no ranking or highlights
+func (r *MockTaskRepository) Search(query string, limit int) ([]domain.SearchResult, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil, nil
+	}
+	var results []domain.SearchResult
+	for _, task := range r.tasks {
+		if !onBoard(task) || len(results) == limit {
+			continue
+		}
+		text := task.Name + "\n" + task.Desc
+		for _, comment := range task.Comments {
+			text += "\n" + comment.Body
+		}
+		if strings.Contains(strings.ToLower(text), query) {
+			results = append(results, domain.SearchResult{Task: r.visible(task), Name: task.Name})
+		}
+	}
+	return results, nil
+}
+
+// countChecklist refreshes the checklist counts of the task, as the SQLite
+// repository computes them on every read
+func (r *MockTaskRepository) countChecklist(taskID string) {
+	for i := range r.tasks {
+		if r.tasks[i].ID != taskID {
+			continue
+		}
+		r.tasks[i].ChecklistTotal, r.tasks[i].ChecklistDone = 0, 0
+		for _, item := range r.checklist {
+			if item.TaskID == taskID {
+				r.tasks[i].ChecklistTotal++
+				if item.Done {
+					r.tasks[i].ChecklistDone++
+				}
+			}
+		}
+	}
 }
 
 // MockProjectRepository implements domain.ProjectRepository for testing
 type MockProjectRepository struct {
 	projects []domain.Project
+	tasks    *MockTaskRepository // optional; lets SaveWorkflow remap task statuses
 }
 
 func NewMockProjectRepository() *MockProjectRepository {
@@ -139,7 +381,7 @@ func (r *MockProjectRepository) Create(project *domain.Project) error {
 
 func (r *MockProjectRepository) GetByID(id string) (*domain.Project, error) {
 	for i, project := range r.projects {
-		if project.ID == id {
+		if project.ID == id && project.DeletedAt == nil {
 			return &r.projects[i], nil
 		}
 	}
@@ -147,7 +389,23 @@ func (r *MockProjectRepository) GetByID(id string) (*domain.Project, error) {
 }
 
 func (r *MockProjectRepository) GetAll() ([]domain.Project, error) {
-	return r.projects, nil
+	var result []domain.Project
+	for _, project := range r.projects {
+		if project.DeletedAt == nil {
+			result = append(result, project)
+		}
+	}
+	return result, nil
+}
+
+func (r *MockProjectRepository) GetTrashed() ([]domain.Project, error) {
+	var result []domain.Project
+	for _, project := range r.projects {
+		if project.DeletedAt != nil {
+			result = append(result, project)
+		}
+	}
+	return result, nil
 }
 
 func (r *MockProjectRepository) Update(project *domain.Project) error {
@@ -160,12 +418,223 @@ func (r *MockProjectRepository) Update(project *domain.Project) error {
 	return nil
 }
 
+func (r *MockProjectRepository) SaveWorkflow(projectID string, workflow domain.Workflow, remap map[domain.Status]domain.Status) error {
+	for i, p := range r.projects {
+		if p.ID == projectID {
+			r.projects[i].Workflow = workflow
+			break
+		}
+	}
+	if r.tasks == nil {
+		return nil
+	}
+	for i, task := range r.tasks.tasks {
+		if to, ok := remap[task.Status]; ok && task.ProjectID == projectID {
+			r.tasks.tasks[i].Status = to
+		}
+	}
+	return nil
+}
+
 func (r *MockProjectRepository) Delete(id string) error {
+	for i, project := range r.projects {
+		if project.ID == id && project.DeletedAt == nil {
+			now := time.Now()
+			r.projects[i].DeletedAt = &now
+			return nil
+		}
+	}
+	return &domain.RepositoryError{Operation: "delete", Entity: "project", ID: id}
+}
+
+func (r *MockProjectRepository) Restore(id string) error {
+	for i, project := range r.projects {
+		if project.ID == id && project.DeletedAt != nil {
+			r.projects[i].DeletedAt = nil
+			return nil
+		}
+	}
+	return &domain.RepositoryError{Operation: "restore", Entity: "project", ID: id}
+}
+
+func (r *MockProjectRepository) Purge(id string) error {
 	for i, project := range r.projects {
 		if project.ID == id {
 			r.projects = append(r.projects[:i], r.projects[i+1:]...)
 			return nil
 		}
 	}
-	return &domain.RepositoryError{Operation: "delete", Entity: "project", ID: id}
+	return &domain.RepositoryError{Operation: "purge", Entity: "project", ID: id}
+}
+
+// MockLabelRepository implements domain.LabelRepository for testing
+type MockLabelRepository struct {
+	labels     []domain.Label
+	taskLabels map[string][]string // task ID -> label IDs
+}
+
+func NewMockLabelRepository() *MockLabelRepository {
+	return &MockLabelRepository{labels: []domain.Label{}, taskLabels: map[string][]string{}}
+}
+
+func (r *MockLabelRepository) Create(label *domain.Label) error {
+	r.labels = append(r.labels, *label)
+	return nil
+}
+
+func (r *MockLabelRepository) GetByID(id string) (*domain.Label, error) {
+	for i := range r.labels {
+		if r.labels[i].ID == id {
+			labelCopy := r.labels[i]
+			return &labelCopy, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *MockLabelRepository) GetByProjectID(projectID string) ([]domain.Label, error) {
+	var result []domain.Label
+	for _, label := range r.labels {
+		if label.ProjectID == projectID {
+			result = append(result, label)
+		}
+	}
+	domain.SortLabels(result)
+	return result, nil
+}
+
+func (r *MockLabelRepository) Update(label *domain.Label) error {
+	for i := range r.labels {
+		if r.labels[i].ID == label.ID {
+			r.labels[i] = *label
+			return nil
+		}
+	}
+	return &domain.RepositoryError{Operation: "update", Entity: "label", ID: label.ID}
+}
+
+func (r *MockLabelRepository) Delete(id string) error {
+	for i := range r.labels {
+		if r.labels[i].ID == id {
+			r.labels = append(r.labels[:i], r.labels[i+1:]...)
+			for taskID, labelIDs := range r.taskLabels {
+				r.taskLabels[taskID] = removeString(labelIDs, id)
+			}
+			return nil
+		}
+	}
+	return &domain.RepositoryError{Operation: "delete", Entity: "label", ID: id}
+}
+
+func (r *MockLabelRepository) SetTaskLabels(taskID string, labelIDs []string) error {
+	r.taskLabels[taskID] = append([]string(nil), labelIDs...)
+	return nil
+}
+
+func removeString(values []string, value string) []string {
+	result := values[:0]
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// MockSavedViewRepository implements domain.SavedViewRepository for testing
+type MockSavedViewRepository struct {
+	views []domain.SavedView
+}
+
+func NewMockSavedViewRepository() *MockSavedViewRepository {
+	return &MockSavedViewRepository{views: []domain.SavedView{}}
+}
+
+func (r *MockSavedViewRepository) Create(view *domain.SavedView) error {
+	r.views = append(r.views, *view)
+	return nil
+}
+
+func (r *MockSavedViewRepository) GetByID(id string) (*domain.SavedView, error) {
+	for _, view := range r.views {
+		if view.ID == id {
+			return &view, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *MockSavedViewRepository) GetByProjectID(projectID string) ([]domain.SavedView, error) {
+	var result []domain.SavedView
+	for _, view := range r.views {
+		if view.IsGlobal() || view.ProjectID == projectID {
+			result = append(result, view)
+		}
+	}
+	domain.SortSavedViews(result)
+	return result, nil
+}
+
+func (r *MockSavedViewRepository) GetAll() ([]domain.SavedView, error) {
+	result := append([]domain.SavedView(nil), r.views...)
+	domain.SortSavedViews(result)
+	return result, nil
+}
+
+func (r *MockSavedViewRepository) Update(view *domain.SavedView) error {
+	for i := range r.views {
+		if r.views[i].ID == view.ID {
+			r.views[i] = *view
+			return nil
+		}
+	}
+	return &domain.RepositoryError{Operation: "update", Entity: "saved view", ID: view.ID}
+}
+
+func (r *MockSavedViewRepository) Delete(id string) error {
+	for i := range r.views {
+		if r.views[i].ID == id {
+			r.views = append(r.views[:i], r.views[i+1:]...)
+			return nil
+		}
+	}
+	return &domain.RepositoryError{Operation: "delete", Entity: "saved view", ID: id}
+}
+
+// MockEventRepository implements domain.EventRepository for testing
+type MockEventRepository struct {
+	events []domain.Event
+}
+
+func NewMockEventRepository() *MockEventRepository {
+	return &MockEventRepository{events: []domain.Event{}}
+}
+
+func (r *MockEventRepository) Append(event *domain.Event) error {
+	event.ID = int64(len(r.events) + 1)
+	r.events = append(r.events, *event)
+	return nil
+}
+
+func (r *MockEventRepository) List(filter domain.EventFilter) ([]domain.Event, error) {
+	var result []domain.Event
+	for _, event := range r.events {
+		if filter.ProjectID != "" && event.ProjectID != filter.ProjectID {
+			continue
+		}
+		if filter.TaskIntID != 0 && event.TaskIntID != filter.TaskIntID {
+			continue
+		}
+		if !filter.Since.IsZero() && event.CreatedAt.Before(filter.Since) {
+			continue
+		}
+		if !filter.Until.IsZero() && !event.CreatedAt.Before(filter.Until) {
+			continue
+		}
+		result = append(result, event)
+	}
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[len(result)-filter.Limit:]
+	}
+	return result, nil
 }
@@ -21,7 +21,7 @@ func NewBoard() *Board {
 
 type BoardComponent struct{}
 
-func (b *BoardComponent) RenderProjectFooter(project *domain.Project, width int, version string) string {
+func (b *BoardComponent) RenderProjectFooter(project *domain.Project, viewName string, width int, version string) string {
 	if project == nil {
 		return ""
 	}
@@ -37,16 +37,22 @@ func (b *BoardComponent) RenderProjectFooter(project *domain.Project, width int,
 
 	helpText := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(colors.Subtext1)).
-		Render(fmt.Sprintf("Kahn %s | Nav: ←→/h/l | Move: space | Project: p | Add: n | Edit: e | Delete: d | Search: / | Quit: q", version))
+		Render(fmt.Sprintf("Kahn %s | Nav: ←→/h/l | Move: space | Project: p | Add: n | Edit: e | Delete: d | Archive: a | Trash: t | Undo: u/ctrl+r | Details: v | Deps: g | Checklist: c | Search: / | Views: s | Find: f | My Work: w | Export: x | Quit: q", version))
 
-	footerContent := lipgloss.JoinHorizontal(
-		lipgloss.Left,
-		projectLabel,
-		lipgloss.NewStyle().Render(" "),
-		projectNameText,
-		lipgloss.NewStyle().Render(" | "),
-		helpText,
-	)
+	parts := []string{projectLabel, " ", projectNameText}
+	if viewName != "" {
+		viewLabel := lipgloss.NewStyle().
+			Foreground(lipgloss.Color(colors.Subtext1)).
+			Render("View:")
+		viewNameText := lipgloss.NewStyle().
+			Foreground(lipgloss.Color(colors.Mauve)).
+			Bold(true).
+			Render(viewName)
+		parts = append(parts, " | ", viewLabel, " ", viewNameText)
+	}
+	parts = append(parts, " | ", helpText)
+
+	footerContent := lipgloss.JoinHorizontal(lipgloss.Left, parts...)
 
 	return lipgloss.NewStyle().
 		Margin(0, 0).
@@ -56,11 +62,15 @@ func (b *BoardComponent) RenderProjectFooter(project *domain.Project, width int,
 }
 
 // RenderSearchBar renders the search input bar at the bottom when search is active
-func (b *BoardComponent) RenderSearchBar(query string, matchCount int, width int) string {
+func (b *BoardComponent) RenderSearchBar(query string, fuzzy bool, matchCount int, errorMessage string, width int) string {
+	label := "Search:"
+	if fuzzy {
+		label = "Fuzzy search:"
+	}
 	searchLabel := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(colors.Mauve)).
 		Bold(true).
-		Render("Search:")
+		Render(label)
 
 	queryText := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(colors.Text)).
@@ -74,10 +84,20 @@ func (b *BoardComponent) RenderSearchBar(query string, matchCount int, width int
 	matchInfo := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(colors.Subtext1)).
 		Render(fmt.Sprintf("(%d matches)", matchCount))
+	if errorMessage != "" {
+		// The board keeps the last filter that parsed, so no count is shown
+		matchInfo = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(colors.Red)).
+			Render("(" + errorMessage + ")")
+	}
 
+	help := "[ESC] Clear search | [ctrl+f] Fuzzy: off | [ctrl+s] Save as view"
+	if fuzzy {
+		help = "[ESC] Clear search | [ctrl+f] Fuzzy: on"
+	}
 	helpText := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(colors.Subtext1)).
-		Render("[ESC] Clear search")
+		Render(help)
 
 	searchContent := lipgloss.JoinHorizontal(
 		lipgloss.Left,
@@ -98,6 +118,19 @@ func (b *BoardComponent) RenderSearchBar(query string, matchCount int, width int
 		Render(searchContent)
 }
 
+// RenderNotice renders a one-line message in place of the project footer
+func (b *BoardComponent) RenderNotice(message string, width int) string {
+	noticeText := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(colors.Yellow)).
+		Render(message)
+
+	return lipgloss.NewStyle().
+		Margin(0, 0).
+		Padding(0, 1).
+		Width(width).
+		Render(noticeText)
+}
+
 func (b *BoardComponent) RenderNoProjectsBoard(width, height int) string {
 	title := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(colors.Mauve)).
@@ -153,7 +186,7 @@ func (b *BoardComponent) RenderTaskDeleteConfirm(task *domain.Task, width, heigh
 		Bold(true).
 		Align(lipgloss.Center).
 		Width(60).
-		Render("⚠️  Delete Task")
+		Render("⚠️  Move Task to Trash")
 
 	taskName := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(colors.Text)).
@@ -164,19 +197,19 @@ func (b *BoardComponent) RenderTaskDeleteConfirm(task *domain.Task, width, heigh
 		Foreground(lipgloss.Color(colors.Text)).
 		Align(lipgloss.Center).
 		Width(60).
-		Render(fmt.Sprintf("Delete task \"%s\"?", taskName))
+		Render(fmt.Sprintf("Move task \"%s\" to the trash?", taskName))
 
 	subWarning := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(colors.Subtext1)).
 		Align(lipgloss.Center).
 		Width(60).
-		Render("This action cannot be undone.")
+		Render("It can be restored from the trash with t.")
 
 	instructions := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(colors.Subtext1)).
 		Align(lipgloss.Center).
 		Width(60).
-		Render("[y] Yes, Delete • [n] No, Cancel")
+		Render("[y] Yes, Move to Trash • [n] No, Cancel")
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -215,7 +248,7 @@ func (b *BoardComponent) RenderTaskDeleteConfirmWithError(task *domain.Task, err
 		Bold(true).
 		Align(lipgloss.Center).
 		Width(60).
-		Render("⚠️  Delete Task")
+		Render("⚠️  Move Task to Trash")
 
 	var content string
 
@@ -253,19 +286,19 @@ func (b *BoardComponent) RenderTaskDeleteConfirmWithError(task *domain.Task, err
 			Foreground(lipgloss.Color(colors.Text)).
 			Align(lipgloss.Center).
 			Width(60).
-			Render(fmt.Sprintf("Delete task \"%s\"?", taskName))
+			Render(fmt.Sprintf("Move task \"%s\" to the trash?", taskName))
 
 		subWarning := lipgloss.NewStyle().
 			Foreground(lipgloss.Color(colors.Subtext1)).
 			Align(lipgloss.Center).
 			Width(60).
-			Render("This action cannot be undone.")
+			Render("It can be restored from the trash with t.")
 
 		instructions := lipgloss.NewStyle().
 			Foreground(lipgloss.Color(colors.Subtext1)).
 			Align(lipgloss.Center).
 			Width(60).
-			Render("[y] Yes, Delete • [n] No, Cancel")
+			Render("[y] Yes, Move to Trash • [n] No, Cancel")
 
 		content = lipgloss.JoinVertical(
 			lipgloss.Left,
@@ -295,57 +328,37 @@ func (b *BoardComponent) RenderTaskDeleteConfirmWithError(task *domain.Task, err
 	)
 }
 
-func (b *BoardComponent) RenderBoard(project *domain.Project, taskLists [3]list.Model, activeListIndex domain.Status, width int, version string, searchActive bool, searchQuery string, searchMatchCount int) string {
-	if project == nil {
+func (b *BoardComponent) RenderBoard(project *domain.Project, taskLists []list.Model, activeListIndex domain.Status, width int, version string, viewName string, searchActive bool, searchQuery string, searchFuzzy bool, searchMatchCount int, searchError string, notice string) string {
+	if project == nil || len(taskLists) == 0 {
 		return ""
 	}
 
-	// Render footer or search bar depending on search state
+	// Render footer, search bar or notice depending on state
 	var footer string
 	if searchActive {
-		footer = b.RenderSearchBar(searchQuery, searchMatchCount, width)
+		footer = b.RenderSearchBar(searchQuery, searchFuzzy, searchMatchCount, searchError, width)
+	} else if notice != "" {
+		footer = b.RenderNotice(notice, width)
 	} else {
-		footer = b.RenderProjectFooter(project, width, version)
+		footer = b.RenderProjectFooter(project, viewName, width, version)
 	}
 
 	columnWidth := taskLists[0].Width()
 
-	notStartedContent := taskLists[domain.NotStarted].View()
-	inProgressContent := taskLists[domain.InProgress].View()
-	doneContent := taskLists[domain.Done].View()
-
-	notStartedView := styles.DefaultStyle.Width(columnWidth).Render(notStartedContent)
-	inProgressView := styles.DefaultStyle.Width(columnWidth).Render(inProgressContent)
-	doneView := styles.DefaultStyle.Width(columnWidth).Render(doneContent)
-
-	focusedNotStartedView := styles.FocusedStyle.Width(columnWidth).Render(notStartedContent)
-	focusedInProgressView := styles.FocusedStyle.Width(columnWidth).Render(inProgressContent)
-	focusedDoneView := styles.FocusedStyle.Width(columnWidth).Render(doneContent)
-
-	boardContent := ""
-	switch activeListIndex {
-	case domain.InProgress:
-		boardContent = lipgloss.JoinHorizontal(
-			lipgloss.Left,
-			notStartedView,
-			focusedInProgressView,
-			doneView,
-		)
-	case domain.Done:
-		boardContent = lipgloss.JoinHorizontal(
-			lipgloss.Left,
-			notStartedView,
-			inProgressView,
-			focusedDoneView,
-		)
-	default:
-		boardContent = lipgloss.JoinHorizontal(
-			lipgloss.Left,
-			focusedNotStartedView,
-			inProgressView,
-			doneView,
-		)
+	// One column per workflow status; the active one gets the focused border and
+	// other columns over their WIP limit a red one
+	columns := make([]string, len(taskLists))
+	for i := range taskLists {
+		status := domain.Status(i)
+		style := styles.DefaultStyle
+		if status == activeListIndex {
+			style = styles.FocusedStyle
+		} else if project.Workflow.OverWIPLimit(status, len(project.GetTasksByStatus(status))) {
+			style = styles.OverLimitStyle
+		}
+		columns[i] = style.Width(columnWidth).Render(taskLists[i].View())
 	}
+	boardContent := lipgloss.JoinHorizontal(lipgloss.Left, columns...)
 
 	return lipgloss.JoinVertical(
 		lipgloss.Top,
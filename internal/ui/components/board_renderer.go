@@ -7,11 +7,17 @@ import (
 
 // BoardRenderer defines the interface for board-related UI rendering
 type BoardRenderer interface {
-	// RenderProjectFooter renders the bottom project footer with name and help text
-	RenderProjectFooter(project *domain.Project, width int, version string) string
+	// RenderProjectFooter renders the bottom project footer with name and help
+	// text, and the name of the applied saved view unless viewName is empty
+	RenderProjectFooter(project *domain.Project, viewName string, width int, version string) string
 
-	// RenderSearchBar renders the search input bar at the bottom when search is active
-	RenderSearchBar(query string, matchCount int, width int) string
+	// RenderSearchBar renders the search input bar at the bottom when search is
+	// active, with errorMessage in place of the match count while the query does
+	// not parse, and whether the query is matched fuzzily against task names
+	RenderSearchBar(query string, fuzzy bool, matchCount int, errorMessage string, width int) string
+
+	// RenderNotice renders a one-line message, such as the result of an export, in place of the footer
+	RenderNotice(message string, width int) string
 
 	// RenderNoProjectsBoard renders the empty state when no projects exist
 	RenderNoProjectsBoard(width, height int) string
@@ -22,7 +28,45 @@ type BoardRenderer interface {
 	// RenderTaskDeleteConfirmWithError renders the task deletion confirmation with error information
 	RenderTaskDeleteConfirmWithError(task *domain.Task, errorMessage string, width, height int) string
 
-	// RenderBoard renders the main kanban board with three columns.
-	// When searchActive is true, displays search bar instead of project footer.
-	RenderBoard(project *domain.Project, taskLists [3]list.Model, activeListIndex domain.Status, width int, version string, searchActive bool, searchQuery string, searchMatchCount int) string
+	// RenderDependencyTree renders the tasks the task numbered rootID waits on and the
+	// tasks waiting on it, each as a tree built from the blockers of tasks
+	RenderDependencyTree(rootID int, tasks []domain.Task, workflow domain.Workflow, width, height int) string
+
+	// RenderChecklist renders the checklist pane of a task with the cursor on the
+	// item at index cursor. A non-empty inputView is the text input of an item being
+	// added or edited.
+	RenderChecklist(task domain.Task, items []domain.ChecklistItem, cursor int, inputView, errorMessage string, width, height int) string
+
+	// RenderTaskDetail renders a task with its description, the tasks it waits on
+	// and those waiting on it and, from index offset on, either its comments or,
+	// when showHistory is true, its history events. linkCursor highlights a linked
+	// task, counting blockers first, and is -1 for none. A non-empty inputView is
+	// the input of a comment being written.
+	RenderTaskDetail(task domain.Task, workflow domain.Workflow, blockers, dependents []domain.Task, linkCursor int, canGoBack bool, history []domain.Event, showHistory bool, offset int, inputView, errorMessage string, width, height int) string
+
+	// RenderTrash renders the Archive tab listing archived tasks, or the Trash tab
+	// listing trashed projects and then trashed tasks, with the cursor on one entry
+	RenderTrash(showTrash bool, archived, tasks []domain.Task, projects []domain.Project, workflow domain.Workflow, cursor int, confirming bool, errorMessage string, width, height int) string
+
+	// RenderGlobalSearch renders the search across every project: the typed query
+	// over its results, best match first, with the cursor on one of them
+	RenderGlobalSearch(query string, results []domain.SearchResult, cursor int, errorMessage string, width, height int) string
+
+	// RenderSavedViews renders the picker listing views below an "All tasks" row
+	// at cursor 0, marking the one whose ID is activeID, or, when naming is true,
+	// the prompt typing the name of a view of query
+	RenderSavedViews(views []domain.SavedView, activeID string, cursor int, naming bool, name, query string, global bool, errorMessage string, width, height int) string
+
+	// RenderMyWorkFooter renders the footer of the My Work board with its help text
+	RenderMyWorkFooter(width int, version string) string
+
+	// RenderMyWork renders the My Work board gathering the cards of every project,
+	// one column per task list, with a non-empty notice replacing the footer
+	RenderMyWork(taskLists []list.Model, activeListIndex domain.Status, width int, version string, notice string) string
+
+	// RenderBoard renders the main kanban board with one column per task list.
+	// When searchActive is true, displays search bar instead of project footer;
+	// otherwise a non-empty notice replaces the footer, which names the applied
+	// saved view when viewName is not empty.
+	RenderBoard(project *domain.Project, taskLists []list.Model, activeListIndex domain.Status, width int, version string, viewName string, searchActive bool, searchQuery string, searchFuzzy bool, searchMatchCount int, searchError string, notice string) string
 }
@@ -1,11 +1,16 @@
 package components
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/stretchr/testify/assert"
 	"kahn/internal/domain"
+	"kahn/internal/ui/styles"
 )
 
 func TestBoardComponent_RenderProjectFooter(t *testing.T) {
@@ -19,7 +24,7 @@ func TestBoardComponent_RenderProjectFooter(t *testing.T) {
 		Color:       "#ff6b6b",
 	}
 
-	result := board.RenderProjectFooter(project, 80, "v1.0.0")
+	result := board.RenderProjectFooter(project, "", 80, "v1.0.0")
 
 	assert.NotEmpty(t, result, "RenderProjectFooter should not return empty string")
 	assert.Contains(t, result, "Test Project", "Should contain project name")
@@ -30,7 +35,7 @@ func TestBoardComponent_RenderProjectFooter(t *testing.T) {
 func TestBoardComponent_RenderProjectFooter_NilProject(t *testing.T) {
 	board := &BoardComponent{}
 
-	result := board.RenderProjectFooter(nil, 80, "v1.0.0")
+	result := board.RenderProjectFooter(nil, "", 80, "v1.0.0")
 
 	assert.Empty(t, result, "RenderProjectFooter with nil project should return empty string")
 }
@@ -59,9 +64,9 @@ func TestBoardComponent_RenderTaskDeleteConfirm(t *testing.T) {
 	result := board.RenderTaskDeleteConfirm(task, 80, 24)
 
 	assert.NotEmpty(t, result, "RenderTaskDeleteConfirm should not return empty string")
-	assert.Contains(t, result, "Delete Task", "Should contain deletion title")
+	assert.Contains(t, result, "Move Task to Trash", "Should contain deletion title")
 	assert.Contains(t, result, "Test Task", "Should contain task name")
-	assert.Contains(t, result, "Yes, Delete", "Should contain confirmation option")
+	assert.Contains(t, result, "Yes, Move to Trash", "Should contain confirmation option")
 }
 
 func TestBoardComponent_RenderTaskDeleteConfirm_NilTask(t *testing.T) {
@@ -85,12 +90,9 @@ func TestBoardComponent_RenderBoard(t *testing.T) {
 
 	// Create test task lists
 	defaultList := list.New([]list.Item{}, list.NewDefaultDelegate(), 20, 10)
-	var taskLists [3]list.Model
-	taskLists[domain.NotStarted] = defaultList
-	taskLists[domain.InProgress] = defaultList
-	taskLists[domain.Done] = defaultList
+	taskLists := []list.Model{defaultList, defaultList, defaultList}
 
-	result := board.RenderBoard(project, taskLists, domain.NotStarted, 80, "v1.0.0", false, "", 0)
+	result := board.RenderBoard(project, taskLists, domain.NotStarted, 80, "v1.0.0", "", false, "", false, 0, "", "")
 
 	assert.NotEmpty(t, result, "RenderBoard should not return empty string")
 	assert.Contains(t, result, "Test Project", "Should contain project name")
@@ -101,12 +103,9 @@ func TestBoardComponent_RenderBoard_NilProject(t *testing.T) {
 
 	// Create test task lists
 	defaultList := list.New([]list.Item{}, list.NewDefaultDelegate(), 20, 10)
-	var taskLists [3]list.Model
-	taskLists[domain.NotStarted] = defaultList
-	taskLists[domain.InProgress] = defaultList
-	taskLists[domain.Done] = defaultList
+	taskLists := []list.Model{defaultList, defaultList, defaultList}
 
-	result := board.RenderBoard(nil, taskLists, domain.NotStarted, 80, "v1.0.0", false, "", 0)
+	result := board.RenderBoard(nil, taskLists, domain.NotStarted, 80, "v1.0.0", "", false, "", false, 0, "", "")
 
 	assert.Empty(t, result, "RenderBoard with nil project should return empty string")
 }
@@ -114,13 +113,22 @@ func TestBoardComponent_RenderBoard_NilProject(t *testing.T) {
 func TestBoardComponent_RenderSearchBar(t *testing.T) {
 	board := &BoardComponent{}
 
-	result := board.RenderSearchBar("test query", 5, 80)
+	result := board.RenderSearchBar("test query", false, 5, "", 80)
 
 	assert.NotEmpty(t, result, "RenderSearchBar should not return empty string")
 	assert.Contains(t, result, "Search:", "Should contain search label")
 	assert.Contains(t, result, "test query", "Should contain the search query")
 	assert.Contains(t, result, "(5 matches)", "Should contain match count")
 	assert.Contains(t, result, "Clear search", "Should contain help text")
+
+	result = board.RenderSearchBar("(bug", false, 5, `missing ")"`, 80)
+	assert.Contains(t, result, `(missing ")")`, "Should show the parse error")
+	assert.NotContains(t, result, "matches", "Should not show a count while the query does not parse")
+
+	result = board.RenderSearchBar("lgn", true, 2, "", 160)
+	assert.Contains(t, result, "Fuzzy search:")
+	assert.Contains(t, result, "Fuzzy: on")
+	assert.NotContains(t, result, "Save as view", "Fuzzy queries cannot be saved")
 }
 
 func TestBoardComponent_RenderBoard_WithSearch(t *testing.T) {
@@ -136,13 +144,10 @@ func TestBoardComponent_RenderBoard_WithSearch(t *testing.T) {
 
 	// Create test task lists
 	defaultList := list.New([]list.Item{}, list.NewDefaultDelegate(), 20, 10)
-	var taskLists [3]list.Model
-	taskLists[domain.NotStarted] = defaultList
-	taskLists[domain.InProgress] = defaultList
-	taskLists[domain.Done] = defaultList
+	taskLists := []list.Model{defaultList, defaultList, defaultList}
 
 	// Test with search active
-	result := board.RenderBoard(project, taskLists, domain.NotStarted, 80, "v1.0.0", true, "api", 3)
+	result := board.RenderBoard(project, taskLists, domain.NotStarted, 80, "v1.0.0", "", true, "api", false, 3, "", "")
 
 	assert.NotEmpty(t, result, "RenderBoard should not return empty string")
 	assert.Contains(t, result, "Search:", "Should contain search bar when search is active")
@@ -151,6 +156,20 @@ func TestBoardComponent_RenderBoard_WithSearch(t *testing.T) {
 	assert.NotContains(t, result, "Test Project", "Should not show project footer when search is active")
 }
 
+func TestBoardComponent_RenderBoard_WithNotice(t *testing.T) {
+	board := &BoardComponent{}
+
+	project := &domain.Project{ID: "test_proj_1", Name: "Test Project"}
+
+	defaultList := list.New([]list.Item{}, list.NewDefaultDelegate(), 20, 10)
+	taskLists := []list.Model{defaultList, defaultList, defaultList}
+
+	result := board.RenderBoard(project, taskLists, domain.NotStarted, 80, "v1.0.0", "", false, "", false, 0, "", "Exported board")
+
+	assert.Contains(t, result, "Exported board", "Should show the notice")
+	assert.NotContains(t, result, "Test Project", "Notice replaces the project footer")
+}
+
 func TestNewBoard(t *testing.T) {
 	board := NewBoard()
 
@@ -166,3 +185,195 @@ func TestBoard_GetRenderer(t *testing.T) {
 	assert.NotNil(t, renderer, "GetRenderer should return a non-nil renderer")
 	assert.Implements(t, (*BoardRenderer)(nil), renderer, "Renderer should implement BoardRenderer interface")
 }
+
+func TestBoardComponent_RenderDependencyTree(t *testing.T) {
+	board := &BoardComponent{}
+	tasks := []domain.Task{
+		{IntID: 1, Name: "Design", Status: domain.InProgress},
+		{IntID: 2, Name: "Build", Status: domain.NotStarted, BlockedBy: []int{1}},
+		{IntID: 3, Name: "Ship", Status: domain.NotStarted, BlockedBy: []int{2}},
+		{IntID: 4, Name: "Unrelated", Status: domain.NotStarted},
+	}
+
+	result := board.RenderDependencyTree(2, tasks, domain.DefaultWorkflow(), 100, 40)
+
+	assert.Contains(t, result, "Dependencies of #2")
+	assert.Contains(t, result, "Waits on")
+	assert.Contains(t, result, "└─ #1 Design")
+	assert.Contains(t, result, "Blocks")
+	assert.Contains(t, result, "└─ #3 Ship")
+	assert.NotContains(t, result, "Unrelated")
+
+	result = board.RenderDependencyTree(4, tasks, domain.DefaultWorkflow(), 100, 40)
+	assert.Contains(t, result, "(none)", "A task without links says so in both trees")
+}
+
+func TestBoardComponent_RenderChecklist(t *testing.T) {
+	board := &BoardComponent{}
+	task := domain.Task{IntID: 7, Name: "Story"}
+	items := []domain.ChecklistItem{
+		{Text: "Write tests", Done: true, Position: 1},
+		{Text: "Update docs", Position: 2},
+	}
+
+	result := board.RenderChecklist(task, items, 1, "", "", 100, 40)
+	assert.Contains(t, result, "Checklist of #7 Story (1/2)")
+	assert.Contains(t, result, "Write tests")
+	assert.Contains(t, result, "> ")
+	assert.Contains(t, result, "[p] Promote to task")
+
+	result = board.RenderChecklist(task, nil, 0, "", "item cannot be empty", 100, 40)
+	assert.Contains(t, result, "No items yet")
+	assert.Contains(t, result, "item cannot be empty")
+
+	result = board.RenderChecklist(task, items, 0, "> New step", "", 100, 40)
+	assert.Contains(t, result, "[enter] Save")
+}
+
+func TestBoardComponent_RenderTaskDetail(t *testing.T) {
+	board := &BoardComponent{}
+	created := time.Date(2026, 9, 2, 14, 30, 0, 0, time.Local)
+	task := domain.Task{
+		IntID: 7, Name: "Flaky test", Desc: "Fails on CI", Type: domain.Bug, Priority: domain.High,
+		Comments: []domain.Comment{
+			{Author: "alice", Body: "Reproduced locally", CreatedAt: created},
+			{Author: "bob", Body: "Raised the timeout", CreatedAt: created.Add(time.Hour)},
+		},
+	}
+
+	result := board.RenderTaskDetail(task, domain.DefaultWorkflow(), nil, nil, -1, false, nil, false, 0, "", "", 100, 40)
+	assert.Contains(t, result, "#7 Flaky test")
+	assert.Contains(t, result, "Not Started · Bug · High priority")
+	assert.Contains(t, result, "Fails on CI")
+	assert.Contains(t, result, "Comments (2)")
+	assert.Contains(t, result, "alice · 2026-09-02 14:30")
+	assert.Contains(t, result, "Raised the timeout")
+	assert.Contains(t, result, "[a] Comment")
+
+	result = board.RenderTaskDetail(task, domain.DefaultWorkflow(), nil, nil, -1, false, nil, false, 1, "", "", 100, 40)
+	assert.Contains(t, result, "↑ 1 earlier")
+	assert.NotContains(t, result, "Reproduced locally")
+
+	result = board.RenderTaskDetail(domain.Task{IntID: 8, Name: "Empty"}, domain.DefaultWorkflow(), nil, nil, -1, false, nil, false, 0, "> typing", "comment cannot be empty", 100, 40)
+	assert.Contains(t, result, "No description")
+	assert.Contains(t, result, "No comments yet")
+	assert.Contains(t, result, "comment cannot be empty")
+	assert.Contains(t, result, "[ctrl+s] Save")
+
+	history := []domain.Event{
+		{TaskIntID: 7, Kind: domain.EventCreated, NewValue: "Not Started", Actor: "alice", CreatedAt: created},
+		{TaskIntID: 7, Kind: domain.EventChanged, Field: "priority", OldValue: "Low", NewValue: "High", Actor: "bob", CreatedAt: created.Add(time.Hour)},
+	}
+	result = board.RenderTaskDetail(task, domain.DefaultWorkflow(), nil, nil, -1, false, history, true, 0, "", "", 100, 40)
+	assert.Contains(t, result, "History (2)")
+	assert.Contains(t, result, "2026-09-02 14:30 alice created in Not Started")
+	assert.Contains(t, result, "bob priority: Low → High")
+	assert.NotContains(t, result, "Reproduced locally")
+	assert.Contains(t, result, "[h] Comments")
+
+	result = board.RenderTaskDetail(task, domain.DefaultWorkflow(), nil, nil, -1, false, nil, true, 0, "", "", 100, 40)
+	assert.Contains(t, result, "No changes recorded")
+
+	// Descriptions are rendered as Markdown
+	markdownTask := domain.Task{IntID: 9, Name: "Docs", Desc: "## Steps\n- run `make`\n```\nmake test\n```"}
+	result = board.RenderTaskDetail(markdownTask, domain.DefaultWorkflow(), nil, nil, -1, false, nil, false, 0, "", "", 100, 40)
+	assert.Contains(t, result, "Steps")
+	assert.NotContains(t, result, "## Steps")
+	assert.Contains(t, result, "• run make")
+	assert.Contains(t, result, " make test")
+	assert.NotContains(t, result, "```")
+
+	task.CreatedAt = created
+	task.UpdatedAt = created.Add(2 * time.Hour)
+	blockers := []domain.Task{{IntID: 3, Name: "Set up CI", Status: domain.InProgress}}
+	dependents := []domain.Task{{IntID: 9, Name: "Release 1.0"}}
+	result = board.RenderTaskDetail(task, domain.DefaultWorkflow(), blockers, dependents, 1, true, nil, false, 0, "", "", 100, 40)
+	assert.Contains(t, result, "Created 2026-09-02 14:30 · Updated 2026-09-02 16:30")
+	assert.Contains(t, result, "Blocked by")
+	assert.Contains(t, result, "#3 Set up CI (In Progress)")
+	assert.Contains(t, result, "Blocks")
+	assert.Contains(t, result, "> #9 Release 1.0 (Not Started)")
+	assert.Contains(t, result, "[b] Blocker")
+	assert.Contains(t, result, "[backspace] Back")
+}
+
+func TestBoardComponent_RenderGlobalSearch(t *testing.T) {
+	board := &BoardComponent{}
+	mark := func(text string) string { return domain.HighlightStart + text + domain.HighlightEnd }
+	results := []domain.SearchResult{
+		{Task: domain.Task{IntID: 3, Name: "Fix login"}, ProjectName: "Website", Name: "Fix " + mark("login"), Snippet: "Fix " + mark("login")},
+		{Task: domain.Task{IntID: 9, Name: "Refresh sessions"}, ProjectName: "Mobile", Name: "Refresh sessions", Snippet: "Users land on the\n" + mark("login") + " page"},
+	}
+
+	result := ansi.Strip(board.RenderGlobalSearch("login", results, 1, "", 120, 40))
+	assert.Contains(t, result, "Find: login")
+	assert.Contains(t, result, "#3 Fix login · Website")
+	assert.Contains(t, result, "> #9 Refresh sessions · Mobile")
+	assert.Contains(t, result, "Users land on the login page", "Snippets are one line without markers")
+	assert.Equal(t, 1, strings.Count(result, "Fix login"), "A snippet repeating the name is left out")
+
+	assert.Contains(t, board.RenderGlobalSearch("zzz", nil, 0, "", 120, 40), "No matching tasks")
+	assert.Contains(t, board.RenderGlobalSearch("", nil, 0, "", 120, 40), "Type to search")
+}
+
+func TestHighlightedLine(t *testing.T) {
+	plain := lipgloss.NewStyle()
+	text := "a " + domain.HighlightStart + "long" + domain.HighlightEnd + " passage"
+	assert.Equal(t, "a long passage", ansi.Strip(highlightedLine(text, 20, plain, plain)))
+	assert.Equal(t, "a lo…", ansi.Strip(highlightedLine(text, 5, plain, plain)))
+
+	escaped := "a \x1b[2J" + domain.HighlightStart + "long\x1b]0;x\x07" + domain.HighlightEnd + " passage"
+	assert.NotContains(t, highlightedLine(escaped, 40, plain, plain), "\x1b", "Control characters are dropped")
+}
+
+func TestBoardComponent_RenderProjectFooter_ViewName(t *testing.T) {
+	board := &BoardComponent{}
+	project := &domain.Project{Name: "Website"}
+
+	result := ansi.Strip(board.RenderProjectFooter(project, "My bugs", 200, "v1.0.0"))
+	assert.Contains(t, result, "Project: Website | View: My bugs |")
+	assert.NotContains(t, ansi.Strip(board.RenderProjectFooter(project, "", 200, "v1.0.0")), "View:")
+}
+
+func TestBoardComponent_RenderSavedViews(t *testing.T) {
+	board := &BoardComponent{}
+	views := []domain.SavedView{
+		{ID: "v1", ProjectID: "p1", Name: "Bugs", Query: "type:bug"},
+		{ID: "v2", Name: "Urgent", Query: "priority:high"},
+	}
+
+	result := ansi.Strip(board.RenderSavedViews(views, "v2", 1, false, "", "", false, "", 120, 40))
+	assert.Contains(t, result, "0   All tasks")
+	assert.Contains(t, result, "> 1   Bugs · type:bug")
+	assert.Contains(t, result, "2 ✓ Urgent (global) · priority:high", "The applied view is marked")
+
+	assert.Contains(t, board.RenderSavedViews(nil, "", 0, false, "", "", false, "", 120, 40), "No saved views")
+
+	result = ansi.Strip(board.RenderSavedViews(nil, "", 0, true, "Mine", "assignee:me", true, "", 120, 40))
+	assert.Contains(t, result, "Name: Mine")
+	assert.Contains(t, result, "Query: assignee:me")
+	assert.Contains(t, result, "For: every project")
+}
+
+func TestBoardComponent_RenderMyWork(t *testing.T) {
+	board := &BoardComponent{}
+	lists := make([]list.Model, len(domain.MyWorkColumnNames))
+	for i, name := range domain.MyWorkColumnNames {
+		lists[i] = list.New(nil, styles.NewInactiveListDelegate(), 50, 10)
+		lists[i].Title = name
+	}
+	card := styles.NewTaskWithTitle(domain.Task{IntID: 1, Name: "Fix login", Priority: domain.High}).WithProject("Website", "#89b4fa")
+	lists[domain.MyWorkUpNext].SetItems([]list.Item{card})
+
+	result := ansi.Strip(board.RenderMyWork(lists, domain.Status(domain.MyWorkUpNext), 200, "v1.0", ""))
+	assert.Contains(t, result, "Up Next")
+	assert.Contains(t, result, "In Progress")
+	assert.Contains(t, result, "Website")
+	assert.Contains(t, result, "Fix login")
+	assert.Contains(t, result, "My Work")
+	assert.Contains(t, result, "Back: w/esc")
+
+	result = ansi.Strip(board.RenderMyWork(lists, domain.Status(domain.MyWorkUpNext), 200, "v1.0", "WIP limit reached"))
+	assert.Contains(t, result, "WIP limit reached", "A notice replaces the footer")
+	assert.NotContains(t, result, "Back: w/esc")
+}
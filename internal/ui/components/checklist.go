@@ -0,0 +1,107 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"kahn/internal/domain"
+	"kahn/internal/ui/colors"
+	"kahn/internal/ui/styles"
+)
+
+// checklistWidth is the inner width of the checklist pane
+const checklistWidth = 60
+
+func (b *BoardComponent) RenderChecklist(task domain.Task, items []domain.ChecklistItem, cursor int, inputView, errorMessage string, width, height int) string {
+	dialogStyles := styles.GetDialogStyles()
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0))
+
+	name := task.Name
+	if runes := []rune(name); len(runes) > 40 {
+		name = string(runes[:39]) + "…"
+	}
+	heading := fmt.Sprintf("Checklist of #%d %s", task.IntID, name)
+	if progress := checklistProgress(items); progress != "" {
+		heading += " (" + progress + ")"
+	}
+	title := dialogStyles.Title.Width(checklistWidth).Render(heading)
+
+	var rows []string
+	for i, item := range items {
+		rows = append(rows, checklistItemLine(item, i == cursor && inputView == ""))
+	}
+	if len(rows) == 0 && inputView == "" {
+		rows = append(rows, muted.Render("No items yet"))
+	}
+
+	// Keep the dialog on screen: title, spacing, input, instructions and border take 14 lines
+	rows = scrollChecklistRows(rows, cursor, max(height-14, 3))
+
+	lines := []string{"", title, ""}
+	lines = append(lines, rows...)
+	if inputView != "" {
+		lines = append(lines, "", inputView)
+	}
+	if errorMessage != "" {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Red)).Width(checklistWidth).Render(errorMessage))
+	}
+
+	instructions := "[space] Toggle • [a] Add • [e] Edit • [d] Delete • [J/K] Move\n[p] Promote to task • [esc] Close"
+	if inputView != "" {
+		instructions = "[enter] Save • [esc] Cancel"
+	}
+	lines = append(lines, "", dialogStyles.Instruction.Width(checklistWidth).Render(instructions))
+
+	form := dialogStyles.Form.
+		Width(checklistWidth + 6).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	return lipgloss.Place(
+		width, height,
+		lipgloss.Center, lipgloss.Center,
+		form,
+	)
+}
+
+// checklistItemLine shows an item as a tick box and its text, struck through
+// once done and highlighted under the cursor
+func checklistItemLine(item domain.ChecklistItem, selected bool) string {
+	box := "󰄱 "
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Text))
+	if item.Done {
+		box = "󰄲 "
+		style = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0)).Strikethrough(true)
+	}
+
+	pointer := "  "
+	if selected {
+		pointer = "> "
+		style = style.Foreground(lipgloss.Color(colors.Blue)).Bold(true)
+	}
+
+	text := item.Text
+	if runes := []rune(text); len(runes) > checklistWidth-6 {
+		text = string(runes[:checklistWidth-7]) + "…"
+	}
+	return pointer + style.Render(box+text)
+}
+
+// checklistProgress counts the done items, e.g. "2/5"
+func checklistProgress(items []domain.ChecklistItem) string {
+	task := domain.Task{ChecklistTotal: len(items)}
+	for _, item := range items {
+		if item.Done {
+			task.ChecklistDone++
+		}
+	}
+	return task.ChecklistProgress()
+}
+
+// scrollChecklistRows keeps at most limit rows, scrolled so the cursor stays visible
+func scrollChecklistRows(rows []string, cursor, limit int) []string {
+	if len(rows) <= limit {
+		return rows
+	}
+	start := min(max(cursor-limit/2, 0), len(rows)-limit)
+	return rows[start : start+limit]
+}
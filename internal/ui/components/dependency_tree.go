@@ -0,0 +1,112 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"kahn/internal/domain"
+	"kahn/internal/ui/colors"
+	"kahn/internal/ui/styles"
+)
+
+// dependencyTreeWidth is the inner width of the dependency dialog
+const dependencyTreeWidth = 64
+
+func (b *BoardComponent) RenderDependencyTree(rootID int, tasks []domain.Task, workflow domain.Workflow, width, height int) string {
+	byID := make(map[int]domain.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.IntID] = task
+	}
+	graph := domain.NewDependencyGraph(tasks)
+
+	dialogStyles := styles.GetDialogStyles()
+	title := dialogStyles.Title.Width(dependencyTreeWidth).Render(fmt.Sprintf("Dependencies of #%d", rootID))
+	heading := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Mauve)).Bold(true)
+
+	waitsOn := dependencyTreeLines(graph.Tree(rootID), byID, workflow)
+	blocks := dependencyTreeLines(graph.Dependents().Tree(rootID), byID, workflow)
+
+	// Keep the dialog on screen: title, headings, spacing, instructions and border take 14 lines
+	maxLines := max(height-14, 4)
+	waitsOn = truncateTreeLines(waitsOn, maxLines/2)
+	blocks = truncateTreeLines(blocks, maxLines-len(waitsOn))
+
+	lines := []string{"", title, "", heading.Render("Waits on")}
+	lines = append(lines, waitsOn...)
+	lines = append(lines, "", heading.Render("Blocks"))
+	lines = append(lines, blocks...)
+	lines = append(lines, "", dialogStyles.Instruction.Width(dependencyTreeWidth).Render("Done tasks no longer block anyone • [esc] Close"))
+
+	form := dialogStyles.Form.
+		Width(dependencyTreeWidth + 6).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	return lipgloss.Place(
+		width, height,
+		lipgloss.Center, lipgloss.Center,
+		form,
+	)
+}
+
+// dependencyTreeLines draws the tree with box-drawing branches under a line for
+// its root task. A tree without children reads "(none)".
+func dependencyTreeLines(tree domain.DependencyTree, byID map[int]domain.Task, workflow domain.Workflow) []string {
+	lines := []string{dependencyTaskLine(tree, byID, workflow)}
+	if len(tree.Children) == 0 {
+		none := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0)).Render("└─ (none)")
+		return append(lines, none)
+	}
+
+	var walk func(node domain.DependencyTree, prefix string)
+	walk = func(node domain.DependencyTree, prefix string) {
+		for i, child := range node.Children {
+			branch, indent := "├─ ", "│  "
+			if i == len(node.Children)-1 {
+				branch, indent = "└─ ", "   "
+			}
+			lines = append(lines, prefix+branch+dependencyTaskLine(child, byID, workflow))
+			walk(child, prefix+indent)
+		}
+	}
+	walk(tree, "")
+	return lines
+}
+
+// dependencyTaskLine shows a task's number, name and column, colored by column
+// like the Graphviz export: green once done, blue once started
+func dependencyTaskLine(node domain.DependencyTree, byID map[int]domain.Task, workflow domain.Workflow) string {
+	task, ok := byID[node.IntID]
+	if !ok {
+		return fmt.Sprintf("#%d (missing)", node.IntID)
+	}
+
+	color := colors.Subtext1
+	switch {
+	case workflow.IsDone(task.Status):
+		color = colors.Green
+	case task.Status != workflow.Statuses()[0]:
+		color = colors.Blue
+	}
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Bold(task.Priority == domain.High)
+
+	name := task.Name
+	if runes := []rune(name); len(runes) > 32 {
+		name = string(runes[:31]) + "…"
+	}
+	line := style.Render(fmt.Sprintf("#%d %s", task.IntID, name)) +
+		lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0)).Render(" · "+workflow.Name(task.Status))
+	if node.Repeated {
+		line += lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0)).Render(" (shown above)")
+	}
+	return line
+}
+
+// truncateTreeLines keeps the first limit lines and says how many were dropped
+func truncateTreeLines(lines []string, limit int) []string {
+	limit = max(limit, 2)
+	if len(lines) <= limit {
+		return lines
+	}
+	more := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0)).Render(fmt.Sprintf("… %d more", len(lines)-limit+1))
+	return append(lines[:limit-1], more)
+}
@@ -0,0 +1,113 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"kahn/internal/domain"
+	"kahn/internal/ui/colors"
+	"kahn/internal/ui/markdown"
+	"kahn/internal/ui/styles"
+)
+
+// globalSearchWidth is the inner width of the global search pane
+const globalSearchWidth = 70
+
+func (b *BoardComponent) RenderGlobalSearch(query string, results []domain.SearchResult, cursor int, errorMessage string, width, height int) string {
+	dialogStyles := styles.GetDialogStyles()
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0))
+
+	title := dialogStyles.Title.Width(globalSearchWidth).Render("Search All Projects")
+	prompt := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Mauve)).Bold(true).Render("Find: ") +
+		lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Text)).Render(query) +
+		lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Mauve)).Render("█")
+
+	var rows []string
+	for i, result := range results {
+		rows = append(rows, searchResultRow(result, i == cursor))
+	}
+	switch {
+	case strings.TrimSpace(query) == "":
+		rows = append(rows, muted.Render("Type to search task names, descriptions and comments"))
+	case len(rows) == 0:
+		rows = append(rows, muted.Render("No matching tasks"))
+	}
+
+	// Keep the dialog on screen: title, prompt, spacing, instructions and border
+	// take 14 lines, and each result two
+	rows = scrollChecklistRows(rows, cursor, max((height-14)/2, 2))
+
+	lines := []string{"", title, "", prompt, ""}
+	lines = append(lines, rows...)
+	if errorMessage != "" {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Red)).Width(globalSearchWidth).Render(errorMessage))
+	}
+	lines = append(lines, "", dialogStyles.Instruction.Width(globalSearchWidth).Render("[↑/↓] Select • [enter] Go to task • [esc] Close"))
+
+	form := dialogStyles.Form.
+		Width(globalSearchWidth + 6).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	return lipgloss.Place(
+		width, height,
+		lipgloss.Center, lipgloss.Center,
+		form,
+	)
+}
+
+// searchResultRow shows a result as its number, name and project over the
+// passage that matched, highlighted under the cursor
+func searchResultRow(result domain.SearchResult, selected bool) string {
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Text))
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0))
+	match := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Yellow)).Bold(true)
+	pointer := "  "
+	if selected {
+		pointer = "> "
+		text = text.Foreground(lipgloss.Color(colors.Blue)).Bold(true)
+	}
+
+	number := fmt.Sprintf("#%d ", result.Task.IntID)
+	project := " · " + result.ProjectName
+	nameWidth := globalSearchWidth - 2 - len([]rune(number)) - len([]rune(project))
+	heading := pointer + text.Render(number) + highlightedLine(result.Name, nameWidth, text, match) + muted.Render(project)
+
+	snippet := strings.Join(strings.Fields(result.Snippet), " ")
+	if domain.StripHighlights(snippet) == result.Task.Name {
+		// The name matched best, and is already shown
+		return heading + "\n"
+	}
+	return heading + "\n    " + highlightedLine(snippet, globalSearchWidth-4, muted, match)
+}
+
+// highlightedLine renders text marked with search highlights in base, its
+// matches in match, cut to width runes
+func highlightedLine(text string, width int, base, match lipgloss.Style) string {
+	room := max(width, 1)
+	truncated := len([]rune(markdown.StripControl(domain.StripHighlights(text)))) > room
+	if truncated {
+		room-- // for the ellipsis
+	}
+
+	var line strings.Builder
+	for _, span := range domain.SplitHighlights(text) {
+		if room <= 0 {
+			break
+		}
+		runes := []rune(markdown.StripControl(span.Text))
+		if len(runes) > room {
+			runes = runes[:room]
+		}
+		style := base
+		if span.Highlighted {
+			style = match
+		}
+		line.WriteString(style.Render(string(runes)))
+		room -= len(runes)
+	}
+	if truncated {
+		line.WriteString(base.Render("…"))
+	}
+	return line.String()
+}
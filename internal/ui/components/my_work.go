@@ -0,0 +1,55 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"kahn/internal/domain"
+	"kahn/internal/ui/colors"
+	"kahn/internal/ui/styles"
+)
+
+func (b *BoardComponent) RenderMyWorkFooter(width int, version string) string {
+	boardName := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(colors.Green)).
+		Bold(true).
+		Render("My Work")
+
+	helpText := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(colors.Subtext1)).
+		Render(fmt.Sprintf("Kahn %s | Every project | Nav: ←→/h/l | Move: space/backspace | Edit: e | Details: v | Open in project: o | Undo: u/ctrl+r | Back: w/esc | Quit: q", version))
+
+	return lipgloss.NewStyle().
+		Margin(0, 0).
+		Padding(0, 1).
+		Width(width).
+		Render(lipgloss.JoinHorizontal(lipgloss.Left, boardName, " | ", helpText))
+}
+
+func (b *BoardComponent) RenderMyWork(taskLists []list.Model, activeListIndex domain.Status, width int, version string, notice string) string {
+	if len(taskLists) == 0 {
+		return ""
+	}
+
+	footer := b.RenderMyWorkFooter(width, version)
+	if notice != "" {
+		footer = b.RenderNotice(notice, width)
+	}
+
+	columnWidth := taskLists[0].Width()
+	columns := make([]string, len(taskLists))
+	for i := range taskLists {
+		style := styles.DefaultStyle
+		if domain.Status(i) == activeListIndex {
+			style = styles.FocusedStyle
+		}
+		columns[i] = style.Width(columnWidth).Render(taskLists[i].View())
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		lipgloss.JoinHorizontal(lipgloss.Left, columns...),
+		footer,
+	)
+}
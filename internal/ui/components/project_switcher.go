@@ -178,8 +178,8 @@ func (psc *ProjectSwitcherComponent) RenderProjectDeleteConfirm(project *domain.
 		)
 	} else {
 		// Show normal confirmation dialog
-		warningMessage := deleteStyles.Message.Width(60).Render(fmt.Sprintf("Delete project \"%s\" and ALL its tasks?", projectName))
-		subWarning := deleteStyles.Message.Width(60).Render("This action cannot be undone.")
+		warningMessage := deleteStyles.Message.Width(60).Render(fmt.Sprintf("Move project \"%s\" and ALL its tasks to the trash?", projectName))
+		subWarning := deleteStyles.Message.Width(60).Render("It can be restored from the trash with t.")
 		instructions := deleteStyles.Message.Width(60).Render("[y] Yes, Delete • [n] No, Cancel")
 
 		content = lipgloss.JoinVertical(
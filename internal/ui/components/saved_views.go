@@ -0,0 +1,104 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"kahn/internal/domain"
+	"kahn/internal/ui/colors"
+	"kahn/internal/ui/styles"
+)
+
+// savedViewsWidth is the inner width of the saved views picker
+const savedViewsWidth = 60
+
+func (b *BoardComponent) RenderSavedViews(views []domain.SavedView, activeID string, cursor int, naming bool, name, query string, global bool, errorMessage string, width, height int) string {
+	dialogStyles := styles.GetDialogStyles()
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0))
+
+	var lines []string
+	var instructions string
+	if naming {
+		scope := "this project"
+		if global {
+			scope = "every project"
+		}
+		prompt := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Mauve)).Bold(true).Render("Name: ") +
+			lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Text)).Render(name) +
+			lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Mauve)).Render("█")
+		lines = []string{
+			"",
+			dialogStyles.Title.Width(savedViewsWidth).Render("Save View"),
+			"",
+			muted.Width(savedViewsWidth).Render("Query: " + query),
+			muted.Render("For: " + scope),
+			"",
+			prompt,
+		}
+		instructions = "[enter] Save • [tab] Project/Global • [esc] Cancel"
+	} else {
+		rows := []string{savedViewRow("0", "All tasks", "", activeID == "", cursor == 0)}
+		for i, view := range views {
+			key := " "
+			if i < 9 {
+				key = fmt.Sprint(i + 1)
+			}
+			label := view.Name
+			if view.IsGlobal() {
+				label += " (global)"
+			}
+			rows = append(rows, savedViewRow(key, label, view.Query, view.ID == activeID, cursor == i+1))
+		}
+		if len(views) == 0 {
+			rows = append(rows, "", muted.Width(savedViewsWidth).Render("No saved views. Search with / and press ctrl+s to save one."))
+		}
+
+		// Keep the dialog on screen: title, spacing, instructions and border take 12 lines
+		rows = scrollChecklistRows(rows, cursor, max(height-12, 3))
+
+		lines = []string{"", dialogStyles.Title.Width(savedViewsWidth).Render("Saved Views"), ""}
+		lines = append(lines, rows...)
+		instructions = "[enter/1-9] Apply • [0] All tasks • [D] Delete • [esc] Close"
+	}
+
+	if errorMessage != "" {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Red)).Width(savedViewsWidth).Render(errorMessage))
+	}
+	lines = append(lines, "", dialogStyles.Instruction.Width(savedViewsWidth).Render(instructions))
+
+	form := dialogStyles.Form.
+		Width(savedViewsWidth + 6).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	return lipgloss.Place(
+		width, height,
+		lipgloss.Center, lipgloss.Center,
+		form,
+	)
+}
+
+// savedViewRow shows a view as its key, name and query, marked when applied and
+// highlighted under the cursor
+func savedViewRow(key, label, query string, active, selected bool) string {
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Text))
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0))
+	pointer := "  "
+	if selected {
+		pointer = "> "
+		style = style.Foreground(lipgloss.Color(colors.Blue)).Bold(true)
+	}
+	mark := "  "
+	if active {
+		mark = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Green)).Render("✓ ")
+	}
+
+	row := pointer + muted.Render(key+" ") + mark + style.Render(label)
+	if query != "" {
+		room := savedViewsWidth - 6 - len([]rune(label)) - 3
+		if runes := []rune(query); len(runes) > room {
+			query = string(runes[:max(room-1, 0)]) + "…"
+		}
+		row += muted.Render(" · " + query)
+	}
+	return row
+}
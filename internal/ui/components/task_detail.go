@@ -0,0 +1,197 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"kahn/internal/domain"
+	"kahn/internal/ui/colors"
+	"kahn/internal/ui/markdown"
+	"kahn/internal/ui/styles"
+)
+
+// taskDetailWidth is the inner width of the task detail pane
+const taskDetailWidth = 70
+
+func (b *BoardComponent) RenderTaskDetail(task domain.Task, workflow domain.Workflow, blockers, dependents []domain.Task, linkCursor int, canGoBack bool, history []domain.Event, showHistory bool, offset int, inputView, errorMessage string, width, height int) string {
+	dialogStyles := styles.GetDialogStyles()
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0))
+	heading := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Mauve)).Bold(true)
+
+	title := dialogStyles.Title.Width(taskDetailWidth).Render(fmt.Sprintf("#%d %s", task.IntID, task.Name))
+
+	meta := []string{workflow.Name(task.Status), task.Type.String(), task.Priority.String() + " priority"}
+	if progress := task.ChecklistProgress(); progress != "" {
+		meta = append(meta, "checklist "+progress)
+	}
+	if task.DueDate != nil {
+		meta = append(meta, "due "+domain.FormatDate(task.DueDate))
+	}
+
+	header := []string{"", title, "", muted.Width(taskDetailWidth).Render(strings.Join(meta, " · "))}
+	if !task.CreatedAt.IsZero() {
+		header = append(header, muted.Render(fmt.Sprintf("Created %s · Updated %s",
+			task.CreatedAt.Local().Format(domain.CommentTimeLayout), task.UpdatedAt.Local().Format(domain.CommentTimeLayout))))
+	}
+	if len(task.Labels) > 0 {
+		header = append(header, strings.TrimPrefix(styles.LabelChips(task.Labels), " "))
+	}
+	header = append(header, "")
+	if task.Desc != "" {
+		header = append(header, markdown.Render(task.Desc, taskDetailWidth))
+	} else {
+		header = append(header, muted.Render("No description"))
+	}
+	// The links are numbered blockers first, as the cursor counts them
+	if len(blockers) > 0 {
+		header = append(header, "", heading.Render("Blocked by"))
+		header = append(header, linkLines(blockers, workflow, linkCursor)...)
+	}
+	if len(dependents) > 0 {
+		header = append(header, "", heading.Render("Blocks"))
+		header = append(header, linkLines(dependents, workflow, linkCursor-len(blockers))...)
+	}
+	if showHistory {
+		header = append(header, "", heading.Render(fmt.Sprintf("History (%d)", len(history))))
+	} else {
+		header = append(header, "", heading.Render(fmt.Sprintf("Comments (%d)", len(task.Comments))))
+	}
+
+	var footer []string
+	if inputView != "" {
+		footer = append(footer, "", inputView)
+	}
+	if errorMessage != "" {
+		footer = append(footer, "", lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Red)).Width(taskDetailWidth).Render(errorMessage))
+	}
+	instructions := "[a] Comment • [h] History • [j/k] Scroll • [esc] Close"
+	if showHistory {
+		instructions = "[a] Comment • [h] Comments • [j/k] Scroll • [esc] Close"
+	}
+	var linkKeys []string
+	if len(blockers)+len(dependents) > 0 {
+		linkKeys = append(linkKeys, "[tab] Links • [enter] Open")
+	}
+	if len(blockers) > 0 {
+		linkKeys = append(linkKeys, "[b] Blocker")
+	}
+	if canGoBack {
+		linkKeys = append(linkKeys, "[backspace] Back")
+	}
+	if len(linkKeys) > 0 {
+		instructions += "\n" + strings.Join(linkKeys, " • ")
+	}
+	if inputView != "" {
+		instructions = "[ctrl+s] Save • [enter] New line • [esc] Cancel"
+	}
+	footer = append(footer, "", dialogStyles.Instruction.Width(taskDetailWidth).Render(instructions))
+
+	// The border and padding of the dialog take 6 lines; the list gets what is left
+	available := height - 6 - lipgloss.Height(strings.Join(header, "\n")) - lipgloss.Height(strings.Join(footer, "\n"))
+	var lines []string
+	if showHistory {
+		lines = append(header, historyLines(history, offset, available)...)
+	} else {
+		lines = append(header, commentLines(task.Comments, offset, available)...)
+	}
+	lines = append(lines, footer...)
+
+	form := dialogStyles.Form.
+		Width(taskDetailWidth + 6).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	return lipgloss.Place(
+		width, height,
+		lipgloss.Center, lipgloss.Center,
+		form,
+	)
+}
+
+// linkLines renders the linked tasks one per line, highlighting the one at
+// index cursor
+func linkLines(tasks []domain.Task, workflow domain.Workflow, cursor int) []string {
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0))
+	name := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Text))
+	selected := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Blue)).Bold(true)
+
+	lines := make([]string, len(tasks))
+	for i, task := range tasks {
+		style, marker := name, "  "
+		if i == cursor {
+			style, marker = selected, "> "
+		}
+		line := style.Render(fmt.Sprintf("%s#%d %s", marker, task.IntID, task.Name)) + muted.Render(" ("+workflow.Name(task.Status)+")")
+		lines[i] = lipgloss.NewStyle().MaxWidth(taskDetailWidth).Render(line)
+	}
+	return lines
+}
+
+// commentLines renders the comments from index offset on, as many as fit in
+// available lines
+func commentLines(comments []domain.Comment, offset, available int) []string {
+	if len(comments) == 0 {
+		return []string{lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0)).Render("No comments yet")}
+	}
+
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0))
+	author := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Blue)).Bold(true)
+	body := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Text)).Width(taskDetailWidth).PaddingLeft(2)
+
+	blocks := make([]string, len(comments))
+	for i, comment := range comments {
+		blocks[i] = author.Render(comment.Author) + muted.Render(" · "+comment.Timestamp()) + "\n" + body.Render(comment.Body)
+	}
+	return scrolledBlocks(blocks, offset, available)
+}
+
+// historyLines renders the events from index offset on, one line each, as many
+// as fit in available lines
+func historyLines(events []domain.Event, offset, available int) []string {
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0))
+	if len(events) == 0 {
+		return []string{muted.Render("No changes recorded")}
+	}
+
+	actor := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Blue)).Bold(true)
+	change := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Text))
+
+	blocks := make([]string, len(events))
+	for i, event := range events {
+		who := muted.Render(event.Timestamp()+" ") + actor.Render(event.Actor) + " "
+		// Long changes wrap under themselves, right of the time and actor
+		what := change.Width(taskDetailWidth - lipgloss.Width(who)).Render(event.Describe())
+		blocks[i] = lipgloss.JoinHorizontal(lipgloss.Top, who, what)
+	}
+	return scrolledBlocks(blocks, offset, available)
+}
+
+// scrolledBlocks returns the blocks from index offset on, as many as fit in
+// available lines, with markers for the blocks scrolled out of view
+func scrolledBlocks(blocks []string, offset, available int) []string {
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0))
+
+	offset = max(min(offset, len(blocks)-1), 0)
+	var lines []string
+	if offset > 0 {
+		lines = append(lines, muted.Render(fmt.Sprintf("↑ %d earlier", offset)))
+	}
+
+	// Leave a line for the marker below in case not every block fits
+	used := len(lines) + 1
+	shown := offset
+	for _, block := range blocks[offset:] {
+		height := lipgloss.Height(block)
+		// The block under the offset is always shown, even when cut short
+		if shown > offset && used+height > available {
+			break
+		}
+		lines = append(lines, block)
+		used += height
+		shown++
+	}
+	if rest := len(blocks) - shown; rest > 0 {
+		lines = append(lines, muted.Render(fmt.Sprintf("↓ %d more", rest)))
+	}
+	return lines
+}
@@ -0,0 +1,93 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"kahn/internal/domain"
+	"kahn/internal/ui/colors"
+	"kahn/internal/ui/styles"
+)
+
+// trashWidth is the inner width of the trash pane
+const trashWidth = 60
+
+func (b *BoardComponent) RenderTrash(showTrash bool, archived, tasks []domain.Task, projects []domain.Project, workflow domain.Workflow, cursor int, confirming bool, errorMessage string, width, height int) string {
+	dialogStyles := styles.GetDialogStyles()
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0))
+	activeTab := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Mauve)).Bold(true).Underline(true)
+
+	archiveTab, trashTab := activeTab.Render(fmt.Sprintf("Archive (%d)", len(archived))),
+		muted.Render(fmt.Sprintf("Trash (%d)", len(projects)+len(tasks)))
+	if showTrash {
+		archiveTab, trashTab = muted.Render(fmt.Sprintf("Archive (%d)", len(archived))),
+			activeTab.Render(fmt.Sprintf("Trash (%d)", len(projects)+len(tasks)))
+	}
+	title := dialogStyles.Title.Width(trashWidth).Render("Trash & Archive")
+	tabs := lipgloss.NewStyle().Width(trashWidth).Align(lipgloss.Center).Render(archiveTab + "   " + trashTab)
+
+	var rows []string
+	if showTrash {
+		for i, project := range projects {
+			line := fmt.Sprintf("project %s · deleted %s", project.Name, domain.FormatDate(project.DeletedAt))
+			rows = append(rows, trashEntryLine(line, i == cursor))
+		}
+		for i, task := range tasks {
+			line := fmt.Sprintf("#%d %s · deleted %s", task.IntID, task.Name, domain.FormatDate(task.DeletedAt))
+			rows = append(rows, trashEntryLine(line, len(projects)+i == cursor))
+		}
+		if len(rows) == 0 {
+			rows = append(rows, muted.Render("The trash is empty"))
+		}
+	} else {
+		for i, task := range archived {
+			line := fmt.Sprintf("#%d %s · %s · archived %s", task.IntID, task.Name, workflow.Name(task.Status), domain.FormatDate(task.ArchivedAt))
+			rows = append(rows, trashEntryLine(line, i == cursor))
+		}
+		if len(rows) == 0 {
+			rows = append(rows, muted.Render("No archived tasks"))
+		}
+	}
+
+	// Keep the dialog on screen: title, tabs, spacing, instructions and border take 14 lines
+	rows = scrollChecklistRows(rows, cursor, max(height-14, 3))
+
+	lines := []string{"", title, "", tabs, ""}
+	lines = append(lines, rows...)
+	if errorMessage != "" {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Red)).Width(trashWidth).Render(errorMessage))
+	}
+
+	instructions := "[tab] Trash • [r] Unarchive • [esc] Close"
+	switch {
+	case confirming:
+		instructions = "Delete permanently? This cannot be undone.\n[y] Yes, Delete • [n] No, Cancel"
+	case showTrash:
+		instructions = "[tab] Archive • [r] Restore • [D] Delete permanently • [esc] Close"
+	}
+	lines = append(lines, "", dialogStyles.Instruction.Width(trashWidth).Render(instructions))
+
+	form := dialogStyles.Form.
+		Width(trashWidth + 6).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	return lipgloss.Place(
+		width, height,
+		lipgloss.Center, lipgloss.Center,
+		form,
+	)
+}
+
+// trashEntryLine shows one entry of the trash pane, highlighted under the cursor
+func trashEntryLine(text string, selected bool) string {
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Text))
+	pointer := "  "
+	if selected {
+		pointer = "> "
+		style = style.Foreground(lipgloss.Color(colors.Blue)).Bold(true)
+	}
+	if runes := []rune(text); len(runes) > trashWidth-2 {
+		text = string(runes[:trashWidth-3]) + "…"
+	}
+	return pointer + style.Render(text)
+}
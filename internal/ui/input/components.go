@@ -3,12 +3,14 @@ package input
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/lipgloss"
 	"kahn/internal/domain"
 	"kahn/internal/ui/colors"
+	"kahn/internal/ui/markdown"
 )
 
 type FormType int
@@ -20,16 +22,19 @@ const (
 )
 
 type InputComponents struct {
-	NameInput      textinput.Model
-	DescInput      textarea.Model
-	PriorityValue  domain.Priority // Track current priority value
-	TypeValue      domain.TaskType // Track current task type value
-	BlockedByValue *int            // Currently selected blocker (nil = None)
-	availableTasks []domain.Task   // Tasks that can block this one
-	blockedByIndex int             // Current index in availableTasks (-1 = None)
-	formType       FormType
-	taskID         string // for edit forms
-	FocusedField   int    // 0=name, 1=desc, 2=priority, 3=type, 4=blockedBy (exported)
+	NameInput       textinput.Model
+	DescInput       textarea.Model
+	LabelsInput     textinput.Model // comma or space separated label names
+	DueInput        textinput.Model // due date, absolute or relative (see domain.ParseDate)
+	PriorityValue   domain.Priority // Track current priority value
+	TypeValue       domain.TaskType // Track current task type value
+	BlockedByValues []int           // Numbers of the selected blockers
+	availableTasks  []domain.Task   // Tasks that can block this one
+	blockedByIndex  int             // Highlighted row in availableTasks (-1 = none available)
+	formType        FormType
+	taskID          string // for edit forms
+	FocusedField    int    // 0=name, 1=desc, 2=priority, 3=type, 4=blockedBy, 5=labels, 6=due (exported)
+	previewDesc     bool   // Show the description rendered as Markdown in place of the text area
 }
 
 func NewInputComponents() InputComponents {
@@ -47,36 +52,63 @@ func NewInputComponents() InputComponents {
 func (ic *InputComponents) SetupForTaskCreate() {
 	ic.formType = TaskCreateForm
 	ic.FocusedField = 0
+	ic.previewDesc = false
 	ic.taskID = ""
 	ic.PriorityValue = domain.Low     // Default to Low priority
 	ic.TypeValue = domain.RegularTask // Default to RegularTask type
-	ic.BlockedByValue = nil           // Default to no blocker
-	ic.blockedByIndex = -1            // -1 represents "None"
+	ic.BlockedByValues = nil          // Default to no blockers
+	ic.blockedByIndex = -1            // Set by SetAvailableTasks
 	ic.availableTasks = []domain.Task{}
 	ic.NameInput = ic.createNameInput("Task name *")
 	ic.DescInput = ic.createDescInput("Task description (optional)")
+	ic.LabelsInput = ic.createLabelsInput()
+	ic.DueInput = ic.createDueInput()
 	ic.NameInput.Focus()
 }
 
-func (ic *InputComponents) SetupForTaskEdit(taskID, name, desc string, priority domain.Priority, taskType domain.TaskType, blockedByIntID *int) {
+func (ic *InputComponents) SetupForTaskEdit(taskID, name, desc string, priority domain.Priority, taskType domain.TaskType, blockedBy []int) {
 	ic.formType = TaskEditForm
 	ic.FocusedField = 0
+	ic.previewDesc = false
 	ic.taskID = taskID
 	ic.PriorityValue = priority
 	ic.TypeValue = taskType
-	ic.BlockedByValue = blockedByIntID
+	ic.BlockedByValues = append([]int(nil), blockedBy...)
 	ic.blockedByIndex = -1 // Will be set by SetAvailableTasks
 	ic.availableTasks = []domain.Task{}
 	ic.NameInput = ic.createNameInput("Task name *")
 	ic.DescInput = ic.createDescInput("Task description (optional)")
+	ic.LabelsInput = ic.createLabelsInput()
+	ic.DueInput = ic.createDueInput()
 	ic.NameInput.SetValue(name)
 	ic.DescInput.SetValue(desc)
 	ic.NameInput.Focus()
 }
 
+// SetLabelNames fills the labels field, as when editing a task that has labels
+func (ic *InputComponents) SetLabelNames(names []string) {
+	ic.LabelsInput.SetValue(strings.Join(names, ", "))
+}
+
+// GetLabelNames returns the label names typed into the labels field
+func (ic *InputComponents) GetLabelNames() []string {
+	return domain.ParseLabelNames(ic.LabelsInput.Value())
+}
+
+// SetDueDate fills the due date field, as when editing a task that has one
+func (ic *InputComponents) SetDueDate(date *time.Time) {
+	ic.DueInput.SetValue(domain.FormatDate(date))
+}
+
+// GetDueDate returns the due date typed into the due field, or nil when empty
+func (ic *InputComponents) GetDueDate() (*time.Time, error) {
+	return domain.ParseDate(ic.DueInput.Value(), time.Now())
+}
+
 func (ic *InputComponents) SetupForProjectCreate() {
 	ic.formType = ProjectCreateForm
 	ic.FocusedField = 0
+	ic.previewDesc = false
 	ic.taskID = ""
 	ic.NameInput = ic.createNameInput("Project name *")
 	ic.DescInput = ic.createDescInput("Project description (optional)")
@@ -94,6 +126,18 @@ func (ic *InputComponents) createNameInput(placeholder string) textinput.Model {
 	return input
 }
 
+func (ic *InputComponents) createLabelsInput() textinput.Model {
+	input := ic.createNameInput("e.g. backend, tech-debt (optional)")
+	input.CharLimit = 200
+	return input
+}
+
+func (ic *InputComponents) createDueInput() textinput.Model {
+	input := ic.createNameInput("e.g. 2026-11-01, +3d, fri (optional)")
+	input.CharLimit = 20
+	return input
+}
+
 func (ic *InputComponents) createDescInput(placeholder string) textarea.Model {
 	ta := textarea.New()
 	ta.Placeholder = placeholder
@@ -120,12 +164,15 @@ func (ic *InputComponents) createDescInput(placeholder string) textarea.Model {
 func (ic *InputComponents) Reset() {
 	ic.NameInput.Reset()
 	ic.DescInput.Reset()
+	ic.LabelsInput.Reset()
+	ic.DueInput.Reset()
 	ic.PriorityValue = domain.Low
 	ic.TypeValue = domain.RegularTask
-	ic.BlockedByValue = nil
+	ic.BlockedByValues = nil
 	ic.blockedByIndex = -1
 	ic.availableTasks = []domain.Task{}
 	ic.FocusedField = 0
+	ic.previewDesc = false
 	ic.taskID = ""
 }
 
@@ -186,66 +233,80 @@ func (ic *InputComponents) CycleTypeDown() {
 func (ic *InputComponents) SetAvailableTasks(tasks []domain.Task) {
 	ic.availableTasks = tasks
 
-	// Find the index of the currently selected blocker
-	if ic.BlockedByValue != nil {
-		for i, task := range tasks {
-			if task.IntID == *ic.BlockedByValue {
-				ic.blockedByIndex = i
-				return
-			}
-		}
-		// If blocker not found in available tasks, reset to None
+	// Selected blockers missing from the list (e.g. finished tasks) are kept so
+	// saving the form does not silently drop them
+	if len(tasks) == 0 {
 		ic.blockedByIndex = -1
-		ic.BlockedByValue = nil
 	} else {
-		ic.blockedByIndex = -1
+		ic.blockedByIndex = 0
 	}
 }
 
-// CycleBlockedByUp cycles to the next blocking task (up in list)
+// CycleBlockedByUp moves the highlight to the previous task in the list
 func (ic *InputComponents) CycleBlockedByUp() {
 	if len(ic.availableTasks) == 0 {
-		// No tasks available, stay at None
 		ic.blockedByIndex = -1
-		ic.BlockedByValue = nil
 		return
 	}
 
-	ic.blockedByIndex++
-	if ic.blockedByIndex >= len(ic.availableTasks) {
-		// Wrap to None
-		ic.blockedByIndex = -1
-		ic.BlockedByValue = nil
-	} else {
-		// Set to the task at current index
-		intID := ic.availableTasks[ic.blockedByIndex].IntID
-		ic.BlockedByValue = &intID
+	ic.blockedByIndex--
+	if ic.blockedByIndex < 0 {
+		// Wrap to last task
+		ic.blockedByIndex = len(ic.availableTasks) - 1
 	}
 }
 
-// CycleBlockedByDown cycles to the previous blocking task (down in list)
+// CycleBlockedByDown moves the highlight to the next task in the list
 func (ic *InputComponents) CycleBlockedByDown() {
 	if len(ic.availableTasks) == 0 {
-		// No tasks available, stay at None
 		ic.blockedByIndex = -1
-		ic.BlockedByValue = nil
 		return
 	}
 
-	ic.blockedByIndex--
-	if ic.blockedByIndex < -1 {
-		// Wrap to last task
-		ic.blockedByIndex = len(ic.availableTasks) - 1
-		intID := ic.availableTasks[ic.blockedByIndex].IntID
-		ic.BlockedByValue = &intID
-	} else if ic.blockedByIndex == -1 {
-		// Set to None
-		ic.BlockedByValue = nil
-	} else {
-		// Set to the task at current index
-		intID := ic.availableTasks[ic.blockedByIndex].IntID
-		ic.BlockedByValue = &intID
+	ic.blockedByIndex++
+	if ic.blockedByIndex >= len(ic.availableTasks) {
+		// Wrap to first task
+		ic.blockedByIndex = 0
+	}
+}
+
+// ToggleBlockedBy adds the highlighted task to the blockers, or removes it
+// when it is already selected
+func (ic *InputComponents) ToggleBlockedBy() {
+	if ic.blockedByIndex < 0 || ic.blockedByIndex >= len(ic.availableTasks) {
+		return
+	}
+
+	intID := ic.availableTasks[ic.blockedByIndex].IntID
+	if !ic.isBlockerSelected(intID) {
+		ic.BlockedByValues = domain.NormalizeBlockers(append(ic.BlockedByValues, intID))
+		return
+	}
+
+	var remaining []int
+	for _, blocker := range ic.BlockedByValues {
+		if blocker != intID {
+			remaining = append(remaining, blocker)
+		}
+	}
+	ic.BlockedByValues = remaining
+}
+
+func (ic *InputComponents) isBlockerSelected(intID int) bool {
+	for _, blocker := range ic.BlockedByValues {
+		if blocker == intID {
+			return true
+		}
 	}
+	return false
+}
+
+// HighlightedBlocker returns the task under the blocked by cursor, if any
+func (ic *InputComponents) HighlightedBlocker() (domain.Task, bool) {
+	if ic.blockedByIndex < 0 || ic.blockedByIndex >= len(ic.availableTasks) {
+		return domain.Task{}, false
+	}
+	return ic.availableTasks[ic.blockedByIndex], true
 }
 
 // FocusBlockedBy focuses the blocked by field
@@ -289,6 +350,21 @@ func (ic *InputComponents) ValidateForSubmit() (bool, string, string) {
 		return false, "name", "Name too long (max 50 characters)"
 	}
 
+	if ic.IsTaskForm() {
+		labels := ic.GetLabelNames()
+		if len(labels) > domain.MaxLabelsPerTask {
+			return false, "labels", fmt.Sprintf("Too many labels (max %d)", domain.MaxLabelsPerTask)
+		}
+		for _, label := range labels {
+			if err := domain.ValidateLabelName(label); err != nil {
+				return false, "labels", fmt.Sprintf("Invalid label %q (letters, digits, '.', '-' and '_' only, max %d characters)", label, domain.MaxLabelNameLength)
+			}
+		}
+		if _, err := ic.GetDueDate(); err != nil {
+			return false, "due_date", "Invalid due date (use YYYY-MM-DD, today, tomorrow, +3d, +2w, +1m or a weekday)"
+		}
+	}
+
 	// Project description validation
 	if ic.formType == ProjectCreateForm {
 		desc := ic.DescInput.Value()
@@ -311,12 +387,16 @@ func (ic *InputComponents) Render(errorMsg string, errorField string, width, hei
 	var priorityField string
 	var typeField string
 	var blockedByField string
+	var labelsField string
+	var dueField string
 
 	// Only show priority, type, and blocked by fields for task forms
 	if ic.formType == TaskCreateForm || ic.formType == TaskEditForm {
 		priorityField = ic.renderPriorityField(errorMsg, errorField)
 		typeField = ic.renderTypeField(errorMsg, errorField)
 		blockedByField = ic.renderBlockedByField(errorMsg, errorField)
+		labelsField = ic.renderFieldWithError(5, errorMsg, errorField)
+		dueField = ic.renderFieldWithError(6, errorMsg, errorField)
 	}
 
 	instructions := ic.getInstructions()
@@ -333,6 +413,8 @@ func (ic *InputComponents) Render(errorMsg string, errorField string, width, hei
 			"Priority:", priorityField, "",
 			"Type:", typeField, "",
 			"Blocked By:", blockedByField, "",
+			"Labels:", labelsField, "",
+			"Due Date:", dueField, "",
 			instructions,
 		)
 	} else {
@@ -354,12 +436,24 @@ func (ic *InputComponents) renderFieldWithError(field int, errorMsg string, erro
 	var isFocused bool
 	var fieldName string
 
-	if field == 0 {
+	switch field {
+	case 0:
 		fieldView = ic.NameInput.View()
 		isFocused = ic.FocusedField == 0
 		fieldName = "name"
-	} else {
+	case 5:
+		fieldView = ic.LabelsInput.View()
+		isFocused = ic.FocusedField == 5
+		fieldName = "labels"
+	case 6:
+		fieldView = ic.DueInput.View()
+		isFocused = ic.FocusedField == 6
+		fieldName = "due_date"
+	default:
 		fieldView = ic.DescInput.View()
+		if ic.previewDesc {
+			fieldView = ic.renderDescPreview()
+		}
 		isFocused = ic.FocusedField == 1
 		fieldName = "description"
 	}
@@ -480,22 +574,37 @@ func (ic *InputComponents) renderTypeField(errorMsg string, errorField string) s
 	return fieldWithBorder
 }
 
-// renderBlockedByField renders the blocked by field with visual indicators
+// renderBlockedByField renders the selected blockers and, while focused, the
+// highlighted task that space toggles
 func (ic *InputComponents) renderBlockedByField(errorMsg string, errorField string) string {
 	var display string
 
-	if ic.BlockedByValue == nil {
+	if len(ic.BlockedByValues) == 0 {
 		display = "Blocked By: (None)"
 	} else {
-		// Find the task name
-		taskName := "(Unknown)"
-		for _, task := range ic.availableTasks {
-			if task.IntID == *ic.BlockedByValue {
-				taskName = task.Name
-				break
+		names := make([]string, len(ic.BlockedByValues))
+		for i, blocker := range ic.BlockedByValues {
+			names[i] = fmt.Sprintf("#%d", blocker)
+			for _, task := range ic.availableTasks {
+				if task.IntID == blocker {
+					names[i] = fmt.Sprintf("#%d %s", blocker, task.Name)
+					break
+				}
+			}
+		}
+		display = "Blocked By: " + strings.Join(names, ", ")
+	}
+
+	if ic.FocusedField == 4 {
+		if task, ok := ic.HighlightedBlocker(); ok {
+			mark := "[ ]"
+			if ic.isBlockerSelected(task.IntID) {
+				mark = "[x]"
 			}
+			display += fmt.Sprintf("\n%s #%d %s", mark, task.IntID, task.Name)
+		} else {
+			display += "\n(no tasks to wait on)"
 		}
-		display = fmt.Sprintf("Blocked By: %s", taskName)
 	}
 
 	if ic.FocusedField == 4 { // BlockedBy field focused
@@ -553,11 +662,11 @@ func (ic *InputComponents) getDescLabel() string {
 func (ic *InputComponents) getInstructions() string {
 	switch ic.formType {
 	case TaskCreateForm:
-		return "Tab: Switch fields • ↑/↓: Change selection • Enter/Ctrl+Enter: Create Task • Esc: Cancel"
+		return "Tab: Switch fields • ↑/↓: Change selection • Space: Toggle blocker • Ctrl+P: Preview description • Enter/Ctrl+Enter: Create Task • Esc: Cancel"
 	case TaskEditForm:
-		return "Tab: Switch fields • ↑/↓: Change selection • Enter/Ctrl+Enter: Save Changes • Esc: Cancel"
+		return "Tab: Switch fields • ↑/↓: Change selection • Space: Toggle blocker • Ctrl+P: Preview description • Enter/Ctrl+Enter: Save Changes • Esc: Cancel"
 	case ProjectCreateForm:
-		return "Tab: Switch fields • Enter/Ctrl+Enter: Create Project • Esc: Cancel"
+		return "Tab: Switch fields • Ctrl+P: Preview description • Enter/Ctrl+Enter: Create Project • Esc: Cancel"
 
 	default:
 		return "Tab: Switch fields • Enter/Ctrl+Enter: Submit • Esc: Cancel"
@@ -597,9 +706,58 @@ func (ic *InputComponents) BlurName() {
 	ic.NameInput.Blur()
 }
 
-// BlurDesc blurs the description input
+// BlurDesc blurs the description input and closes its preview
 func (ic *InputComponents) BlurDesc() {
 	ic.DescInput.Blur()
+	ic.previewDesc = false
+}
+
+// ToggleDescPreview switches the description field between the text area and
+// the description rendered as Markdown
+func (ic *InputComponents) ToggleDescPreview() {
+	ic.previewDesc = !ic.previewDesc
+}
+
+// IsPreviewingDesc returns whether the description field shows the rendered preview
+func (ic *InputComponents) IsPreviewingDesc() bool {
+	return ic.previewDesc
+}
+
+// renderDescPreview renders the description as it shows in the task detail
+// view, at the width of the text area and at most twice its height
+func (ic *InputComponents) renderDescPreview() string {
+	if strings.TrimSpace(ic.DescInput.Value()) == "" {
+		return lipgloss.NewStyle().
+			Foreground(lipgloss.Color(colors.Subtext0)).
+			Width(ic.DescInput.Width()).
+			Height(ic.DescInput.Height()).
+			Render("Nothing to preview")
+	}
+	return lipgloss.NewStyle().
+		MaxHeight(2 * ic.DescInput.Height()).
+		Render(markdown.Render(ic.DescInput.Value(), ic.DescInput.Width()))
+}
+
+// FocusLabels focuses the labels input
+func (ic *InputComponents) FocusLabels() {
+	ic.FocusedField = 5
+	ic.LabelsInput.Focus()
+}
+
+// BlurLabels blurs the labels input
+func (ic *InputComponents) BlurLabels() {
+	ic.LabelsInput.Blur()
+}
+
+// FocusDue focuses the due date input
+func (ic *InputComponents) FocusDue() {
+	ic.FocusedField = 6
+	ic.DueInput.Focus()
+}
+
+// BlurDue blurs the due date input
+func (ic *InputComponents) BlurDue() {
+	ic.DueInput.Blur()
 }
 
 // FocusType focuses the type field
@@ -616,6 +774,8 @@ func (ic *InputComponents) BlurType() {
 func (ic *InputComponents) Blur() {
 	ic.NameInput.Blur()
 	ic.DescInput.Blur()
+	ic.LabelsInput.Blur()
+	ic.DueInput.Blur()
 }
 
 // GetFormType returns the current form type
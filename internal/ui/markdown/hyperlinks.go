@@ -0,0 +1,66 @@
+package markdown
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"kahn/internal/domain"
+)
+
+// Hyperlink settings accepted by SetHyperlinks
+const (
+	HyperlinksAuto   = "auto"
+	HyperlinksAlways = "always"
+	HyperlinksNever  = "never"
+)
+
+// hyperlinks tells whether links are rendered as OSC-8 hyperlinks
+var hyperlinks = terminalSupportsHyperlinks()
+
+// SetHyperlinks makes links clickable with "always", shows their addresses with
+// "never", and with "auto" or an empty value picks by the terminal kahn runs in
+func SetHyperlinks(setting string) error {
+	switch strings.ToLower(strings.TrimSpace(setting)) {
+	case "", HyperlinksAuto:
+		hyperlinks = terminalSupportsHyperlinks()
+	case HyperlinksAlways:
+		hyperlinks = true
+	case HyperlinksNever:
+		hyperlinks = false
+	default:
+		return domain.NewValidationError("hyperlinks", fmt.Sprintf("unknown hyperlinks setting %q; expected auto, always or never", setting))
+	}
+	return nil
+}
+
+// terminalSupportsHyperlinks guesses from the environment whether the terminal
+// opens OSC-8 hyperlinks. Terminals without support print the link text alone,
+// which would hide the address, so unknown terminals get no hyperlinks.
+func terminalSupportsHyperlinks() bool {
+	term := os.Getenv("TERM")
+	if term == "dumb" || os.Getenv("TMUX") != "" || strings.HasPrefix(term, "screen") {
+		return false
+	}
+
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper", "Tabby", "rio":
+		return true
+	}
+	for _, key := range []string{"KITTY_WINDOW_ID", "WT_SESSION", "KONSOLE_VERSION", "ALACRITTY_WINDOW_ID", "DOMTERM"} {
+		if os.Getenv(key) != "" {
+			return true
+		}
+	}
+	// VTE based terminals such as GNOME Terminal support them from 0.50
+	if version, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && version >= 5000 {
+		return true
+	}
+	for _, name := range []string{"kitty", "alacritty", "foot", "wezterm", "ghostty"} {
+		if strings.Contains(term, name) {
+			return true
+		}
+	}
+	return false
+}
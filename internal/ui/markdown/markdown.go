@@ -0,0 +1,335 @@
+// Package markdown renders task descriptions written in Markdown for the
+// terminal, styled with the Catppuccin palette. It covers what descriptions use:
+// headings, paragraphs, bullet, numbered and task lists, block quotes, rules,
+// fenced code blocks, and inline code, emphasis and links. Single line breaks
+// are kept, as in comments on a code forge, since descriptions are typed into a
+// text area line by line.
+package markdown
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"kahn/internal/ui/colors"
+)
+
+var (
+	headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+	rulePattern    = regexp.MustCompile(`^(?:(?:\*\s*){3,}|(?:-\s*){3,}|(?:_\s*){3,})$`)
+	listPattern    = regexp.MustCompile(`^(\s*)([-*+]|\d{1,9}[.)])\s+(.*)$`)
+	taskPattern    = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+)
+
+// styles holds the look of each element
+type styles struct {
+	text     lipgloss.Style
+	muted    lipgloss.Style
+	headings []lipgloss.Style // by level, from 1
+	marker   lipgloss.Style   // bullets and numbers
+	done     lipgloss.Style   // checked task list boxes
+	quote    lipgloss.Style
+	quoteBar lipgloss.Style
+	rule     lipgloss.Style
+	code     lipgloss.Style // inline code
+	block    lipgloss.Style // fenced code blocks
+	link     lipgloss.Style
+}
+
+func newStyles() styles {
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Text))
+	return styles{
+		text:  text,
+		muted: lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0)),
+		headings: []lipgloss.Style{
+			lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Mauve)).Bold(true).Underline(true),
+			lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Mauve)).Bold(true),
+			lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Lavender)).Bold(true),
+			lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Blue)).Bold(true),
+			lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Sapphire)).Bold(true),
+			lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext1)).Bold(true),
+		},
+		marker:   lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Peach)),
+		done:     lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Green)),
+		quote:    lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext1)).Italic(true),
+		quoteBar: lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Overlay1)),
+		rule:     lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Surface2)),
+		code:     lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Peach)).Background(lipgloss.Color(colors.Surface0)),
+		block:    lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Green)).Background(lipgloss.Color(colors.Surface0)).Padding(0, 1),
+		link:     lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Blue)).Underline(true),
+	}
+}
+
+// Render renders source wrapped to width columns. Links are clickable when
+// hyperlinks are enabled, and otherwise followed by their address. Control
+// characters in source are dropped, so it cannot send escape sequences of its
+// own to the terminal.
+func Render(source string, width int) string {
+	width = max(width, 10)
+	r := renderer{styles: newStyles(), width: width}
+
+	lines := strings.Split(StripControl(strings.ReplaceAll(source, "\r\n", "\n")), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.ReplaceAll(lines[i], "\t", "    ")
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			r.blank()
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			fence := trimmed[:3]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, strings.ReplaceAll(lines[i], "\t", "    "))
+			}
+			r.codeBlock(code)
+		case headingPattern.MatchString(trimmed):
+			match := headingPattern.FindStringSubmatch(trimmed)
+			style := r.headings[len(match[1])-1]
+			r.add(style.Width(width).Render(r.inline(match[2], style)))
+		case rulePattern.MatchString(trimmed):
+			r.add(r.rule.Render(strings.Repeat("─", width)))
+		case strings.HasPrefix(trimmed, ">"):
+			r.quoteLine(strings.TrimSpace(strings.TrimPrefix(trimmed, ">")))
+		case listPattern.MatchString(line):
+			match := listPattern.FindStringSubmatch(line)
+			r.listItem(len(match[1])/2, match[2], match[3])
+		default:
+			r.add(r.text.Width(width).Render(r.inline(trimmed, r.text)))
+		}
+	}
+	return strings.Join(r.trimmed(), "\n")
+}
+
+// renderer collects the rendered lines of one source
+type renderer struct {
+	styles
+	width int
+	lines []string
+}
+
+func (r *renderer) add(block string) {
+	r.lines = append(r.lines, block)
+}
+
+// blank separates blocks by one empty line, however many the source has
+func (r *renderer) blank() {
+	if len(r.lines) > 0 && r.lines[len(r.lines)-1] != "" {
+		r.lines = append(r.lines, "")
+	}
+}
+
+func (r *renderer) trimmed() []string {
+	lines := r.lines
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// codeBlock renders the lines of a fenced block as they are, on a shaded
+// background that spans the width
+func (r *renderer) codeBlock(code []string) {
+	if len(code) == 0 {
+		code = []string{""}
+	}
+	r.add(r.block.Width(r.width).Render(strings.Join(code, "\n")))
+}
+
+func (r *renderer) quoteLine(text string) {
+	bar := r.quoteBar.Render("│ ")
+	wrapped := r.quote.Width(r.width - 2).Render(r.inline(text, r.quote))
+	for _, line := range strings.Split(wrapped, "\n") {
+		r.add(bar + line)
+	}
+}
+
+// listItem renders an item nested depth levels deep, with its text hanging
+// right of the bullet, number or task box
+func (r *renderer) listItem(depth int, marker, text string) {
+	switch {
+	case taskPattern.MatchString(text):
+		match := taskPattern.FindStringSubmatch(text)
+		text = match[2]
+		if match[1] == " " {
+			marker = r.marker.Render("☐")
+		} else {
+			marker = r.done.Render("☑")
+		}
+	case marker == "-" || marker == "*" || marker == "+":
+		bullets := []string{"•", "◦", "▪"}
+		marker = r.marker.Render(bullets[depth%len(bullets)])
+	default:
+		marker = r.marker.Render(marker)
+	}
+
+	prefix := strings.Repeat("  ", depth) + marker + " "
+	indent := lipgloss.Width(prefix)
+	body := r.text.Width(max(r.width-indent, 1)).Render(r.inline(text, r.text))
+	r.add(lipgloss.JoinHorizontal(lipgloss.Top, prefix, body))
+}
+
+// inline renders the code spans, emphasis and links in text on top of base
+func (r *renderer) inline(text string, base lipgloss.Style) string {
+	var out, plain strings.Builder
+	flush := func() {
+		if plain.Len() > 0 {
+			out.WriteString(base.Render(plain.String()))
+			plain.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+		switch {
+		case rest[0] == '\\' && len(rest) > 1 && strings.ContainsRune("\\`*_[]()<>#~!-+.", rune(rest[1])):
+			plain.WriteByte(rest[1])
+			i += 2
+			continue
+		case rest[0] == '`':
+			if end := strings.IndexByte(rest[1:], '`'); end >= 0 {
+				flush()
+				out.WriteString(r.code.Render(rest[1 : end+1]))
+				i += end + 2
+				continue
+			}
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if inner, n := delimited(rest, rest[:2]); n > 0 {
+				flush()
+				out.WriteString(r.inline(inner, base.Bold(true)))
+				i += n
+				continue
+			}
+		case strings.HasPrefix(rest, "~~"):
+			if inner, n := delimited(rest, "~~"); n > 0 {
+				flush()
+				out.WriteString(r.inline(inner, base.Strikethrough(true)))
+				i += n
+				continue
+			}
+		case rest[0] == '*' || (rest[0] == '_' && (i == 0 || !isWordByte(text[i-1]))):
+			if inner, n := delimited(rest, rest[:1]); n > 0 && (rest[0] == '*' || n == len(rest) || !isWordByte(rest[n])) {
+				flush()
+				out.WriteString(r.inline(inner, base.Italic(true)))
+				i += n
+				continue
+			}
+		case rest[0] == '[':
+			if label, url, n := linkAt(rest); n > 0 {
+				flush()
+				out.WriteString(r.hyperlink(r.inline(label, r.link), label, url))
+				i += n
+				continue
+			}
+		case rest[0] == '<':
+			if end := strings.IndexByte(rest, '>'); end > 0 && isURL(rest[1:end]) {
+				flush()
+				url := rest[1:end]
+				out.WriteString(r.hyperlink(r.link.Render(url), url, url))
+				i += end + 1
+				continue
+			}
+		case hasURLScheme(rest) && (i == 0 || strings.ContainsRune(" (", rune(text[i-1]))):
+			if url := bareURL(rest); isURL(url) {
+				flush()
+				out.WriteString(r.hyperlink(r.link.Render(url), url, url))
+				i += len(url)
+				continue
+			}
+		}
+		plain.WriteByte(rest[0])
+		i++
+	}
+	flush()
+	return out.String()
+}
+
+// hyperlink makes rendered clickable, or follows it with url when hyperlinks are off
+// and label does not already show it
+func (r *renderer) hyperlink(rendered, label, url string) string {
+	if hyperlinks {
+		return ansi.SetHyperlink(strings.Map(withoutControl, url)) + rendered + ansi.ResetHyperlink()
+	}
+	if label == url {
+		return rendered
+	}
+	return rendered + r.muted.Render(" ("+url+")")
+}
+
+// delimited returns the text between the delim opening s and the next one, and
+// how many bytes that takes with both delimiters. It returns 0 bytes when there
+// is no closing delimiter or the text is empty or padded with spaces.
+func delimited(s, delim string) (string, int) {
+	end := strings.Index(s[len(delim):], delim)
+	if end <= 0 {
+		return "", 0
+	}
+	inner := s[len(delim) : len(delim)+end]
+	if strings.TrimSpace(inner) != inner {
+		return "", 0
+	}
+	return inner, end + 2*len(delim)
+}
+
+// linkAt parses the [label](url) link opening s, returning 0 bytes when s does
+// not open one or the address is not a URL, so the text stays plain
+func linkAt(s string) (label, url string, n int) {
+	closeLabel := strings.Index(s, "](")
+	if closeLabel <= 0 {
+		return "", "", 0
+	}
+	closeURL := strings.IndexByte(s[closeLabel+2:], ')')
+	if closeURL < 0 {
+		return "", "", 0
+	}
+	url = strings.TrimSpace(s[closeLabel+2 : closeLabel+2+closeURL])
+	// A title after the address, as in [label](url "title"), is dropped
+	if space := strings.IndexAny(url, " \t"); space >= 0 {
+		url = url[:space]
+	}
+	if !isURL(url) {
+		return "", "", 0
+	}
+	return s[1:closeLabel], url, closeLabel + 2 + closeURL + 1
+}
+
+// isURL reports whether s is a web or mail address that can be linked: one with
+// a known scheme and no control characters, which could end the hyperlink's
+// escape sequence early and inject another
+func isURL(s string) bool {
+	return hasURLScheme(s) && !strings.ContainsFunc(s, unicode.IsControl)
+}
+
+func hasURLScheme(s string) bool {
+	return strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "mailto:")
+}
+
+// StripControl removes the C0 and C1 control characters from s except newlines
+// and tabs
+func StripControl(s string) string {
+	return strings.Map(withoutControl, s)
+}
+
+// withoutControl drops the C0 and C1 control characters except newlines and
+// tabs for strings.Map
+func withoutControl(r rune) rune {
+	if unicode.IsControl(r) && r != '\n' && r != '\t' {
+		return -1
+	}
+	return r
+}
+
+// bareURL returns the address opening s, up to the next space and without the
+// punctuation that ends the sentence around it
+func bareURL(s string) string {
+	if end := strings.IndexAny(s, " \t"); end >= 0 {
+		s = s[:end]
+	}
+	return strings.TrimRight(s, ".,;:!?)'\"")
+}
+
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
@@ -0,0 +1,143 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withHyperlinks runs the test with hyperlinks turned on or off
+func withHyperlinks(t *testing.T, enabled bool) {
+	previous := hyperlinks
+	hyperlinks = enabled
+	t.Cleanup(func() { hyperlinks = previous })
+}
+
+// plainLines renders source and returns its lines without styling or padding
+func plainLines(source string, width int) []string {
+	lines := strings.Split(ansi.Strip(Render(source, width)), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return lines
+}
+
+func TestRender_Blocks(t *testing.T) {
+	withHyperlinks(t, false)
+
+	source := "# Login fails\n\nSteps:\n\n\n1. Open the app\n2. Sign in\n- [ ] add a test\n- [x] reproduce\n- outer\n  - inner\n> seen on staging\n***\n```go\nfunc main() {\n\tlogin()\n}\n```\nafter"
+	assert.Equal(t, []string{
+		"Login fails",
+		"",
+		"Steps:",
+		"",
+		"1. Open the app",
+		"2. Sign in",
+		"☐ add a test",
+		"☑ reproduce",
+		"• outer",
+		"  ◦ inner",
+		"│ seen on staging",
+		strings.Repeat("─", 30),
+		" func main() {",
+		"     login()",
+		" }",
+		"after",
+	}, plainLines(source, 30))
+}
+
+func TestRender_Wrapping(t *testing.T) {
+	withHyperlinks(t, false)
+
+	lines := plainLines("- a list item long enough to wrap onto a second line", 20)
+	require.Len(t, lines, 3)
+	assert.Equal(t, "• a list item long", lines[0])
+	assert.Equal(t, "  enough to wrap", lines[1], "Wrapped item text hangs under the text, not the bullet")
+	for _, line := range plainLines("plain words that keep going well past the width", 20) {
+		assert.LessOrEqual(t, ansi.StringWidth(line), 20)
+	}
+}
+
+func TestRender_Inline(t *testing.T) {
+	withHyperlinks(t, false)
+
+	assert.Equal(t, []string{"Use go test with care and rm -rf"},
+		plainLines("Use `go test` with **care** and *rm -rf*", 60))
+	assert.Equal(t, []string{"a *literal* star and snake_case_name"},
+		plainLines(`a \*literal\* star and snake_case_name`, 60))
+	assert.Equal(t, []string{"See the docs (https://example.com/docs) or https://example.com."},
+		plainLines("See [the docs](https://example.com/docs) or https://example.com.", 80))
+	assert.Equal(t, []string{"Mail mailto:me@example.com"}, plainLines("Mail <mailto:me@example.com>", 80))
+}
+
+func TestRender_Hyperlinks(t *testing.T) {
+	withHyperlinks(t, true)
+
+	rendered := Render("See [the docs](https://example.com/docs).", 60)
+	assert.Contains(t, rendered, ansi.SetHyperlink("https://example.com/docs"))
+	assert.Contains(t, rendered, ansi.ResetHyperlink())
+	assert.NotContains(t, ansi.Strip(rendered), "(https://example.com/docs)", "Clickable links hide the address")
+	assert.Equal(t, "See the docs.", strings.TrimRight(ansi.Strip(rendered), " "))
+}
+
+func TestRender_UnsafeLinks(t *testing.T) {
+	withHyperlinks(t, true)
+
+	for _, source := range []string{
+		"[click](javascript:alert(1))",
+		"[file](file:///etc/passwd)",
+	} {
+		rendered := Render(source, 80)
+		assert.NotContains(t, rendered, "\x1b]8;;", "No hyperlink for %q", source)
+	}
+
+	plain := plainLines("[click](javascript:alert(1))", 80)
+	assert.Equal(t, []string{"[click](javascript:alert(1))"}, plain, "The text stays as written")
+
+	for _, source := range []string{
+		"[bell](https://example.com/\x07x)",
+		"<https://example.com/\u009bx>",
+		"https://example.com/\x1bx",
+	} {
+		rendered := Render(source, 80)
+		assert.Contains(t, rendered, ansi.SetHyperlink("https://example.com/x"), "Control characters are dropped from %q", source)
+	}
+}
+
+func TestRender_StripsControlCharacters(t *testing.T) {
+	withHyperlinks(t, false)
+
+	for _, source := range []string{
+		"hello \x1b]8;;http://evil\x1b\\click\x1b]8;;\x1b\\ and \x1b[2J",
+		"`\x1b[31mred` and **\u009b2Jbold**",
+		"```\n\x1b[2J\x07code\n```",
+		"- item \x1b]0;title\x07\n> quote \x1b[5m",
+	} {
+		rendered := Render(source, 80)
+		assert.NotContains(t, rendered, "\x1b", "No escape sequence survives %q", source)
+		assert.NotContains(t, rendered, "\u009b", "No escape sequence survives %q", source)
+		assert.NotContains(t, rendered, "\x07", "No escape sequence survives %q", source)
+	}
+
+	plain := plainLines("hello \x1b[2Jworld\tand more", 80)
+	assert.Equal(t, []string{"hello [2Jworld    and more"}, plain, "Tabs are kept and expanded")
+}
+
+func TestSetHyperlinks(t *testing.T) {
+	withHyperlinks(t, false)
+
+	require.NoError(t, SetHyperlinks("Always"))
+	assert.True(t, hyperlinks)
+	require.NoError(t, SetHyperlinks("never"))
+	assert.False(t, hyperlinks)
+
+	t.Setenv("TERM", "xterm-kitty")
+	t.Setenv("TMUX", "")
+	require.NoError(t, SetHyperlinks(""))
+	assert.True(t, hyperlinks, "auto recognises the terminal")
+
+	assert.ErrorContains(t, SetHyperlinks("sometimes"), "expected auto, always or never")
+}
@@ -14,3 +14,9 @@ var FocusedStyle = lipgloss.NewStyle().
 	Border(lipgloss.RoundedBorder()).
 	BorderForeground(lipgloss.Color(colors.Green)).
 	Padding(1, 2)
+
+// OverLimitStyle marks an unfocused column holding more tasks than its WIP limit
+var OverLimitStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color(colors.Red)).
+	Padding(1, 2)
@@ -1,6 +1,9 @@
 package styles
 
 import (
+	"strings"
+	"time"
+
 	"kahn/internal/domain"
 	"kahn/internal/ui/colors"
 
@@ -24,6 +27,22 @@ var (
 				Foreground(lipgloss.Color(colors.Red)).
 				Bold(true)
 
+	// Due date badge styles; tasks in a done column never look late
+	dueStyles = map[domain.DueState]lipgloss.Style{
+		domain.DueLater: lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0)),
+		domain.DueSoon:  lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Yellow)),
+		domain.Overdue:  lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Red)).Bold(true),
+	}
+
+	// Characters of a task name matched by a fuzzy search
+	fuzzyMatchStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(colors.Yellow)).
+			Underline(true)
+
+	// Checklist progress badge styles, green once every item is ticked off
+	checklistStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Subtext0))
+	checklistCompleteStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Green))
+
 	// Priority color styles (cached) - using values instead of pointers
 	priorityStyles = map[domain.Priority]lipgloss.Style{
 		domain.Low:    lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Green)),
@@ -39,11 +58,22 @@ type TaskWithTitle struct {
 	priorityText string
 	isSelected   bool
 	isActiveList bool
+	isDone       bool  // the task sits in the workflow's done column
+	matched      []int // byte offsets in the name matched by a fuzzy search
+	project      string
+	projectColor string
 }
 
-// Title returns the priority-formatted title for display
-
+// Title returns the priority-formatted title for display, after the name of
+// the task's project on boards gathering several projects
 func (t TaskWithTitle) Title() string {
+	if t.project == "" {
+		return t.title()
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(t.projectColor)).Bold(true).Render(t.project) + " " + t.title()
+}
+
+func (t TaskWithTitle) title() string {
 
 	title := t.Task.Title()
 	switch t.Task.Type {
@@ -56,22 +86,114 @@ func (t TaskWithTitle) Title() string {
 	}
 
 	// Check if task is blocked - render in red to indicate it's blocked
-	if t.Task.BlockedBy != nil {
+	if t.Task.IsBlocked() {
 		if t.isSelected && t.isActiveList {
-			return blockedSelectedStyle.Render(t.priorityText + title)
+			return t.renderMatched(blockedSelectedStyle, t.priorityText+title) + t.badges()
 		}
-		return blockedStyle.Render(t.priorityText + title)
+		return t.renderMatched(blockedStyle, t.priorityText+title) + t.badges()
 	}
 
 	// Original behavior for non-blocked tasks
 	if t.isSelected && t.isActiveList {
 
-		return selectedStyle.Render(t.priorityText + title)
+		return t.renderMatched(selectedStyle, t.priorityText+title) + t.badges()
 	} else {
 
 		priorityStyled := priorityStyles[t.Task.Priority].Render(t.priorityText)
-		return priorityStyled + title
+		if len(t.matched) > 0 {
+			return priorityStyled + t.renderMatched(lipgloss.NewStyle(), title) + t.badges()
+		}
+		return priorityStyled + title + t.badges()
+	}
+}
+
+// renderMatched renders text, which ends with the task name, in style with the
+// characters matched by a fuzzy search highlighted
+func (t TaskWithTitle) renderMatched(style lipgloss.Style, text string) string {
+	if len(t.matched) == 0 {
+		return style.Render(text)
+	}
+
+	offset := len(text) - len(t.Task.Name)
+	matched := make(map[int]bool, len(t.matched))
+	for _, index := range t.matched {
+		matched[offset+index] = true
 	}
+	highlight := fuzzyMatchStyle.Inherit(style)
+
+	// Render runs of matched and unmatched characters in one piece each
+	var rendered strings.Builder
+	start, inMatch := 0, false
+	for i := range text {
+		if matched[i] != inMatch && i > start {
+			rendered.WriteString(runStyle(inMatch, highlight, style).Render(text[start:i]))
+			start = i
+		}
+		inMatch = matched[i]
+	}
+	rendered.WriteString(runStyle(inMatch, highlight, style).Render(text[start:]))
+	return rendered.String()
+}
+
+func runStyle(matched bool, highlight, style lipgloss.Style) lipgloss.Style {
+	if matched {
+		return highlight
+	}
+	return style
+}
+
+// badges renders what follows the task name: checklist progress, label chips,
+// then the due date
+func (t TaskWithTitle) badges() string {
+	return ChecklistBadge(t.Task) + LabelChips(t.Task.Labels) + DueBadge(t.Task, t.isDone, time.Now())
+}
+
+// ChecklistBadge renders the task's checklist progress such as "2/5", or
+// nothing for a task without a checklist
+func ChecklistBadge(task domain.Task) string {
+	progress := task.ChecklistProgress()
+	if progress == "" {
+		return ""
+	}
+	if task.ChecklistDone == task.ChecklistTotal {
+		return " " + checklistCompleteStyle.Render("󰄲 "+progress)
+	}
+	return " " + checklistStyle.Render("󰄱 "+progress)
+}
+
+// DueBadge renders the due date of a task, colored by how close it is: red when
+// overdue, yellow when due soon. Finished tasks keep a plain badge.
+func DueBadge(task domain.Task, isDone bool, now time.Time) string {
+	state := task.DueState(now)
+	if state == domain.NoDueDate {
+		return ""
+	}
+	if isDone {
+		state = domain.DueLater
+	}
+
+	layout := "Jan 2"
+	if task.DueDate.Year() != now.Year() {
+		layout = "Jan 2 2006"
+	}
+	text := "󰃭 " + task.DueDate.Format(layout)
+	if state == domain.Overdue {
+		text += " overdue"
+	}
+	return " " + dueStyles[state].Render(text)
+}
+
+// LabelChips renders labels as chips in their own colors, each preceded by a space
+func LabelChips(labels []domain.Label) string {
+	var chips strings.Builder
+	for _, label := range labels {
+		chips.WriteString(" ")
+		chips.WriteString(lipgloss.NewStyle().
+			Background(lipgloss.Color(label.Color)).
+			Foreground(lipgloss.Color(colors.Base)).
+			Render(" " + label.Name + " "))
+	}
+	return chips.String()
 }
 
 // GetTaskType returns the task type for interface compliance
@@ -87,6 +209,28 @@ func NewTaskWithTitle(task domain.Task) TaskWithTitle {
 	}
 }
 
+// WithDone returns a copy marked as sitting in the done column, which turns off
+// overdue highlighting
+func (t TaskWithTitle) WithDone(isDone bool) TaskWithTitle {
+	t.isDone = isDone
+	return t
+}
+
+// WithMatches returns a copy highlighting the characters of the name at the
+// byte offsets matched by a fuzzy search
+func (t TaskWithTitle) WithMatches(indexes []int) TaskWithTitle {
+	t.matched = indexes
+	return t
+}
+
+// WithProject returns a copy showing the name of the task's project, in the
+// project's color, before the title
+func (t TaskWithTitle) WithProject(name, color string) TaskWithTitle {
+	t.project = name
+	t.projectColor = color
+	return t
+}
+
 // UpdateTaskSelection updates selection state for all items in a list
 func UpdateTaskSelection(items []list.Item, selectedIndex int, isActiveList bool) []list.Item {
 	updatedItems := make([]list.Item, len(items))
@@ -0,0 +1,84 @@
+package styles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"kahn/internal/domain"
+)
+
+func TestTaskWithTitle_LabelChips(t *testing.T) {
+	task := domain.Task{ID: "task_1", IntID: 1, Name: "Fix login", Priority: domain.Low}
+	plain := NewTaskWithTitle(task).Title()
+	assert.Empty(t, LabelChips(task.Labels))
+
+	task.Labels = []domain.Label{
+		{Name: "backend", Color: "#89b4fa"},
+		{Name: "tech-debt", Color: "#f9e2af"},
+	}
+	labelled := NewTaskWithTitle(task).Title()
+
+	assert.Equal(t, plain+LabelChips(task.Labels), labelled)
+	assert.Contains(t, LabelChips(task.Labels), " backend ")
+	assert.Contains(t, LabelChips(task.Labels), " tech-debt ")
+}
+
+func TestDueBadge(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.Local)
+	day := func(y int, m time.Month, d int) *time.Time {
+		date := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+		return &date
+	}
+
+	task := domain.Task{Name: "Ship it"}
+	assert.Empty(t, DueBadge(task, false, now), "no badge without a due date")
+
+	task.DueDate = day(2026, 10, 20)
+	assert.Contains(t, DueBadge(task, false, now), "Oct 20")
+	assert.NotContains(t, DueBadge(task, false, now), "2026", "current year is implied")
+
+	task.DueDate = day(2027, 1, 5)
+	assert.Contains(t, DueBadge(task, false, now), "Jan 5 2027")
+
+	task.DueDate = day(2026, 10, 13)
+	assert.Contains(t, DueBadge(task, false, now), "overdue")
+	assert.NotContains(t, DueBadge(task, true, now), "overdue", "done tasks are never overdue")
+}
+
+func TestChecklistBadge(t *testing.T) {
+	task := domain.Task{ID: "task_1", IntID: 1, Name: "Story", Priority: domain.Low}
+	assert.Empty(t, ChecklistBadge(task), "no badge without a checklist")
+
+	task.ChecklistTotal, task.ChecklistDone = 5, 2
+	assert.Contains(t, ChecklistBadge(task), "2/5")
+	assert.Contains(t, NewTaskWithTitle(task).Title(), "2/5", "progress shows next to the title")
+
+	task.ChecklistDone = 5
+	assert.Contains(t, ChecklistBadge(task), "5/5")
+}
+
+func TestTaskWithTitle_FuzzyMatches(t *testing.T) {
+	task := domain.Task{ID: "task_1", IntID: 1, Name: "Fix login", Priority: domain.Low}
+	plain := NewTaskWithTitle(task)
+	highlighted := plain.WithMatches([]int{0, 4, 5})
+
+	assert.Equal(t, ansi.Strip(plain.Title()), ansi.Strip(highlighted.Title()), "Highlighting keeps the text")
+	assert.Equal(t, plain.Title(), plain.WithMatches(nil).Title())
+
+	selected := UpdateTaskSelection([]list.Item{highlighted}, 0, true)[0].(TaskWithTitle)
+	assert.Equal(t, ansi.Strip(plain.Title()), ansi.Strip(selected.Title()))
+}
+
+func TestTaskWithTitle_WithProject(t *testing.T) {
+	task := domain.Task{ID: "task_1", IntID: 1, Name: "Fix login", Priority: domain.High}
+	plain := NewTaskWithTitle(task)
+	withProject := plain.WithProject("Website", "#89b4fa")
+
+	assert.Equal(t, "Website "+ansi.Strip(plain.Title()), ansi.Strip(withProject.Title()))
+
+	selected := UpdateTaskSelection([]list.Item{withProject}, 0, true)[0].(TaskWithTitle)
+	assert.Equal(t, ansi.Strip(withProject.Title()), ansi.Strip(selected.Title()), "Selection keeps the project name")
+}
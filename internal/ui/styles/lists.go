@@ -1,6 +1,8 @@
 package styles
 
 import (
+	"fmt"
+
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/lipgloss"
 	"kahn/internal/domain"
@@ -47,20 +49,50 @@ func GetListTitleStyle(status domain.Status) lipgloss.Style {
 	}
 }
 
+// ColumnTitle is the text of a column title: the column name and, when the column
+// has a WIP limit, a count/limit counter. A zero Limit means no limit.
+type ColumnTitle struct {
+	Name  string
+	Count int
+	Limit int
+}
+
+func (c ColumnTitle) String() string {
+	if c.Limit == 0 {
+		return c.Name
+	}
+	return fmt.Sprintf("%s %d/%d", c.Name, c.Count, c.Limit)
+}
+
+// OverLimit reports whether the column holds more tasks than its WIP limit
+func (c ColumnTitle) OverLimit() bool {
+	return c.Limit > 0 && c.Count > c.Limit
+}
+
 // ApplyListTitleStyles applies proper title styles to task lists
 func ApplyListTitleStyles(taskLists []list.Model) {
 	ApplyFocusedTitleStyles(taskLists, domain.NotStarted)
 }
 
-// ApplyFocusedTitleStyles applies focused title styles to task lists
-func ApplyFocusedTitleStyles(taskLists []list.Model, activeListIndex domain.Status) {
-	if len(taskLists) < 3 {
-		return
-	}
-
+// ApplyFocusedTitleStyles applies focused title styles to task lists, one per workflow column.
+// When titles are given they replace the list titles, and columns over their WIP
+// limit get a red title.
+func ApplyFocusedTitleStyles(taskLists []list.Model, activeListIndex domain.Status, titles ...ColumnTitle) {
 	for i := range taskLists {
 		status := domain.Status(i)
-		if status == activeListIndex {
+		overLimit := false
+		if i < len(titles) {
+			taskLists[i].Title = titles[i].String()
+			overLimit = titles[i].OverLimit()
+		}
+
+		if overLimit {
+			// Lists over their WIP limit get a red title, focused or not
+			taskLists[i].Styles.Title = lipgloss.NewStyle().
+				Foreground(lipgloss.Color(colors.Red)).
+				Bold(true).
+				Align(lipgloss.Center)
+		} else if status == activeListIndex {
 			// Focused list gets green title
 			taskLists[i].Styles.Title = lipgloss.NewStyle().
 				Foreground(lipgloss.Color(colors.Green)).
@@ -81,3 +81,21 @@ func TestApplyFocusedTitleStyles(t *testing.T) {
 	assert.Equal(t, taskLists[0].Styles.Title.GetForeground(), lipgloss.Color(colors.Text), "Unfocused list should have white title")
 	assert.Equal(t, taskLists[2].Styles.Title.GetForeground(), lipgloss.Color(colors.Text), "Unfocused list should have white title")
 }
+
+func TestApplyFocusedTitleStyles_WIPCounters(t *testing.T) {
+	defaultList := list.New([]list.Item{}, list.NewDefaultDelegate(), 20, 10)
+	taskLists := []list.Model{defaultList, defaultList, defaultList}
+	titles := []ColumnTitle{
+		{Name: "Not Started", Count: 5},
+		{Name: "In Progress", Count: 3, Limit: 4},
+		{Name: "Done", Count: 2, Limit: 1},
+	}
+
+	ApplyFocusedTitleStyles(taskLists, domain.InProgress, titles...)
+
+	assert.Equal(t, "Not Started", taskLists[0].Title, "Columns without a limit show no counter")
+	assert.Equal(t, "In Progress 3/4", taskLists[1].Title)
+	assert.Equal(t, "Done 2/1", taskLists[2].Title)
+	assert.Equal(t, lipgloss.Color(colors.Green), taskLists[1].Styles.Title.GetForeground(), "Focused list within its limit stays green")
+	assert.Equal(t, lipgloss.Color(colors.Red), taskLists[2].Styles.Title.GetForeground(), "List over its limit gets a red title")
+}
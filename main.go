@@ -2,9 +2,13 @@ package main
 
 import (
 	"kahn/internal/app"
+	"kahn/internal/cli"
 	"kahn/internal/config"
 	"kahn/internal/database"
+	"kahn/internal/domain"
+	"kahn/internal/ui/markdown"
 	"log"
+	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -14,11 +18,24 @@ var (
 )
 
 func main() {
+	if cli.IsCommand(os.Args[1:]) {
+		os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
 	config, err := config.LoadConfig()
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	wipEnforcement, err := domain.ParseWIPEnforcement(config.Board.WIPEnforcement)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	if err := markdown.SetHyperlinks(config.Board.Hyperlinks); err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
 	database, err := database.NewDatabase(config)
 	if err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
@@ -26,6 +43,10 @@ func main() {
 	defer database.Close()
 
 	m := app.NewKahnModel(database, Version)
+	m.SetWIPEnforcement(wipEnforcement)
+	m.SetAuthor(config.User.Name)
+	m.SetExportDir(config.Board.ExportDir)
+	m.AutoArchive(config.Board.AutoArchiveDays)
 	p := tea.NewProgram(m, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		log.Fatal(err)